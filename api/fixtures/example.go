@@ -16,6 +16,9 @@ type ExampleResources struct {
 	SigningKey     *corev1.Secret
 	SSHKey         *corev1.Secret
 	Cluster        *hyperv1.HostedCluster
+
+	// AdditionalTrustBundle is only set when the cluster has one.
+	AdditionalTrustBundle *corev1.ConfigMap
 }
 
 func (o *ExampleResources) AsObjects() []crclient.Object {
@@ -29,6 +32,9 @@ func (o *ExampleResources) AsObjects() []crclient.Object {
 	if o.SSHKey != nil {
 		objects = append(objects, o.SSHKey)
 	}
+	if o.AdditionalTrustBundle != nil {
+		objects = append(objects, o.AdditionalTrustBundle)
+	}
 	return objects
 }
 
@@ -48,6 +54,22 @@ type ExampleOptions struct {
 	PublicZoneID     string
 	PrivateZoneID    string
 
+	ControllerAvailabilityPolicy hyperv1.AvailabilityPolicy
+	NetworkType                  hyperv1.NetworkType
+
+	// ComputeIPv6CIDR, ClusterIPv6CIDR and ServiceIPv6CIDR are the IPv6
+	// networks of a dual-stack cluster.
+	ComputeIPv6CIDR string
+	ClusterIPv6CIDR string
+	ServiceIPv6CIDR string
+
+	// Proxy is the cluster-wide proxy of the cluster, if any.
+	Proxy *hyperv1.ProxySpec
+
+	// AdditionalTrustBundle holds PEM encoded certificates trusted by the
+	// cluster in addition to the system roots, if any.
+	AdditionalTrustBundle []byte
+
 	AWS ExampleAWSOptions
 }
 
@@ -60,9 +82,22 @@ type ExampleAWSOptions struct {
 	InstanceProfile string
 	InstanceType    string
 	Roles           []hyperv1.AWSRoleCredentials
+	EndpointAccess  hyperv1.AWSEndpointAccessType
 }
 
 func (o ExampleOptions) Resources() *ExampleResources {
+	networking := hyperv1.ClusterNetworking{
+		ServiceCIDR: "172.31.0.0/16",
+		PodCIDR:     "10.132.0.0/14",
+		MachineCIDR: o.ComputeCIDR,
+		NetworkType: o.NetworkType,
+	}
+	if len(o.ComputeIPv6CIDR) > 0 {
+		networking.ClusterNetwork = []hyperv1.ClusterNetworkEntry{{CIDR: networking.PodCIDR}, {CIDR: o.ClusterIPv6CIDR}}
+		networking.ServiceNetwork = []string{networking.ServiceCIDR, o.ServiceIPv6CIDR}
+		networking.MachineNetwork = []hyperv1.MachineNetworkEntry{{CIDR: networking.MachineCIDR}, {CIDR: o.ComputeIPv6CIDR}}
+	}
+
 	namespace := &corev1.Namespace{
 		TypeMeta: metav1.TypeMeta{
 			Kind:       "Namespace",
@@ -134,6 +169,25 @@ func (o ExampleOptions) Resources() *ExampleResources {
 		sshKeyReference = corev1.LocalObjectReference{Name: sshKeySecret.Name}
 	}
 
+	var additionalTrustBundle *corev1.ConfigMap
+	var additionalTrustBundleReference *corev1.LocalObjectReference
+	if len(o.AdditionalTrustBundle) > 0 {
+		additionalTrustBundle = &corev1.ConfigMap{
+			TypeMeta: metav1.TypeMeta{
+				Kind:       "ConfigMap",
+				APIVersion: corev1.SchemeGroupVersion.String(),
+			},
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: namespace.Name,
+				Name:      o.Name + "-ca-bundle",
+			},
+			Data: map[string]string{
+				"ca-bundle.crt": string(o.AdditionalTrustBundle),
+			},
+		}
+		additionalTrustBundleReference = &corev1.LocalObjectReference{Name: additionalTrustBundle.Name}
+	}
+
 	cluster := &hyperv1.HostedCluster{
 		TypeMeta: metav1.TypeMeta{
 			Kind:       "HostedCluster",
@@ -148,21 +202,20 @@ func (o ExampleOptions) Resources() *ExampleResources {
 				Image: o.ReleaseImage,
 			},
 			InitialComputeReplicas: o.NodePoolReplicas,
-			Networking: hyperv1.ClusterNetworking{
-				ServiceCIDR: "172.31.0.0/16",
-				PodCIDR:     "10.132.0.0/14",
-				MachineCIDR: o.ComputeCIDR,
-			},
-			InfraID:    o.InfraID,
-			PullSecret: corev1.LocalObjectReference{Name: pullSecret.Name},
-			SigningKey: corev1.LocalObjectReference{Name: signingKeySecret.Name},
-			IssuerURL:  o.IssuerURL,
-			SSHKey:     sshKeyReference,
+			Networking:             networking,
+			InfraID:                o.InfraID,
+			PullSecret:             corev1.LocalObjectReference{Name: pullSecret.Name},
+			SigningKey:             corev1.LocalObjectReference{Name: signingKeySecret.Name},
+			IssuerURL:              o.IssuerURL,
+			SSHKey:                 sshKeyReference,
 			DNS: hyperv1.DNSSpec{
 				BaseDomain:    o.BaseDomain,
 				PublicZoneID:  o.PublicZoneID,
 				PrivateZoneID: o.PrivateZoneID,
 			},
+			ControllerAvailabilityPolicy: o.ControllerAvailabilityPolicy,
+			Proxy:                        o.Proxy,
+			AdditionalTrustBundle:        additionalTrustBundleReference,
 			Platform: hyperv1.PlatformSpec{
 				Type: hyperv1.AWSPlatform,
 				AWS: &hyperv1.AWSPlatformSpec{
@@ -182,11 +235,19 @@ func (o ExampleOptions) Resources() *ExampleResources {
 					},
 					KubeCloudControllerCreds: corev1.LocalObjectReference{Name: awsCredsSecret.Name},
 					NodePoolManagementCreds:  corev1.LocalObjectReference{Name: awsCredsSecret.Name},
+					DNSManagementCreds:       corev1.LocalObjectReference{Name: awsCredsSecret.Name},
+					EndpointAccess:           o.AWS.EndpointAccess,
 				},
 			},
 		},
 	}
 
+	if len(o.AWS.EndpointAccess) > 0 && o.AWS.EndpointAccess != hyperv1.Public {
+		// The control plane operator manages the VPC endpoint of the private
+		// Kube API server.
+		cluster.Spec.Platform.AWS.ControlPlaneOperatorCreds = corev1.LocalObjectReference{Name: awsCredsSecret.Name}
+	}
+
 	return &ExampleResources{
 		Namespace:      namespace,
 		PullSecret:     pullSecret,
@@ -194,5 +255,7 @@ func (o ExampleOptions) Resources() *ExampleResources {
 		SigningKey:     signingKeySecret,
 		SSHKey:         sshKeySecret,
 		Cluster:        cluster,
+
+		AdditionalTrustBundle: additionalTrustBundle,
 	}
 }
@@ -16,6 +16,7 @@ import (
 	capiaws "github.com/openshift/hypershift/thirdparty/clusterapiprovideraws/v1alpha3"
 
 	hyperv1 "github.com/openshift/hypershift/api/v1alpha1"
+	hyperv1beta1 "github.com/openshift/hypershift/api/v1beta1"
 )
 
 var (
@@ -36,6 +37,7 @@ func init() {
 	capiaws.AddToScheme(Scheme)
 	clientgoscheme.AddToScheme(Scheme)
 	hyperv1.AddToScheme(Scheme)
+	hyperv1beta1.AddToScheme(Scheme)
 	capiv1.AddToScheme(Scheme)
 	configv1.AddToScheme(Scheme)
 	securityv1.AddToScheme(Scheme)
@@ -0,0 +1,11 @@
+package v1alpha1
+
+// v1alpha1 is the storage version and the conversion hub for the
+// hypershift.openshift.io API group. Every other served version converts to
+// and from these types.
+
+func (*HostedCluster) Hub()        {}
+func (*NodePool) Hub()             {}
+func (*HostedControlPlane) Hub()   {}
+func (*ExternalInfraCluster) Hub() {}
+func (*MachineConfigServer) Hub()  {}
@@ -37,9 +37,94 @@ type HostedControlPlaneSpec struct {
 	Platform     PlatformSpec                `json:"platform"`
 	DNS          DNSSpec                     `json:"dns"`
 
+	// ClusterNetwork, ServiceNetwork and MachineNetwork are the IP address
+	// pools of the pods, services and machines of the guest cluster. They
+	// are propagated from the HostedCluster, and their first entries are
+	// PodCIDR, ServiceCIDR and MachineCIDR.
+	// +optional
+	ClusterNetwork []ClusterNetworkEntry `json:"clusterNetwork,omitempty"`
+	// +optional
+	ServiceNetwork []string `json:"serviceNetwork,omitempty"`
+	// +optional
+	MachineNetwork []MachineNetworkEntry `json:"machineNetwork,omitempty"`
+
+	// NetworkType is the network plugin of the guest cluster. It is
+	// propagated from the HostedCluster.
+	// +kubebuilder:default=OpenShiftSDN
+	// +optional
+	NetworkType NetworkType `json:"networkType,omitempty"`
+
 	// KubeConfig specifies the name and key for the kubeconfig secret
 	// +optional
 	KubeConfig *KubeconfigSecretRef `json:"kubeconfig,omitempty"`
+
+	// PausedUntil pauses reconciliation of the HostedControlPlane. It is
+	// propagated from the HostedCluster and has the same format: either a
+	// boolean or an RFC3339 timestamp.
+	// +optional
+	PausedUntil *string `json:"pausedUntil,omitempty"`
+
+	// Configuration contains global configuration for the guest cluster. It
+	// is propagated from the HostedCluster.
+	// +optional
+	Configuration *ClusterConfiguration `json:"configuration,omitempty"`
+
+	// Proxy configures the cluster-wide HTTP proxy of the guest cluster. It
+	// is propagated from the HostedCluster.
+	// +optional
+	Proxy *ProxySpec `json:"proxy,omitempty"`
+
+	// AdditionalTrustBundle references a ConfigMap in the control plane
+	// namespace whose "ca-bundle.crt" key holds additional trusted
+	// certificates. It is copied from the one referenced by the
+	// HostedCluster.
+	// +optional
+	AdditionalTrustBundle *corev1.LocalObjectReference `json:"additionalTrustBundle,omitempty"`
+
+	// ImageContentSources lists the mirrors from which the images of
+	// source repositories are pulled. It is propagated from the
+	// HostedCluster.
+	// +optional
+	ImageContentSources []ImageContentSource `json:"imageContentSources,omitempty"`
+
+	// OAuth configures the OAuth server of the guest cluster. Secrets and
+	// config maps referenced by identity providers are resolved in the
+	// control plane namespace.
+	// +optional
+	OAuth *OAuthSpec `json:"oauth,omitempty"`
+
+	// ControllerAvailabilityPolicy specifies the availability of the control
+	// plane components. It is propagated from the HostedCluster.
+	// +kubebuilder:default=SingleReplica
+	// +optional
+	ControllerAvailabilityPolicy AvailabilityPolicy `json:"controllerAvailabilityPolicy,omitempty"`
+
+	// Sizing configures the resources requested by the control plane
+	// components. It is propagated from the HostedCluster, with the profile
+	// chosen by the HostedCluster when automatic sizing is enabled.
+	// +optional
+	Sizing *ControlPlaneSizing `json:"sizing,omitempty"`
+
+	// ControlPlanePlacement configures where the pods of the control plane
+	// are scheduled. It is propagated from the HostedCluster.
+	// +optional
+	ControlPlanePlacement *ControlPlanePlacement `json:"controlPlanePlacement,omitempty"`
+
+	// ControlPlaneOverrides are patches applied to the rendered manifests of
+	// the control plane. They are propagated from the HostedCluster.
+	// +optional
+	ControlPlaneOverrides []ControlPlaneOverride `json:"controlPlaneOverrides,omitempty"`
+
+	// Services configures how each control plane service is published. It is
+	// propagated from the HostedCluster.
+	// +optional
+	Services []ServicePublishingStrategyMapping `json:"services,omitempty"`
+
+	// NodeConnectivity is the data path through which the control plane
+	// reaches the guest cluster. It is propagated from the HostedCluster.
+	// +kubebuilder:default=OpenVPN
+	// +optional
+	NodeConnectivity NodeConnectivityType `json:"nodeConnectivity,omitempty"`
 }
 
 type KubeconfigSecretRef struct {
@@ -50,40 +135,60 @@ type KubeconfigSecretRef struct {
 type ConditionType string
 
 const (
+	// Available indicates whether the control plane is serving requests.
 	Available ConditionType = "Available"
-)
 
-type ConditionStatus string
+	// ReconciliationPaused indicates whether reconciliation of the resource
+	// is paused through its spec.pausedUntil field.
+	ReconciliationPaused ConditionType = "ReconciliationPaused"
 
-const (
-	ConditionTrue    ConditionStatus = "True"
-	ConditionFalse   ConditionStatus = "False"
-	ConditionUnknown ConditionStatus = "Unknown"
-)
+	// InfrastructureReady indicates whether the services and load balancers
+	// fronting the control plane have been provisioned.
+	InfrastructureReady ConditionType = "InfrastructureReady"
 
-type HostedControlPlaneCondition struct {
-	// type specifies the aspect reported by this condition.
-	// +kubebuilder:validation:Required
-	Type ConditionType `json:"type"`
+	// EtcdAvailable indicates whether the etcd cluster backing the control
+	// plane is available.
+	EtcdAvailable ConditionType = "EtcdAvailable"
 
-	// status of the condition, one of True, False, Unknown.
-	// +kubebuilder:validation:Required
-	Status ConditionStatus `json:"status"`
+	// KubeAPIServerAvailable indicates whether the kube-apiserver deployment
+	// of the control plane is available.
+	KubeAPIServerAvailable ConditionType = "KubeAPIServerAvailable"
 
-	// lastTransitionTime is the time of the last update to the current status property.
-	// +kubebuilder:validation:Required
-	LastTransitionTime metav1.Time `json:"lastTransitionTime"`
+	// ReleaseImageValid indicates whether the release image could be looked
+	// up and contains valid component versions.
+	ReleaseImageValid ConditionType = "ReleaseImageValid"
 
-	// reason is the CamelCase reason for the condition's current status.
-	// +kubebuilder:validation:Optional
-	Reason string `json:"reason,omitempty"`
+	// ValidConfiguration indicates whether the spec of the resource could be
+	// rendered into a control plane.
+	ValidConfiguration ConditionType = "ValidConfiguration"
 
-	// message provides additional information about the current condition.
-	// This is only to be consumed by humans.  It may contain Line Feed
-	// characters (U+000A), which should be rendered as new lines.
-	// +kubebuilder:validation:Optional
-	Message string `json:"message,omitempty"`
-}
+	// ValidReferencedResources indicates whether the resources referenced by
+	// a HostedCluster, such as its pull secret, signing key, SSH key and cloud
+	// credentials, exist and contain the expected keys.
+	ValidReferencedResources ConditionType = "ValidReferencedResources"
+
+	// ValidControlPlaneOverrides indicates whether all of the control plane
+	// overrides could be applied to the rendered manifests.
+	ValidControlPlaneOverrides ConditionType = "ValidControlPlaneOverrides"
+
+	// IdentityProvidersDiscovered indicates whether the endpoints of all
+	// OpenID identity providers could be discovered. Identity providers which
+	// fail to be discovered use their last discovered endpoints, and are left
+	// out of the OAuth server until they are discovered once.
+	IdentityProvidersDiscovered ConditionType = "IdentityProvidersDiscovered"
+
+	// Degraded indicates that the control plane is failing to reach its
+	// desired state.
+	Degraded ConditionType = "Degraded"
+
+	// Progressing indicates that the control plane is rolling out a change,
+	// such as a new release image.
+	Progressing ConditionType = "Progressing"
+
+	// AutoscalingActive indicates whether the cluster autoscaler is available
+	// and scales at least one NodePool of the HostedCluster.
+	AutoscalingActive ConditionType = "AutoscalingActive"
+)
 
 // HostedControlPlaneStatus defines the observed state of HostedControlPlane
 type HostedControlPlaneStatus struct {
@@ -122,10 +227,48 @@ type HostedControlPlaneStatus struct {
 	// for this control plane.
 	KubeConfig *KubeconfigSecretRef `json:"kubeConfig,omitempty"`
 
+	// KubeadminPassword is a reference to the secret containing the initial
+	// kubeadmin user password for the guest cluster under the "password" key.
+	// +optional
+	KubeadminPassword *corev1.LocalObjectReference `json:"kubeadminPassword,omitempty"`
+
+	// ConsoleURL is the URL of the guest cluster web console.
+	// +optional
+	ConsoleURL string `json:"consoleURL,omitempty"`
+
+	// OAuthCallbackURL is the base URL of the OAuth server callback endpoint
+	// which identity providers redirect to. The name of the identity provider
+	// is appended to it.
+	// +optional
+	OAuthCallbackURL string `json:"oauthCallbackURL,omitempty"`
+
+	// PrivateLink records the AWS resources which publish the Kube API server
+	// to the VPC of the cluster with PublicAndPrivate and Private endpoint
+	// access.
+	// +optional
+	PrivateLink *PrivateLinkStatus `json:"privateLink,omitempty"`
+
 	// Condition contains details for one aspect of the current state of the HostedControlPlane.
-	// Current condition types are: "Available"
+	// Current condition types are: "Available", "InfrastructureReady",
+	// "EtcdAvailable", "KubeAPIServerAvailable", "ReleaseImageValid",
+	// "ValidConfiguration", "ValidControlPlaneOverrides",
+	// "IdentityProvidersDiscovered", "Degraded" and "Progressing".
 	// +kubebuilder:validation:Required
-	Conditions []HostedControlPlaneCondition `json:"conditions"`
+	Conditions []metav1.Condition `json:"conditions"`
+}
+
+// PrivateLinkStatus records the AWS resources which publish the Kube API
+// server of a HostedControlPlane through AWS PrivateLink.
+type PrivateLinkStatus struct {
+	// EndpointServiceID is the ID of the VPC endpoint service of the private
+	// Kube API server load balancer.
+	// +optional
+	EndpointServiceID string `json:"endpointServiceID,omitempty"`
+
+	// EndpointID is the ID of the VPC endpoint towards the VPC endpoint
+	// service in the VPC of the cluster.
+	// +optional
+	EndpointID string `json:"endpointID,omitempty"`
 }
 
 // +kubebuilder:object:root=true
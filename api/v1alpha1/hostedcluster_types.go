@@ -3,6 +3,7 @@ package v1alpha1
 import (
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 
 	configv1 "github.com/openshift/api/config/v1"
 )
@@ -11,6 +12,29 @@ func init() {
 	SchemeBuilder.Register(&HostedCluster{}, &HostedClusterList{})
 }
 
+// The image annotations below override the image of a component the
+// hypershift operator manages next to the control plane of a HostedCluster.
+// Since the components run with credentials of the management cluster, an
+// annotation is only honoured when the hypershift operator allows it with its
+// --allowed-image-annotations flag.
+const (
+	// ControlPlaneOperatorImageAnnotation overrides the image of the control
+	// plane operator of a HostedCluster.
+	ControlPlaneOperatorImageAnnotation = "hypershift.openshift.io/control-plane-operator-image"
+
+	// ClusterAPIManagerImageAnnotation overrides the image of the cluster API
+	// manager of a HostedCluster.
+	ClusterAPIManagerImageAnnotation = "hypershift.openshift.io/capi-manager-image"
+
+	// ClusterAPIProviderAWSImageAnnotation overrides the image of the cluster
+	// API AWS provider of a HostedCluster.
+	ClusterAPIProviderAWSImageAnnotation = "hypershift.openshift.io/capi-provider-aws-image"
+
+	// ClusterAutoscalerImageAnnotation overrides the image of the cluster
+	// autoscaler of a HostedCluster.
+	ClusterAutoscalerImageAnnotation = "hypershift.openshift.io/cluster-autoscaler-image"
+)
+
 // HostedClusterSpec defines the desired state of HostedCluster
 type HostedClusterSpec struct {
 
@@ -39,6 +63,458 @@ type HostedClusterSpec struct {
 
 	// DNS configuration for the cluster
 	DNS DNSSpec `json:"dns,omitempty"`
+
+	// PausedUntil pauses reconciliation of the HostedCluster and the resources
+	// it manages. It is either a boolean or an RFC3339 timestamp. When "true",
+	// reconciliation is paused until the field is removed or set to "false".
+	// When a timestamp, reconciliation is paused until that time passes.
+	// +optional
+	PausedUntil *string `json:"pausedUntil,omitempty"`
+
+	// Configuration contains global configuration for the guest cluster, in
+	// the form of config.openshift.io/v1 resources. It is used to configure
+	// both the control plane components and the guest cluster itself.
+	// +optional
+	Configuration *ClusterConfiguration `json:"configuration,omitempty"`
+
+	// Proxy configures the cluster-wide HTTP proxy through which the guest
+	// cluster and the control plane components which make outbound calls
+	// reach external hosts. It takes precedence over the proxy settings of
+	// a Proxy resource in Configuration.
+	// +optional
+	Proxy *ProxySpec `json:"proxy,omitempty"`
+
+	// AdditionalTrustBundle references a ConfigMap in the HostedCluster
+	// namespace whose "ca-bundle.crt" key holds PEM encoded certificates
+	// which are trusted in addition to the system roots. It is trusted by
+	// the control plane components which make outbound calls, published as
+	// the user-ca-bundle ConfigMap of the guest cluster and written to the
+	// nodes. Changing it replaces the nodes of the NodePools.
+	// +optional
+	AdditionalTrustBundle *corev1.LocalObjectReference `json:"additionalTrustBundle,omitempty"`
+
+	// ImageContentSources lists the mirrors from which the images of
+	// source repositories are pulled. The first mirror of a repository is
+	// used to resolve the release image and to pull the images of the control
+	// plane components, and all mirrors are configured on the guest cluster
+	// and its nodes as an ImageContentSourcePolicy.
+	// +optional
+	ImageContentSources []ImageContentSource `json:"imageContentSources,omitempty"`
+
+	// Autoscaling tunes the cluster autoscaler which scales the NodePools of
+	// the HostedCluster which have autoscaling enabled.
+	// +optional
+	Autoscaling *ClusterAutoscaling `json:"autoscaling,omitempty"`
+
+	// OAuth configures the OAuth server of the guest cluster.
+	// +optional
+	OAuth *OAuthSpec `json:"oauth,omitempty"`
+
+	// ControllerAvailabilityPolicy specifies the availability of the control
+	// plane components. HighlyAvailable runs three replicas of each component
+	// on different management cluster nodes, spread across zones where the
+	// management cluster has enough of them, and a three member etcd cluster.
+	// +kubebuilder:default=SingleReplica
+	// +optional
+	ControllerAvailabilityPolicy AvailabilityPolicy `json:"controllerAvailabilityPolicy,omitempty"`
+
+	// Sizing configures the resources requested by the control plane
+	// components.
+	// +optional
+	Sizing *ControlPlaneSizing `json:"sizing,omitempty"`
+
+	// ControlPlanePlacement configures where the pods of the control plane
+	// are scheduled on the management cluster.
+	// +optional
+	ControlPlanePlacement *ControlPlanePlacement `json:"controlPlanePlacement,omitempty"`
+
+	// ControlPlaneOverrides are patches applied to the rendered manifests of
+	// the control plane, in order. They are meant for one-off adjustments
+	// which the API does not support, such as an extra flag of a component.
+	// Overrides which fail to apply are reported by the
+	// ValidControlPlaneOverrides condition and skipped.
+	// +optional
+	ControlPlaneOverrides []ControlPlaneOverride `json:"controlPlaneOverrides,omitempty"`
+
+	// Services configures how each control plane service is published to the
+	// guest cluster and its clients. A service which is not listed uses its
+	// default strategy: LoadBalancer for the API server and VPN, and Route for
+	// the OAuth server, ignition and Konnectivity server. The strategies are
+	// immutable.
+	// +optional
+	Services []ServicePublishingStrategyMapping `json:"services,omitempty"`
+
+	// NodeConnectivity is the data path through which the control plane
+	// reaches kubelets, webhooks and aggregated API servers in the guest
+	// cluster. It can be changed to migrate an existing cluster from one data
+	// path to the other.
+	// +kubebuilder:default=OpenVPN
+	// +optional
+	NodeConnectivity NodeConnectivityType `json:"nodeConnectivity,omitempty"`
+}
+
+// NodeConnectivityType is a data path from the control plane to the guest
+// cluster.
+// +kubebuilder:validation:Enum=OpenVPN;Konnectivity
+type NodeConnectivityType string
+
+const (
+	// OpenVPN connects the control plane to the guest cluster with an
+	// OpenVPN server, a client in the Kube API server pod and a client
+	// deployed to the guest cluster. The clients are privileged.
+	OpenVPN NodeConnectivityType = "OpenVPN"
+
+	// Konnectivity connects the control plane to the guest cluster with the
+	// Kubernetes apiserver-network-proxy: a server next to the Kube API
+	// server, to which agents deployed to the guest cluster nodes connect.
+	// The Kube API server and OpenShift API server send their traffic to the
+	// guest cluster through the server.
+	Konnectivity NodeConnectivityType = "Konnectivity"
+)
+
+// ServiceType is a control plane service which is reachable from outside of
+// the management cluster.
+// +kubebuilder:validation:Enum=APIServer;OAuthServer;VPN;Ignition;KonnectivityServer;OVNSbDb
+type ServiceType string
+
+const (
+	// APIServer is the Kubernetes API server.
+	APIServer ServiceType = "APIServer"
+
+	// OAuthServer is the OAuth server.
+	OAuthServer ServiceType = "OAuthServer"
+
+	// VPN is the VPN server to which the workers connect.
+	VPN ServiceType = "VPN"
+
+	// Ignition is the server from which the workers fetch their ignition
+	// configuration.
+	Ignition ServiceType = "Ignition"
+
+	// KonnectivityServer is the Konnectivity server to which the agents on
+	// the workers connect.
+	KonnectivityServer ServiceType = "KonnectivityServer"
+
+	// OVNSbDb is the OVN southbound database to which the nodes of a cluster
+	// with the OVNKubernetes network type connect.
+	OVNSbDb ServiceType = "OVNSbDb"
+)
+
+// PublishingStrategyType is a way of publishing a control plane service.
+// +kubebuilder:validation:Enum=LoadBalancer;NodePort;Route
+type PublishingStrategyType string
+
+const (
+	// LoadBalancer publishes the service through a load balancer of the
+	// platform of the management cluster.
+	LoadBalancer PublishingStrategyType = "LoadBalancer"
+
+	// NodePort publishes the service on a port of the management cluster
+	// nodes.
+	NodePort PublishingStrategyType = "NodePort"
+
+	// Route publishes the service through the router of the management
+	// cluster. TLS services are passed through to the service based on SNI.
+	// The VPN cannot be published through a route.
+	Route PublishingStrategyType = "Route"
+)
+
+// ServicePublishingStrategyMapping is the publishing strategy of a control
+// plane service.
+type ServicePublishingStrategyMapping struct {
+	// Service is the control plane service.
+	Service ServiceType `json:"service"`
+
+	// ServicePublishingStrategy is the way the service is published.
+	ServicePublishingStrategy `json:"servicePublishingStrategy"`
+}
+
+// ServicePublishingStrategy is a way of publishing a control plane service.
+type ServicePublishingStrategy struct {
+	// Type is the type of the strategy.
+	Type PublishingStrategyType `json:"type"`
+
+	// NodePort configures the NodePort strategy, which requires it.
+	// +optional
+	NodePort *NodePortPublishingStrategy `json:"nodePort,omitempty"`
+
+	// Hostname is a stable DNS name for the API server or OAuth server
+	// published with the LoadBalancer or Route strategy. It is used instead of
+	// the address of the load balancer or route in the serving certificate,
+	// the kubeconfigs and the status of the cluster. When the AWS platform has
+	// DNS management credentials, a record for it is managed in the public and
+	// private zones of the cluster which contain it; otherwise it must resolve
+	// to the load balancer or router. Managed records are marked with a TXT
+	// record of the same name prefixed with `_hypershift-owner.`, and existing
+	// records of the hostname without it are never changed.
+	// The hostname cannot be set or changed once the cluster is created: the
+	// certificates and kubeconfigs of the cluster are generated once with the
+	// address of the service, and are not regenerated for a new hostname.
+	// +optional
+	Hostname string `json:"hostname,omitempty"`
+
+	// ServingCert is a reference to a secret with a serving certificate for
+	// the hostname, which must have the `tls.crt` and `tls.key` keys and may
+	// have a `ca.crt` key with the certificate authority which signed it.
+	// When it is not set, a certificate for the hostname is generated.
+	// Kubeconfigs for the hostname trust `ca.crt` when it is present, and the
+	// system trust store otherwise. Unlike the rest of the strategy, the
+	// serving certificate may be changed.
+	// +optional
+	ServingCert *corev1.LocalObjectReference `json:"servingCert,omitempty"`
+}
+
+// NodePortPublishingStrategy configures a service published on a port of the
+// management cluster nodes.
+type NodePortPublishingStrategy struct {
+	// Address is the DNS name or IP address through which clients reach the
+	// management cluster nodes, for example that of a load balancer in front
+	// of them.
+	// +kubebuilder:validation:MinLength=1
+	Address string `json:"address"`
+
+	// Port is the node port of the service. A port is allocated when it is
+	// not set.
+	// +optional
+	Port int32 `json:"port,omitempty"`
+}
+
+// ControlPlanePlacement configures the scheduling of the control plane pods,
+// including etcd, the VPN server and the operators deployed for the cluster.
+// It is added to the scheduling constraints of each component.
+type ControlPlanePlacement struct {
+	// NodeSelector is merged into the node selector of the pods.
+	// +optional
+	NodeSelector map[string]string `json:"nodeSelector,omitempty"`
+
+	// Tolerations are added to the tolerations of the pods.
+	// +optional
+	Tolerations []corev1.Toleration `json:"tolerations,omitempty"`
+
+	// PriorityClassName is the priority class of the pods. The etcd members
+	// do not support priority classes.
+	// +optional
+	PriorityClassName string `json:"priorityClassName,omitempty"`
+
+	// TopologySpreadConstraints are the topology spread constraints of the
+	// pods. A constraint without a label selector selects the pods of the
+	// same component. The etcd members do not support topology spread
+	// constraints.
+	// +optional
+	TopologySpreadConstraints []corev1.TopologySpreadConstraint `json:"topologySpreadConstraints,omitempty"`
+}
+
+// ControlPlaneOverridePatchType is the type of the patch of a control plane
+// override.
+// +kubebuilder:validation:Enum=StrategicMerge;JSON
+type ControlPlaneOverridePatchType string
+
+const (
+	// StrategicMergePatchType is a strategic merge patch. Manifests of kinds
+	// without a strategic merge schema, such as the etcd cluster, are
+	// patched with a JSON merge patch.
+	StrategicMergePatchType ControlPlaneOverridePatchType = "StrategicMerge"
+
+	// JSONPatchType is a JSON patch as defined by RFC 6902.
+	JSONPatchType ControlPlaneOverridePatchType = "JSON"
+)
+
+// ControlPlaneOverride is a patch to a rendered control plane manifest.
+type ControlPlaneOverride struct {
+	// Manifest is the name of the rendered manifest to patch, for example
+	// kube-apiserver-deployment.yaml.
+	// +kubebuilder:validation:MinLength=1
+	Manifest string `json:"manifest"`
+
+	// Type is the type of the patch.
+	// +kubebuilder:default=StrategicMerge
+	// +optional
+	Type ControlPlaneOverridePatchType `json:"type,omitempty"`
+
+	// Patch is the patch in YAML or JSON.
+	// +kubebuilder:validation:MinLength=1
+	Patch string `json:"patch"`
+}
+
+// ControlPlaneSizingProfile is a named set of resource requests for the
+// control plane components.
+// +kubebuilder:validation:Enum=Small;Medium;Large
+type ControlPlaneSizingProfile string
+
+const (
+	// SmallSizingProfile suits clusters of up to 10 nodes.
+	SmallSizingProfile ControlPlaneSizingProfile = "Small"
+
+	// MediumSizingProfile suits clusters of up to 100 nodes.
+	MediumSizingProfile ControlPlaneSizingProfile = "Medium"
+
+	// LargeSizingProfile suits clusters of more than 100 nodes.
+	LargeSizingProfile ControlPlaneSizingProfile = "Large"
+)
+
+// ControlPlaneSizing configures the resources of the control plane
+// components. Changing it rolls out the affected components.
+type ControlPlaneSizing struct {
+	// Profile is the sizing profile of the control plane. The default is
+	// Small.
+	// +optional
+	Profile ControlPlaneSizingProfile `json:"profile,omitempty"`
+
+	// Auto chooses the profile from the number of nodes of the NodePools of
+	// the cluster. Profile is then the smallest profile that is chosen. The
+	// control plane only moves to a smaller profile once the nodes are 20%
+	// below the limit of that profile.
+	// +optional
+	Auto bool `json:"auto,omitempty"`
+
+	// Overrides replaces the resource requests and limits of the profile for
+	// individual components. Each override may appear at most once per
+	// component.
+	// +optional
+	Overrides []ControlPlaneComponentResources `json:"overrides,omitempty"`
+}
+
+// ControlPlaneComponentResources overrides the resources of a control plane
+// component.
+type ControlPlaneComponentResources struct {
+	// Name is the name of the component.
+	// +kubebuilder:validation:Enum=etcd;kube-apiserver;kube-controller-manager;kube-scheduler;openshift-apiserver;openshift-oauth-apiserver;openshift-controller-manager;cluster-policy-controller;oauth-openshift;cluster-version-operator
+	Name string `json:"name"`
+
+	// Resources are the requests and limits of the component. A request or
+	// limit set here replaces the one of the profile for the same resource,
+	// and a request of the profile above a limit set here is lowered to the
+	// limit. Only cpu and memory may be set.
+	Resources corev1.ResourceRequirements `json:"resources"`
+}
+
+// AvailabilityPolicy specifies the availability of control plane components.
+// +kubebuilder:validation:Enum=HighlyAvailable;SingleReplica
+type AvailabilityPolicy string
+
+const (
+	// HighlyAvailable runs multiple replicas of control plane components.
+	HighlyAvailable AvailabilityPolicy = "HighlyAvailable"
+
+	// SingleReplica runs a single replica of control plane components.
+	SingleReplica AvailabilityPolicy = "SingleReplica"
+)
+
+// OAuthSpec configures the OAuth server of a guest cluster.
+type OAuthSpec struct {
+	// IdentityProviders is an ordered list of ways for users to identify
+	// themselves. Secrets and config maps referenced by an identity provider
+	// must exist in the namespace of the HostedCluster, and are synced into the
+	// control plane namespace.
+	// +optional
+	IdentityProviders []configv1.IdentityProvider `json:"identityProviders,omitempty"`
+
+	// DisableKubeadmin removes the kubeadmin user from the guest cluster. It
+	// requires at least one identity provider and cannot be unset once set.
+	// +optional
+	DisableKubeadmin bool `json:"disableKubeadmin,omitempty"`
+}
+
+// ClusterConfiguration contains the global configuration of a guest cluster.
+type ClusterConfiguration struct {
+	// Items embeds config.openshift.io/v1 resources. Supported kinds are
+	// APIServer, OAuth, Proxy, Image, Network, Scheduler, Ingress, FeatureGate
+	// and Build. Each resource must be named "cluster" and may appear at most
+	// once. Removing a resource leaves the corresponding resource in the guest
+	// cluster unchanged.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:pruning:PreserveUnknownFields
+	Items []runtime.RawExtension `json:"items,omitempty"`
+}
+
+// ProxySpec configures the cluster-wide HTTP proxy of a guest cluster.
+type ProxySpec struct {
+	// HTTPProxy is the URL of the proxy for HTTP requests. Its scheme must
+	// be http.
+	// +optional
+	HTTPProxy string `json:"httpProxy,omitempty"`
+
+	// HTTPSProxy is the URL of the proxy for HTTPS requests. Its scheme
+	// must be http or https.
+	// +optional
+	HTTPSProxy string `json:"httpsProxy,omitempty"`
+
+	// NoProxy is a comma-separated list of hostnames, domains and CIDRs
+	// which are reached without the proxy. The cluster, service and machine
+	// networks of the cluster are always reached without the proxy, and do
+	// not need to be listed.
+	// +optional
+	NoProxy string `json:"noProxy,omitempty"`
+}
+
+// ClusterAutoscaling tunes the cluster autoscaler of a HostedCluster. Unset
+// fields keep the defaults of the cluster autoscaler.
+type ClusterAutoscaling struct {
+	// ScaleDownDelayAfterAdd is how long after a scale up the autoscaler
+	// resumes evaluating nodes for scale down.
+	// +optional
+	ScaleDownDelayAfterAdd *metav1.Duration `json:"scaleDownDelayAfterAdd,omitempty"`
+
+	// ScaleDownUtilizationThreshold is the ratio of the requested resources
+	// to the capacity of a node under which the node is considered for scale
+	// down. It is a decimal number greater than 0 and at most 1, such as
+	// "0.5".
+	// +optional
+	ScaleDownUtilizationThreshold *string `json:"scaleDownUtilizationThreshold,omitempty"`
+
+	// MaxNodesTotal is the maximum number of nodes across all NodePools of
+	// the HostedCluster. The autoscaler does not scale up beyond it.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	MaxNodesTotal *int32 `json:"maxNodesTotal,omitempty"`
+
+	// Expander selects the NodePool to scale up when several could schedule
+	// the pending pods.
+	// +optional
+	Expander ExpanderType `json:"expander,omitempty"`
+
+	// BalanceSimilarNodeGroups keeps the sizes of NodePools with the same
+	// instance type and labels balanced.
+	// +optional
+	BalanceSimilarNodeGroups bool `json:"balanceSimilarNodeGroups,omitempty"`
+
+	// MaxPodGracePeriod is the number of seconds the autoscaler waits for
+	// the pods of a node to terminate gracefully during scale down.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	MaxPodGracePeriod *int32 `json:"maxPodGracePeriod,omitempty"`
+}
+
+// ExpanderType is a strategy of the cluster autoscaler to choose the NodePool
+// to scale up.
+// +kubebuilder:validation:Enum=random;most-pods;least-waste
+type ExpanderType string
+
+const (
+	// RandomExpander scales up a random NodePool.
+	RandomExpander ExpanderType = "random"
+
+	// MostPodsExpander scales up the NodePool which schedules the most
+	// pending pods.
+	MostPodsExpander ExpanderType = "most-pods"
+
+	// LeastWasteExpander scales up the NodePool which leaves the least idle
+	// CPU and memory once the pending pods are scheduled.
+	LeastWasteExpander ExpanderType = "least-waste"
+)
+
+// ImageContentSource maps a source repository to the mirrors which serve
+// its images.
+type ImageContentSource struct {
+	// Source is the repository whose images are mirrored, e.g.
+	// quay.io/openshift-release-dev/ocp-release. The images of its nested
+	// repositories are mirrored as well.
+	Source string `json:"source"`
+
+	// Mirrors are the repositories which serve the images of the source, in
+	// order of preference.
+	// +optional
+	Mirrors []string `json:"mirrors,omitempty"`
 }
 
 // DNSSpec specifies the DNS configuration in the cluster
@@ -61,8 +537,67 @@ type ClusterNetworking struct {
 	ServiceCIDR string `json:"serviceCIDR"`
 	PodCIDR     string `json:"podCIDR"`
 	MachineCIDR string `json:"machineCIDR"`
+
+	// ClusterNetwork is the list of IP address pools for pods, at most one
+	// per IP family. The first entry must be PodCIDR. Defaults to PodCIDR
+	// with a host prefix of 23.
+	// +optional
+	ClusterNetwork []ClusterNetworkEntry `json:"clusterNetwork,omitempty"`
+
+	// ServiceNetwork is the list of IP address pools for services, at most
+	// one per IP family. A cluster with both an IPv4 and an IPv6 service
+	// network is dual-stack. The first entry must be ServiceCIDR. Defaults to
+	// ServiceCIDR.
+	// +optional
+	ServiceNetwork []string `json:"serviceNetwork,omitempty"`
+
+	// MachineNetwork is the list of IP address pools for machines. The first
+	// entry must be MachineCIDR. Defaults to MachineCIDR.
+	// +optional
+	MachineNetwork []MachineNetworkEntry `json:"machineNetwork,omitempty"`
+
+	// NetworkType is the network plugin of the guest cluster. It cannot be
+	// changed after the cluster is created.
+	// +kubebuilder:default=OpenShiftSDN
+	// +optional
+	NetworkType NetworkType `json:"networkType,omitempty"`
+}
+
+// ClusterNetworkEntry is an IP address pool from which pod IP addresses are
+// allocated.
+type ClusterNetworkEntry struct {
+	// CIDR is the IP address pool.
+	CIDR string `json:"cidr"`
+
+	// HostPrefix is the prefix length of the subnet allocated to each node
+	// from the pool. Defaults to 23 for IPv4 and 64 for IPv6.
+	// +optional
+	HostPrefix int32 `json:"hostPrefix,omitempty"`
+}
+
+// MachineNetworkEntry is an IP address pool of the machines of a cluster.
+type MachineNetworkEntry struct {
+	// CIDR is the IP address pool.
+	CIDR string `json:"cidr"`
 }
 
+// NetworkType is a network plugin of a guest cluster.
+// +kubebuilder:validation:Enum=OpenShiftSDN;OVNKubernetes
+type NetworkType string
+
+const (
+	// OpenShiftSDN is the OpenShift SDN network plugin, which encapsulates
+	// pod traffic with VXLAN.
+	OpenShiftSDN NetworkType = "OpenShiftSDN"
+
+	// OVNKubernetes is the OVN-Kubernetes network plugin, which encapsulates
+	// pod traffic with Geneve. The cluster network operator and
+	// ovnkube-master run in the control plane namespace, and the nodes
+	// connect to the OVN southbound database published as the OVNSbDb
+	// service.
+	OVNKubernetes NetworkType = "OVNKubernetes"
+)
+
 // PlatformType is a specific supported infrastructure provider.
 // +kubebuilder:validation:Enum=AWS
 type PlatformType string
@@ -113,8 +648,59 @@ type AWSPlatformSpec struct {
 	// The secret should have exactly one key, `credentials`, whose value is
 	// an AWS credentials file.
 	NodePoolManagementCreds corev1.LocalObjectReference `json:"nodePoolManagementCreds"`
+
+	// DNSManagementCreds is a reference to a secret containing cloud
+	// credentials with permissions to change the records of the public and
+	// private zones of the cluster. It is required for the records of the
+	// hostnames of published services to be managed.
+	// The secret should have exactly one key, `credentials`, whose value is
+	// an AWS credentials file.
+	// +optional
+	DNSManagementCreds corev1.LocalObjectReference `json:"dnsManagementCreds,omitempty"`
+
+	// ControlPlaneOperatorCreds is a reference to a secret containing cloud
+	// credentials with permissions to manage the VPC endpoint service of the
+	// private Kube API server load balancer, and the VPC endpoint towards it
+	// in the VPC of the cluster. It is required unless the endpoint access is
+	// Public.
+	// The secret should have exactly one key, `credentials`, whose value is
+	// an AWS credentials file.
+	// +optional
+	ControlPlaneOperatorCreds corev1.LocalObjectReference `json:"controlPlaneOperatorCreds,omitempty"`
+
+	// EndpointAccess specifies from where the Kube API server of the cluster
+	// can be reached. With PublicAndPrivate and Private access, the Kube API
+	// server is published on an internal load balancer which is exposed to the
+	// VPC of the cluster through AWS PrivateLink, and the nodes reach it with
+	// the api record of the private zone of the cluster. With Private access,
+	// the Kube API server has no public address, and since only the Kube API
+	// server is published through PrivateLink, the cluster must use the
+	// Konnectivity node connectivity and publish the OAuth server, ignition
+	// and Konnectivity server with the NodePort strategy.
+	// +kubebuilder:validation:Enum=Public;PublicAndPrivate;Private
+	// +kubebuilder:default=Public
+	// +optional
+	EndpointAccess AWSEndpointAccessType `json:"endpointAccess,omitempty"`
 }
 
+// AWSEndpointAccessType specifies from where the Kube API server of a cluster
+// can be reached.
+type AWSEndpointAccessType string
+
+const (
+	// Public endpoint access publishes the Kube API server with its
+	// publishing strategy only.
+	Public AWSEndpointAccessType = "Public"
+
+	// PublicAndPrivate endpoint access publishes the Kube API server with its
+	// publishing strategy, and privately to the VPC of the cluster.
+	PublicAndPrivate AWSEndpointAccessType = "PublicAndPrivate"
+
+	// Private endpoint access publishes the Kube API server privately to the
+	// VPC of the cluster only.
+	Private AWSEndpointAccessType = "Private"
+)
+
 type AWSRoleCredentials struct {
 	ARN       string `json:"arn"`
 	Namespace string `json:"namespace"`
@@ -154,9 +740,67 @@ type HostedClusterStatus struct {
 	// +optional
 	KubeConfig *corev1.LocalObjectReference `json:"kubeconfig,omitempty"`
 
+	// KubeadminPassword is a reference to the secret containing the initial
+	// kubeadmin user password for the cluster under the "password" key.
+	// +optional
+	KubeadminPassword *corev1.LocalObjectReference `json:"kubeadminPassword,omitempty"`
+
+	// ControlPlaneEndpoint contains the endpoint information by which
+	// external clients can access the cluster API server.
+	// +optional
+	ControlPlaneEndpoint APIEndpoint `json:"controlPlaneEndpoint,omitempty"`
+
+	// ConsoleURL is the URL of the cluster web console.
+	// +optional
+	ConsoleURL string `json:"consoleURL,omitempty"`
+
+	// OAuthCallbackURL is the base URL of the OAuth server callback endpoint
+	// which identity providers redirect to. The name of the identity provider
+	// is appended to it.
+	// +optional
+	OAuthCallbackURL string `json:"oauthCallbackURL,omitempty"`
+
+	// SizingProfile is the sizing profile applied to the control plane, which
+	// depends on the number of nodes when spec.sizing.auto is set.
+	// +optional
+	SizingProfile ControlPlaneSizingProfile `json:"sizingProfile,omitempty"`
+
+	// NodePools summarizes the status of the NodePools of the cluster.
+	// +optional
+	NodePools []NodePoolSummary `json:"nodePools,omitempty"`
+
+	// ReadyNodes is the number of ready nodes across all NodePools of the
+	// cluster.
+	// +optional
+	ReadyNodes int32 `json:"readyNodes"`
+
+	// Conditions contains details for the current state of the HostedCluster.
+	// Besides "Available", "ReconciliationPaused" and "ValidReferencedResources",
+	// the conditions reported by the HostedControlPlane are copied here.
+	// "ValidConfiguration" also reports problems with the HostedCluster spec.
 	Conditions []metav1.Condition `json:"conditions"`
 }
 
+// NodePoolSummary summarizes the status of a NodePool of a HostedCluster.
+type NodePoolSummary struct {
+	// Name is the name of the NodePool.
+	Name string `json:"name"`
+
+	// DesiredReplicas is the requested number of nodes, or the number of
+	// nodes the autoscaler currently asks for if the NodePool is autoscaled.
+	DesiredReplicas int32 `json:"desiredReplicas"`
+
+	// ReadyReplicas is the most recently observed number of ready nodes.
+	ReadyReplicas int32 `json:"readyReplicas"`
+
+	// Version is the semantic version of the release applied to the NodePool.
+	// +optional
+	Version string `json:"version,omitempty"`
+
+	// Upgrading indicates whether the NodePool is rolling out a new version.
+	Upgrading bool `json:"upgrading"`
+}
+
 // ClusterVersionStatus reports the status of the cluster versioning,
 // including any upgrades that are in progress. The current field will
 // be set to whichever version the cluster is reconciling to, and the
@@ -195,6 +839,7 @@ type ClusterVersionStatus struct {
 // +kubebuilder:subresource:status
 // +kubebuilder:printcolumn:name="Version",type="string",JSONPath=".status.version.history[?(@.state==\"Completed\")].version",description="Version"
 // +kubebuilder:printcolumn:name="KubeConfig",type="string",JSONPath=".status.kubeconfig.name",description="KubeConfig Secret"
+// +kubebuilder:printcolumn:name="Nodes",type="integer",JSONPath=".status.readyNodes",description="Ready Nodes"
 // +kubebuilder:printcolumn:name="Available",type="string",JSONPath=".status.conditions[?(@.type==\"Available\")].status",description="Available"
 // HostedCluster is the Schema for the hostedclusters API
 type HostedCluster struct {
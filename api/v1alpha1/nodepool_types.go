@@ -10,6 +10,12 @@ const (
 	NodePoolAsExpectedConditionReason       = "AsExpected"
 	NodePoolValidationFailedConditionReason = "ValidationFailed"
 	NodePoolUpgradingConditionType          = "Upgrading"
+	NodePoolReadyConditionType              = "Ready"
+	NodePoolScalingUpConditionType          = "ScalingUp"
+	NodePoolScalingDownConditionType        = "ScalingDown"
+	NodePoolWaitingForMachinesReason        = "WaitingForAvailableMachines"
+	NodePoolMachinesFailedReason            = "MachinesFailed"
+	NodePoolWaitingForNodesReason           = "WaitingForReadyNodes"
 )
 
 func init() {
@@ -27,6 +33,7 @@ func init() {
 // +kubebuilder:printcolumn:name="NodeCount",type="integer",JSONPath=".status.nodeCount",description="Available Nodes"
 // +kubebuilder:printcolumn:name="Autoscaling",type="string",JSONPath=".status.conditions[?(@.type==\"AutoscalingEnabled\")].status",description="Autoscaling Enabled"
 // +kubebuilder:printcolumn:name="Autorepair",type="string",JSONPath=".status.conditions[?(@.type==\"AutorepairEnabled\")].status",description="Node Autorepair Enabled"
+// +kubebuilder:printcolumn:name="Ready",type="string",JSONPath=".status.conditions[?(@.type==\"Ready\")].status",description="Ready"
 // +kubebuilder:printcolumn:name="Version",type="string",JSONPath=".status.version",description="Current version"
 // +kubebuilder:printcolumn:name="Upgrading",type="string",JSONPath=".status.conditions[?(@.type==\"Upgrading\")].status",description="Upgrade in progress"
 type NodePool struct {
@@ -72,6 +79,62 @@ type NodePoolStatus struct {
 	// an image artifact e.g an AMI in AWS.
 	// +kubebuilder:validation:Optional
 	Version string `json:"version,omitempty"`
+
+	// Replicas is the desired number of machines of the NodePool, i.e. the
+	// replicas of its MachineDeployment. For an autoscaled NodePool this is
+	// the size the autoscaler currently asks for.
+	// +optional
+	Replicas int32 `json:"replicas,omitempty"`
+
+	// ReadyReplicas is the number of machines of the NodePool whose node is
+	// ready.
+	// +optional
+	ReadyReplicas int32 `json:"readyReplicas,omitempty"`
+
+	// AvailableReplicas is the number of machines of the NodePool which have
+	// been ready for at least the minimum ready period.
+	// +optional
+	AvailableReplicas int32 `json:"availableReplicas,omitempty"`
+
+	// UpdatedReplicas is the number of machines of the NodePool which run the
+	// current machine template and version.
+	// +optional
+	UpdatedReplicas int32 `json:"updatedReplicas,omitempty"`
+
+	// Machines reports the state of each machine of the NodePool.
+	// +optional
+	Machines []NodePoolMachineStatus `json:"machines,omitempty"`
+}
+
+// NodePoolMachineStatus reports the state of a single machine of a NodePool.
+type NodePoolMachineStatus struct {
+	// Name is the name of the machine.
+	Name string `json:"name"`
+
+	// Phase is the lifecycle phase of the machine, e.g. Provisioning,
+	// Running or Failed.
+	// +optional
+	Phase string `json:"phase,omitempty"`
+
+	// NodeName is the name of the guest cluster node backing the machine.
+	// +optional
+	NodeName string `json:"nodeName,omitempty"`
+
+	// NodeReady indicates whether the guest cluster node backing the machine
+	// reports the Ready condition. It is unset while the nodes of the guest
+	// cluster can't be read.
+	// +optional
+	NodeReady *bool `json:"nodeReady,omitempty"`
+
+	// FailureReason is a short machine readable reason for a terminal
+	// failure of the machine or its infrastructure.
+	// +optional
+	FailureReason string `json:"failureReason,omitempty"`
+
+	// FailureMessage describes a terminal failure of the machine or its
+	// infrastructure.
+	// +optional
+	FailureMessage string `json:"failureMessage,omitempty"`
 }
 
 // +kubebuilder:object:root=true
@@ -24,7 +24,7 @@ import (
 	configv1 "github.com/openshift/api/config/v1"
 	"k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	runtime "k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime"
 )
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
@@ -89,6 +89,8 @@ func (in *AWSPlatformSpec) DeepCopyInto(out *AWSPlatformSpec) {
 	}
 	out.KubeCloudControllerCreds = in.KubeCloudControllerCreds
 	out.NodePoolManagementCreds = in.NodePoolManagementCreds
+	out.DNSManagementCreds = in.DNSManagementCreds
+	out.ControlPlaneOperatorCreds = in.ControlPlaneOperatorCreds
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AWSPlatformSpec.
@@ -163,9 +165,96 @@ func (in *AWSServiceEndpoint) DeepCopy() *AWSServiceEndpoint {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterAutoscaling) DeepCopyInto(out *ClusterAutoscaling) {
+	*out = *in
+	if in.ScaleDownDelayAfterAdd != nil {
+		in, out := &in.ScaleDownDelayAfterAdd, &out.ScaleDownDelayAfterAdd
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.ScaleDownUtilizationThreshold != nil {
+		in, out := &in.ScaleDownUtilizationThreshold, &out.ScaleDownUtilizationThreshold
+		*out = new(string)
+		**out = **in
+	}
+	if in.MaxNodesTotal != nil {
+		in, out := &in.MaxNodesTotal, &out.MaxNodesTotal
+		*out = new(int32)
+		**out = **in
+	}
+	if in.MaxPodGracePeriod != nil {
+		in, out := &in.MaxPodGracePeriod, &out.MaxPodGracePeriod
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterAutoscaling.
+func (in *ClusterAutoscaling) DeepCopy() *ClusterAutoscaling {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterAutoscaling)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterConfiguration) DeepCopyInto(out *ClusterConfiguration) {
+	*out = *in
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]runtime.RawExtension, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterConfiguration.
+func (in *ClusterConfiguration) DeepCopy() *ClusterConfiguration {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterConfiguration)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterNetworkEntry) DeepCopyInto(out *ClusterNetworkEntry) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterNetworkEntry.
+func (in *ClusterNetworkEntry) DeepCopy() *ClusterNetworkEntry {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterNetworkEntry)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ClusterNetworking) DeepCopyInto(out *ClusterNetworking) {
 	*out = *in
+	if in.ClusterNetwork != nil {
+		in, out := &in.ClusterNetwork, &out.ClusterNetwork
+		*out = make([]ClusterNetworkEntry, len(*in))
+		copy(*out, *in)
+	}
+	if in.ServiceNetwork != nil {
+		in, out := &in.ServiceNetwork, &out.ServiceNetwork
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.MachineNetwork != nil {
+		in, out := &in.MachineNetwork, &out.MachineNetwork
+		*out = make([]MachineNetworkEntry, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterNetworking.
@@ -201,6 +290,95 @@ func (in *ClusterVersionStatus) DeepCopy() *ClusterVersionStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ControlPlaneComponentResources) DeepCopyInto(out *ControlPlaneComponentResources) {
+	*out = *in
+	in.Resources.DeepCopyInto(&out.Resources)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ControlPlaneComponentResources.
+func (in *ControlPlaneComponentResources) DeepCopy() *ControlPlaneComponentResources {
+	if in == nil {
+		return nil
+	}
+	out := new(ControlPlaneComponentResources)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ControlPlaneOverride) DeepCopyInto(out *ControlPlaneOverride) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ControlPlaneOverride.
+func (in *ControlPlaneOverride) DeepCopy() *ControlPlaneOverride {
+	if in == nil {
+		return nil
+	}
+	out := new(ControlPlaneOverride)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ControlPlanePlacement) DeepCopyInto(out *ControlPlanePlacement) {
+	*out = *in
+	if in.NodeSelector != nil {
+		in, out := &in.NodeSelector, &out.NodeSelector
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Tolerations != nil {
+		in, out := &in.Tolerations, &out.Tolerations
+		*out = make([]v1.Toleration, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.TopologySpreadConstraints != nil {
+		in, out := &in.TopologySpreadConstraints, &out.TopologySpreadConstraints
+		*out = make([]v1.TopologySpreadConstraint, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ControlPlanePlacement.
+func (in *ControlPlanePlacement) DeepCopy() *ControlPlanePlacement {
+	if in == nil {
+		return nil
+	}
+	out := new(ControlPlanePlacement)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ControlPlaneSizing) DeepCopyInto(out *ControlPlaneSizing) {
+	*out = *in
+	if in.Overrides != nil {
+		in, out := &in.Overrides, &out.Overrides
+		*out = make([]ControlPlaneComponentResources, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ControlPlaneSizing.
+func (in *ControlPlaneSizing) DeepCopy() *ControlPlaneSizing {
+	if in == nil {
+		return nil
+	}
+	out := new(ControlPlaneSizing)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DNSSpec) DeepCopyInto(out *DNSSpec) {
 	*out = *in
@@ -392,9 +570,68 @@ func (in *HostedClusterSpec) DeepCopyInto(out *HostedClusterSpec) {
 	out.PullSecret = in.PullSecret
 	out.SigningKey = in.SigningKey
 	out.SSHKey = in.SSHKey
-	out.Networking = in.Networking
+	in.Networking.DeepCopyInto(&out.Networking)
 	in.Platform.DeepCopyInto(&out.Platform)
 	out.DNS = in.DNS
+	if in.PausedUntil != nil {
+		in, out := &in.PausedUntil, &out.PausedUntil
+		*out = new(string)
+		**out = **in
+	}
+	if in.Configuration != nil {
+		in, out := &in.Configuration, &out.Configuration
+		*out = new(ClusterConfiguration)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Proxy != nil {
+		in, out := &in.Proxy, &out.Proxy
+		*out = new(ProxySpec)
+		**out = **in
+	}
+	if in.AdditionalTrustBundle != nil {
+		in, out := &in.AdditionalTrustBundle, &out.AdditionalTrustBundle
+		*out = new(v1.LocalObjectReference)
+		**out = **in
+	}
+	if in.ImageContentSources != nil {
+		in, out := &in.ImageContentSources, &out.ImageContentSources
+		*out = make([]ImageContentSource, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Autoscaling != nil {
+		in, out := &in.Autoscaling, &out.Autoscaling
+		*out = new(ClusterAutoscaling)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.OAuth != nil {
+		in, out := &in.OAuth, &out.OAuth
+		*out = new(OAuthSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Sizing != nil {
+		in, out := &in.Sizing, &out.Sizing
+		*out = new(ControlPlaneSizing)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ControlPlanePlacement != nil {
+		in, out := &in.ControlPlanePlacement, &out.ControlPlanePlacement
+		*out = new(ControlPlanePlacement)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ControlPlaneOverrides != nil {
+		in, out := &in.ControlPlaneOverrides, &out.ControlPlaneOverrides
+		*out = make([]ControlPlaneOverride, len(*in))
+		copy(*out, *in)
+	}
+	if in.Services != nil {
+		in, out := &in.Services, &out.Services
+		*out = make([]ServicePublishingStrategyMapping, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HostedClusterSpec.
@@ -420,6 +657,17 @@ func (in *HostedClusterStatus) DeepCopyInto(out *HostedClusterStatus) {
 		*out = new(v1.LocalObjectReference)
 		**out = **in
 	}
+	if in.KubeadminPassword != nil {
+		in, out := &in.KubeadminPassword, &out.KubeadminPassword
+		*out = new(v1.LocalObjectReference)
+		**out = **in
+	}
+	out.ControlPlaneEndpoint = in.ControlPlaneEndpoint
+	if in.NodePools != nil {
+		in, out := &in.NodePools, &out.NodePools
+		*out = make([]NodePoolSummary, len(*in))
+		copy(*out, *in)
+	}
 	if in.Conditions != nil {
 		in, out := &in.Conditions, &out.Conditions
 		*out = make([]metav1.Condition, len(*in))
@@ -466,22 +714,6 @@ func (in *HostedControlPlane) DeepCopyObject() runtime.Object {
 	return nil
 }
 
-// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *HostedControlPlaneCondition) DeepCopyInto(out *HostedControlPlaneCondition) {
-	*out = *in
-	in.LastTransitionTime.DeepCopyInto(&out.LastTransitionTime)
-}
-
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HostedControlPlaneCondition.
-func (in *HostedControlPlaneCondition) DeepCopy() *HostedControlPlaneCondition {
-	if in == nil {
-		return nil
-	}
-	out := new(HostedControlPlaneCondition)
-	in.DeepCopyInto(out)
-	return out
-}
-
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *HostedControlPlaneList) DeepCopyInto(out *HostedControlPlaneList) {
 	*out = *in
@@ -522,11 +754,80 @@ func (in *HostedControlPlaneSpec) DeepCopyInto(out *HostedControlPlaneSpec) {
 	out.SSHKey = in.SSHKey
 	in.Platform.DeepCopyInto(&out.Platform)
 	out.DNS = in.DNS
+	if in.ClusterNetwork != nil {
+		in, out := &in.ClusterNetwork, &out.ClusterNetwork
+		*out = make([]ClusterNetworkEntry, len(*in))
+		copy(*out, *in)
+	}
+	if in.ServiceNetwork != nil {
+		in, out := &in.ServiceNetwork, &out.ServiceNetwork
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.MachineNetwork != nil {
+		in, out := &in.MachineNetwork, &out.MachineNetwork
+		*out = make([]MachineNetworkEntry, len(*in))
+		copy(*out, *in)
+	}
 	if in.KubeConfig != nil {
 		in, out := &in.KubeConfig, &out.KubeConfig
 		*out = new(KubeconfigSecretRef)
 		**out = **in
 	}
+	if in.PausedUntil != nil {
+		in, out := &in.PausedUntil, &out.PausedUntil
+		*out = new(string)
+		**out = **in
+	}
+	if in.Configuration != nil {
+		in, out := &in.Configuration, &out.Configuration
+		*out = new(ClusterConfiguration)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Proxy != nil {
+		in, out := &in.Proxy, &out.Proxy
+		*out = new(ProxySpec)
+		**out = **in
+	}
+	if in.AdditionalTrustBundle != nil {
+		in, out := &in.AdditionalTrustBundle, &out.AdditionalTrustBundle
+		*out = new(v1.LocalObjectReference)
+		**out = **in
+	}
+	if in.ImageContentSources != nil {
+		in, out := &in.ImageContentSources, &out.ImageContentSources
+		*out = make([]ImageContentSource, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.OAuth != nil {
+		in, out := &in.OAuth, &out.OAuth
+		*out = new(OAuthSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Sizing != nil {
+		in, out := &in.Sizing, &out.Sizing
+		*out = new(ControlPlaneSizing)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ControlPlanePlacement != nil {
+		in, out := &in.ControlPlanePlacement, &out.ControlPlanePlacement
+		*out = new(ControlPlanePlacement)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ControlPlaneOverrides != nil {
+		in, out := &in.ControlPlaneOverrides, &out.ControlPlaneOverrides
+		*out = make([]ControlPlaneOverride, len(*in))
+		copy(*out, *in)
+	}
+	if in.Services != nil {
+		in, out := &in.Services, &out.Services
+		*out = make([]ServicePublishingStrategyMapping, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HostedControlPlaneSpec.
@@ -557,9 +858,19 @@ func (in *HostedControlPlaneStatus) DeepCopyInto(out *HostedControlPlaneStatus)
 		*out = new(KubeconfigSecretRef)
 		**out = **in
 	}
+	if in.KubeadminPassword != nil {
+		in, out := &in.KubeadminPassword, &out.KubeadminPassword
+		*out = new(v1.LocalObjectReference)
+		**out = **in
+	}
+	if in.PrivateLink != nil {
+		in, out := &in.PrivateLink, &out.PrivateLink
+		*out = new(PrivateLinkStatus)
+		**out = **in
+	}
 	if in.Conditions != nil {
 		in, out := &in.Conditions, &out.Conditions
-		*out = make([]HostedControlPlaneCondition, len(*in))
+		*out = make([]metav1.Condition, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
@@ -576,6 +887,26 @@ func (in *HostedControlPlaneStatus) DeepCopy() *HostedControlPlaneStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ImageContentSource) DeepCopyInto(out *ImageContentSource) {
+	*out = *in
+	if in.Mirrors != nil {
+		in, out := &in.Mirrors, &out.Mirrors
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ImageContentSource.
+func (in *ImageContentSource) DeepCopy() *ImageContentSource {
+	if in == nil {
+		return nil
+	}
+	out := new(ImageContentSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *KubeconfigSecretRef) DeepCopyInto(out *KubeconfigSecretRef) {
 	*out = *in
@@ -680,6 +1011,21 @@ func (in *MachineConfigServerStatus) DeepCopy() *MachineConfigServerStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MachineNetworkEntry) DeepCopyInto(out *MachineNetworkEntry) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MachineNetworkEntry.
+func (in *MachineNetworkEntry) DeepCopy() *MachineNetworkEntry {
+	if in == nil {
+		return nil
+	}
+	out := new(MachineNetworkEntry)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *NodePool) DeepCopyInto(out *NodePool) {
 	*out = *in
@@ -764,6 +1110,26 @@ func (in *NodePoolList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodePoolMachineStatus) DeepCopyInto(out *NodePoolMachineStatus) {
+	*out = *in
+	if in.NodeReady != nil {
+		in, out := &in.NodeReady, &out.NodeReady
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodePoolMachineStatus.
+func (in *NodePoolMachineStatus) DeepCopy() *NodePoolMachineStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(NodePoolMachineStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *NodePoolManagement) DeepCopyInto(out *NodePoolManagement) {
 	*out = *in
@@ -837,6 +1203,13 @@ func (in *NodePoolStatus) DeepCopyInto(out *NodePoolStatus) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.Machines != nil {
+		in, out := &in.Machines, &out.Machines
+		*out = make([]NodePoolMachineStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodePoolStatus.
@@ -849,6 +1222,58 @@ func (in *NodePoolStatus) DeepCopy() *NodePoolStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodePoolSummary) DeepCopyInto(out *NodePoolSummary) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodePoolSummary.
+func (in *NodePoolSummary) DeepCopy() *NodePoolSummary {
+	if in == nil {
+		return nil
+	}
+	out := new(NodePoolSummary)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodePortPublishingStrategy) DeepCopyInto(out *NodePortPublishingStrategy) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodePortPublishingStrategy.
+func (in *NodePortPublishingStrategy) DeepCopy() *NodePortPublishingStrategy {
+	if in == nil {
+		return nil
+	}
+	out := new(NodePortPublishingStrategy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OAuthSpec) DeepCopyInto(out *OAuthSpec) {
+	*out = *in
+	if in.IdentityProviders != nil {
+		in, out := &in.IdentityProviders, &out.IdentityProviders
+		*out = make([]configv1.IdentityProvider, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OAuthSpec.
+func (in *OAuthSpec) DeepCopy() *OAuthSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(OAuthSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PlatformSpec) DeepCopyInto(out *PlatformSpec) {
 	*out = *in
@@ -869,6 +1294,36 @@ func (in *PlatformSpec) DeepCopy() *PlatformSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PrivateLinkStatus) DeepCopyInto(out *PrivateLinkStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PrivateLinkStatus.
+func (in *PrivateLinkStatus) DeepCopy() *PrivateLinkStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(PrivateLinkStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProxySpec) DeepCopyInto(out *ProxySpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProxySpec.
+func (in *ProxySpec) DeepCopy() *ProxySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ProxySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Release) DeepCopyInto(out *Release) {
 	*out = *in
@@ -883,3 +1338,44 @@ func (in *Release) DeepCopy() *Release {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ServicePublishingStrategy) DeepCopyInto(out *ServicePublishingStrategy) {
+	*out = *in
+	if in.NodePort != nil {
+		in, out := &in.NodePort, &out.NodePort
+		*out = new(NodePortPublishingStrategy)
+		**out = **in
+	}
+	if in.ServingCert != nil {
+		in, out := &in.ServingCert, &out.ServingCert
+		*out = new(v1.LocalObjectReference)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServicePublishingStrategy.
+func (in *ServicePublishingStrategy) DeepCopy() *ServicePublishingStrategy {
+	if in == nil {
+		return nil
+	}
+	out := new(ServicePublishingStrategy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ServicePublishingStrategyMapping) DeepCopyInto(out *ServicePublishingStrategyMapping) {
+	*out = *in
+	in.ServicePublishingStrategy.DeepCopyInto(&out.ServicePublishingStrategy)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServicePublishingStrategyMapping.
+func (in *ServicePublishingStrategyMapping) DeepCopy() *ServicePublishingStrategyMapping {
+	if in == nil {
+		return nil
+	}
+	out := new(ServicePublishingStrategyMapping)
+	in.DeepCopyInto(out)
+	return out
+}
@@ -0,0 +1,160 @@
+package v1beta1
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	"sigs.k8s.io/controller-runtime/pkg/conversion"
+
+	"github.com/openshift/hypershift/api/v1alpha1"
+)
+
+// InitialComputeReplicasAnnotation preserves the v1alpha1
+// spec.initialComputeReplicas field, which has no v1beta1 equivalent, so that
+// HostedClusters round-trip between versions without loss. NodePools should be
+// created explicitly instead.
+const InitialComputeReplicasAnnotation = "hypershift.openshift.io/initial-compute-replicas"
+
+// Fields which are identical between versions are carried across by their
+// shared JSON representation; only the fields whose shape changed are
+// converted explicitly.
+func convertJSON(src, dst interface{}) error {
+	data, err := json.Marshal(src)
+	if err != nil {
+		return fmt.Errorf("failed to marshal %T: %w", src, err)
+	}
+	if err := json.Unmarshal(data, dst); err != nil {
+		return fmt.Errorf("failed to unmarshal into %T: %w", dst, err)
+	}
+	return nil
+}
+
+// ConvertTo converts this HostedCluster to the hub version.
+func (src *HostedCluster) ConvertTo(dstRaw conversion.Hub) error {
+	dst := dstRaw.(*v1alpha1.HostedCluster)
+	if err := convertJSON(src, dst); err != nil {
+		return err
+	}
+	dst.APIVersion = v1alpha1.GroupVersion.String()
+	if value, ok := dst.Annotations[InitialComputeReplicasAnnotation]; ok {
+		replicas, err := strconv.Atoi(value)
+		if err != nil {
+			return fmt.Errorf("invalid %s annotation: %w", InitialComputeReplicasAnnotation, err)
+		}
+		dst.Spec.InitialComputeReplicas = replicas
+		delete(dst.Annotations, InitialComputeReplicasAnnotation)
+		if len(dst.Annotations) == 0 {
+			dst.Annotations = nil
+		}
+	}
+	return nil
+}
+
+// ConvertFrom converts from the hub version to this HostedCluster.
+func (dst *HostedCluster) ConvertFrom(srcRaw conversion.Hub) error {
+	src := srcRaw.(*v1alpha1.HostedCluster)
+	if err := convertJSON(src, dst); err != nil {
+		return err
+	}
+	dst.APIVersion = GroupVersion.String()
+	if src.Spec.InitialComputeReplicas != 0 {
+		if dst.Annotations == nil {
+			dst.Annotations = map[string]string{}
+		}
+		dst.Annotations[InitialComputeReplicasAnnotation] = strconv.Itoa(src.Spec.InitialComputeReplicas)
+	}
+	return nil
+}
+
+// ConvertTo converts this NodePool to the hub version.
+func (src *NodePool) ConvertTo(dstRaw conversion.Hub) error {
+	dst := dstRaw.(*v1alpha1.NodePool)
+	if err := convertJSON(src, dst); err != nil {
+		return err
+	}
+	dst.APIVersion = v1alpha1.GroupVersion.String()
+	dst.Spec.Management = v1alpha1.NodePoolManagement{
+		MaxUnavailable: int(src.Spec.Management.RollingUpdate.MaxUnavailable),
+		MaxSurge:       int(src.Spec.Management.RollingUpdate.MaxSurge),
+		AutoRepair:     src.Spec.Management.AutoRepair,
+	}
+	return nil
+}
+
+// ConvertFrom converts from the hub version to this NodePool.
+func (dst *NodePool) ConvertFrom(srcRaw conversion.Hub) error {
+	src := srcRaw.(*v1alpha1.NodePool)
+	if err := convertJSON(src, dst); err != nil {
+		return err
+	}
+	dst.APIVersion = GroupVersion.String()
+	dst.Spec.Management = NodePoolManagement{
+		RollingUpdate: RollingUpdate{
+			MaxUnavailable: int32(src.Spec.Management.MaxUnavailable),
+			MaxSurge:       int32(src.Spec.Management.MaxSurge),
+		},
+		AutoRepair: src.Spec.Management.AutoRepair,
+	}
+	return nil
+}
+
+// ConvertTo converts this HostedControlPlane to the hub version.
+func (src *HostedControlPlane) ConvertTo(dstRaw conversion.Hub) error {
+	dst := dstRaw.(*v1alpha1.HostedControlPlane)
+	if err := convertJSON(src, dst); err != nil {
+		return err
+	}
+	dst.APIVersion = v1alpha1.GroupVersion.String()
+	return nil
+}
+
+// ConvertFrom converts from the hub version to this HostedControlPlane.
+func (dst *HostedControlPlane) ConvertFrom(srcRaw conversion.Hub) error {
+	src := srcRaw.(*v1alpha1.HostedControlPlane)
+	if err := convertJSON(src, dst); err != nil {
+		return err
+	}
+	dst.APIVersion = GroupVersion.String()
+	return nil
+}
+
+// ConvertTo converts this ExternalInfraCluster to the hub version.
+func (src *ExternalInfraCluster) ConvertTo(dstRaw conversion.Hub) error {
+	dst := dstRaw.(*v1alpha1.ExternalInfraCluster)
+	if err := convertJSON(src, dst); err != nil {
+		return err
+	}
+	dst.APIVersion = v1alpha1.GroupVersion.String()
+	return nil
+}
+
+// ConvertFrom converts from the hub version to this ExternalInfraCluster.
+func (dst *ExternalInfraCluster) ConvertFrom(srcRaw conversion.Hub) error {
+	src := srcRaw.(*v1alpha1.ExternalInfraCluster)
+	if err := convertJSON(src, dst); err != nil {
+		return err
+	}
+	dst.APIVersion = GroupVersion.String()
+	return nil
+}
+
+// ConvertTo converts this MachineConfigServer to the hub version.
+func (src *MachineConfigServer) ConvertTo(dstRaw conversion.Hub) error {
+	dst := dstRaw.(*v1alpha1.MachineConfigServer)
+	if err := convertJSON(src, dst); err != nil {
+		return err
+	}
+	dst.APIVersion = v1alpha1.GroupVersion.String()
+	return nil
+}
+
+// ConvertFrom converts from the hub version to this MachineConfigServer.
+func (dst *MachineConfigServer) ConvertFrom(srcRaw conversion.Hub) error {
+	src := srcRaw.(*v1alpha1.MachineConfigServer)
+	if err := convertJSON(src, dst); err != nil {
+		return err
+	}
+	dst.APIVersion = GroupVersion.String()
+	return nil
+}
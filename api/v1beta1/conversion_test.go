@@ -0,0 +1,164 @@
+package v1beta1
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
+	"sigs.k8s.io/controller-runtime/pkg/conversion"
+
+	"github.com/openshift/hypershift/api/v1alpha1"
+)
+
+var now = metav1.NewTime(time.Now().Truncate(time.Second))
+
+func TestHubRoundTrip(t *testing.T) {
+	tests := map[string]struct {
+		Hub   conversion.Hub
+		Spoke conversion.Convertible
+	}{
+		"hostedcluster with initial compute replicas": {
+			Hub: &v1alpha1.HostedCluster{
+				TypeMeta:   metav1.TypeMeta{APIVersion: v1alpha1.GroupVersion.String(), Kind: "HostedCluster"},
+				ObjectMeta: metav1.ObjectMeta{Namespace: "clusters", Name: "example", Labels: map[string]string{"a": "b"}},
+				Spec: v1alpha1.HostedClusterSpec{
+					Release:                v1alpha1.Release{Image: "release"},
+					InitialComputeReplicas: 3,
+					PullSecret:             corev1.LocalObjectReference{Name: "pull-secret"},
+					Networking:             v1alpha1.ClusterNetworking{ServiceCIDR: "172.31.0.0/16", PodCIDR: "10.132.0.0/14", MachineCIDR: "10.0.0.0/16"},
+					Platform: v1alpha1.PlatformSpec{
+						Type: v1alpha1.AWSPlatform,
+						AWS:  &v1alpha1.AWSPlatformSpec{Region: "us-east-1", NodePoolDefaults: &v1alpha1.AWSNodePoolPlatform{InstanceType: "m5.large"}},
+					},
+					InfraID: "infra",
+				},
+				Status: v1alpha1.HostedClusterStatus{
+					Conditions: []metav1.Condition{{Type: "Available", Status: metav1.ConditionTrue, Reason: "AsExpected", LastTransitionTime: now}},
+				},
+			},
+			Spoke: &HostedCluster{},
+		},
+		"nodepool with management settings": {
+			Hub: &v1alpha1.NodePool{
+				TypeMeta:   metav1.TypeMeta{APIVersion: v1alpha1.GroupVersion.String(), Kind: "NodePool"},
+				ObjectMeta: metav1.ObjectMeta{Namespace: "clusters", Name: "example"},
+				Spec: v1alpha1.NodePoolSpec{
+					ClusterName: "example",
+					NodeCount:   pointer.Int32Ptr(2),
+					Management:  v1alpha1.NodePoolManagement{MaxUnavailable: 1, MaxSurge: 2, AutoRepair: true},
+					Platform:    v1alpha1.NodePoolPlatform{AWS: &v1alpha1.AWSNodePoolPlatform{InstanceType: "m5.large"}},
+				},
+				Status: v1alpha1.NodePoolStatus{NodeCount: 2},
+			},
+			Spoke: &NodePool{},
+		},
+		"hostedcontrolplane with conditions": {
+			Hub: &v1alpha1.HostedControlPlane{
+				TypeMeta:   metav1.TypeMeta{APIVersion: v1alpha1.GroupVersion.String(), Kind: "HostedControlPlane"},
+				ObjectMeta: metav1.ObjectMeta{Namespace: "clusters-example", Name: "example"},
+				Spec:       v1alpha1.HostedControlPlaneSpec{ReleaseImage: "release", ServiceCIDR: "172.31.0.0/16"},
+				Status: v1alpha1.HostedControlPlaneStatus{
+					Ready: true,
+					Conditions: []metav1.Condition{
+						{Type: string(v1alpha1.Available), Status: metav1.ConditionTrue, ObservedGeneration: 2, LastTransitionTime: now, Reason: "AsExpected", Message: "ready"},
+					},
+				},
+			},
+			Spoke: &HostedControlPlane{},
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			if err := test.Spoke.ConvertFrom(test.Hub); err != nil {
+				t.Fatalf("failed to convert from hub: %v", err)
+			}
+			actual := reflect.New(reflect.TypeOf(test.Hub).Elem()).Interface().(conversion.Hub)
+			if err := test.Spoke.ConvertTo(actual); err != nil {
+				t.Fatalf("failed to convert to hub: %v", err)
+			}
+			if !equality.Semantic.DeepEqual(test.Hub, actual) {
+				t.Errorf(cmp.Diff(test.Hub, actual))
+			}
+		})
+	}
+}
+
+func TestSpokeRoundTrip(t *testing.T) {
+	tests := map[string]struct {
+		Spoke       conversion.Convertible
+		ExpectedHub conversion.Hub
+	}{
+		"hostedcluster without initial compute replicas": {
+			Spoke: &HostedCluster{
+				TypeMeta:   metav1.TypeMeta{APIVersion: GroupVersion.String(), Kind: "HostedCluster"},
+				ObjectMeta: metav1.ObjectMeta{Namespace: "clusters", Name: "example", Annotations: map[string]string{"a": "b"}},
+				Spec: HostedClusterSpec{
+					Release:    Release{Image: "release"},
+					PullSecret: corev1.LocalObjectReference{Name: "pull-secret"},
+					Networking: ClusterNetworking{ServiceCIDR: "172.31.0.0/16", PodCIDR: "10.132.0.0/14", MachineCIDR: "10.0.0.0/16"},
+					Platform:   PlatformSpec{Type: AWSPlatform, AWS: &AWSPlatformSpec{Region: "us-east-1"}},
+					InfraID:    "infra",
+				},
+			},
+			ExpectedHub: &v1alpha1.HostedCluster{
+				TypeMeta:   metav1.TypeMeta{APIVersion: v1alpha1.GroupVersion.String(), Kind: "HostedCluster"},
+				ObjectMeta: metav1.ObjectMeta{Namespace: "clusters", Name: "example", Annotations: map[string]string{"a": "b"}},
+				Spec: v1alpha1.HostedClusterSpec{
+					Release:    v1alpha1.Release{Image: "release"},
+					PullSecret: corev1.LocalObjectReference{Name: "pull-secret"},
+					Networking: v1alpha1.ClusterNetworking{ServiceCIDR: "172.31.0.0/16", PodCIDR: "10.132.0.0/14", MachineCIDR: "10.0.0.0/16"},
+					Platform:   v1alpha1.PlatformSpec{Type: v1alpha1.AWSPlatform, AWS: &v1alpha1.AWSPlatformSpec{Region: "us-east-1"}},
+					InfraID:    "infra",
+				},
+			},
+		},
+		"nodepool with a rolling update": {
+			Spoke: &NodePool{
+				TypeMeta:   metav1.TypeMeta{APIVersion: GroupVersion.String(), Kind: "NodePool"},
+				ObjectMeta: metav1.ObjectMeta{Namespace: "clusters", Name: "example"},
+				Spec: NodePoolSpec{
+					ClusterName: "example",
+					NodeCount:   pointer.Int32Ptr(3),
+					Management: NodePoolManagement{
+						RollingUpdate: RollingUpdate{MaxUnavailable: 1, MaxSurge: 2},
+						AutoRepair:    true,
+					},
+					Platform: NodePoolPlatform{AWS: &AWSNodePoolPlatform{InstanceType: "m5.large"}},
+				},
+			},
+			ExpectedHub: &v1alpha1.NodePool{
+				TypeMeta:   metav1.TypeMeta{APIVersion: v1alpha1.GroupVersion.String(), Kind: "NodePool"},
+				ObjectMeta: metav1.ObjectMeta{Namespace: "clusters", Name: "example"},
+				Spec: v1alpha1.NodePoolSpec{
+					ClusterName: "example",
+					NodeCount:   pointer.Int32Ptr(3),
+					Management:  v1alpha1.NodePoolManagement{MaxUnavailable: 1, MaxSurge: 2, AutoRepair: true},
+					Platform:    v1alpha1.NodePoolPlatform{AWS: &v1alpha1.AWSNodePoolPlatform{InstanceType: "m5.large"}},
+				},
+			},
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			hub := reflect.New(reflect.TypeOf(test.ExpectedHub).Elem()).Interface().(conversion.Hub)
+			if err := test.Spoke.ConvertTo(hub); err != nil {
+				t.Fatalf("failed to convert to hub: %v", err)
+			}
+			if !equality.Semantic.DeepEqual(test.ExpectedHub, hub) {
+				t.Errorf(cmp.Diff(test.ExpectedHub, hub))
+			}
+			actual := reflect.New(reflect.TypeOf(test.Spoke).Elem()).Interface().(conversion.Convertible)
+			if err := actual.ConvertFrom(hub); err != nil {
+				t.Fatalf("failed to convert from hub: %v", err)
+			}
+			if !equality.Semantic.DeepEqual(test.Spoke, actual) {
+				t.Errorf(cmp.Diff(test.Spoke, actual))
+			}
+		})
+	}
+}
@@ -0,0 +1,242 @@
+package v1beta1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	NodePoolAutoscalingEnabledConditionType = "AutoscalingEnabled"
+	NodePoolAutorepairEnabledConditionType  = "AutorepairEnabled"
+	NodePoolAsExpectedConditionReason       = "AsExpected"
+	NodePoolValidationFailedConditionReason = "ValidationFailed"
+	NodePoolUpgradingConditionType          = "Upgrading"
+	NodePoolReadyConditionType              = "Ready"
+	NodePoolScalingUpConditionType          = "ScalingUp"
+	NodePoolScalingDownConditionType        = "ScalingDown"
+	NodePoolWaitingForMachinesReason        = "WaitingForAvailableMachines"
+	NodePoolMachinesFailedReason            = "MachinesFailed"
+	NodePoolWaitingForNodesReason           = "WaitingForReadyNodes"
+)
+
+func init() {
+	SchemeBuilder.Register(&NodePool{})
+	SchemeBuilder.Register(&NodePoolList{})
+}
+
+// NodePool defines the desired state of NodePool
+// +kubebuilder:resource:path=nodepools,shortName=np;nps,scope=Namespaced
+// +kubebuilder:subresource:status
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:scale:specpath=.spec.nodeCount,statuspath=.status.nodeCount
+// +kubebuilder:printcolumn:name="Cluster",type="string",JSONPath=".spec.clusterName",description="Cluster"
+// +kubebuilder:printcolumn:name="NodeCount",type="integer",JSONPath=".status.nodeCount",description="Available Nodes"
+// +kubebuilder:printcolumn:name="Autoscaling",type="string",JSONPath=".status.conditions[?(@.type==\"AutoscalingEnabled\")].status",description="Autoscaling Enabled"
+// +kubebuilder:printcolumn:name="Autorepair",type="string",JSONPath=".status.conditions[?(@.type==\"AutorepairEnabled\")].status",description="Node Autorepair Enabled"
+// +kubebuilder:printcolumn:name="Ready",type="string",JSONPath=".status.conditions[?(@.type==\"Ready\")].status",description="Ready"
+// +kubebuilder:printcolumn:name="Version",type="string",JSONPath=".status.version",description="Current version"
+// +kubebuilder:printcolumn:name="Upgrading",type="string",JSONPath=".status.conditions[?(@.type==\"Upgrading\")].status",description="Upgrade in progress"
+type NodePool struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   NodePoolSpec   `json:"spec,omitempty"`
+	Status NodePoolStatus `json:"status,omitempty"`
+}
+
+// NodePoolSpec defines the desired state of NodePool
+type NodePoolSpec struct {
+	// ClusterName is the name of the Cluster this object belongs to.
+	ClusterName string `json:"clusterName"`
+	// +optional
+	NodeCount *int32 `json:"nodeCount"`
+	// +optional
+	AutoScaling *NodePoolAutoScaling `json:"autoScaling,omitempty"`
+
+	// Management specifies how the nodes of the NodePool are replaced and
+	// repaired.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default={rollingUpdate: {maxSurge: 1, maxUnavailable: 0}, autoRepair: false}
+	Management NodePoolManagement `json:"management"`
+	Platform   NodePoolPlatform   `json:"platform"`
+
+	// +kubebuilder:validation:Optional
+	// Release specifies the release image to use for this NodePool
+	// For a nodePool a given version dictates the ignition config and
+	// an image artifact e.g an AMI in AWS.
+	// Release specifies the release image to use for this HostedCluster
+	Release Release `json:"release"`
+}
+
+// NodePoolStatus defines the observed state of NodePool
+type NodePoolStatus struct {
+	// NodeCount is the most recently observed number of replicas.
+	// +optional
+	NodeCount  int32              `json:"nodeCount"`
+	Conditions []metav1.Condition `json:"conditions"`
+
+	// Version is the semantic version of the release applied by
+	// the hosted control plane operator.
+	// For a nodePool a given version represents the ignition config and
+	// an image artifact e.g an AMI in AWS.
+	// +kubebuilder:validation:Optional
+	Version string `json:"version,omitempty"`
+
+	// Replicas is the desired number of machines of the NodePool, i.e. the
+	// replicas of its MachineDeployment. For an autoscaled NodePool this is
+	// the size the autoscaler currently asks for.
+	// +optional
+	Replicas int32 `json:"replicas,omitempty"`
+
+	// ReadyReplicas is the number of machines of the NodePool whose node is
+	// ready.
+	// +optional
+	ReadyReplicas int32 `json:"readyReplicas,omitempty"`
+
+	// AvailableReplicas is the number of machines of the NodePool which have
+	// been ready for at least the minimum ready period.
+	// +optional
+	AvailableReplicas int32 `json:"availableReplicas,omitempty"`
+
+	// UpdatedReplicas is the number of machines of the NodePool which run the
+	// current machine template and version.
+	// +optional
+	UpdatedReplicas int32 `json:"updatedReplicas,omitempty"`
+
+	// Machines reports the state of each machine of the NodePool.
+	// +optional
+	Machines []NodePoolMachineStatus `json:"machines,omitempty"`
+}
+
+// NodePoolMachineStatus reports the state of a single machine of a NodePool.
+type NodePoolMachineStatus struct {
+	// Name is the name of the machine.
+	Name string `json:"name"`
+
+	// Phase is the lifecycle phase of the machine, e.g. Provisioning,
+	// Running or Failed.
+	// +optional
+	Phase string `json:"phase,omitempty"`
+
+	// NodeName is the name of the guest cluster node backing the machine.
+	// +optional
+	NodeName string `json:"nodeName,omitempty"`
+
+	// NodeReady indicates whether the guest cluster node backing the machine
+	// reports the Ready condition. It is unset while the nodes of the guest
+	// cluster can't be read.
+	// +optional
+	NodeReady *bool `json:"nodeReady,omitempty"`
+
+	// FailureReason is a short machine readable reason for a terminal
+	// failure of the machine or its infrastructure.
+	// +optional
+	FailureReason string `json:"failureReason,omitempty"`
+
+	// FailureMessage describes a terminal failure of the machine or its
+	// infrastructure.
+	// +optional
+	FailureMessage string `json:"failureMessage,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// NodePoolList contains a list of NodePools.
+type NodePoolList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []NodePool `json:"items"`
+}
+
+// NodePoolManagement specifies the behavior of the nodes of a NodePool during
+// upgrades and failures.
+type NodePoolManagement struct {
+	// RollingUpdate specifies how nodes are replaced when the NodePool
+	// configuration or release changes.
+	// +optional
+	RollingUpdate RollingUpdate `json:"rollingUpdate"`
+
+	// AutoRepair enables automatic replacement of unhealthy nodes.
+	// +optional
+	AutoRepair bool `json:"autoRepair"`
+}
+
+// RollingUpdate specifies the bounds of a rolling node replacement.
+type RollingUpdate struct {
+	// MaxUnavailable is the maximum number of nodes that can be unavailable
+	// during the update.
+	// +optional
+	// +kubebuilder:default=0
+	// +kubebuilder:validation:Minimum=0
+	MaxUnavailable int32 `json:"maxUnavailable"`
+
+	// MaxSurge is the maximum number of nodes that can be created above the
+	// desired node count during the update.
+	// +optional
+	// +kubebuilder:default=1
+	// +kubebuilder:validation:Minimum=0
+	MaxSurge int32 `json:"maxSurge"`
+}
+
+type NodePoolAutoScaling struct {
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	Min *int `json:"min"`
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	Max *int `json:"max"`
+}
+
+// NodePoolPlatform is the platform-specific configuration for a node
+// pool. Only one of the platforms should be set.
+type NodePoolPlatform struct {
+	// AWS is the configuration used when installing on AWS.
+	AWS *AWSNodePoolPlatform `json:"aws,omitempty"`
+}
+
+// AWSNodePoolPlatform stores the configuration for a node pool
+// installed on AWS.
+type AWSNodePoolPlatform struct {
+	// InstanceType defines the ec2 instance type.
+	// eg. m4-large
+	InstanceType    string `json:"instanceType"`
+	InstanceProfile string `json:"instanceProfile,omitempty"`
+	// Subnet is the subnet to use for instances
+	// +optional
+	Subnet *AWSResourceReference `json:"subnet,omitempty"`
+	// AMI is the image id to use
+	// +optional
+	AMI string `json:"ami,omitempty"`
+	// SecurityGroups is the set of security groups to associate with nodepool machines
+	// +optional
+	SecurityGroups []AWSResourceReference `json:"securityGroups,omitempty"`
+	// Zone is the availability zone where the instances are created
+	// +optional
+	Zone string `json:"zone,omitempty"`
+}
+
+// AWSResourceReference is a reference to a specific AWS resource by ID, ARN, or filters.
+// Only one of ID, ARN or Filters may be specified. Specifying more than one will result in
+// a validation error.
+type AWSResourceReference struct {
+	// ID of resource
+	// +optional
+	ID *string `json:"id,omitempty"`
+
+	// ARN of resource
+	// +optional
+	ARN *string `json:"arn,omitempty"`
+
+	// Filters is a set of key/value pairs used to identify a resource
+	// They are applied according to the rules defined by the AWS API:
+	// https://docs.aws.amazon.com/AWSEC2/latest/UserGuide/Using_Filtering.html
+	// +optional
+	Filters []Filter `json:"filters,omitempty"`
+}
+
+// Filter is a filter used to identify an AWS resource
+type Filter struct {
+	// Name of the filter. Filter names are case-sensitive.
+	Name string `json:"name"`
+
+	// Values includes one or more filter values. Filter values are case-sensitive.
+	Values []string `json:"values"`
+}
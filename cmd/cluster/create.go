@@ -15,6 +15,7 @@ import (
 
 	hyperapi "github.com/openshift/hypershift/api"
 	apifixtures "github.com/openshift/hypershift/api/fixtures"
+	hyperv1 "github.com/openshift/hypershift/api/v1alpha1"
 	awsinfra "github.com/openshift/hypershift/cmd/infra/aws"
 	"github.com/openshift/hypershift/version"
 
@@ -42,6 +43,18 @@ type Options struct {
 	BaseDomain         string
 	PublicZoneID       string
 	PrivateZoneID      string
+
+	ControllerAvailabilityPolicy string
+	NetworkType                  string
+	EndpointAccess               string
+	MachineCIDR                  string
+	EnableIPv6                   bool
+	ClusterIPv6CIDR              string
+	ServiceIPv6CIDR              string
+	HTTPProxy                    string
+	HTTPSProxy                   string
+	NoProxy                      string
+	AdditionalTrustBundleFile    string
 }
 
 func NewCreateCommand() *cobra.Command {
@@ -73,6 +86,13 @@ func NewCreateCommand() *cobra.Command {
 		Region:             "us-east-1",
 		InfraID:            "",
 		InstanceType:       "m4.large",
+
+		ControllerAvailabilityPolicy: string(hyperv1.SingleReplica),
+		NetworkType:                  string(hyperv1.OpenShiftSDN),
+		EndpointAccess:               string(hyperv1.Public),
+		MachineCIDR:                  awsinfra.DefaultCIDRBlock,
+		ClusterIPv6CIDR:              "fd01::/48",
+		ServiceIPv6CIDR:              "fd02::/112",
 	}
 
 	cmd.Flags().StringVar(&opts.Namespace, "namespace", opts.Namespace, "A namespace to contain the generated resources")
@@ -89,6 +109,19 @@ func NewCreateCommand() *cobra.Command {
 	cmd.Flags().StringVar(&opts.InfraID, "infra-id", opts.InfraID, "Infrastructure ID to use for AWS resources.")
 	cmd.Flags().StringVar(&opts.InstanceType, "instance-type", opts.InstanceType, "Instance type for AWS instances.")
 	cmd.Flags().StringVar(&opts.BaseDomain, "base-domain", opts.BaseDomain, "The ingress base domain for the cluster")
+	cmd.Flags().StringVar(&opts.ControllerAvailabilityPolicy, "control-plane-availability-policy", opts.ControllerAvailabilityPolicy, "Availability policy for the control plane components (HighlyAvailable or SingleReplica)")
+	cmd.Flags().StringVar(&opts.NetworkType, "network-type", opts.NetworkType, "Network type of the cluster (OpenShiftSDN or OVNKubernetes)")
+	cmd.Flags().StringVar(&opts.EndpointAccess, "endpoint-access", opts.EndpointAccess, "Endpoint access of the Kube API server of the cluster (Public, PublicAndPrivate or Private)")
+
+	cmd.Flags().StringVar(&opts.MachineCIDR, "machine-cidr", opts.MachineCIDR, "The IPv4 CIDR block of the machines, used for the VPC when infrastructure is created")
+	cmd.Flags().BoolVar(&opts.EnableIPv6, "enable-ipv6", opts.EnableIPv6, "Create a dual-stack cluster with IPv6 machine, cluster and service networks (requires the OVNKubernetes network type)")
+	cmd.Flags().StringVar(&opts.ClusterIPv6CIDR, "cluster-cidr-ipv6", opts.ClusterIPv6CIDR, "The IPv6 cluster network of a dual-stack cluster")
+	cmd.Flags().StringVar(&opts.ServiceIPv6CIDR, "service-cidr-ipv6", opts.ServiceIPv6CIDR, "The IPv6 service network of a dual-stack cluster")
+
+	cmd.Flags().StringVar(&opts.HTTPProxy, "http-proxy", opts.HTTPProxy, "The URL of the cluster-wide proxy for HTTP requests")
+	cmd.Flags().StringVar(&opts.HTTPSProxy, "https-proxy", opts.HTTPSProxy, "The URL of the cluster-wide proxy for HTTPS requests")
+	cmd.Flags().StringVar(&opts.NoProxy, "no-proxy", opts.NoProxy, "Comma-separated hosts, domains and CIDRs reached without the proxy, in addition to the cluster networks")
+	cmd.Flags().StringVar(&opts.AdditionalTrustBundleFile, "additional-trust-bundle", opts.AdditionalTrustBundleFile, "Path to a file of PEM encoded certificates trusted by the cluster in addition to the system roots")
 
 	cmd.MarkFlagRequired("pull-secret")
 	cmd.MarkFlagRequired("aws-creds")
@@ -124,6 +157,14 @@ func CreateCluster(ctx context.Context, opts Options) error {
 		}
 		sshKey = key
 	}
+	var additionalTrustBundle []byte
+	if len(opts.AdditionalTrustBundleFile) > 0 {
+		bundle, err := ioutil.ReadFile(opts.AdditionalTrustBundleFile)
+		if err != nil {
+			return fmt.Errorf("failed to read additional trust bundle file: %w", err)
+		}
+		additionalTrustBundle = bundle
+	}
 	if len(opts.ReleaseImage) == 0 {
 		return fmt.Errorf("release-image flag is required if default can not be fetched")
 	}
@@ -156,6 +197,10 @@ func CreateCluster(ctx context.Context, opts Options) error {
 			AWSCredentialsFile: opts.AWSCredentialsFile,
 			Name:               opts.Name,
 			BaseDomain:         opts.BaseDomain,
+			NetworkType:        opts.NetworkType,
+			MachineCIDR:        opts.MachineCIDR,
+			EnableIPv6:         opts.EnableIPv6,
+			EndpointAccess:     opts.EndpointAccess,
 		}
 		infra, err = opt.CreateInfra()
 		if err != nil {
@@ -185,6 +230,19 @@ func CreateCluster(ctx context.Context, opts Options) error {
 		}
 	}
 
+	var computeIPv6CIDR string
+	if opts.EnableIPv6 {
+		if len(infra.ComputeIPv6CIDR) == 0 {
+			return fmt.Errorf("the infrastructure has no IPv6 CIDR block, which a dual-stack cluster requires")
+		}
+		computeIPv6CIDR = infra.ComputeIPv6CIDR
+	}
+
+	var proxy *hyperv1.ProxySpec
+	if len(opts.HTTPProxy) > 0 || len(opts.HTTPSProxy) > 0 {
+		proxy = &hyperv1.ProxySpec{HTTPProxy: opts.HTTPProxy, HTTPSProxy: opts.HTTPSProxy, NoProxy: opts.NoProxy}
+	}
+
 	exampleObjects := apifixtures.ExampleOptions{
 		Namespace:        opts.Namespace,
 		Name:             infra.Name,
@@ -200,6 +258,14 @@ func CreateCluster(ctx context.Context, opts Options) error {
 		BaseDomain:       infra.BaseDomain,
 		PublicZoneID:     infra.PublicZoneID,
 		PrivateZoneID:    infra.PrivateZoneID,
+
+		ControllerAvailabilityPolicy: hyperv1.AvailabilityPolicy(opts.ControllerAvailabilityPolicy),
+		NetworkType:                  hyperv1.NetworkType(opts.NetworkType),
+		ComputeIPv6CIDR:              computeIPv6CIDR,
+		ClusterIPv6CIDR:              opts.ClusterIPv6CIDR,
+		ServiceIPv6CIDR:              opts.ServiceIPv6CIDR,
+		Proxy:                        proxy,
+		AdditionalTrustBundle:        additionalTrustBundle,
 		AWS: apifixtures.ExampleAWSOptions{
 			Region:          infra.Region,
 			Zone:            infra.Zone,
@@ -208,6 +274,7 @@ func CreateCluster(ctx context.Context, opts Options) error {
 			SecurityGroupID: infra.SecurityGroupID,
 			InstanceProfile: iamInfo.ProfileName,
 			InstanceType:    opts.InstanceType,
+			EndpointAccess:  hyperv1.AWSEndpointAccessType(opts.EndpointAccess),
 			Roles:           iamInfo.Roles,
 		},
 	}.Resources().AsObjects()
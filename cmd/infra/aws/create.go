@@ -15,6 +15,8 @@ import (
 	"github.com/aws/aws-sdk-go/service/route53"
 	"github.com/aws/aws-sdk-go/service/route53/route53iface"
 	"github.com/spf13/cobra"
+
+	hyperv1 "github.com/openshift/hypershift/api/v1alpha1"
 )
 
 type CreateInfraOptions struct {
@@ -25,6 +27,10 @@ type CreateInfraOptions struct {
 	BaseDomain         string
 	OutputFile         string
 	AdditionalTags     []string
+	NetworkType        string
+	MachineCIDR        string
+	EnableIPv6         bool
+	EndpointAccess     string
 
 	additionalEC2Tags []*ec2.Tag
 }
@@ -34,6 +40,7 @@ type CreateInfraOutput struct {
 	Zone            string `json:"zone"`
 	InfraID         string `json:"infraID"`
 	ComputeCIDR     string `json:"computeCIDR"`
+	ComputeIPv6CIDR string `json:"computeIPv6CIDR,omitempty"`
 	VPCID           string `json:"vpcID"`
 	PrivateSubnetID string `json:"privateSubnetID"`
 	PublicSubnetID  string `json:"publicSubnetID"`
@@ -45,9 +52,7 @@ type CreateInfraOutput struct {
 }
 
 const (
-	DefaultCIDRBlock  = "10.0.0.0/16"
-	PrivateSubnetCIDR = "10.0.128.0/20"
-	PublicSubnetCIDR  = "10.0.0.0/20"
+	DefaultCIDRBlock = "10.0.0.0/16"
 
 	clusterTagValue = "owned"
 )
@@ -59,8 +64,11 @@ func NewCreateCommand() *cobra.Command {
 	}
 
 	opts := CreateInfraOptions{
-		Region: "us-east-1",
-		Name:   "example",
+		Region:         "us-east-1",
+		Name:           "example",
+		NetworkType:    string(hyperv1.OpenShiftSDN),
+		MachineCIDR:    DefaultCIDRBlock,
+		EndpointAccess: string(hyperv1.Public),
 	}
 
 	cmd.Flags().StringVar(&opts.InfraID, "infra-id", opts.InfraID, "Cluster ID with which to tag AWS resources (required)")
@@ -70,6 +78,11 @@ func NewCreateCommand() *cobra.Command {
 	cmd.Flags().StringSliceVar(&opts.AdditionalTags, "additional-tags", opts.AdditionalTags, "Additional tags to set on AWS resources")
 	cmd.Flags().StringVar(&opts.Name, "name", opts.Name, "A name for the cluster")
 	cmd.Flags().StringVar(&opts.BaseDomain, "base-domain", opts.BaseDomain, "The ingress base domain for the cluster")
+	cmd.Flags().StringVar(&opts.NetworkType, "network-type", opts.NetworkType, "Network type of the cluster, which determines the overlay traffic allowed between workers (OpenShiftSDN or OVNKubernetes)")
+
+	cmd.Flags().StringVar(&opts.MachineCIDR, "machine-cidr", opts.MachineCIDR, "The IPv4 CIDR block of the VPC, from which the public and private subnets are allocated")
+	cmd.Flags().BoolVar(&opts.EnableIPv6, "enable-ipv6", opts.EnableIPv6, "Assign an Amazon provided IPv6 CIDR block to the VPC and its subnets, for dual-stack clusters")
+	cmd.Flags().StringVar(&opts.EndpointAccess, "endpoint-access", opts.EndpointAccess, "Endpoint access of the Kube API server of the cluster (Public, PublicAndPrivate or Private). Unless it is Public, the workers are allowed to reach the VPC endpoint of the Kube API server")
 
 	cmd.MarkFlagRequired("infra-id")
 	cmd.MarkFlagRequired("aws-creds")
@@ -117,9 +130,21 @@ func (o *CreateInfraOptions) CreateInfra() (*CreateInfraOutput, error) {
 	if err = o.parseAdditionalTags(); err != nil {
 		return nil, err
 	}
+	if len(o.MachineCIDR) == 0 {
+		o.MachineCIDR = DefaultCIDRBlock
+	}
+	switch hyperv1.AWSEndpointAccessType(o.EndpointAccess) {
+	case "", hyperv1.Public, hyperv1.PublicAndPrivate, hyperv1.Private:
+	default:
+		return nil, fmt.Errorf("unsupported endpoint access %q", o.EndpointAccess)
+	}
+	publicSubnetCIDR, privateSubnetCIDR, err := subnetCIDRs(o.MachineCIDR)
+	if err != nil {
+		return nil, err
+	}
 	result := &CreateInfraOutput{
 		InfraID:     o.InfraID,
-		ComputeCIDR: DefaultCIDRBlock,
+		ComputeCIDR: o.MachineCIDR,
 		Region:      o.Region,
 		Name:        o.Name,
 		BaseDomain:  o.BaseDomain,
@@ -136,14 +161,27 @@ func (o *CreateInfraOptions) CreateInfra() (*CreateInfraOutput, error) {
 	if err != nil {
 		return nil, err
 	}
+	var publicSubnetIPv6CIDR, privateSubnetIPv6CIDR string
+	if o.EnableIPv6 {
+		result.ComputeIPv6CIDR, err = o.vpcIPv6CIDR(client, result.VPCID)
+		if err != nil {
+			return nil, err
+		}
+		if publicSubnetIPv6CIDR, err = ipv6SubnetCIDR(result.ComputeIPv6CIDR, 0); err != nil {
+			return nil, err
+		}
+		if privateSubnetIPv6CIDR, err = ipv6SubnetCIDR(result.ComputeIPv6CIDR, 1); err != nil {
+			return nil, err
+		}
+	}
 	if err = o.CreateDHCPOptions(client, result.VPCID); err != nil {
 		return nil, err
 	}
-	result.PrivateSubnetID, err = o.CreatePrivateSubnet(client, result.VPCID, result.Zone)
+	result.PrivateSubnetID, err = o.CreatePrivateSubnet(client, result.VPCID, result.Zone, privateSubnetCIDR, privateSubnetIPv6CIDR)
 	if err != nil {
 		return nil, err
 	}
-	result.PublicSubnetID, err = o.CreatePublicSubnet(client, result.VPCID, result.Zone)
+	result.PublicSubnetID, err = o.CreatePublicSubnet(client, result.VPCID, result.Zone, publicSubnetCIDR, publicSubnetIPv6CIDR)
 	if err != nil {
 		return nil, err
 	}
@@ -151,15 +189,22 @@ func (o *CreateInfraOptions) CreateInfra() (*CreateInfraOutput, error) {
 	if err != nil {
 		return nil, err
 	}
+	var egressOnlyIGWID string
+	if o.EnableIPv6 {
+		egressOnlyIGWID, err = o.CreateEgressOnlyInternetGateway(client, result.VPCID)
+		if err != nil {
+			return nil, err
+		}
+	}
 	natGatewayID, err := o.CreateNATGateway(client, result.PublicSubnetID, result.Zone)
 	if err != nil {
 		return nil, err
 	}
-	result.SecurityGroupID, err = o.CreateWorkerSecurityGroup(client, result.VPCID)
+	result.SecurityGroupID, err = o.CreateWorkerSecurityGroup(client, result.VPCID, result.ComputeIPv6CIDR)
 	if err != nil {
 		return nil, err
 	}
-	privateRouteTable, err := o.CreatePrivateRouteTable(client, result.VPCID, natGatewayID, result.PrivateSubnetID, result.Zone)
+	privateRouteTable, err := o.CreatePrivateRouteTable(client, result.VPCID, natGatewayID, egressOnlyIGWID, result.PrivateSubnetID, result.Zone)
 	if err != nil {
 		return nil, err
 	}
@@ -80,6 +80,7 @@ func (o *DestroyInfraOptions) DestroyInfra(ctx context.Context) error {
 		return err
 	}
 	errs = append(errs, o.DestroyInternetGateways(ctx, ec2client)...)
+	errs = append(errs, o.DestroyEgressOnlyInternetGateways(ctx, ec2client)...)
 	errs = append(errs, o.DestroyVPCs(ctx, ec2client, elbclient)...)
 	errs = append(errs, o.DestroyDHCPOptions(ctx, ec2client)...)
 	errs = append(errs, o.DestroyEIPs(ctx, ec2client)...)
@@ -336,6 +337,31 @@ func (o *DestroyInfraOptions) DestroyInternetGateways(ctx context.Context, clien
 	return nil
 }
 
+func (o *DestroyInfraOptions) DestroyEgressOnlyInternetGateways(ctx context.Context, client ec2iface.EC2API) []error {
+	var errs []error
+	deleteEgressOnlyInternetGateways := func(out *ec2.DescribeEgressOnlyInternetGatewaysOutput, _ bool) bool {
+		for _, gateway := range out.EgressOnlyInternetGateways {
+			_, err := client.DeleteEgressOnlyInternetGatewayWithContext(ctx, &ec2.DeleteEgressOnlyInternetGatewayInput{
+				EgressOnlyInternetGatewayId: gateway.EgressOnlyInternetGatewayId,
+			})
+			if err != nil {
+				errs = append(errs, err)
+			} else {
+				log.Info("Deleted egress only internet gateway", "id", aws.StringValue(gateway.EgressOnlyInternetGatewayId))
+			}
+		}
+		return true
+	}
+
+	err := client.DescribeEgressOnlyInternetGatewaysPagesWithContext(ctx,
+		&ec2.DescribeEgressOnlyInternetGatewaysInput{Filters: o.ec2Filters()},
+		deleteEgressOnlyInternetGateways)
+	if err != nil {
+		errs = append(errs, err)
+	}
+	return errs
+}
+
 func (o *DestroyInfraOptions) DestroySubnets(ctx context.Context, client ec2iface.EC2API, vpcID *string) []error {
 	var errs []error
 	deleteSubnets := func(out *ec2.DescribeSubnetsOutput, _ bool) bool {
@@ -40,8 +40,9 @@ func (o *CreateInfraOptions) createVPC(client ec2iface.EC2API) (string, error) {
 	}
 	if len(vpcID) == 0 {
 		createResult, err := client.CreateVpc(&ec2.CreateVpcInput{
-			CidrBlock:         aws.String(DefaultCIDRBlock),
-			TagSpecifications: o.ec2TagSpecifications("vpc", vpcName),
+			CidrBlock:                   aws.String(o.MachineCIDR),
+			AmazonProvidedIpv6CidrBlock: aws.Bool(o.EnableIPv6),
+			TagSpecifications:           o.ec2TagSpecifications("vpc", vpcName),
 		})
 		if err != nil {
 			return "", fmt.Errorf("failed to create VPC: %w", err)
@@ -175,15 +176,60 @@ func (o *CreateInfraOptions) existingDHCPOptions(client ec2iface.EC2API) (string
 	return optID, nil
 }
 
-func (o *CreateInfraOptions) CreatePrivateSubnet(client ec2iface.EC2API, vpcID string, zone string) (string, error) {
-	return o.CreateSubnet(client, vpcID, zone, PrivateSubnetCIDR, fmt.Sprintf("%s-private-%s", o.InfraID, zone))
+// vpcIPv6CIDR returns the Amazon provided IPv6 CIDR block of the VPC,
+// associating one with the VPC if it has none.
+func (o *CreateInfraOptions) vpcIPv6CIDR(client ec2iface.EC2API, vpcID string) (string, error) {
+	var cidr string
+	backoff := wait.Backoff{
+		Steps:    10,
+		Duration: 3 * time.Second,
+		Factor:   1.0,
+		Jitter:   0.1,
+	}
+	err := retry.OnError(backoff, func(error) bool { return true }, func() error {
+		result, err := client.DescribeVpcs(&ec2.DescribeVpcsInput{VpcIds: []*string{aws.String(vpcID)}})
+		if err != nil {
+			return fmt.Errorf("cannot describe VPC: %w", err)
+		}
+		if len(result.Vpcs) == 0 {
+			return fmt.Errorf("VPC %s not found", vpcID)
+		}
+		associations := result.Vpcs[0].Ipv6CidrBlockAssociationSet
+		if len(associations) == 0 {
+			_, err = client.AssociateVpcCidrBlock(&ec2.AssociateVpcCidrBlockInput{
+				VpcId:                       aws.String(vpcID),
+				AmazonProvidedIpv6CidrBlock: aws.Bool(true),
+			})
+			if err != nil {
+				return fmt.Errorf("cannot associate an IPv6 CIDR block with the VPC: %w", err)
+			}
+			log.Info("Associated IPv6 CIDR block with VPC", "id", vpcID)
+			return fmt.Errorf("IPv6 CIDR block not associated yet")
+		}
+		if state := aws.StringValue(associations[0].Ipv6CidrBlockState.State); state != ec2.VpcCidrBlockStateCodeAssociated {
+			return fmt.Errorf("IPv6 CIDR block is %s", state)
+		}
+		cidr = aws.StringValue(associations[0].Ipv6CidrBlock)
+		return nil
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to get the IPv6 CIDR block of the VPC: %w", err)
+	}
+	log.Info("Using VPC IPv6 CIDR block", "id", vpcID, "cidr", cidr)
+	return cidr, nil
 }
 
-func (o *CreateInfraOptions) CreatePublicSubnet(client ec2iface.EC2API, vpcID string, zone string) (string, error) {
-	return o.CreateSubnet(client, vpcID, zone, PublicSubnetCIDR, fmt.Sprintf("%s-public-%s", o.InfraID, zone))
+func (o *CreateInfraOptions) CreatePrivateSubnet(client ec2iface.EC2API, vpcID, zone, cidr, ipv6CIDR string) (string, error) {
+	return o.CreateSubnet(client, vpcID, zone, cidr, ipv6CIDR, fmt.Sprintf("%s-private-%s", o.InfraID, zone))
 }
 
-func (o *CreateInfraOptions) CreateSubnet(client ec2iface.EC2API, vpcID, zone, cidr, name string) (string, error) {
+func (o *CreateInfraOptions) CreatePublicSubnet(client ec2iface.EC2API, vpcID, zone, cidr, ipv6CIDR string) (string, error) {
+	return o.CreateSubnet(client, vpcID, zone, cidr, ipv6CIDR, fmt.Sprintf("%s-public-%s", o.InfraID, zone))
+}
+
+// CreateSubnet creates a subnet with the CIDR block, and with the IPv6 CIDR
+// block if one is given.
+func (o *CreateInfraOptions) CreateSubnet(client ec2iface.EC2API, vpcID, zone, cidr, ipv6CIDR, name string) (string, error) {
 	subnetID, err := o.existingSubnet(client, name)
 	if err != nil {
 		return "", err
@@ -192,17 +238,31 @@ func (o *CreateInfraOptions) CreateSubnet(client ec2iface.EC2API, vpcID, zone, c
 		log.Info("Found existing subnet", "name", name, "id", subnetID)
 		return subnetID, nil
 	}
-	result, err := client.CreateSubnet(&ec2.CreateSubnetInput{
+	input := &ec2.CreateSubnetInput{
 		AvailabilityZone:  aws.String(zone),
 		VpcId:             aws.String(vpcID),
 		CidrBlock:         aws.String(cidr),
 		TagSpecifications: o.ec2TagSpecifications("subnet", name),
-	})
+	}
+	if len(ipv6CIDR) > 0 {
+		input.Ipv6CidrBlock = aws.String(ipv6CIDR)
+	}
+	result, err := client.CreateSubnet(input)
 	if err != nil {
 		return "", fmt.Errorf("cannot create public subnet: %w", err)
 	}
 	subnetID = aws.StringValue(result.Subnet.SubnetId)
 	log.Info("Created subnet", "name", name, "id", subnetID)
+	if len(ipv6CIDR) > 0 {
+		_, err = client.ModifySubnetAttribute(&ec2.ModifySubnetAttributeInput{
+			SubnetId:                    aws.String(subnetID),
+			AssignIpv6AddressOnCreation: &ec2.AttributeBooleanValue{Value: aws.Bool(true)},
+		})
+		if err != nil {
+			return "", fmt.Errorf("cannot enable IPv6 address assignment on subnet: %w", err)
+		}
+		log.Info("Enabled IPv6 address assignment on subnet", "name", name, "id", subnetID)
+	}
 	return subnetID, nil
 }
 
@@ -268,6 +328,31 @@ func (o *CreateInfraOptions) existingInternetGateway(client ec2iface.EC2API, nam
 	return nil, nil
 }
 
+// CreateEgressOnlyInternetGateway creates the gateway through which the
+// private subnet reaches IPv6 destinations outside of the VPC.
+func (o *CreateInfraOptions) CreateEgressOnlyInternetGateway(client ec2iface.EC2API, vpcID string) (string, error) {
+	gatewayName := fmt.Sprintf("%s-eigw", o.InfraID)
+	result, err := client.DescribeEgressOnlyInternetGateways(&ec2.DescribeEgressOnlyInternetGatewaysInput{Filters: o.ec2Filters(gatewayName)})
+	if err != nil {
+		return "", fmt.Errorf("cannot list egress only internet gateways: %w", err)
+	}
+	if len(result.EgressOnlyInternetGateways) > 0 {
+		gatewayID := aws.StringValue(result.EgressOnlyInternetGateways[0].EgressOnlyInternetGatewayId)
+		log.Info("Found existing egress only internet gateway", "id", gatewayID)
+		return gatewayID, nil
+	}
+	createResult, err := client.CreateEgressOnlyInternetGateway(&ec2.CreateEgressOnlyInternetGatewayInput{
+		VpcId:             aws.String(vpcID),
+		TagSpecifications: o.ec2TagSpecifications("egress-only-internet-gateway", gatewayName),
+	})
+	if err != nil {
+		return "", fmt.Errorf("cannot create egress only internet gateway: %w", err)
+	}
+	gatewayID := aws.StringValue(createResult.EgressOnlyInternetGateway.EgressOnlyInternetGatewayId)
+	log.Info("Created egress only internet gateway", "id", gatewayID)
+	return gatewayID, nil
+}
+
 func (o *CreateInfraOptions) CreateNATGateway(client ec2iface.EC2API, publicSubnetID, availabilityZone string) (string, error) {
 	allocationID, err := o.existingEIP(client)
 	if err != nil {
@@ -342,7 +427,7 @@ func (o *CreateInfraOptions) existingNATGateway(client ec2iface.EC2API, name str
 	return nil, nil
 }
 
-func (o *CreateInfraOptions) CreatePrivateRouteTable(client ec2iface.EC2API, vpcID, natGatewayID, subnetID, zone string) (string, error) {
+func (o *CreateInfraOptions) CreatePrivateRouteTable(client ec2iface.EC2API, vpcID, natGatewayID, egressOnlyIGWID, subnetID, zone string) (string, error) {
 	tableName := fmt.Sprintf("%s-private-%s", o.InfraID, zone)
 	routeTable, err := o.existingRouteTable(client, tableName)
 	if err != nil {
@@ -382,6 +467,21 @@ func (o *CreateInfraOptions) CreatePrivateRouteTable(client ec2iface.EC2API, vpc
 	} else {
 		log.Info("Found existing route to NAT gateway", "route table", aws.StringValue(routeTable.RouteTableId), "nat gateway", natGatewayID)
 	}
+	if len(egressOnlyIGWID) > 0 {
+		if !o.hasIPv6Route(routeTable, egressOnlyIGWID) {
+			_, err = client.CreateRoute(&ec2.CreateRouteInput{
+				RouteTableId:                routeTable.RouteTableId,
+				EgressOnlyInternetGatewayId: aws.String(egressOnlyIGWID),
+				DestinationIpv6CidrBlock:    aws.String("::/0"),
+			})
+			if err != nil {
+				return "", fmt.Errorf("cannot create egress only internet gateway route in private route table: %w", err)
+			}
+			log.Info("Created route to egress only internet gateway", "route table", aws.StringValue(routeTable.RouteTableId), "gateway", egressOnlyIGWID)
+		} else {
+			log.Info("Found existing route to egress only internet gateway", "route table", aws.StringValue(routeTable.RouteTableId), "gateway", egressOnlyIGWID)
+		}
+	}
 	if !o.hasAssociatedSubnet(routeTable, subnetID) {
 		_, err = client.AssociateRouteTable(&ec2.AssociateRouteTableInput{
 			RouteTableId: routeTable.RouteTableId,
@@ -459,6 +559,21 @@ func (o *CreateInfraOptions) CreatePublicRouteTable(client ec2iface.EC2API, vpcI
 	} else {
 		log.Info("Found existing route to internet gateway", "route table", tableID, "internet gateway", igwID)
 	}
+	if o.EnableIPv6 {
+		if !o.hasIPv6Route(routeTable, igwID) {
+			_, err = client.CreateRoute(&ec2.CreateRouteInput{
+				DestinationIpv6CidrBlock: aws.String("::/0"),
+				RouteTableId:             aws.String(tableID),
+				GatewayId:                aws.String(igwID),
+			})
+			if err != nil {
+				return "", fmt.Errorf("cannot create IPv6 route to internet gateway: %w", err)
+			}
+			log.Info("Created IPv6 route to internet gateway", "route table", tableID, "internet gateway", igwID)
+		} else {
+			log.Info("Found existing IPv6 route to internet gateway", "route table", tableID, "internet gateway", igwID)
+		}
+	}
 
 	// Associate the route table with the public subnet ID
 	if !o.hasAssociatedSubnet(routeTable, subnetID) {
@@ -520,6 +635,18 @@ func (o *CreateInfraOptions) hasInternetGatewayRoute(table *ec2.RouteTable, igwI
 	return false
 }
 
+// hasIPv6Route returns whether the route table has a default IPv6 route to
+// the internet gateway or egress only internet gateway.
+func (o *CreateInfraOptions) hasIPv6Route(table *ec2.RouteTable, gatewayID string) bool {
+	for _, route := range table.Routes {
+		if (aws.StringValue(route.GatewayId) == gatewayID || aws.StringValue(route.EgressOnlyInternetGatewayId) == gatewayID) &&
+			aws.StringValue(route.DestinationIpv6CidrBlock) == "::/0" {
+			return true
+		}
+	}
+	return false
+}
+
 func (o *CreateInfraOptions) hasAssociatedSubnet(table *ec2.RouteTable, subnetID string) bool {
 	for _, assoc := range table.Associations {
 		if aws.StringValue(assoc.RouteTableId) == subnetID {
@@ -10,9 +10,13 @@ import (
 
 	"k8s.io/apimachinery/pkg/util/wait"
 	"k8s.io/client-go/util/retry"
+
+	hyperv1 "github.com/openshift/hypershift/api/v1alpha1"
 )
 
-func (o *CreateInfraOptions) CreateWorkerSecurityGroup(client ec2iface.EC2API, vpcID string) (string, error) {
+// CreateWorkerSecurityGroup creates the security group of the workers. When
+// the VPC has an IPv6 CIDR block, IPv6 traffic is allowed as for IPv4.
+func (o *CreateInfraOptions) CreateWorkerSecurityGroup(client ec2iface.EC2API, vpcID, vpcIPv6CIDR string) (string, error) {
 	groupName := fmt.Sprintf("%s-worker-sg", o.InfraID)
 	securityGroup, err := o.existingSecurityGroup(client, groupName)
 	if err != nil {
@@ -70,7 +74,7 @@ func (o *CreateInfraOptions) CreateWorkerSecurityGroup(client ec2iface.EC2API, v
 			IpProtocol: aws.String("icmp"),
 			IpRanges: []*ec2.IpRange{
 				{
-					CidrIp: aws.String(DefaultCIDRBlock),
+					CidrIp: aws.String(o.MachineCIDR),
 				},
 			},
 			FromPort: aws.Int64(-1),
@@ -80,65 +84,12 @@ func (o *CreateInfraOptions) CreateWorkerSecurityGroup(client ec2iface.EC2API, v
 			IpProtocol: aws.String("tcp"),
 			IpRanges: []*ec2.IpRange{
 				{
-					CidrIp: aws.String(DefaultCIDRBlock),
+					CidrIp: aws.String(o.MachineCIDR),
 				},
 			},
 			FromPort: aws.Int64(22),
 			ToPort:   aws.Int64(22),
 		},
-		{
-			FromPort:   aws.Int64(4789),
-			ToPort:     aws.Int64(4789),
-			IpProtocol: aws.String("udp"),
-			UserIdGroupPairs: []*ec2.UserIdGroupPair{
-				{
-					GroupId: aws.String(securityGroupID),
-					UserId:  aws.String(sgUserID),
-				},
-			},
-		},
-		{
-			FromPort:   aws.Int64(6081),
-			ToPort:     aws.Int64(6081),
-			IpProtocol: aws.String("udp"),
-			UserIdGroupPairs: []*ec2.UserIdGroupPair{
-				{
-					GroupId: aws.String(securityGroupID),
-					UserId:  aws.String(sgUserID),
-				},
-			},
-		},
-		{
-			FromPort:   aws.Int64(500),
-			ToPort:     aws.Int64(500),
-			IpProtocol: aws.String("udp"),
-			UserIdGroupPairs: []*ec2.UserIdGroupPair{
-				{
-					GroupId: aws.String(securityGroupID),
-					UserId:  aws.String(sgUserID),
-				},
-			},
-		},
-		{
-			FromPort:   aws.Int64(4500),
-			ToPort:     aws.Int64(4500),
-			IpProtocol: aws.String("udp"),
-			UserIdGroupPairs: []*ec2.UserIdGroupPair{
-				{
-					GroupId: aws.String(securityGroupID),
-					UserId:  aws.String(sgUserID),
-				},
-			},
-		},
-		{
-			IpProtocol: aws.String("50"),
-			UserIdGroupPairs: []*ec2.UserIdGroupPair{
-				{
-					GroupId: aws.String(securityGroupID),
-					UserId:  aws.String(sgUserID),
-				},
-			},
-		},
 		{
 			FromPort:   aws.Int64(9000),
 			ToPort:     aws.Int64(9999),
@@ -196,6 +147,57 @@ func (o *CreateInfraOptions) CreateWorkerSecurityGroup(client ec2iface.EC2API, v
 		},
 	}
 
+	if len(vpcIPv6CIDR) > 0 {
+		egressPermissions = append(egressPermissions, &ec2.IpPermission{
+			IpProtocol: aws.String("-1"),
+			Ipv6Ranges: []*ec2.Ipv6Range{
+				{
+					CidrIpv6: aws.String("::/0"),
+				},
+			},
+		})
+		ingressPermissions = append(ingressPermissions,
+			&ec2.IpPermission{
+				// ICMPv6
+				IpProtocol: aws.String("58"),
+				Ipv6Ranges: []*ec2.Ipv6Range{
+					{
+						CidrIpv6: aws.String(vpcIPv6CIDR),
+					},
+				},
+				FromPort: aws.Int64(-1),
+				ToPort:   aws.Int64(-1),
+			},
+			&ec2.IpPermission{
+				IpProtocol: aws.String("tcp"),
+				Ipv6Ranges: []*ec2.Ipv6Range{
+					{
+						CidrIpv6: aws.String(vpcIPv6CIDR),
+					},
+				},
+				FromPort: aws.Int64(22),
+				ToPort:   aws.Int64(22),
+			},
+		)
+	}
+
+	if len(o.EndpointAccess) > 0 && hyperv1.AWSEndpointAccessType(o.EndpointAccess) != hyperv1.Public {
+		// The VPC endpoint of the Kube API server has the security group of
+		// the workers.
+		ingressPermissions = append(ingressPermissions, &ec2.IpPermission{
+			IpProtocol: aws.String("tcp"),
+			IpRanges: []*ec2.IpRange{
+				{
+					CidrIp: aws.String(o.MachineCIDR),
+				},
+			},
+			FromPort: aws.Int64(6443),
+			ToPort:   aws.Int64(6443),
+		})
+	}
+
+	ingressPermissions = append(ingressPermissions, overlayIngressPermissions(hyperv1.NetworkType(o.NetworkType), securityGroupID, sgUserID)...)
+
 	var egressToAuthorize []*ec2.IpPermission
 	var ingressToAuthorize []*ec2.IpPermission
 
@@ -234,6 +236,41 @@ func (o *CreateInfraOptions) CreateWorkerSecurityGroup(client ec2iface.EC2API, v
 	return securityGroupID, nil
 }
 
+// overlayIngressPermissions returns the rules which allow the overlay network
+// traffic of the network type between workers: VXLAN for OpenShiftSDN, and
+// Geneve and IPsec for OVNKubernetes.
+func overlayIngressPermissions(networkType hyperv1.NetworkType, securityGroupID, sgUserID string) []*ec2.IpPermission {
+	workerPermission := func(protocol string, port int64) *ec2.IpPermission {
+		permission := &ec2.IpPermission{
+			IpProtocol: aws.String(protocol),
+			UserIdGroupPairs: []*ec2.UserIdGroupPair{
+				{
+					GroupId: aws.String(securityGroupID),
+					UserId:  aws.String(sgUserID),
+				},
+			},
+		}
+		if port > 0 {
+			permission.FromPort = aws.Int64(port)
+			permission.ToPort = aws.Int64(port)
+		}
+		return permission
+	}
+	switch networkType {
+	case hyperv1.OVNKubernetes:
+		return []*ec2.IpPermission{
+			workerPermission("udp", 6081),
+			workerPermission("udp", 500),
+			workerPermission("udp", 4500),
+			workerPermission("50", 0),
+		}
+	default:
+		return []*ec2.IpPermission{
+			workerPermission("udp", 4789),
+		}
+	}
+}
+
 func (o *CreateInfraOptions) existingSecurityGroup(client ec2iface.EC2API, name string) (*ec2.SecurityGroup, error) {
 	result, err := client.DescribeSecurityGroups(&ec2.DescribeSecurityGroupsInput{Filters: o.ec2Filters(name)})
 	if err != nil {
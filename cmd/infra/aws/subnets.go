@@ -0,0 +1,49 @@
+package aws
+
+import (
+	"fmt"
+	"net"
+)
+
+// subnetCIDRs returns the CIDR blocks of the public and private subnets of a
+// VPC. Each subnet is a sixteenth of the VPC, the public subnet at its start
+// and the private subnet at its middle, so that 10.0.0.0/16 has the subnets
+// 10.0.0.0/20 and 10.0.128.0/20.
+func subnetCIDRs(vpcCIDR string) (string, string, error) {
+	ip, network, err := net.ParseCIDR(vpcCIDR)
+	if err != nil {
+		return "", "", fmt.Errorf("invalid machine CIDR %q: %w", vpcCIDR, err)
+	}
+	if ip.To4() == nil {
+		return "", "", fmt.Errorf("machine CIDR %q is not an IPv4 network", vpcCIDR)
+	}
+	ones, bits := network.Mask.Size()
+	// AWS subnets are at most /28.
+	if ones > 24 {
+		return "", "", fmt.Errorf("machine CIDR %q must be at least a /24", vpcCIDR)
+	}
+	subnetMask := net.CIDRMask(ones+4, bits)
+	public := &net.IPNet{IP: network.IP.To4(), Mask: subnetMask}
+
+	private := make(net.IP, net.IPv4len)
+	copy(private, network.IP.To4())
+	// Set the most significant bit of the host part of the VPC network.
+	private[ones/8] |= 0x80 >> (ones % 8)
+	return public.String(), (&net.IPNet{IP: private, Mask: subnetMask}).String(), nil
+}
+
+// ipv6SubnetCIDR returns the index'th /64 subnet of the /56 IPv6 CIDR block
+// which AWS provides to a VPC.
+func ipv6SubnetCIDR(vpcCIDR string, index byte) (string, error) {
+	_, network, err := net.ParseCIDR(vpcCIDR)
+	if err != nil {
+		return "", fmt.Errorf("invalid VPC IPv6 CIDR %q: %w", vpcCIDR, err)
+	}
+	if ones, bits := network.Mask.Size(); bits != 8*net.IPv6len || ones > 56 {
+		return "", fmt.Errorf("VPC IPv6 CIDR %q must be at least a /56", vpcCIDR)
+	}
+	ip := make(net.IP, net.IPv6len)
+	copy(ip, network.IP)
+	ip[7] = index
+	return (&net.IPNet{IP: ip, Mask: net.CIDRMask(64, 128)}).String(), nil
+}
@@ -1,11 +1,19 @@
 package assets
 
 import (
+	"sort"
+	"strings"
+
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
 	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/utils/pointer"
+
+	"github.com/openshift/hypershift/hypershift-operator/webhook"
 )
 
 type HyperShiftNamespace struct {
@@ -30,9 +38,29 @@ type HyperShiftOperatorDeployment struct {
 	OperatorImage  string
 	ServiceAccount *corev1.ServiceAccount
 	Replicas       int32
+	EnableWebhook  bool
+	ImageOverrides map[string]string
+	// AllowedImageAnnotations are the names of the components whose images
+	// may be overridden per HostedCluster with an annotation.
+	AllowedImageAnnotations []string
 }
 
 func (o HyperShiftOperatorDeployment) Build() *appsv1.Deployment {
+	args := []string{"run", "--namespace", "$(MY_NAMESPACE)", "--deployment-name", "operator"}
+	if o.EnableWebhook {
+		args = append(args, "--enable-webhook", "--webhook-cert-dir", "/var/run/secrets/serving-cert")
+	}
+	if len(o.ImageOverrides) > 0 {
+		var overrides []string
+		for name, image := range o.ImageOverrides {
+			overrides = append(overrides, name+"="+image)
+		}
+		sort.Strings(overrides)
+		args = append(args, "--image-overrides", strings.Join(overrides, ","))
+	}
+	if len(o.AllowedImageAnnotations) > 0 {
+		args = append(args, "--allowed-image-annotations", strings.Join(o.AllowedImageAnnotations, ","))
+	}
 	deployment := &appsv1.Deployment{
 		TypeMeta: metav1.TypeMeta{
 			Kind:       "Deployment",
@@ -73,7 +101,28 @@ func (o HyperShiftOperatorDeployment) Build() *appsv1.Deployment {
 								},
 							},
 							Command: []string{"/usr/bin/hypershift-operator"},
-							Args:    []string{"run", "--namespace", "$(MY_NAMESPACE)", "--deployment-name", "operator"},
+							Args:    args,
+							Ports: []corev1.ContainerPort{
+								{
+									Name:          "webhook",
+									ContainerPort: webhook.ServerPort,
+									Protocol:      corev1.ProtocolTCP,
+								},
+							},
+							VolumeMounts: []corev1.VolumeMount{
+								{
+									Name:      "serving-cert",
+									MountPath: "/var/run/secrets/serving-cert",
+								},
+							},
+						},
+					},
+					Volumes: []corev1.Volume{
+						{
+							Name: "serving-cert",
+							VolumeSource: corev1.VolumeSource{
+								EmptyDir: &corev1.EmptyDirVolumeSource{},
+							},
 						},
 					},
 				},
@@ -83,6 +132,141 @@ func (o HyperShiftOperatorDeployment) Build() *appsv1.Deployment {
 	return deployment
 }
 
+type HyperShiftOperatorService struct {
+	Namespace *corev1.Namespace
+}
+
+func (o HyperShiftOperatorService) Build() *corev1.Service {
+	service := &corev1.Service{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "Service",
+			APIVersion: corev1.SchemeGroupVersion.String(),
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: o.Namespace.Name,
+			Name:      webhook.ServiceName,
+		},
+		Spec: corev1.ServiceSpec{
+			Selector: map[string]string{
+				"name": "operator",
+			},
+			Ports: []corev1.ServicePort{
+				{
+					Name:       "webhook",
+					Port:       443,
+					Protocol:   corev1.ProtocolTCP,
+					TargetPort: intstr.FromString("webhook"),
+				},
+			},
+		},
+	}
+	return service
+}
+
+type HyperShiftMutatingWebhookConfiguration struct {
+	Namespace *corev1.Namespace
+}
+
+func (o HyperShiftMutatingWebhookConfiguration) Build() *admissionregistrationv1.MutatingWebhookConfiguration {
+	config := &admissionregistrationv1.MutatingWebhookConfiguration{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "MutatingWebhookConfiguration",
+			APIVersion: admissionregistrationv1.SchemeGroupVersion.String(),
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name: webhook.WebhookConfigurationName,
+		},
+		Webhooks: []admissionregistrationv1.MutatingWebhook{
+			{
+				Name:                    "hostedclusters.defaulting.hypershift.openshift.io",
+				ClientConfig:            webhookClientConfig(o.Namespace.Name, webhook.HostedClusterDefaultingPath),
+				Rules:                   webhookRules("hostedclusters"),
+				FailurePolicy:           webhookFailurePolicy(),
+				SideEffects:             webhookSideEffects(),
+				AdmissionReviewVersions: []string{"v1", "v1beta1"},
+			},
+			{
+				Name:                    "nodepools.defaulting.hypershift.openshift.io",
+				ClientConfig:            webhookClientConfig(o.Namespace.Name, webhook.NodePoolDefaultingPath),
+				Rules:                   webhookRules("nodepools"),
+				FailurePolicy:           webhookFailurePolicy(),
+				SideEffects:             webhookSideEffects(),
+				AdmissionReviewVersions: []string{"v1", "v1beta1"},
+			},
+		},
+	}
+	return config
+}
+
+type HyperShiftValidatingWebhookConfiguration struct {
+	Namespace *corev1.Namespace
+}
+
+func (o HyperShiftValidatingWebhookConfiguration) Build() *admissionregistrationv1.ValidatingWebhookConfiguration {
+	config := &admissionregistrationv1.ValidatingWebhookConfiguration{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "ValidatingWebhookConfiguration",
+			APIVersion: admissionregistrationv1.SchemeGroupVersion.String(),
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name: webhook.WebhookConfigurationName,
+		},
+		Webhooks: []admissionregistrationv1.ValidatingWebhook{
+			{
+				Name:                    "hostedclusters.validating.hypershift.openshift.io",
+				ClientConfig:            webhookClientConfig(o.Namespace.Name, webhook.HostedClusterValidatingPath),
+				Rules:                   webhookRules("hostedclusters"),
+				FailurePolicy:           webhookFailurePolicy(),
+				SideEffects:             webhookSideEffects(),
+				AdmissionReviewVersions: []string{"v1", "v1beta1"},
+			},
+			{
+				Name:                    "nodepools.validating.hypershift.openshift.io",
+				ClientConfig:            webhookClientConfig(o.Namespace.Name, webhook.NodePoolValidatingPath),
+				Rules:                   webhookRules("nodepools"),
+				FailurePolicy:           webhookFailurePolicy(),
+				SideEffects:             webhookSideEffects(),
+				AdmissionReviewVersions: []string{"v1", "v1beta1"},
+			},
+		},
+	}
+	return config
+}
+
+func webhookClientConfig(namespace, path string) admissionregistrationv1.WebhookClientConfig {
+	return admissionregistrationv1.WebhookClientConfig{
+		Service: &admissionregistrationv1.ServiceReference{
+			Namespace: namespace,
+			Name:      webhook.ServiceName,
+			Path:      pointer.StringPtr(path),
+			Port:      pointer.Int32Ptr(443),
+		},
+	}
+}
+
+func webhookRules(resource string) []admissionregistrationv1.RuleWithOperations {
+	return []admissionregistrationv1.RuleWithOperations{
+		{
+			Operations: []admissionregistrationv1.OperationType{admissionregistrationv1.Create, admissionregistrationv1.Update},
+			Rule: admissionregistrationv1.Rule{
+				APIGroups:   []string{"hypershift.openshift.io"},
+				APIVersions: []string{"v1alpha1"},
+				Resources:   []string{resource},
+			},
+		},
+	}
+}
+
+func webhookFailurePolicy() *admissionregistrationv1.FailurePolicyType {
+	policy := admissionregistrationv1.Fail
+	return &policy
+}
+
+func webhookSideEffects() *admissionregistrationv1.SideEffectClass {
+	sideEffects := admissionregistrationv1.SideEffectClassNone
+	return &sideEffects
+}
+
 type HyperShiftOperatorServiceAccount struct {
 	Namespace *corev1.Namespace
 }
@@ -179,9 +363,19 @@ func (o HyperShiftOperatorClusterRole) Build() *rbacv1.ClusterRole {
 			},
 			{
 				APIGroups: []string{"apps"},
-				Resources: []string{"deployments"},
+				Resources: []string{"deployments", "statefulsets"},
 				Verbs:     []string{"*"},
 			},
+			{
+				APIGroups: []string{"policy"},
+				Resources: []string{"poddisruptionbudgets"},
+				Verbs:     []string{"*"},
+			},
+			{
+				APIGroups: []string{"admissionregistration.k8s.io"},
+				Resources: []string{"mutatingwebhookconfigurations", "validatingwebhookconfigurations"},
+				Verbs:     []string{"get", "list", "watch", "update", "patch"},
+			},
 			{
 				APIGroups: []string{"etcd.database.coreos.com"},
 				Resources: []string{"*"},
@@ -227,22 +421,51 @@ func (o HyperShiftOperatorClusterRoleBinding) Build() *rbacv1.ClusterRoleBinding
 	return binding
 }
 
-type HyperShiftHostedClustersCustomResourceDefinition struct{}
+// HyperShiftConversionWebhook is the CRD conversion configuration which routes
+// conversion between served HyperShift API versions through the operator.
+type HyperShiftConversionWebhook struct {
+	Namespace *corev1.Namespace
+}
+
+func (o HyperShiftConversionWebhook) Build() *apiextensionsv1.CustomResourceConversion {
+	return &apiextensionsv1.CustomResourceConversion{
+		Strategy: apiextensionsv1.WebhookConverter,
+		Webhook: &apiextensionsv1.WebhookConversion{
+			ClientConfig: &apiextensionsv1.WebhookClientConfig{
+				Service: &apiextensionsv1.ServiceReference{
+					Namespace: o.Namespace.Name,
+					Name:      webhook.ServiceName,
+					Path:      pointer.StringPtr(webhook.ConversionPath),
+					Port:      pointer.Int32Ptr(443),
+				},
+			},
+			ConversionReviewVersions: []string{"v1", "v1beta1"},
+		},
+	}
+}
+
+type HyperShiftHostedClustersCustomResourceDefinition struct {
+	Conversion *apiextensionsv1.CustomResourceConversion
+}
 
 func (o HyperShiftHostedClustersCustomResourceDefinition) Build() *apiextensionsv1.CustomResourceDefinition {
-	return getCustomResourceDefinition("hypershift-operator/hypershift.openshift.io_hostedclusters.yaml")
+	return getHyperShiftCustomResourceDefinition("hypershift-operator/hypershift.openshift.io_hostedclusters.yaml", o.Conversion)
 }
 
-type HyperShiftNodePoolsCustomResourceDefinition struct{}
+type HyperShiftNodePoolsCustomResourceDefinition struct {
+	Conversion *apiextensionsv1.CustomResourceConversion
+}
 
 func (o HyperShiftNodePoolsCustomResourceDefinition) Build() *apiextensionsv1.CustomResourceDefinition {
-	return getCustomResourceDefinition("hypershift-operator/hypershift.openshift.io_nodepools.yaml")
+	return getHyperShiftCustomResourceDefinition("hypershift-operator/hypershift.openshift.io_nodepools.yaml", o.Conversion)
 }
 
-type HyperShiftHostedControlPlaneCustomResourceDefinition struct{}
+type HyperShiftHostedControlPlaneCustomResourceDefinition struct {
+	Conversion *apiextensionsv1.CustomResourceConversion
+}
 
 func (o HyperShiftHostedControlPlaneCustomResourceDefinition) Build() *apiextensionsv1.CustomResourceDefinition {
-	crd := getCustomResourceDefinition("hypershift-operator/hypershift.openshift.io_hostedcontrolplanes.yaml")
+	crd := getHyperShiftCustomResourceDefinition("hypershift-operator/hypershift.openshift.io_hostedcontrolplanes.yaml", o.Conversion)
 	if crd.Labels == nil {
 		crd.Labels = map[string]string{}
 	}
@@ -250,10 +473,12 @@ func (o HyperShiftHostedControlPlaneCustomResourceDefinition) Build() *apiextens
 	return crd
 }
 
-type HyperShiftExternalInfraClustersCustomResourceDefinition struct{}
+type HyperShiftExternalInfraClustersCustomResourceDefinition struct {
+	Conversion *apiextensionsv1.CustomResourceConversion
+}
 
 func (o HyperShiftExternalInfraClustersCustomResourceDefinition) Build() *apiextensionsv1.CustomResourceDefinition {
-	crd := getCustomResourceDefinition("hypershift-operator/hypershift.openshift.io_externalinfraclusters.yaml")
+	crd := getHyperShiftCustomResourceDefinition("hypershift-operator/hypershift.openshift.io_externalinfraclusters.yaml", o.Conversion)
 	if crd.Labels == nil {
 		crd.Labels = map[string]string{}
 	}
@@ -261,13 +486,34 @@ func (o HyperShiftExternalInfraClustersCustomResourceDefinition) Build() *apiext
 	return crd
 }
 
-type HyperShiftMachineConfigServersCustomResourceDefinition struct{}
+type HyperShiftMachineConfigServersCustomResourceDefinition struct {
+	Conversion *apiextensionsv1.CustomResourceConversion
+}
 
 func (o HyperShiftMachineConfigServersCustomResourceDefinition) Build() *apiextensionsv1.CustomResourceDefinition {
-	crd := getCustomResourceDefinition("hypershift-operator/hypershift.openshift.io_machineconfigservers.yaml")
+	crd := getHyperShiftCustomResourceDefinition("hypershift-operator/hypershift.openshift.io_machineconfigservers.yaml", o.Conversion)
 	if crd.Labels == nil {
 		crd.Labels = map[string]string{}
 	}
 	crd.Labels["cluster.x-k8s.io/v1alpha4"] = "v1alpha1"
 	return crd
 }
+
+// getHyperShiftCustomResourceDefinition loads a HyperShift CRD. Without a
+// conversion webhook only the storage version is served: the API server would
+// otherwise convert between versions by only rewriting the apiVersion.
+func getHyperShiftCustomResourceDefinition(file string, conversion *apiextensionsv1.CustomResourceConversion) *apiextensionsv1.CustomResourceDefinition {
+	crd := getCustomResourceDefinition(file)
+	if conversion != nil {
+		crd.Spec.Conversion = conversion.DeepCopy()
+		return crd
+	}
+	var versions []apiextensionsv1.CustomResourceDefinitionVersion
+	for _, version := range crd.Spec.Versions {
+		if version.Storage {
+			versions = append(versions, version)
+		}
+	}
+	crd.Spec.Versions = versions
+	return crd
+}
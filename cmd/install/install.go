@@ -23,6 +23,7 @@ import (
 	"os"
 
 	"github.com/spf13/cobra"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	"k8s.io/apimachinery/pkg/types"
 
 	hyperapi "github.com/openshift/hypershift/api"
@@ -39,6 +40,9 @@ type Options struct {
 	HyperShiftOperatorReplicas int32
 	Development                bool
 	Render                     bool
+	EnableWebhook              bool
+	ImageOverrides             map[string]string
+	AllowedImageAnnotations    []string
 }
 
 func NewCommand() *cobra.Command {
@@ -53,11 +57,16 @@ func NewCommand() *cobra.Command {
 	cmd.Flags().StringVar(&opts.HyperShiftImage, "hypershift-image", version.HyperShiftImage, "The HyperShift image to deploy")
 	cmd.Flags().BoolVar(&opts.Development, "development", false, "Enable tweaks to facilitate local development")
 	cmd.Flags().BoolVar(&opts.Render, "render", false, "Render output as YAML to stdout instead of applying")
+	cmd.Flags().BoolVar(&opts.EnableWebhook, "enable-webhook", true, "Deploy and register the HostedCluster and NodePool admission webhooks and the API conversion webhook. Without it only the v1alpha1 API is served")
+	cmd.Flags().StringToStringVar(&opts.ImageOverrides, "image-overrides", nil, "Images which replace the default images of the components the HyperShift operator manages next to each control plane, by component name")
+	cmd.Flags().StringSliceVar(&opts.AllowedImageAnnotations, "allowed-image-annotations", nil, "Names of the components whose images may be overridden per HostedCluster with an annotation")
 
 	cmd.Run = func(cmd *cobra.Command, args []string) {
 		switch {
 		case opts.Development:
 			opts.HyperShiftOperatorReplicas = 0
+			// There is no in-cluster operator to serve admission requests
+			opts.EnableWebhook = false
 		default:
 			opts.HyperShiftOperatorReplicas = 1
 		}
@@ -112,14 +121,20 @@ func apply(ctx context.Context, objects []crclient.Object) error {
 }
 
 func hyperShiftOperatorManifests(opts Options) []crclient.Object {
-	hostedClustersCRD := assets.HyperShiftHostedClustersCustomResourceDefinition{}.Build()
-	nodePoolsCRD := assets.HyperShiftNodePoolsCustomResourceDefinition{}.Build()
-	hostedControlPlanesCRD := assets.HyperShiftHostedControlPlaneCustomResourceDefinition{}.Build()
-	externalInfraClustersCRD := assets.HyperShiftExternalInfraClustersCustomResourceDefinition{}.Build()
-	machineConfigServersCRD := assets.HyperShiftMachineConfigServersCustomResourceDefinition{}.Build()
 	operatorNamespace := assets.HyperShiftNamespace{
 		Name: opts.Namespace,
 	}.Build()
+	var conversion *apiextensionsv1.CustomResourceConversion
+	if opts.EnableWebhook {
+		conversion = assets.HyperShiftConversionWebhook{
+			Namespace: operatorNamespace,
+		}.Build()
+	}
+	hostedClustersCRD := assets.HyperShiftHostedClustersCustomResourceDefinition{Conversion: conversion}.Build()
+	nodePoolsCRD := assets.HyperShiftNodePoolsCustomResourceDefinition{Conversion: conversion}.Build()
+	hostedControlPlanesCRD := assets.HyperShiftHostedControlPlaneCustomResourceDefinition{Conversion: conversion}.Build()
+	externalInfraClustersCRD := assets.HyperShiftExternalInfraClustersCustomResourceDefinition{Conversion: conversion}.Build()
+	machineConfigServersCRD := assets.HyperShiftMachineConfigServersCustomResourceDefinition{Conversion: conversion}.Build()
 	operatorServiceAccount := assets.HyperShiftOperatorServiceAccount{
 		Namespace: operatorNamespace,
 	}.Build()
@@ -129,13 +144,16 @@ func hyperShiftOperatorManifests(opts Options) []crclient.Object {
 		ServiceAccount: operatorServiceAccount,
 	}.Build()
 	operatorDeployment := assets.HyperShiftOperatorDeployment{
-		Namespace:      operatorNamespace,
-		OperatorImage:  opts.HyperShiftImage,
-		ServiceAccount: operatorServiceAccount,
-		Replicas:       opts.HyperShiftOperatorReplicas,
+		Namespace:               operatorNamespace,
+		OperatorImage:           opts.HyperShiftImage,
+		ServiceAccount:          operatorServiceAccount,
+		Replicas:                opts.HyperShiftOperatorReplicas,
+		EnableWebhook:           opts.EnableWebhook,
+		ImageOverrides:          opts.ImageOverrides,
+		AllowedImageAnnotations: opts.AllowedImageAnnotations,
 	}.Build()
 
-	return []crclient.Object{
+	objects := []crclient.Object{
 		hostedClustersCRD,
 		nodePoolsCRD,
 		hostedControlPlanesCRD,
@@ -145,8 +163,22 @@ func hyperShiftOperatorManifests(opts Options) []crclient.Object {
 		operatorServiceAccount,
 		operatorClusterRole,
 		operatorClusterRoleBinding,
-		operatorDeployment,
 	}
+	// The webhook configurations must exist before the operator starts so
+	// that it can publish its CA bundle into them.
+	if opts.EnableWebhook {
+		operatorService := assets.HyperShiftOperatorService{
+			Namespace: operatorNamespace,
+		}.Build()
+		mutatingWebhookConfiguration := assets.HyperShiftMutatingWebhookConfiguration{
+			Namespace: operatorNamespace,
+		}.Build()
+		validatingWebhookConfiguration := assets.HyperShiftValidatingWebhookConfiguration{
+			Namespace: operatorNamespace,
+		}.Build()
+		objects = append(objects, operatorService, mutatingWebhookConfiguration, validatingWebhookConfiguration)
+	}
+	return append(objects, operatorDeployment)
 }
 
 func clusterAPIManifests() []crclient.Object {
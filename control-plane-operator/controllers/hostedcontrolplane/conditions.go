@@ -0,0 +1,249 @@
+package hostedcontrolplane
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	hyperv1 "github.com/openshift/hypershift/api/v1alpha1"
+)
+
+const (
+	kubeAPIServerDeploymentName = "kube-apiserver"
+	etcdClusterName             = "etcd"
+
+	// componentStatusRequeueInterval is how often an unavailable control plane
+	// is re-checked, since the etcd cluster is not watched.
+	componentStatusRequeueInterval = 10 * time.Second
+)
+
+var (
+	etcdClusterGVK = schema.GroupVersionKind{Group: "etcd.database.coreos.com", Version: "v1beta2", Kind: "EtcdCluster"}
+
+	// availableConditions are the conditions which must all be true for the
+	// control plane to be available.
+	availableConditions = []hyperv1.ConditionType{
+		hyperv1.InfrastructureReady,
+		hyperv1.ReleaseImageValid,
+		hyperv1.ValidConfiguration,
+		hyperv1.EtcdAvailable,
+		hyperv1.KubeAPIServerAvailable,
+	}
+)
+
+// configurationError is an error in the spec of a HostedControlPlane or in the
+// resources it references, as opposed to a failure to reconcile it. Only
+// configuration errors are reported by the ValidConfiguration condition.
+type configurationError struct {
+	err error
+}
+
+func (e *configurationError) Error() string {
+	return e.err.Error()
+}
+
+func (e *configurationError) Unwrap() error {
+	return e.err
+}
+
+// invalidConfiguration marks an error as a configuration error.
+func invalidConfiguration(err error) error {
+	return &configurationError{err: err}
+}
+
+// setCondition sets a condition on the HostedControlPlane, recording the
+// generation it was observed at.
+func setCondition(hcp *hyperv1.HostedControlPlane, conditionType hyperv1.ConditionType, status metav1.ConditionStatus, reason, message string) {
+	meta.SetStatusCondition(&hcp.Status.Conditions, metav1.Condition{
+		Type:               string(conditionType),
+		Status:             status,
+		Reason:             reason,
+		Message:            message,
+		ObservedGeneration: hcp.Generation,
+	})
+}
+
+// updateStatus refreshes the etcd and kube-apiserver conditions, derives the
+// Available, Degraded and Progressing conditions and persists the status if
+// it changed. A non-nil err marks the control plane as degraded with the
+// given reason and is returned unchanged.
+func (r *HostedControlPlaneReconciler) updateStatus(ctx context.Context, hcp *hyperv1.HostedControlPlane, oldStatus *hyperv1.HostedControlPlaneStatus,
+	degradedReason string, result ctrl.Result, err error) (ctrl.Result, error) {
+	if err := r.reconcileEtcdAvailableCondition(ctx, hcp); err != nil {
+		r.Log.Error(err, "failed to determine etcd availability")
+	}
+	kasRollingOut, kasErr := r.reconcileKubeAPIServerAvailableCondition(ctx, hcp)
+	if kasErr != nil {
+		r.Log.Error(kasErr, "failed to determine kube-apiserver availability")
+	}
+
+	if err != nil {
+		setCondition(hcp, hyperv1.Degraded, metav1.ConditionTrue, degradedReason, err.Error())
+	} else {
+		setCondition(hcp, hyperv1.Degraded, metav1.ConditionFalse, "AsExpected", "")
+	}
+
+	switch {
+	case hcp.Spec.ReleaseImage != hcp.Status.ReleaseImage:
+		setCondition(hcp, hyperv1.Progressing, metav1.ConditionTrue, "ReleaseImageRollout", fmt.Sprintf("Rolling out release image %s", hcp.Spec.ReleaseImage))
+	case kasRollingOut:
+		setCondition(hcp, hyperv1.Progressing, metav1.ConditionTrue, "KubeAPIServerRollout", "The kube-apiserver deployment is rolling out")
+	default:
+		setCondition(hcp, hyperv1.Progressing, metav1.ConditionFalse, "AsExpected", "")
+	}
+
+	available := computeAvailableCondition(hcp.Status.Conditions)
+	available.ObservedGeneration = hcp.Generation
+	meta.SetStatusCondition(&hcp.Status.Conditions, available)
+	hcp.Status.Ready = available.Status == metav1.ConditionTrue
+	if !hcp.Status.Ready && err == nil && result.IsZero() {
+		result.RequeueAfter = componentStatusRequeueInterval
+	}
+
+	if reflect.DeepEqual(oldStatus, &hcp.Status) {
+		// No change to status, nothing to sync
+		return result, err
+	}
+	if updateErr := r.Status().Update(ctx, hcp); updateErr != nil {
+		r.Log.Error(updateErr, "failed to update status")
+		result.Requeue = true
+	}
+	return result, err
+}
+
+// computeAvailableCondition returns an Available condition which is true only
+// if all of the availableConditions are true, or otherwise carries the reason
+// of the first one which is not.
+func computeAvailableCondition(conditions []metav1.Condition) metav1.Condition {
+	for _, conditionType := range availableConditions {
+		condition := meta.FindStatusCondition(conditions, string(conditionType))
+		if condition == nil {
+			return metav1.Condition{
+				Type:    string(hyperv1.Available),
+				Status:  metav1.ConditionFalse,
+				Reason:  "StatusUnknown",
+				Message: fmt.Sprintf("Condition %s has not been reported yet", conditionType),
+			}
+		}
+		if condition.Status != metav1.ConditionTrue {
+			return metav1.Condition{
+				Type:    string(hyperv1.Available),
+				Status:  metav1.ConditionFalse,
+				Reason:  condition.Reason,
+				Message: condition.Message,
+			}
+		}
+	}
+	return metav1.Condition{
+		Type:    string(hyperv1.Available),
+		Status:  metav1.ConditionTrue,
+		Reason:  "AsExpected",
+		Message: "HostedControlPlane is ready",
+	}
+}
+
+func (r *HostedControlPlaneReconciler) reconcileEtcdAvailableCondition(ctx context.Context, hcp *hyperv1.HostedControlPlane) error {
+	etcdCluster := &unstructured.Unstructured{}
+	etcdCluster.SetGroupVersionKind(etcdClusterGVK)
+	err := r.Get(ctx, client.ObjectKey{Namespace: hcp.Namespace, Name: etcdClusterName}, etcdCluster)
+	if err != nil {
+		if apierrors.IsNotFound(err) || meta.IsNoMatchError(err) {
+			setCondition(hcp, hyperv1.EtcdAvailable, metav1.ConditionFalse, "EtcdClusterNotFound", "The etcd cluster has not been created yet")
+			return nil
+		}
+		setCondition(hcp, hyperv1.EtcdAvailable, metav1.ConditionUnknown, "StatusUnknown", err.Error())
+		return fmt.Errorf("failed to get etcd cluster: %w", err)
+	}
+	status, message := etcdClusterAvailability(etcdCluster)
+	if status == corev1.ConditionTrue {
+		setCondition(hcp, hyperv1.EtcdAvailable, metav1.ConditionTrue, "EtcdClusterAvailable", message)
+	} else {
+		setCondition(hcp, hyperv1.EtcdAvailable, metav1.ConditionFalse, "EtcdClusterUnavailable", message)
+	}
+	return nil
+}
+
+// etcdClusterAvailability reports the status and message of the Available
+// condition the etcd operator maintains on an EtcdCluster.
+func etcdClusterAvailability(etcdCluster *unstructured.Unstructured) (corev1.ConditionStatus, string) {
+	conditions, _, _ := unstructured.NestedSlice(etcdCluster.Object, "status", "conditions")
+	for _, raw := range conditions {
+		condition, ok := raw.(map[string]interface{})
+		if !ok || condition["type"] != "Available" {
+			continue
+		}
+		status, _ := condition["status"].(string)
+		message, _ := condition["message"].(string)
+		if message == "" {
+			message, _ = condition["reason"].(string)
+		}
+		return corev1.ConditionStatus(status), message
+	}
+	return corev1.ConditionFalse, "The etcd cluster has not reported its availability yet"
+}
+
+// reconcileKubeAPIServerAvailableCondition sets the KubeAPIServerAvailable
+// condition and returns whether the deployment is in the middle of a rollout.
+func (r *HostedControlPlaneReconciler) reconcileKubeAPIServerAvailableCondition(ctx context.Context, hcp *hyperv1.HostedControlPlane) (bool, error) {
+	deployment := &appsv1.Deployment{}
+	err := r.Get(ctx, client.ObjectKey{Namespace: hcp.Namespace, Name: kubeAPIServerDeploymentName}, deployment)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			setCondition(hcp, hyperv1.KubeAPIServerAvailable, metav1.ConditionFalse, "DeploymentNotFound", "The kube-apiserver deployment has not been created yet")
+			return false, nil
+		}
+		setCondition(hcp, hyperv1.KubeAPIServerAvailable, metav1.ConditionUnknown, "StatusUnknown", err.Error())
+		return false, fmt.Errorf("failed to get kube-apiserver deployment: %w", err)
+	}
+	available := false
+	for _, condition := range deployment.Status.Conditions {
+		if condition.Type == appsv1.DeploymentAvailable && condition.Status == corev1.ConditionTrue {
+			available = true
+			break
+		}
+	}
+	if available {
+		setCondition(hcp, hyperv1.KubeAPIServerAvailable, metav1.ConditionTrue, "DeploymentAvailable", fmt.Sprintf("%d of %d replicas are available", deployment.Status.AvailableReplicas, deployment.Status.Replicas))
+	} else {
+		setCondition(hcp, hyperv1.KubeAPIServerAvailable, metav1.ConditionFalse, "DeploymentUnavailable", fmt.Sprintf("%d of %d replicas are available", deployment.Status.AvailableReplicas, deployment.Status.Replicas))
+	}
+	return isDeploymentRollingOut(deployment), nil
+}
+
+func isDeploymentRollingOut(deployment *appsv1.Deployment) bool {
+	if deployment.Status.ObservedGeneration < deployment.Generation {
+		return true
+	}
+	replicas := int32(1)
+	if deployment.Spec.Replicas != nil {
+		replicas = *deployment.Spec.Replicas
+	}
+	return deployment.Status.UpdatedReplicas < replicas || deployment.Status.Replicas > deployment.Status.UpdatedReplicas
+}
+
+// enqueueNamespaceHostedControlPlanes maps an object in a control plane
+// namespace to the HostedControlPlanes of that namespace.
+func (r *HostedControlPlaneReconciler) enqueueNamespaceHostedControlPlanes(obj client.Object) []reconcile.Request {
+	hcpList := &hyperv1.HostedControlPlaneList{}
+	if err := r.List(context.Background(), hcpList, client.InNamespace(obj.GetNamespace())); err != nil {
+		ctrl.Log.Error(err, "failed to list hosted control planes", "namespace", obj.GetNamespace())
+		return nil
+	}
+	var requests []reconcile.Request
+	for _, hcp := range hcpList.Items {
+		requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&hcp)})
+	}
+	return requests
+}
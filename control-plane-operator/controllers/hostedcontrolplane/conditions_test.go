@@ -0,0 +1,93 @@
+package hostedcontrolplane
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	hyperv1 "github.com/openshift/hypershift/api/v1alpha1"
+	"github.com/openshift/hypershift/control-plane-operator/controllers/hostedcontrolplane/render"
+)
+
+func TestComputeAvailableCondition(t *testing.T) {
+	allTrue := func() []metav1.Condition {
+		var conditions []metav1.Condition
+		for _, conditionType := range availableConditions {
+			conditions = append(conditions, metav1.Condition{Type: string(conditionType), Status: metav1.ConditionTrue, Reason: "AsExpected"})
+		}
+		return conditions
+	}
+	tests := map[string]struct {
+		Conditions     []metav1.Condition
+		ExpectedStatus metav1.ConditionStatus
+		ExpectedReason string
+	}{
+		"no conditions reported": {
+			Conditions:     nil,
+			ExpectedStatus: metav1.ConditionFalse,
+			ExpectedReason: "StatusUnknown",
+		},
+		"all components available": {
+			Conditions:     allTrue(),
+			ExpectedStatus: metav1.ConditionTrue,
+			ExpectedReason: "AsExpected",
+		},
+		"etcd unavailable": {
+			Conditions: func() []metav1.Condition {
+				conditions := allTrue()
+				for i := range conditions {
+					if conditions[i].Type == string(hyperv1.EtcdAvailable) {
+						conditions[i].Status = metav1.ConditionFalse
+						conditions[i].Reason = "EtcdClusterUnavailable"
+					}
+				}
+				return conditions
+			}(),
+			ExpectedStatus: metav1.ConditionFalse,
+			ExpectedReason: "EtcdClusterUnavailable",
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			actual := computeAvailableCondition(test.Conditions)
+			if actual.Type != string(hyperv1.Available) {
+				t.Errorf("expected condition type %s, got %s", hyperv1.Available, actual.Type)
+			}
+			if actual.Status != test.ExpectedStatus || actual.Reason != test.ExpectedReason {
+				t.Errorf("expected %s/%s, got %s/%s", test.ExpectedStatus, test.ExpectedReason, actual.Status, actual.Reason)
+			}
+		})
+	}
+}
+
+func TestConfigurationError(t *testing.T) {
+	tests := map[string]struct {
+		Sizing                *hyperv1.ControlPlaneSizing
+		ExpectedConfiguration bool
+	}{
+		"valid sizing": {
+			Sizing: &hyperv1.ControlPlaneSizing{Profile: hyperv1.MediumSizingProfile},
+		},
+		"unknown sizing profile": {
+			Sizing:                &hyperv1.ControlPlaneSizing{Profile: "Huge"},
+			ExpectedConfiguration: true,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			hcp := &hyperv1.HostedControlPlane{}
+			hcp.Spec.Sizing = test.Sizing
+			err := setComponentResources(hcp, render.NewClusterParams())
+			if (err != nil) != test.ExpectedConfiguration {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			// Configuration errors are still recognized once wrapped.
+			var configErr *configurationError
+			if isConfiguration := errors.As(fmt.Errorf("failed to generate control plane manifests: %w", err), &configErr); isConfiguration != test.ExpectedConfiguration {
+				t.Errorf("expected configuration error to be %t, got %v", test.ExpectedConfiguration, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,411 @@
+package hostedcontrolplane
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/route53"
+	"github.com/aws/aws-sdk-go/service/route53/route53iface"
+	routev1 "github.com/openshift/api/route/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	hyperv1 "github.com/openshift/hypershift/api/v1alpha1"
+	hyperutil "github.com/openshift/hypershift/support/util"
+)
+
+const (
+	// dnsRecordTTL is the TTL in seconds of the records managed for the
+	// hostnames of published services.
+	dnsRecordTTL = 30
+
+	// dnsRecordResyncInterval is how long the records ensured for a target
+	// are trusted before they are verified again.
+	dnsRecordResyncInterval = 10 * time.Minute
+
+	// dnsOwnershipRecordPrefix is prepended to a name for the name of its
+	// ownership TXT record.
+	dnsOwnershipRecordPrefix = "_hypershift-owner."
+)
+
+// hostnameServices are the published services which may have a hostname.
+var hostnameServices = []hyperv1.ServiceType{hyperv1.APIServer, hyperv1.OAuthServer}
+
+// newAWSSession returns a session for a region which uses an AWS credentials
+// file.
+func newAWSSession(credentials []byte, region string) (*session.Session, error) {
+	file, err := ioutil.TempFile("", "aws-credentials")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create credentials file: %w", err)
+	}
+	defer os.Remove(file.Name())
+	if _, err := file.Write(credentials); err != nil {
+		file.Close()
+		return nil, fmt.Errorf("failed to write credentials file: %w", err)
+	}
+	if err := file.Close(); err != nil {
+		return nil, fmt.Errorf("failed to write credentials file: %w", err)
+	}
+	// The credentials file is loaded when the session is created.
+	s, err := session.NewSessionWithOptions(session.Options{
+		Config: aws.Config{
+			Region: aws.String(region),
+		},
+		SharedConfigState: session.SharedConfigEnable,
+		SharedConfigFiles: []string{file.Name()},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create client session: %w", err)
+	}
+	return s, nil
+}
+
+// newRoute53Client returns a Route53 client which uses an AWS credentials file.
+func newRoute53Client(credentials []byte) (route53iface.Route53API, error) {
+	// Route53 is a global service which is reached through us-east-1.
+	s, err := newAWSSession(credentials, "us-east-1")
+	if err != nil {
+		return nil, err
+	}
+	return route53.New(s), nil
+}
+
+// dnsManagerFor returns the DNS manager for the DNS management credentials of
+// the HostedControlPlane, or nil when it has none. The manager is reused
+// until the credentials change.
+func (r *HostedControlPlaneReconciler) dnsManagerFor(ctx context.Context, hcp *hyperv1.HostedControlPlane) (*dnsManager, error) {
+	if hcp.Spec.Platform.AWS == nil || len(hcp.Spec.Platform.AWS.DNSManagementCreds.Name) == 0 {
+		return nil, nil
+	}
+	secret := &corev1.Secret{}
+	if err := r.Get(ctx, client.ObjectKey{Namespace: hcp.Namespace, Name: hcp.Spec.Platform.AWS.DNSManagementCreds.Name}, secret); err != nil {
+		return nil, fmt.Errorf("failed to get dns management credentials: %w", err)
+	}
+	owner := dnsOwner(hcp)
+	credentialsVersion := fmt.Sprintf("%s/%s", secret.UID, secret.ResourceVersion)
+	if r.dnsManager != nil && r.dnsManager.credentialsVersion == credentialsVersion && r.dnsManager.owner == owner {
+		return r.dnsManager, nil
+	}
+	credentials, hasCredentials := secret.Data["credentials"]
+	if !hasCredentials {
+		return nil, fmt.Errorf("dns management credentials secret %q is missing the credentials key", secret.Name)
+	}
+	c, err := newRoute53Client(credentials)
+	if err != nil {
+		return nil, err
+	}
+	r.dnsManager = newDNSManager(c, owner)
+	r.dnsManager.credentialsVersion = credentialsVersion
+	return r.dnsManager, nil
+}
+
+// dnsZones returns the IDs of the public and private zones of the
+// HostedControlPlane.
+func dnsZones(hcp *hyperv1.HostedControlPlane) []string {
+	var zones []string
+	for _, zone := range []string{hcp.Spec.DNS.PublicZoneID, hcp.Spec.DNS.PrivateZoneID} {
+		if len(zone) > 0 {
+			zones = append(zones, zone)
+		}
+	}
+	return zones
+}
+
+// ensureHostname returns the hostname of a service published with the
+// strategy once the load balancer address or router hostname it resolves to
+// is known, and points the records of the hostname in the zones of the
+// HostedControlPlane to it when there are DNS management credentials.
+func (r *HostedControlPlaneReconciler) ensureHostname(ctx context.Context, hcp *hyperv1.HostedControlPlane, strategy hyperv1.ServicePublishingStrategy, address string, routeKey client.ObjectKey) (string, error) {
+	target := address
+	if strategy.Type == hyperv1.Route {
+		route := &routev1.Route{}
+		if err := r.Get(ctx, routeKey, route); err != nil {
+			return "", fmt.Errorf("failed to get route: %w", err)
+		}
+		target = routerCanonicalHostname(route)
+	}
+	if len(target) == 0 {
+		return "", nil
+	}
+	dns, err := r.dnsManagerFor(ctx, hcp)
+	if err != nil {
+		return "", err
+	}
+	if dns == nil {
+		return strategy.Hostname, nil
+	}
+	for _, zoneID := range dnsZones(hcp) {
+		if err := dns.ensureRecord(ctx, zoneID, strategy.Hostname, target); err != nil {
+			return "", fmt.Errorf("failed to ensure dns record %s in zone %s: %w", strategy.Hostname, zoneID, err)
+		}
+	}
+	return strategy.Hostname, nil
+}
+
+// deleteDNSRecords deletes the records of the hostnames of the published
+// services of the HostedControlPlane from its zones.
+func (r *HostedControlPlaneReconciler) deleteDNSRecords(ctx context.Context, hcp *hyperv1.HostedControlPlane) error {
+	dns, err := r.dnsManagerFor(ctx, hcp)
+	if apierrors.IsNotFound(err) {
+		// The credentials are removed with the control plane namespace.
+		r.Log.Info("DNS management credentials not found, skipping the deletion of dns records")
+		return nil
+	}
+	if err != nil || dns == nil {
+		return err
+	}
+	for _, service := range hostnameServices {
+		hostname := hyperutil.ServicePublishingStrategy(hcp.Spec.Services, service).Hostname
+		if len(hostname) == 0 {
+			continue
+		}
+		for _, zoneID := range dnsZones(hcp) {
+			if err := dns.deleteRecord(ctx, zoneID, hostname); err != nil {
+				return fmt.Errorf("failed to delete dns record %s from zone %s: %w", hostname, zoneID, err)
+			}
+		}
+	}
+	return nil
+}
+
+// routerCanonicalHostname returns the hostname of the router which admitted
+// the route, if any.
+func routerCanonicalHostname(route *routev1.Route) string {
+	for _, ingress := range route.Status.Ingress {
+		if len(ingress.RouterCanonicalHostname) > 0 {
+			return ingress.RouterCanonicalHostname
+		}
+	}
+	return ""
+}
+
+// dnsManager manages the records of the hostnames of a HostedControlPlane in
+// its zones. Each record it creates comes with an ownership TXT record, and
+// records without one for the HostedControlPlane are never changed or
+// deleted. It remembers the names of the zones and the targets of the records
+// it ensured, so that Route53 is only called again when a target changes or
+// the records are due to be verified.
+type dnsManager struct {
+	client route53iface.Route53API
+
+	// owner identifies the HostedControlPlane in the ownership records.
+	owner string
+
+	// credentialsVersion identifies the credentials of the client.
+	credentialsVersion string
+
+	zoneNames map[string]string
+	ensured   map[string]ensuredDNSRecord
+	now       func() time.Time
+}
+
+// ensuredDNSRecord is the target of a record at the time it was ensured.
+type ensuredDNSRecord struct {
+	target string
+	time   time.Time
+}
+
+func newDNSManager(c route53iface.Route53API, owner string) *dnsManager {
+	return &dnsManager{
+		client:    c,
+		owner:     owner,
+		zoneNames: map[string]string{},
+		ensured:   map[string]ensuredDNSRecord{},
+		now:       time.Now,
+	}
+}
+
+// dnsOwner identifies the HostedControlPlane in the ownership records of the
+// records it manages.
+func dnsOwner(hcp *hyperv1.HostedControlPlane) string {
+	return fmt.Sprintf("\"hypershift/owner=%s/%s\"", hcp.Namespace, hcp.Name)
+}
+
+// ownershipRecordName returns the name of the ownership TXT record of a name.
+// It cannot be the name itself, which may have a CNAME record.
+func ownershipRecordName(name string) string {
+	return dnsOwnershipRecordPrefix + name
+}
+
+// zoneContains returns whether the zone of the given ID contains the name.
+// Records are only managed in the zones which contain them.
+func (m *dnsManager) zoneContains(ctx context.Context, zoneID, name string) (bool, error) {
+	zoneName, hasName := m.zoneNames[zoneID]
+	if !hasName {
+		zone, err := m.client.GetHostedZoneWithContext(ctx, &route53.GetHostedZoneInput{Id: aws.String(zoneID)})
+		if err != nil {
+			return false, fmt.Errorf("failed to get hosted zone: %w", err)
+		}
+		zoneName = strings.TrimSuffix(aws.StringValue(zone.HostedZone.Name), ".")
+		m.zoneNames[zoneID] = zoneName
+	}
+	name = strings.TrimSuffix(name, ".")
+	return name == zoneName || strings.HasSuffix(name, "."+zoneName), nil
+}
+
+// ensureRecord points a record of the zone to a target, with an A record when
+// the target is an IP address and a CNAME record otherwise. It fails when the
+// name has records which are not owned by the HostedControlPlane.
+func (m *dnsManager) ensureRecord(ctx context.Context, zoneID, name, target string) error {
+	key := zoneID + "/" + name
+	if ensured, isEnsured := m.ensured[key]; isEnsured && ensured.target == target && m.now().Sub(ensured.time) < dnsRecordResyncInterval {
+		return nil
+	}
+	contains, err := m.zoneContains(ctx, zoneID, name)
+	if err != nil {
+		return err
+	}
+	if contains {
+		if err := m.changeRecord(ctx, zoneID, name, target); err != nil {
+			return err
+		}
+	}
+	m.ensured[key] = ensuredDNSRecord{target: target, time: m.now()}
+	return nil
+}
+
+func (m *dnsManager) changeRecord(ctx context.Context, zoneID, name, target string) error {
+	recordType := route53.RRTypeCname
+	if ip := net.ParseIP(target); ip != nil {
+		recordType = route53.RRTypeA
+		if ip.To4() == nil {
+			recordType = route53.RRTypeAaaa
+		}
+	}
+	records, owned, err := m.findRecords(ctx, zoneID, name)
+	if err != nil {
+		return err
+	}
+	if len(records) > 0 && !owned {
+		return fmt.Errorf("the existing records of %s are not owned by the hosted control plane", name)
+	}
+	var changes []*route53.Change
+	upToDate := false
+	for _, record := range records {
+		if aws.StringValue(record.Type) != recordType {
+			// A name cannot have a CNAME record together with other records.
+			changes = append(changes, &route53.Change{
+				Action:            aws.String(route53.ChangeActionDelete),
+				ResourceRecordSet: record,
+			})
+			continue
+		}
+		upToDate = len(record.ResourceRecords) == 1 && aws.StringValue(record.ResourceRecords[0].Value) == target
+	}
+	if upToDate && owned && len(changes) == 0 {
+		return nil
+	}
+	if !owned {
+		changes = append(changes, &route53.Change{
+			Action:            aws.String(route53.ChangeActionUpsert),
+			ResourceRecordSet: m.ownershipRecord(name),
+		})
+	}
+	changes = append(changes, &route53.Change{
+		Action: aws.String(route53.ChangeActionUpsert),
+		ResourceRecordSet: &route53.ResourceRecordSet{
+			Name:            aws.String(name),
+			Type:            aws.String(recordType),
+			TTL:             aws.Int64(dnsRecordTTL),
+			ResourceRecords: []*route53.ResourceRecord{{Value: aws.String(target)}},
+		},
+	})
+	_, err = m.client.ChangeResourceRecordSetsWithContext(ctx, &route53.ChangeResourceRecordSetsInput{
+		HostedZoneId: aws.String(zoneID),
+		ChangeBatch:  &route53.ChangeBatch{Changes: changes},
+	})
+	return err
+}
+
+// deleteRecord deletes the records of a name from the zone together with its
+// ownership record, if the HostedControlPlane owns them.
+func (m *dnsManager) deleteRecord(ctx context.Context, zoneID, name string) error {
+	delete(m.ensured, zoneID+"/"+name)
+	contains, err := m.zoneContains(ctx, zoneID, name)
+	if err != nil || !contains {
+		return err
+	}
+	records, owned, err := m.findRecords(ctx, zoneID, name)
+	if err != nil || !owned {
+		return err
+	}
+	changes := []*route53.Change{{
+		Action:            aws.String(route53.ChangeActionDelete),
+		ResourceRecordSet: m.ownershipRecord(name),
+	}}
+	for _, record := range records {
+		changes = append(changes, &route53.Change{
+			Action:            aws.String(route53.ChangeActionDelete),
+			ResourceRecordSet: record,
+		})
+	}
+	_, err = m.client.ChangeResourceRecordSetsWithContext(ctx, &route53.ChangeResourceRecordSetsInput{
+		HostedZoneId: aws.String(zoneID),
+		ChangeBatch:  &route53.ChangeBatch{Changes: changes},
+	})
+	return err
+}
+
+// ownershipRecord returns the ownership TXT record of a name.
+func (m *dnsManager) ownershipRecord(name string) *route53.ResourceRecordSet {
+	return &route53.ResourceRecordSet{
+		Name:            aws.String(ownershipRecordName(name)),
+		Type:            aws.String(route53.RRTypeTxt),
+		TTL:             aws.Int64(dnsRecordTTL),
+		ResourceRecords: []*route53.ResourceRecord{{Value: aws.String(m.owner)}},
+	}
+}
+
+// findRecords returns the A, AAAA and CNAME records of a name in the zone,
+// and whether the name has an ownership record for the HostedControlPlane.
+func (m *dnsManager) findRecords(ctx context.Context, zoneID, name string) ([]*route53.ResourceRecordSet, bool, error) {
+	records, err := listDNSRecords(ctx, m.client, zoneID, name, route53.RRTypeA, route53.RRTypeAaaa, route53.RRTypeCname)
+	if err != nil {
+		return nil, false, err
+	}
+	ownershipRecords, err := listDNSRecords(ctx, m.client, zoneID, ownershipRecordName(name), route53.RRTypeTxt)
+	if err != nil {
+		return nil, false, err
+	}
+	owned := false
+	for _, record := range ownershipRecords {
+		for _, value := range record.ResourceRecords {
+			if aws.StringValue(value.Value) == m.owner {
+				owned = true
+			}
+		}
+	}
+	return records, owned, nil
+}
+
+// listDNSRecords returns the records of a name in the zone of the given types.
+func listDNSRecords(ctx context.Context, c route53iface.Route53API, zoneID, name string, recordTypes ...string) ([]*route53.ResourceRecordSet, error) {
+	fqdn := strings.ToLower(strings.TrimSuffix(name, ".")) + "."
+	output, err := c.ListResourceRecordSetsWithContext(ctx, &route53.ListResourceRecordSetsInput{
+		HostedZoneId:    aws.String(zoneID),
+		StartRecordName: aws.String(fqdn),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list records: %w", err)
+	}
+	var records []*route53.ResourceRecordSet
+	for _, record := range output.ResourceRecordSets {
+		if strings.ToLower(aws.StringValue(record.Name)) != fqdn {
+			continue
+		}
+		for _, recordType := range recordTypes {
+			if aws.StringValue(record.Type) == recordType {
+				records = append(records, record)
+			}
+		}
+	}
+	return records, nil
+}
@@ -0,0 +1,242 @@
+package hostedcontrolplane
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/service/route53"
+	"github.com/aws/aws-sdk-go/service/route53/route53iface"
+	"github.com/google/go-cmp/cmp"
+)
+
+// fakeRoute53 is a single zone which applies and records the changes made to
+// it and counts the calls made to it.
+type fakeRoute53 struct {
+	route53iface.Route53API
+	zoneName string
+	records  []*route53.ResourceRecordSet
+	changes  []*route53.Change
+	calls    int
+}
+
+func (f *fakeRoute53) GetHostedZoneWithContext(ctx aws.Context, input *route53.GetHostedZoneInput, opts ...request.Option) (*route53.GetHostedZoneOutput, error) {
+	f.calls++
+	return &route53.GetHostedZoneOutput{HostedZone: &route53.HostedZone{Id: input.Id, Name: aws.String(f.zoneName + ".")}}, nil
+}
+
+func (f *fakeRoute53) ListResourceRecordSetsWithContext(ctx aws.Context, input *route53.ListResourceRecordSetsInput, opts ...request.Option) (*route53.ListResourceRecordSetsOutput, error) {
+	f.calls++
+	return &route53.ListResourceRecordSetsOutput{ResourceRecordSets: f.records}, nil
+}
+
+func (f *fakeRoute53) ChangeResourceRecordSetsWithContext(ctx aws.Context, input *route53.ChangeResourceRecordSetsInput, opts ...request.Option) (*route53.ChangeResourceRecordSetsOutput, error) {
+	f.calls++
+	f.changes = append(f.changes, input.ChangeBatch.Changes...)
+	for _, c := range input.ChangeBatch.Changes {
+		// Route53 returns fully qualified names.
+		changed := *c.ResourceRecordSet
+		changed.Name = aws.String(strings.TrimSuffix(aws.StringValue(changed.Name), ".") + ".")
+		var records []*route53.ResourceRecordSet
+		for _, record := range f.records {
+			if aws.StringValue(record.Name) != aws.StringValue(changed.Name) || aws.StringValue(record.Type) != aws.StringValue(changed.Type) {
+				records = append(records, record)
+			}
+		}
+		if aws.StringValue(c.Action) == route53.ChangeActionUpsert {
+			records = append(records, &changed)
+		}
+		f.records = records
+	}
+	return &route53.ChangeResourceRecordSetsOutput{}, nil
+}
+
+func recordSet(name, recordType, value string) *route53.ResourceRecordSet {
+	return &route53.ResourceRecordSet{
+		Name:            aws.String(name),
+		Type:            aws.String(recordType),
+		TTL:             aws.Int64(dnsRecordTTL),
+		ResourceRecords: []*route53.ResourceRecord{{Value: aws.String(value)}},
+	}
+}
+
+type change struct {
+	Action string
+	Type   string
+	Value  string
+}
+
+func changeSummary(changes []*route53.Change) []change {
+	var result []change
+	for _, c := range changes {
+		result = append(result, change{
+			Action: aws.StringValue(c.Action),
+			Type:   aws.StringValue(c.ResourceRecordSet.Type),
+			Value:  aws.StringValue(c.ResourceRecordSet.ResourceRecords[0].Value),
+		})
+	}
+	return result
+}
+
+const testDNSOwner = "\"hypershift/owner=clusters-example/example\""
+
+// ownershipRecordSet returns the ownership record of a name for an owner.
+func ownershipRecordSet(name, owner string) *route53.ResourceRecordSet {
+	return recordSet(ownershipRecordName(name), "TXT", owner)
+}
+
+func TestEnsureDNSRecord(t *testing.T) {
+	tests := map[string]struct {
+		Name            string
+		Target          string
+		Records         []*route53.ResourceRecordSet
+		ExpectedChanges []change
+		ExpectedError   bool
+	}{
+		"load balancer hostname": {
+			Name:   "api.example.com",
+			Target: "lb.elb.amazonaws.com",
+			ExpectedChanges: []change{
+				{Action: "UPSERT", Type: "TXT", Value: testDNSOwner},
+				{Action: "UPSERT", Type: "CNAME", Value: "lb.elb.amazonaws.com"},
+			},
+		},
+		"load balancer ip address": {
+			Name:   "api.example.com",
+			Target: "192.0.2.1",
+			ExpectedChanges: []change{
+				{Action: "UPSERT", Type: "TXT", Value: testDNSOwner},
+				{Action: "UPSERT", Type: "A", Value: "192.0.2.1"},
+			},
+		},
+		"existing record": {
+			Name:   "api.example.com",
+			Target: "lb.elb.amazonaws.com",
+			Records: []*route53.ResourceRecordSet{
+				recordSet("api.example.com.", "CNAME", "lb.elb.amazonaws.com"),
+				ownershipRecordSet("api.example.com.", testDNSOwner),
+			},
+		},
+		"outdated record": {
+			Name:   "api.example.com",
+			Target: "lb.elb.amazonaws.com",
+			Records: []*route53.ResourceRecordSet{
+				recordSet("api.example.com.", "CNAME", "old.elb.amazonaws.com"),
+				ownershipRecordSet("api.example.com.", testDNSOwner),
+			},
+			ExpectedChanges: []change{{Action: "UPSERT", Type: "CNAME", Value: "lb.elb.amazonaws.com"}},
+		},
+		"record of another type": {
+			Name:   "api.example.com",
+			Target: "lb.elb.amazonaws.com",
+			Records: []*route53.ResourceRecordSet{
+				recordSet("api.example.com.", "A", "192.0.2.1"),
+				ownershipRecordSet("api.example.com.", testDNSOwner),
+			},
+			ExpectedChanges: []change{
+				{Action: "DELETE", Type: "A", Value: "192.0.2.1"},
+				{Action: "UPSERT", Type: "CNAME", Value: "lb.elb.amazonaws.com"},
+			},
+		},
+		"record without an owner": {
+			Name:          "api.example.com",
+			Target:        "lb.elb.amazonaws.com",
+			Records:       []*route53.ResourceRecordSet{recordSet("api.example.com.", "CNAME", "old.elb.amazonaws.com")},
+			ExpectedError: true,
+		},
+		"record of another owner": {
+			Name:   "api.example.com",
+			Target: "lb.elb.amazonaws.com",
+			Records: []*route53.ResourceRecordSet{
+				recordSet("api.example.com.", "CNAME", "old.elb.amazonaws.com"),
+				ownershipRecordSet("api.example.com.", "\"hypershift/owner=clusters-other/other\""),
+			},
+			ExpectedError: true,
+		},
+		"name outside of the zone": {
+			Name:   "api.example.org",
+			Target: "lb.elb.amazonaws.com",
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			client := &fakeRoute53{zoneName: "example.com", records: test.Records}
+			err := newDNSManager(client, testDNSOwner).ensureRecord(context.Background(), "zone", test.Name, test.Target)
+			if test.ExpectedError {
+				if err == nil {
+					t.Fatalf("expected an error")
+				}
+			} else if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if diff := cmp.Diff(test.ExpectedChanges, changeSummary(client.changes)); diff != "" {
+				t.Errorf("unexpected changes (-want +got): %s", diff)
+			}
+		})
+	}
+}
+
+func TestEnsureDNSRecordCache(t *testing.T) {
+	client := &fakeRoute53{zoneName: "example.com"}
+	m := newDNSManager(client, testDNSOwner)
+	now := time.Now()
+	m.now = func() time.Time { return now }
+	ensure := func(target string, expectedCalls int) {
+		t.Helper()
+		client.calls = 0
+		if err := m.ensureRecord(context.Background(), "zone", "api.example.com", target); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if client.calls != expectedCalls {
+			t.Errorf("expected %d route53 calls, got %d", expectedCalls, client.calls)
+		}
+	}
+	// The zone is looked up once, and the records are listed and changed.
+	ensure("lb.elb.amazonaws.com", 4)
+	// Route53 is not called again for the same target.
+	ensure("lb.elb.amazonaws.com", 0)
+	// The records are listed and changed for a new target.
+	ensure("other.elb.amazonaws.com", 3)
+	// The records are verified once the resync interval has passed.
+	now = now.Add(dnsRecordResyncInterval)
+	ensure("other.elb.amazonaws.com", 2)
+}
+
+func TestDeleteDNSRecord(t *testing.T) {
+	tests := map[string]struct {
+		Records         []*route53.ResourceRecordSet
+		ExpectedChanges []change
+	}{
+		"owned record": {
+			Records: []*route53.ResourceRecordSet{
+				recordSet("api.example.com.", "CNAME", "lb.elb.amazonaws.com"),
+				ownershipRecordSet("api.example.com.", testDNSOwner),
+				recordSet("apps.example.com.", "CNAME", "router.example.com"),
+			},
+			ExpectedChanges: []change{
+				{Action: "DELETE", Type: "TXT", Value: testDNSOwner},
+				{Action: "DELETE", Type: "CNAME", Value: "lb.elb.amazonaws.com"},
+			},
+		},
+		"record without an owner": {
+			Records: []*route53.ResourceRecordSet{
+				recordSet("api.example.com.", "CNAME", "lb.elb.amazonaws.com"),
+				recordSet("api.example.com.", "TXT", "owner"),
+			},
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			client := &fakeRoute53{zoneName: "example.com", records: test.Records}
+			if err := newDNSManager(client, testDNSOwner).deleteRecord(context.Background(), "zone", "api.example.com"); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if diff := cmp.Diff(test.ExpectedChanges, changeSummary(client.changes)); diff != "" {
+				t.Errorf("unexpected changes (-want +got): %s", diff)
+			}
+		})
+	}
+}
@@ -6,10 +6,10 @@ import (
 	crand "crypto/rand"
 	"encoding/base64"
 	"encoding/hex"
+	"errors"
 	"fmt"
 	"math/big"
 	"math/rand"
-	"reflect"
 	"strings"
 	"time"
 
@@ -20,6 +20,7 @@ import (
 	securityv1 "github.com/openshift/api/security/v1"
 
 	"golang.org/x/crypto/bcrypt"
+	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
@@ -28,16 +29,21 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/serializer"
 	"k8s.io/apimachinery/pkg/types"
-	"k8s.io/apimachinery/pkg/util/errors"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
 	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/validation/field"
 	"k8s.io/apimachinery/pkg/util/yaml"
 	"k8s.io/client-go/tools/record"
 	"k8s.io/client-go/util/workqueue"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/source"
 
 	"github.com/openshift/hypershift/thirdparty/clusterapi/util"
 
@@ -46,23 +52,25 @@ import (
 	"github.com/openshift/hypershift/control-plane-operator/controllers/hostedcontrolplane/render/pki"
 	pkiutil "github.com/openshift/hypershift/control-plane-operator/controllers/hostedcontrolplane/render/pki/util"
 	"github.com/openshift/hypershift/control-plane-operator/releaseinfo"
+	hyperconfig "github.com/openshift/hypershift/support/config"
+	hyperutil "github.com/openshift/hypershift/support/util"
 )
 
 const (
-	finalizer                  = "hypershift.openshift.io/finalizer"
-	APIServerPort              = 6443
-	DefaultAdminKubeconfigName = "admin-kubeconfig"
-	DefaultAdminKubeconfigKey  = "kubeconfig"
-	kubeAPIServerServiceName   = "kube-apiserver"
-	vpnServiceName             = "openvpn-server"
-	oauthServiceName           = "oauth-openshift"
-	pullSecretName             = "pull-secret"
-	vpnServiceAccountName      = "vpn"
-	ingressOperatorNamespace   = "openshift-ingress-operator"
-	hypershiftRouteLabel       = "hypershift.openshift.io/cluster"
-	oauthBrandingManifest      = "v4-0-config-system-branding.yaml"
-	DefaultAPIServerIPAddress  = "172.20.0.1"
-	externalOauthPort          = 443
+	finalizer                            = "hypershift.openshift.io/finalizer"
+	APIServerPort                        = 6443
+	DefaultAdminKubeconfigName           = "admin-kubeconfig"
+	DefaultAdminKubeconfigKey            = "kubeconfig"
+	kubeAPIServerServiceName             = "kube-apiserver"
+	vpnServiceName                       = "openvpn-server"
+	oauthServiceName                     = "oauth-openshift"
+	pullSecretName                       = "pull-secret"
+	vpnServiceAccountName                = "vpn"
+	ingressOperatorNamespace             = "openshift-ingress-operator"
+	hypershiftRouteLabel                 = "hypershift.openshift.io/cluster"
+	oauthBrandingManifest                = "v4-0-config-system-branding.yaml"
+	kubeadminPasswordSecretName          = "kubeadmin-password"
+	kubeadminPasswordTargetConfigMapName = "user-manifest-kubeadmin-password"
 )
 
 var (
@@ -78,16 +86,35 @@ var (
 
 type InfrastructureStatus struct {
 	APIAddress            string
+	APIPort               int32
+	NodeAPIAddress        string
+	NodeAPIPort           int32
 	OAuthAddress          string
+	OAuthPort             int32
 	VPNAddress            string
+	VPNPort               int32
+	KonnectivityAddress   string
+	KonnectivityPort      int32
+	OVNSbDbAddress        string
+	OVNSbDbPort           int32
 	OpenShiftAPIAddress   string
 	OauthAPIServerAddress string
+
+	// ovnSbDbPublished is whether the OVN southbound database is published,
+	// which it only is for the OVNKubernetes network type.
+	ovnSbDbPublished bool
 }
 
+// IsReady returns whether the addresses of the published services are known.
+// Only the address of the VPN or the Konnectivity server is set, depending on
+// the data path to the guest cluster, and the address of the OVN southbound
+// database only when it is published.
 func (s InfrastructureStatus) IsReady() bool {
 	return len(s.APIAddress) > 0 &&
+		len(s.NodeAPIAddress) > 0 &&
 		len(s.OAuthAddress) > 0 &&
-		len(s.VPNAddress) > 0
+		(len(s.VPNAddress) > 0 || len(s.KonnectivityAddress) > 0) &&
+		(!s.ovnSbDbPublished || len(s.OVNSbDbAddress) > 0)
 }
 
 type HostedControlPlaneReconciler struct {
@@ -97,11 +124,26 @@ type HostedControlPlaneReconciler struct {
 	ReleaseProvider releaseinfo.Provider
 
 	recorder record.EventRecorder
+
+	// privateLinkClients are the AWS clients of the last control plane
+	// operator credentials, which are reused until the credentials change.
+	privateLinkClients *privateLinkClients
+
+	// dnsManager manages the records with the last DNS management
+	// credentials, and is reused until the credentials change.
+	dnsManager *dnsManager
 }
 
 func (r *HostedControlPlaneReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	_, err := ctrl.NewControllerManagedBy(mgr).
 		For(&hyperv1.HostedControlPlane{}).
+		Watches(&source.Kind{Type: &appsv1.Deployment{}}, handler.EnqueueRequestsFromMapFunc(r.enqueueNamespaceHostedControlPlanes),
+			builder.WithPredicates(predicate.NewPredicateFuncs(func(obj client.Object) bool {
+				return obj.GetName() == kubeAPIServerDeploymentName
+			}))).
+		Watches(&source.Kind{Type: &corev1.Secret{}}, handler.EnqueueRequestsFromMapFunc(r.enqueueHostedControlPlanesForIdentityProviderObject)).
+		Watches(&source.Kind{Type: &corev1.ConfigMap{}}, handler.EnqueueRequestsFromMapFunc(r.enqueueHostedControlPlanesForIdentityProviderObject)).
+		Watches(&source.Kind{Type: &corev1.ConfigMap{}}, handler.EnqueueRequestsFromMapFunc(r.enqueueHostedControlPlanesForAdditionalTrustBundle)).
 		WithOptions(controller.Options{
 			RateLimiter: workqueue.NewItemExponentialFailureRateLimiter(1*time.Second, 10*time.Second),
 		}).
@@ -115,67 +157,6 @@ func (r *HostedControlPlaneReconciler) SetupWithManager(mgr ctrl.Manager) error
 	return nil
 }
 
-func getConditionByType(conditions []hyperv1.HostedControlPlaneCondition, conditionType hyperv1.ConditionType) *hyperv1.HostedControlPlaneCondition {
-	for k, v := range conditions {
-		if v.Type == conditionType {
-			return &conditions[k]
-		}
-	}
-	return nil
-}
-
-func setConditionByType(conditions *[]hyperv1.HostedControlPlaneCondition, conditionType hyperv1.ConditionType, status hyperv1.ConditionStatus, reason, message string) {
-	existingCondition := getConditionByType(*conditions, conditionType)
-	if existingCondition == nil {
-		newCondition := hyperv1.HostedControlPlaneCondition{
-			Type:    conditionType,
-			Status:  status,
-			Reason:  reason,
-			Message: message,
-		}
-		*conditions = append(*conditions, newCondition)
-	} else {
-		existingCondition.Status = status
-		existingCondition.Reason = reason
-		existingCondition.Message = message
-	}
-}
-
-func (r *HostedControlPlaneReconciler) setAvailableCondition(ctx context.Context, hostedControlPlane *hyperv1.HostedControlPlane, oldStatus *hyperv1.HostedControlPlaneStatus,
-	status hyperv1.ConditionStatus, reason, message string, result ctrl.Result, err error) (ctrl.Result, error) {
-	conditions := &hostedControlPlane.Status.Conditions
-
-	setConditionByType(conditions, hyperv1.Available, status, reason, message)
-
-	// Sync status.ready with Available condition
-	condition := getConditionByType(*conditions, hyperv1.Available)
-	if condition != nil && condition.Status == hyperv1.ConditionTrue {
-		hostedControlPlane.Status.Ready = true
-	} else {
-		hostedControlPlane.Status.Ready = false
-	}
-
-	if reflect.DeepEqual(oldStatus, hostedControlPlane.Status) {
-		// No change to status, nothing to sync
-		return result, err
-	}
-
-	// Check for changed conditions to update LastTransitionTime
-	for k, condition := range *conditions {
-		oldCondition := getConditionByType(oldStatus.Conditions, condition.Type)
-		if oldCondition == nil || *oldCondition != condition {
-			(*conditions)[k].LastTransitionTime = metav1.Now()
-		}
-	}
-
-	if updateErr := r.Status().Update(ctx, hostedControlPlane); updateErr != nil {
-		r.Log.Error(updateErr, "failed to update status")
-		result.Requeue = true
-	}
-
-	return result, err
-}
-
 func (r *HostedControlPlaneReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
 	r.Log = ctrl.LoggerFrom(ctx)
 	r.Log.Info("Reconciling")
@@ -223,6 +204,15 @@ func (r *HostedControlPlaneReconciler) Reconcile(ctx context.Context, req ctrl.R
 		return ctrl.Result{}, nil
 	}
 
+	isPaused, pausedDuration, err := hyperutil.IsReconciliationPaused(time.Now(), hostedControlPlane.Spec.PausedUntil)
+	if err != nil {
+		r.Log.Error(err, "ignoring invalid pausedUntil value")
+	}
+	if isPaused {
+		r.Log.Info("Reconciliation paused", "pausedUntil", *hostedControlPlane.Spec.PausedUntil)
+		return ctrl.Result{RequeueAfter: pausedDuration}, nil
+	}
+
 	// Ensure the hostedControlPlane has a finalizer for cleanup
 	if !controllerutil.ContainsFinalizer(hostedControlPlane, finalizer) {
 		controllerutil.AddFinalizer(hostedControlPlane, finalizer)
@@ -242,34 +232,50 @@ func (r *HostedControlPlaneReconciler) Reconcile(ctx context.Context, req ctrl.R
 	infraStatus, err := r.ensureInfrastructure(ctx, hostedControlPlane)
 	if err != nil {
 		r.Log.Error(err, "failed to ensure infrastructure")
-		return r.setAvailableCondition(ctx, hostedControlPlane, oldStatus, hyperv1.ConditionFalse, "InfrastructureEnsureFailed", err.Error(), result, fmt.Errorf("failed to ensure infrastructure: %w", err))
+		setCondition(hostedControlPlane, hyperv1.InfrastructureReady, metav1.ConditionFalse, "InfrastructureEnsureFailed", err.Error())
+		return r.updateStatus(ctx, hostedControlPlane, oldStatus, "InfrastructureEnsureFailed", result, fmt.Errorf("failed to ensure infrastructure: %w", err))
 	}
 
 	// Wait for things like LB services to become available
 	if !infraStatus.IsReady() {
 		result.RequeueAfter = 5 * time.Second
 		r.Log.Info("Cluster infrastructure is still provisioning, will try again later")
-		return r.setAvailableCondition(ctx, hostedControlPlane, oldStatus, hyperv1.ConditionFalse, "WaitingOnInfrastructureReady", "Cluster infrastructure is still provisioning", result, nil)
+		setCondition(hostedControlPlane, hyperv1.InfrastructureReady, metav1.ConditionFalse, "WaitingOnInfrastructureReady", "Cluster infrastructure is still provisioning")
+		return r.updateStatus(ctx, hostedControlPlane, oldStatus, "", result, nil)
 	}
+	setCondition(hostedControlPlane, hyperv1.InfrastructureReady, metav1.ConditionTrue, "AsExpected", "Cluster infrastructure is provisioned")
 	hostedControlPlane.Status.ControlPlaneEndpoint = hyperv1.APIEndpoint{
 		Host: infraStatus.APIAddress,
-		Port: APIServerPort,
+		Port: infraStatus.APIPort,
 	}
 
-	releaseImage, err := r.ReleaseProvider.Lookup(ctx, hostedControlPlane.Spec.ReleaseImage)
+	releaseImage, err := r.lookupReleaseImage(ctx, hostedControlPlane)
 	if err != nil {
-		return r.setAvailableCondition(ctx, hostedControlPlane, oldStatus, hyperv1.ConditionFalse, "ReleaseInfoLookupFailed", err.Error(), ctrl.Result{}, fmt.Errorf("failed to look up release info: %w", err))
+		setCondition(hostedControlPlane, hyperv1.ReleaseImageValid, metav1.ConditionFalse, "ReleaseInfoLookupFailed", err.Error())
+		return r.updateStatus(ctx, hostedControlPlane, oldStatus, "ReleaseInfoLookupFailed", ctrl.Result{}, fmt.Errorf("failed to look up release info: %w", err))
 	}
 	componentVersions, err := releaseImage.ComponentVersions()
 	if err != nil {
-		return r.setAvailableCondition(ctx, hostedControlPlane, oldStatus, hyperv1.ConditionFalse, "InvalidComponentVersion", err.Error(), ctrl.Result{}, fmt.Errorf("invalid component versions found in release info: %w", err))
+		setCondition(hostedControlPlane, hyperv1.ReleaseImageValid, metav1.ConditionFalse, "InvalidComponentVersion", err.Error())
+		return r.updateStatus(ctx, hostedControlPlane, oldStatus, "InvalidComponentVersion", ctrl.Result{}, fmt.Errorf("invalid component versions found in release info: %w", err))
 	}
+	setCondition(hostedControlPlane, hyperv1.ReleaseImageValid, metav1.ConditionTrue, "AsExpected", fmt.Sprintf("Release image %s is valid", hostedControlPlane.Spec.ReleaseImage))
 	r.Log.Info("found release info for image", "releaseImage", hostedControlPlane.Spec.ReleaseImage, "info", releaseImage, "componentImages", releaseImage.ComponentImages(), "componentVersions", componentVersions)
 
 	if hostedControlPlane.Status.Version == "" {
 		hostedControlPlane.Status.Version = releaseImage.Version()
 	}
 
+	if err := r.restartManifestsBootstrapperForNodeConnectivity(ctx, hostedControlPlane); err != nil {
+		return ctrl.Result{}, err
+	}
+	if err := r.restartManifestsBootstrapperForAdditionalTrustBundle(ctx, hostedControlPlane); err != nil {
+		return ctrl.Result{}, err
+	}
+	if err := r.restartManifestsBootstrapperForImageContentSources(ctx, hostedControlPlane); err != nil {
+		return ctrl.Result{}, err
+	}
+
 	// During an upgrade, if there's an old bootstrapper pod referring to the old
 	// image, delete the pod to make way for the new one to be rendered. This is
 	// a hack to avoid the refactoring of moving this pod into the hosted cluster
@@ -294,12 +300,23 @@ func (r *HostedControlPlaneReconciler) Reconcile(ctx context.Context, req ctrl.R
 		}
 	}
 
+	manifests, err := r.generateControlPlaneManifests(ctx, hostedControlPlane, infraStatus, releaseImage)
+	if err != nil {
+		var configErr *configurationError
+		if !errors.As(err, &configErr) {
+			return r.updateStatus(ctx, hostedControlPlane, oldStatus, "ManifestGenerationFailed", result, fmt.Errorf("failed to generate control plane manifests: %w", err))
+		}
+		setCondition(hostedControlPlane, hyperv1.ValidConfiguration, metav1.ConditionFalse, "InvalidConfiguration", configErr.Error())
+		return r.updateStatus(ctx, hostedControlPlane, oldStatus, "InvalidConfiguration", result, fmt.Errorf("failed to generate control plane manifests: %w", err))
+	}
+	setCondition(hostedControlPlane, hyperv1.ValidConfiguration, metav1.ConditionTrue, "AsExpected", "Configuration is valid")
+
 	// Install the control plane into the infrastructure
 	r.Log.Info("Creating hosted control plane")
-	err = r.ensureControlPlane(ctx, hostedControlPlane, infraStatus, releaseImage)
+	err = r.ensureControlPlane(ctx, hostedControlPlane, infraStatus, manifests)
 	if err != nil {
 		r.Log.Error(err, "failed to ensure control plane")
-		return r.setAvailableCondition(ctx, hostedControlPlane, oldStatus, hyperv1.ConditionFalse, "ControlPlaneEnsureFailed", err.Error(), result, fmt.Errorf("failed to ensure control plane: %w", err))
+		return r.updateStatus(ctx, hostedControlPlane, oldStatus, "ControlPlaneEnsureFailed", result, fmt.Errorf("failed to ensure control plane: %w", err))
 	}
 
 	if hostedControlPlane.Spec.KubeConfig != nil {
@@ -310,6 +327,18 @@ func (r *HostedControlPlaneReconciler) Reconcile(ctx context.Context, req ctrl.R
 			Key:  DefaultAdminKubeconfigKey,
 		}
 	}
+	if kubeadminDisabled(hostedControlPlane) {
+		hostedControlPlane.Status.KubeadminPassword = nil
+	} else {
+		hostedControlPlane.Status.KubeadminPassword = &corev1.LocalObjectReference{Name: kubeadminPasswordSecretName}
+	}
+
+	baseDomain, err := clusterBaseDomain(r.Client, ctx, hostedControlPlane)
+	if err != nil {
+		return r.updateStatus(ctx, hostedControlPlane, oldStatus, "ControlPlaneEnsureFailed", result, fmt.Errorf("couldn't determine cluster base domain name: %w", err))
+	}
+	hostedControlPlane.Status.ConsoleURL = consoleURL(baseDomain)
+	hostedControlPlane.Status.OAuthCallbackURL = oauthCallbackURL(infraStatus.OAuthAddress, infraStatus.OAuthPort)
 
 	// At this point the latest image is considered to be rolled out. If we're transitioning
 	// from one image to another, record that on status and note the time.
@@ -323,11 +352,17 @@ func (r *HostedControlPlaneReconciler) Reconcile(ctx context.Context, req ctrl.R
 	}
 
 	r.Log.Info("Successfully reconciled")
-	return r.setAvailableCondition(ctx, hostedControlPlane, oldStatus, hyperv1.ConditionTrue, "AsExpected", "HostedControlPlane is ready", ctrl.Result{}, nil)
+	return r.updateStatus(ctx, hostedControlPlane, oldStatus, "", ctrl.Result{}, nil)
 }
 
 func (r *HostedControlPlaneReconciler) delete(ctx context.Context, hcp *hyperv1.HostedControlPlane) error {
-	releaseImage, err := r.ReleaseProvider.Lookup(ctx, hcp.Spec.ReleaseImage)
+	if err := r.deleteDNSRecords(ctx, hcp); err != nil {
+		return err
+	}
+	if err := r.deletePrivateAPI(ctx, hcp); err != nil {
+		return err
+	}
+	releaseImage, err := r.lookupReleaseImage(ctx, hcp)
 	if err != nil {
 		return fmt.Errorf("failed to look up release info: %w", err)
 	}
@@ -345,25 +380,91 @@ func (r *HostedControlPlaneReconciler) ensureInfrastructure(ctx context.Context,
 	status := InfrastructureStatus{}
 
 	targetNamespace := hcp.GetNamespace()
+	connectivity := hyperutil.NodeConnectivity(hcp.Spec.NodeConnectivity)
 	// Ensure that we can run privileged pods
-	if err := ensureVPNSCC(r, hcp, targetNamespace); err != nil {
-		return status, fmt.Errorf("failed to ensure privileged SCC for the new namespace: %w", err)
+	if connectivity == hyperv1.OpenVPN {
+		if err := ensureVPNSCC(r, hcp, targetNamespace); err != nil {
+			return status, fmt.Errorf("failed to ensure privileged SCC for the new namespace: %w", err)
+		}
 	}
 
+	apiStrategy := hyperutil.ServicePublishingStrategy(hcp.Spec.Services, hyperv1.APIServer)
+	oauthStrategy := hyperutil.ServicePublishingStrategy(hcp.Spec.Services, hyperv1.OAuthServer)
+	vpnStrategy := hyperutil.ServicePublishingStrategy(hcp.Spec.Services, hyperv1.VPN)
+	konnectivityStrategy := hyperutil.ServicePublishingStrategy(hcp.Spec.Services, hyperv1.KonnectivityServer)
+	ovnSbDbStrategy := hyperutil.ServicePublishingStrategy(hcp.Spec.Services, hyperv1.OVNSbDb)
+
 	// Create Kube APIServer service
-	r.Log.Info("Creating Kube API service")
-	apiService, err := createKubeAPIServerService(r, hcp, targetNamespace)
+	r.Log.Info("Creating Kube API service", "strategy", apiStrategy.Type)
+	apiService, err := createKubeAPIServerService(r, hcp, targetNamespace, apiStrategy)
 	if err != nil {
 		return status, fmt.Errorf("failed to create Kube API service: %w", err)
 	}
 	r.Log.Info("Created Kube API service")
 
-	r.Log.Info("Creating VPN service")
-	vpnService, err := createVPNServerService(r, hcp, targetNamespace)
-	if err != nil {
-		return status, fmt.Errorf("failed to create vpn server service: %w", err)
+	access := hyperutil.EndpointAccess(hcp.Spec.Platform)
+	var privateAPIService *corev1.Service
+	if access != hyperv1.Public {
+		r.Log.Info("Creating private Kube API service", "endpointAccess", access)
+		privateAPIService, err = createPrivateKubeAPIServerService(r, hcp, targetNamespace)
+		if err != nil {
+			return status, fmt.Errorf("failed to create private Kube API service: %w", err)
+		}
+		r.Log.Info("Created private Kube API service")
+	}
+
+	apiRoute := createPassthroughRoute(targetNamespace, kubeAPIServerServiceName, kubeAPIServerServiceName)
+	apiRoute.Spec.Host = apiStrategy.Hostname
+	if apiStrategy.Type == hyperv1.Route {
+		r.Log.Info("Creating Kube API route")
+		apiRoute.OwnerReferences = ensureHCPOwnerRef(hcp, apiRoute.OwnerReferences)
+		if err := r.Create(ctx, apiRoute); err != nil && !apierrors.IsAlreadyExists(err) {
+			return status, fmt.Errorf("failed to create Kube API route: %w", err)
+		}
+	}
+
+	var vpnService, konnectivityService *corev1.Service
+	konnectivityRoute := createKonnectivityServerRoute(targetNamespace)
+	if connectivity == hyperv1.Konnectivity {
+		r.Log.Info("Creating Konnectivity server service", "strategy", konnectivityStrategy.Type)
+		konnectivityService, err = createKonnectivityServerService(r, hcp, targetNamespace, konnectivityStrategy)
+		if err != nil {
+			return status, fmt.Errorf("failed to create konnectivity server service: %w", err)
+		}
+		r.Log.Info("Created Konnectivity server service")
+		if konnectivityStrategy.Type == hyperv1.Route {
+			r.Log.Info("Creating Konnectivity server route")
+			konnectivityRoute.OwnerReferences = ensureHCPOwnerRef(hcp, konnectivityRoute.OwnerReferences)
+			if err := r.Create(ctx, konnectivityRoute); err != nil && !apierrors.IsAlreadyExists(err) {
+				return status, fmt.Errorf("failed to create konnectivity server route: %w", err)
+			}
+		}
+	} else {
+		r.Log.Info("Creating VPN service", "strategy", vpnStrategy.Type)
+		vpnService, err = createVPNServerService(r, hcp, targetNamespace, vpnStrategy)
+		if err != nil {
+			return status, fmt.Errorf("failed to create vpn server service: %w", err)
+		}
+		r.Log.Info("Created VPN service")
+	}
+
+	var ovnSbDbService *corev1.Service
+	ovnSbDbRoute := createOVNSbDbRoute(targetNamespace)
+	if usesOVNKubernetes(hcp) {
+		r.Log.Info("Creating OVN sbdb service", "strategy", ovnSbDbStrategy.Type)
+		ovnSbDbService, err = createOVNSbDbService(r, hcp, targetNamespace, ovnSbDbStrategy)
+		if err != nil {
+			return status, fmt.Errorf("failed to create ovn sbdb service: %w", err)
+		}
+		r.Log.Info("Created OVN sbdb service")
+		if ovnSbDbStrategy.Type == hyperv1.Route {
+			r.Log.Info("Creating OVN sbdb route")
+			ovnSbDbRoute.OwnerReferences = ensureHCPOwnerRef(hcp, ovnSbDbRoute.OwnerReferences)
+			if err := r.Create(ctx, ovnSbDbRoute); err != nil && !apierrors.IsAlreadyExists(err) {
+				return status, fmt.Errorf("failed to create ovn sbdb route: %w", err)
+			}
+		}
 	}
-	r.Log.Info("Created VPN service")
 
 	r.Log.Info("Creating Openshift API service")
 	openshiftAPIService, err := createOpenshiftService(r, hcp, targetNamespace)
@@ -379,43 +480,85 @@ func (r *HostedControlPlaneReconciler) ensureInfrastructure(ctx context.Context,
 	}
 	r.Log.Info("Created Openshift Oauth API service")
 
-	r.Log.Info("Creating OAuth service")
-	_, err = createOauthService(r, hcp, targetNamespace)
+	r.Log.Info("Creating OAuth service", "strategy", oauthStrategy.Type)
+	oauthService, err := createOauthService(r, hcp, targetNamespace, oauthStrategy)
 	if err != nil {
 		return status, fmt.Errorf("error creating service for oauth: %w", err)
 	}
 
-	r.Log.Info("Creating oauth server route")
 	oauthRoute := createOauthServerRoute(targetNamespace)
-	oauthRoute.OwnerReferences = ensureHCPOwnerRef(hcp, oauthRoute.OwnerReferences)
-	if err := r.Create(ctx, oauthRoute); err != nil && !apierrors.IsAlreadyExists(err) {
-		return status, fmt.Errorf("failed to create oauth server route: %w", err)
+	oauthRoute.Spec.Host = oauthStrategy.Hostname
+	if oauthStrategy.Type == hyperv1.Route {
+		r.Log.Info("Creating oauth server route")
+		oauthRoute.OwnerReferences = ensureHCPOwnerRef(hcp, oauthRoute.OwnerReferences)
+		if err := r.Create(ctx, oauthRoute); err != nil && !apierrors.IsAlreadyExists(err) {
+			return status, fmt.Errorf("failed to create oauth server route: %w", err)
+		}
 	}
 
-	apiAddress, err := getLoadBalancerServiceAddress(r, ctx, client.ObjectKeyFromObject(apiService))
-	if err != nil {
-		return status, fmt.Errorf("failed to get service: %w", err)
+	if privateAPIService != nil {
+		status.NodeAPIAddress, err = r.ensurePrivateAPI(ctx, hcp, client.ObjectKeyFromObject(privateAPIService))
+		if err != nil {
+			return status, fmt.Errorf("failed to ensure private Kube API endpoint: %w", err)
+		}
+		status.NodeAPIPort = privateAPIPort
+	}
+	if access == hyperv1.Private {
+		// The Kube API server has no public address.
+		status.APIAddress, status.APIPort = status.NodeAPIAddress, status.NodeAPIPort
+	} else {
+		status.APIAddress, status.APIPort, err = getPublishedServiceAddress(r, ctx, client.ObjectKeyFromObject(apiService), client.ObjectKeyFromObject(apiRoute), apiStrategy)
+		if err != nil {
+			return status, fmt.Errorf("failed to get Kube API address: %w", err)
+		}
+		if len(apiStrategy.Hostname) > 0 && len(status.APIAddress) > 0 {
+			status.APIAddress, err = r.ensureHostname(ctx, hcp, apiStrategy, status.APIAddress, client.ObjectKeyFromObject(apiRoute))
+			if err != nil {
+				return status, fmt.Errorf("failed to ensure Kube API hostname: %w", err)
+			}
+		}
+		if access == hyperv1.Public {
+			status.NodeAPIAddress, status.NodeAPIPort = status.APIAddress, status.APIPort
+		}
 	}
-	status.APIAddress = apiAddress
 
-	oauthAddress, err := getRouteAddress(r, ctx, client.ObjectKeyFromObject(oauthRoute))
+	status.OAuthAddress, status.OAuthPort, err = getPublishedServiceAddress(r, ctx, client.ObjectKeyFromObject(oauthService), client.ObjectKeyFromObject(oauthRoute), oauthStrategy)
 	if err != nil {
-		return status, fmt.Errorf("failed get get route address: %w", err)
+		return status, fmt.Errorf("failed to get oauth address: %w", err)
+	}
+	if len(oauthStrategy.Hostname) > 0 && len(status.OAuthAddress) > 0 {
+		status.OAuthAddress, err = r.ensureHostname(ctx, hcp, oauthStrategy, status.OAuthAddress, client.ObjectKeyFromObject(oauthRoute))
+		if err != nil {
+			return status, fmt.Errorf("failed to ensure oauth hostname: %w", err)
+		}
 	}
-	status.OAuthAddress = oauthAddress
 
-	vpnAddress, err := getLoadBalancerServiceAddress(r, ctx, client.ObjectKeyFromObject(vpnService))
-	if err != nil {
-		return status, fmt.Errorf("failed to get service: %w", err)
+	if konnectivityService != nil {
+		status.KonnectivityAddress, status.KonnectivityPort, err = getPublishedServiceAddress(r, ctx, client.ObjectKeyFromObject(konnectivityService), client.ObjectKeyFromObject(konnectivityRoute), konnectivityStrategy)
+		if err != nil {
+			return status, fmt.Errorf("failed to get konnectivity server address: %w", err)
+		}
+	}
+	if vpnService != nil {
+		status.VPNAddress, status.VPNPort, err = getPublishedServiceAddress(r, ctx, client.ObjectKeyFromObject(vpnService), client.ObjectKey{}, vpnStrategy)
+		if err != nil {
+			return status, fmt.Errorf("failed to get vpn address: %w", err)
+		}
+	}
+	if ovnSbDbService != nil {
+		status.ovnSbDbPublished = true
+		status.OVNSbDbAddress, status.OVNSbDbPort, err = getPublishedServiceAddress(r, ctx, client.ObjectKeyFromObject(ovnSbDbService), client.ObjectKeyFromObject(ovnSbDbRoute), ovnSbDbStrategy)
+		if err != nil {
+			return status, fmt.Errorf("failed to get ovn sbdb address: %w", err)
+		}
 	}
-	status.VPNAddress = vpnAddress
 	status.OpenShiftAPIAddress = openshiftAPIService.Spec.ClusterIP
 	status.OauthAPIServerAddress = oauthAPIService.Spec.ClusterIP
 
 	return status, nil
 }
 
-func (r *HostedControlPlaneReconciler) ensureControlPlane(ctx context.Context, hcp *hyperv1.HostedControlPlane, infraStatus InfrastructureStatus, releaseImage *releaseinfo.ReleaseImage) error {
+func (r *HostedControlPlaneReconciler) ensureControlPlane(ctx context.Context, hcp *hyperv1.HostedControlPlane, infraStatus InfrastructureStatus, manifests map[string][]byte) error {
 	r.Log.Info("ensuring control plane for cluster", "cluster", hcp.Name)
 
 	targetNamespace := hcp.GetNamespace()
@@ -449,11 +592,6 @@ func (r *HostedControlPlaneReconciler) ensureControlPlane(ctx context.Context, h
 		}
 	}
 
-	manifests, err := r.generateControlPlaneManifests(ctx, hcp, infraStatus, releaseImage)
-	if err != nil {
-		return err
-	}
-
 	// Create oauth branding manifest because it cannot be applied
 	manifestBytes := manifests[oauthBrandingManifest]
 	manifestObj := &unstructured.Unstructured{}
@@ -472,24 +610,16 @@ func (r *HostedControlPlaneReconciler) ensureControlPlane(ctx context.Context, h
 	}
 	r.Log.Info("successfully applied all manifests")
 
-	kubeadminPassword, err := generateKubeadminPassword()
-	if err != nil {
-		return fmt.Errorf("failed to generate kubeadmin password: %w", err)
+	if err := r.deleteUnusedNodeConnectivity(ctx, hcp); err != nil {
+		return err
 	}
 
-	kubeadminPasswordTargetSecret, err := generateKubeadminPasswordTargetSecret(r.Scheme(), kubeadminPassword, targetNamespace)
-	if err != nil {
-		return fmt.Errorf("failed to create kubeadmin secret manifest for target cluster: %w", err)
-	}
-	kubeadminPasswordTargetSecret.OwnerReferences = ensureHCPOwnerRef(hcp, kubeadminPasswordTargetSecret.OwnerReferences)
-	if err := r.Create(ctx, kubeadminPasswordTargetSecret); err != nil && !apierrors.IsAlreadyExists(err) {
-		return fmt.Errorf("failed to generate kubeadminPasswordTargetSecret: %w", err)
+	if err := r.deleteUnusedImageContentSources(ctx, hcp); err != nil {
+		return err
 	}
 
-	kubeadminPasswordSecret := generateKubeadminPasswordSecret(targetNamespace, kubeadminPassword)
-	kubeadminPasswordSecret.OwnerReferences = ensureHCPOwnerRef(hcp, kubeadminPasswordSecret.OwnerReferences)
-	if err := r.Create(ctx, kubeadminPasswordSecret); err != nil && !apierrors.IsAlreadyExists(err) {
-		return fmt.Errorf("failed to generate kubeadminPasswordSecret: %w", err)
+	if err := r.reconcileKubeadminPassword(ctx, hcp); err != nil {
+		return err
 	}
 
 	pkiSecret := &corev1.Secret{
@@ -503,27 +633,45 @@ func (r *HostedControlPlaneReconciler) ensureControlPlane(ctx context.Context, h
 		return fmt.Errorf("failed to get pki secret: %w", err)
 	}
 
-	kubeconfigSecret, err := generateKubeconfigSecret(hcp.GetNamespace(), hcp.Spec.KubeConfig, pkiSecret.Data["admin.kubeconfig"])
+	kubeconfig := pkiSecret.Data["admin.kubeconfig"]
+	apiCert, err := r.getServingCert(ctx, hcp.Namespace, hyperutil.ServicePublishingStrategy(hcp.Spec.Services, hyperv1.APIServer))
 	if err != nil {
-		return fmt.Errorf("failed to create kubeconfig secret manifest for management cluster: %w", err)
+		return err
 	}
-	kubeconfigSecret.OwnerReferences = ensureHCPOwnerRef(hcp, kubeconfigSecret.OwnerReferences)
-	if err := r.Create(ctx, kubeconfigSecret); err != nil && !apierrors.IsAlreadyExists(err) {
-		return fmt.Errorf("failed to generate kubeconfigSecret: %w", err)
+	if apiCert != nil {
+		if kubeconfig, err = servingCertKubeconfig(kubeconfig, apiCert); err != nil {
+			return fmt.Errorf("failed to update the admin kubeconfig for the serving cert: %w", err)
+		}
 	}
-
-	baseDomain, err := clusterBaseDomain(r.Client, ctx, hcp)
+	kubeconfigSecret, err := generateKubeconfigSecret(hcp.GetNamespace(), hcp.Spec.KubeConfig, kubeconfig)
 	if err != nil {
-		return fmt.Errorf("couldn't determine cluster base domain  name: %w", err)
+		return fmt.Errorf("failed to create kubeconfig secret manifest for management cluster: %w", err)
+	}
+	// The kubeconfig is updated when the certificate authority of the serving
+	// certificate changes.
+	kubeconfigData := kubeconfigSecret.Data
+	_, err = controllerutil.CreateOrUpdate(ctx, r.Client, kubeconfigSecret, func() error {
+		kubeconfigSecret.OwnerReferences = ensureHCPOwnerRef(hcp, kubeconfigSecret.OwnerReferences)
+		kubeconfigSecret.Data = kubeconfigData
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to generate kubeconfigSecret: %w", err)
 	}
-	r.Log.Info(fmt.Sprintf("Cluster API URL: %s", fmt.Sprintf("https://%s:%d", infraStatus.APIAddress, APIServerPort)))
-	r.Log.Info(fmt.Sprintf("Kubeconfig is available in secret admin-kubeconfig in the %s namespace", hcp.GetNamespace()))
-	r.Log.Info(fmt.Sprintf("Console URL:  %s", fmt.Sprintf("https://console-openshift-console.%s", fmt.Sprintf("apps.%s", baseDomain))))
-	r.Log.Info(fmt.Sprintf("kubeadmin password is available in secret %q in the %s namespace", "kubeadmin-password", targetNamespace))
 
 	return nil
 }
 
+// lookupReleaseImage looks up the release image of the HostedControlPlane,
+// pulling it and its component images from their registry mirrors.
+func (r *HostedControlPlaneReconciler) lookupReleaseImage(ctx context.Context, hcp *hyperv1.HostedControlPlane) (*releaseinfo.ReleaseImage, error) {
+	provider := &releaseinfo.RegistryMirrorProviderDecorator{
+		Delegate:            r.ReleaseProvider,
+		ImageContentSources: hcp.Spec.ImageContentSources,
+	}
+	return provider.Lookup(ctx, hcp.Spec.ReleaseImage)
+}
+
 func (r *HostedControlPlaneReconciler) generateControlPlaneManifests(ctx context.Context, hcp *hyperv1.HostedControlPlane, infraStatus InfrastructureStatus, releaseImage *releaseinfo.ReleaseImage) (map[string][]byte, error) {
 	targetNamespace := hcp.GetNamespace()
 
@@ -536,7 +684,7 @@ func (r *HostedControlPlaneReconciler) generateControlPlaneManifests(ctx context
 		}
 		data, hasSSHKeyData := sshKeySecret.Data["id_rsa.pub"]
 		if !hasSSHKeyData {
-			return nil, fmt.Errorf("SSH key secret secret %s is missing the id_rsa.pub key", hcp.Spec.SSHKey.Name)
+			return nil, invalidConfiguration(fmt.Errorf("SSH key secret secret %s is missing the id_rsa.pub key", hcp.Spec.SSHKey.Name))
 		}
 		sshKeyData = data
 	}
@@ -546,19 +694,44 @@ func (r *HostedControlPlaneReconciler) generateControlPlaneManifests(ctx context
 		return nil, fmt.Errorf("couldn't determine cluster base domain  name: %w", err)
 	}
 
+	var globalConfig hyperconfig.GlobalConfig
+	if hcp.Spec.Configuration != nil {
+		var errs field.ErrorList
+		if globalConfig, errs = hyperconfig.ParseGlobalConfig(hcp.Spec.Configuration.Items, field.NewPath("spec", "configuration", "items")); len(errs) > 0 {
+			return nil, invalidConfiguration(errs.ToAggregate())
+		}
+	}
+
 	params := render.NewClusterParams()
 	params.Namespace = targetNamespace
 	params.ExternalAPIDNSName = infraStatus.APIAddress
-	params.ExternalAPIPort = APIServerPort
-	params.ExternalAPIAddress = DefaultAPIServerIPAddress
+	params.ExternalAPIPort = uint(infraStatus.APIPort)
+	params.ExternalAPIAddress = hyperconfig.DefaultAPIServerIPAddress
+	params.NodeAPIDNSName = infraStatus.NodeAPIAddress
+	params.NodeAPIPort = uint(infraStatus.NodeAPIPort)
 	params.ExternalOpenVPNAddress = infraStatus.VPNAddress
-	params.ExternalOpenVPNPort = 1194
+	params.ExternalOpenVPNPort = uint(infraStatus.VPNPort)
+	params.NodeConnectivity = string(hyperutil.NodeConnectivity(hcp.Spec.NodeConnectivity))
+	params.ExternalKonnectivityAddress = infraStatus.KonnectivityAddress
+	params.ExternalKonnectivityPort = uint(infraStatus.KonnectivityPort)
+	params.ExternalOVNSbDbAddress = infraStatus.OVNSbDbAddress
+	params.ExternalOVNSbDbPort = uint(infraStatus.OVNSbDbPort)
 	params.ExternalOauthDNSName = infraStatus.OAuthAddress
-	params.ExternalOauthPort = externalOauthPort
+	params.ExternalOauthPort = uint(infraStatus.OAuthPort)
+	if hyperutil.ServicePublishingStrategy(hcp.Spec.Services, hyperv1.APIServer).Type == hyperv1.NodePort {
+		params.APINodePort = uint(infraStatus.APIPort)
+	}
 	params.ServiceCIDR = hcp.Spec.ServiceCIDR
 	params.PodCIDR = hcp.Spec.PodCIDR
 	params.MachineCIDR = hcp.Spec.MachineCIDR
-	params.ReleaseImage = hcp.Spec.ReleaseImage
+	for _, entry := range hyperutil.ClusterNetworks(hcp.Spec.PodCIDR, hcp.Spec.ClusterNetwork) {
+		params.ClusterNetwork = append(params.ClusterNetwork, render.ClusterNetworkEntry{CIDR: entry.CIDR, HostPrefix: entry.HostPrefix})
+	}
+	params.ServiceNetwork = hyperutil.ServiceNetworks(hcp.Spec.ServiceCIDR, hcp.Spec.ServiceNetwork)
+	for _, entry := range hyperutil.MachineNetworks(hcp.Spec.MachineCIDR, hcp.Spec.MachineNetwork) {
+		params.MachineNetwork = append(params.MachineNetwork, entry.CIDR)
+	}
+	params.ReleaseImage = releaseinfo.MirroredImage(hcp.Spec.ReleaseImage, hcp.Spec.ImageContentSources)
 	params.IngressSubdomain = fmt.Sprintf("apps.%s", baseDomain)
 	params.OpenShiftAPIClusterIP = infraStatus.OpenShiftAPIAddress
 	params.OauthAPIClusterIP = infraStatus.OauthAPIServerAddress
@@ -585,11 +758,38 @@ func (r *HostedControlPlaneReconciler) generateControlPlaneManifests(ctx context
 	params.InternalAPIPort = APIServerPort
 	params.IssuerURL = hcp.Spec.IssuerURL
 	params.EtcdClientName = "etcd-client"
-	params.NetworkType = "OpenShiftSDN"
+	params.NetworkType = string(hyperv1.OpenShiftSDN)
+	if len(hcp.Spec.NetworkType) > 0 {
+		params.NetworkType = string(hcp.Spec.NetworkType)
+	}
 	params.ImageRegistryHTTPSecret = generateImageRegistrySecret()
 	params.APIAvailabilityPolicy = render.SingleReplica
 	params.ControllerAvailabilityPolicy = render.SingleReplica
+	if hcp.Spec.ControllerAvailabilityPolicy == hyperv1.HighlyAvailable {
+		params.APIAvailabilityPolicy = render.HighlyAvailable
+		params.ControllerAvailabilityPolicy = render.HighlyAvailable
+	}
 	params.SSHKey = string(sshKeyData)
+	params.GlobalConfig = globalConfig
+	if proxy := hcp.Spec.Proxy; proxy != nil {
+		params.HTTPProxy = proxy.HTTPProxy
+		params.HTTPSProxy = proxy.HTTPSProxy
+		params.NoProxy = hyperutil.NoProxy(proxy, append(append(params.ClusterCIDRs(), params.ServiceCIDRs()...), params.MachineCIDRs()...)...)
+	}
+	params.ExtraFeatureGates = globalConfig.FeatureGates()
+	if err := r.reconcileAdditionalTrustBundleParams(ctx, hcp, params); err != nil {
+		return nil, err
+	}
+	reconcileImageContentSourcesParams(hcp, params)
+	if err := r.reconcileIdentityProviderParams(ctx, hcp, params); err != nil {
+		return nil, err
+	}
+	if err := setComponentResources(hcp, params); err != nil {
+		return nil, err
+	}
+	if hcp.Spec.ControlPlanePlacement != nil {
+		params.MasterPriorityClass = hcp.Spec.ControlPlanePlacement.PriorityClassName
+	}
 
 	// Generate PKI data just once and store it in a secret. PKI generation isn't
 	// deterministic and shouldn't be performed with every reconcile, otherwise
@@ -601,6 +801,21 @@ func (r *HostedControlPlaneReconciler) generateControlPlaneManifests(ctx context
 		},
 		Data: map[string][]byte{},
 	}
+	pkiParams := &render.PKIParams{
+		ExternalAPIAddress:          infraStatus.APIAddress,
+		NodeInternalAPIServerIP:     hyperconfig.DefaultAPIServerIPAddress,
+		ExternalAPIPort:             uint(infraStatus.APIPort),
+		NodeAPIAddress:              infraStatus.NodeAPIAddress,
+		NodeAPIPort:                 uint(infraStatus.NodeAPIPort),
+		InternalAPIPort:             APIServerPort,
+		ServiceCIDR:                 hcp.Spec.ServiceCIDR,
+		ServiceNetwork:              params.ServiceCIDRs(),
+		ExternalOauthAddress:        infraStatus.OAuthAddress,
+		IngressSubdomain:            "apps." + baseDomain,
+		ExternalOpenVPNAddress:      infraStatus.VPNAddress,
+		ExternalKonnectivityAddress: infraStatus.KonnectivityAddress,
+		Namespace:                   targetNamespace,
+	}
 	needsPkiSecret := false
 	if err := r.Get(ctx, client.ObjectKeyFromObject(pkiSecret), pkiSecret); err != nil {
 		if apierrors.IsNotFound(err) {
@@ -610,19 +825,14 @@ func (r *HostedControlPlaneReconciler) generateControlPlaneManifests(ctx context
 		}
 	} else {
 		r.Log.Info("using existing pki secret")
+		// A cluster migrated to Konnectivity has no Konnectivity PKI yet.
+		if len(infraStatus.KonnectivityAddress) > 0 {
+			if err := r.ensureKonnectivityPKI(ctx, pkiSecret, pkiParams); err != nil {
+				return nil, err
+			}
+		}
 	}
 	if needsPkiSecret {
-		pkiParams := &render.PKIParams{
-			ExternalAPIAddress:      infraStatus.APIAddress,
-			NodeInternalAPIServerIP: DefaultAPIServerIPAddress,
-			ExternalAPIPort:         APIServerPort,
-			InternalAPIPort:         APIServerPort,
-			ServiceCIDR:             hcp.Spec.ServiceCIDR,
-			ExternalOauthAddress:    infraStatus.OAuthAddress,
-			IngressSubdomain:        "apps." + baseDomain,
-			ExternalOpenVPNAddress:  infraStatus.VPNAddress,
-			Namespace:               targetNamespace,
-		}
 		r.Log.Info("generating PKI secret data")
 		data, err := pki.GeneratePKI(pkiParams)
 		if err != nil {
@@ -669,6 +879,9 @@ func (r *HostedControlPlaneReconciler) generateControlPlaneManifests(ctx context
 	}
 	pkiSecret.Data["service-account.key"] = signingKeySecretData
 	pkiSecret.Data["service-account.pub"] = pubPEMKey
+	if err := r.applyServingCerts(ctx, hcp, params, pkiSecret.Data); err != nil {
+		return nil, err
+	}
 
 	manifests, err := render.RenderClusterManifests(params, releaseImage, pullSecretData, pkiSecret.Data)
 	if err != nil {
@@ -676,27 +889,32 @@ func (r *HostedControlPlaneReconciler) generateControlPlaneManifests(ctx context
 	}
 
 	kubeAPIServerParams := &render.KubeAPIServerParams{
-		PodCIDR:               params.PodCIDR,
-		ServiceCIDR:           params.ServiceCIDR,
-		ExternalAPIAddress:    params.ExternalAPIAddress,
-		APIServerAuditEnabled: params.APIServerAuditEnabled,
-		CloudProvider:         params.CloudProvider,
-		EtcdClientName:        params.EtcdClientName,
-		DefaultFeatureGates:   params.DefaultFeatureGates,
-		ExtraFeatureGates:     params.ExtraFeatureGates,
-		IngressSubdomain:      params.IngressSubdomain,
-		InternalAPIPort:       params.InternalAPIPort,
-		IssuerURL:             params.IssuerURL,
-		NamedCerts:            params.NamedCerts,
-		PKI:                   pkiSecret.Data,
-		APIAvailabilityPolicy: render.KubeAPIServerParamsAvailabilityPolicy(params.APIAvailabilityPolicy),
-		ClusterID:             params.ClusterID,
-		Images:                releaseImage.ComponentImages(),
-		ApiserverLivenessPath: params.ApiserverLivenessPath,
-		APINodePort:           params.APINodePort,
-		ExternalOauthPort:     params.ExternalOauthPort,
-		ExternalOauthDNSName:  params.ExternalOauthDNSName,
-		InfraID:               hcp.Spec.InfraID,
+		PodCIDR:                params.PodCIDR,
+		ServiceCIDR:            params.ServiceCIDR,
+		ClusterCIDRs:           params.ClusterCIDRs(),
+		ServiceCIDRs:           params.ServiceCIDRs(),
+		ExternalAPIAddress:     params.ExternalAPIAddress,
+		APIServerAuditEnabled:  params.APIServerAuditEnabled,
+		CloudProvider:          params.CloudProvider,
+		EtcdClientName:         params.EtcdClientName,
+		DefaultFeatureGates:    params.DefaultFeatureGates,
+		ExtraFeatureGates:      params.ExtraFeatureGates,
+		IngressSubdomain:       params.IngressSubdomain,
+		InternalAPIPort:        params.InternalAPIPort,
+		IssuerURL:              params.IssuerURL,
+		NamedCerts:             params.NamedCerts,
+		PKI:                    pkiSecret.Data,
+		APIAvailabilityPolicy:  render.KubeAPIServerParamsAvailabilityPolicy(params.APIAvailabilityPolicy),
+		ClusterID:              params.ClusterID,
+		Images:                 releaseImage.ComponentImages(),
+		ApiserverLivenessPath:  params.ApiserverLivenessPath,
+		APINodePort:            params.APINodePort,
+		ExternalOauthPort:      params.ExternalOauthPort,
+		ExternalOauthDNSName:   params.ExternalOauthDNSName,
+		InfraID:                hcp.Spec.InfraID,
+		GlobalConfig:           params.GlobalConfig,
+		KubeAPIServerResources: params.KubeAPIServerResources,
+		NodeConnectivity:       params.NodeConnectivity,
 	}
 	if hcp.Spec.Platform.AWS != nil {
 		kubeAPIServerParams.AWSRegion = hcp.Spec.Platform.AWS.Region
@@ -717,15 +935,59 @@ func (r *HostedControlPlaneReconciler) generateControlPlaneManifests(ctx context
 		manifests[k] = kubeAPIServerManifests[k]
 	}
 
+	if err := render.ApplyPodPlacement(manifests, podPlacement(hcp.Spec.ControlPlanePlacement)); err != nil {
+		return nil, fmt.Errorf("failed to place control plane pods: %w", err)
+	}
+
+	// Invalid overrides are reported rather than failing the rollout of the
+	// rest of the control plane.
+	if err := applyControlPlaneOverrides(manifests, hcp.Spec.ControlPlaneOverrides); err != nil {
+		setCondition(hcp, hyperv1.ValidControlPlaneOverrides, metav1.ConditionFalse, "InvalidOverrides", err.Error())
+	} else {
+		setCondition(hcp, hyperv1.ValidControlPlaneOverrides, metav1.ConditionTrue, "AsExpected", "All control plane overrides were applied")
+	}
+
 	return manifests, nil
 }
 
-func createKubeAPIServerService(client client.Client, hcp *hyperv1.HostedControlPlane, namespace string) (*corev1.Service, error) {
+// podPlacement returns the placement of the control plane pods of the
+// HostedControlPlane.
+func podPlacement(placement *hyperv1.ControlPlanePlacement) *render.PodPlacement {
+	if placement == nil {
+		return nil
+	}
+	return &render.PodPlacement{
+		NodeSelector:              placement.NodeSelector,
+		Tolerations:               placement.Tolerations,
+		PriorityClassName:         placement.PriorityClassName,
+		TopologySpreadConstraints: placement.TopologySpreadConstraints,
+	}
+}
+
+// setComponentResources sets the resources of the control plane components
+// from the sizing profile and overrides of the HostedControlPlane.
+func setComponentResources(hcp *hyperv1.HostedControlPlane, params *render.ClusterParams) error {
+	var profile string
+	overrides := map[string]corev1.ResourceRequirements{}
+	if hcp.Spec.Sizing != nil {
+		profile = string(hcp.Spec.Sizing.Profile)
+		for _, override := range hcp.Spec.Sizing.Overrides {
+			overrides[override.Name] = override.Resources
+		}
+	}
+	resources, err := render.ComponentResources(profile, overrides)
+	if err != nil {
+		return invalidConfiguration(fmt.Errorf("failed to size the control plane: %w", err))
+	}
+	params.SetComponentResources(resources)
+	return nil
+}
+
+func createKubeAPIServerService(client client.Client, hcp *hyperv1.HostedControlPlane, namespace string, strategy hyperv1.ServicePublishingStrategy) (*corev1.Service, error) {
 	svc := &corev1.Service{}
 	svc.Namespace = namespace
 	svc.Name = kubeAPIServerServiceName
 	svc.Spec.Selector = map[string]string{"app": "kube-apiserver"}
-	svc.Spec.Type = corev1.ServiceTypeLoadBalancer
 	svc.Spec.Ports = []corev1.ServicePort{
 		{
 			Port:       6443,
@@ -733,6 +995,11 @@ func createKubeAPIServerService(client client.Client, hcp *hyperv1.HostedControl
 			TargetPort: intstr.FromInt(6443),
 		},
 	}
+	// With private endpoint access, the Kube API server is only published by
+	// the private Kube API service.
+	if hyperutil.EndpointAccess(hcp.Spec.Platform) != hyperv1.Private {
+		applyPublishingStrategy(svc, strategy)
+	}
 	svc.OwnerReferences = ensureHCPOwnerRef(hcp, svc.OwnerReferences)
 	if err := client.Create(context.TODO(), svc); err != nil {
 		if !apierrors.IsAlreadyExists(err) {
@@ -742,12 +1009,11 @@ func createKubeAPIServerService(client client.Client, hcp *hyperv1.HostedControl
 	return svc, nil
 }
 
-func createVPNServerService(client client.Client, hcp *hyperv1.HostedControlPlane, namespace string) (*corev1.Service, error) {
+func createVPNServerService(client client.Client, hcp *hyperv1.HostedControlPlane, namespace string, strategy hyperv1.ServicePublishingStrategy) (*corev1.Service, error) {
 	svc := &corev1.Service{}
 	svc.Namespace = namespace
 	svc.Name = vpnServiceName
 	svc.Spec.Selector = map[string]string{"app": "openvpn-server"}
-	svc.Spec.Type = corev1.ServiceTypeLoadBalancer
 	svc.Spec.Ports = []corev1.ServicePort{
 		{
 			Port:       1194,
@@ -755,6 +1021,7 @@ func createVPNServerService(client client.Client, hcp *hyperv1.HostedControlPlan
 			TargetPort: intstr.FromInt(1194),
 		},
 	}
+	applyPublishingStrategy(svc, strategy)
 	svc.OwnerReferences = ensureHCPOwnerRef(hcp, svc.OwnerReferences)
 	if err := client.Create(context.TODO(), svc); err != nil {
 		if !apierrors.IsAlreadyExists(err) {
@@ -814,12 +1081,11 @@ func createOauthAPIService(c client.Client, hcp *hyperv1.HostedControlPlane, nam
 	return svc, nil
 }
 
-func createOauthService(client client.Client, hcp *hyperv1.HostedControlPlane, namespace string) (*corev1.Service, error) {
+func createOauthService(client client.Client, hcp *hyperv1.HostedControlPlane, namespace string, strategy hyperv1.ServicePublishingStrategy) (*corev1.Service, error) {
 	svc := &corev1.Service{}
 	svc.Namespace = namespace
 	svc.Name = oauthServiceName
 	svc.Spec.Selector = map[string]string{"app": "oauth-openshift"}
-	svc.Spec.Type = corev1.ServiceTypeClusterIP
 	svc.Spec.Ports = []corev1.ServicePort{
 		{
 			Name:       "https",
@@ -828,6 +1094,7 @@ func createOauthService(client client.Client, hcp *hyperv1.HostedControlPlane, n
 			TargetPort: intstr.FromInt(6443),
 		},
 	}
+	applyPublishingStrategy(svc, strategy)
 	svc.OwnerReferences = ensureHCPOwnerRef(hcp, svc.OwnerReferences)
 	err := client.Create(context.TODO(), svc)
 	if err != nil && !apierrors.IsAlreadyExists(err) {
@@ -885,22 +1152,7 @@ func ensureDefaultIngressControllerSelector(c client.Client) error {
 }
 
 func createOauthServerRoute(namespace string) *routev1.Route {
-	return &routev1.Route{
-		ObjectMeta: metav1.ObjectMeta{
-			Namespace: namespace,
-			Name:      "oauth",
-		},
-		Spec: routev1.RouteSpec{
-			To: routev1.RouteTargetReference{
-				Kind: "Service",
-				Name: "oauth-openshift",
-			},
-			TLS: &routev1.TLSConfig{
-				Termination:                   routev1.TLSTerminationPassthrough,
-				InsecureEdgeTerminationPolicy: routev1.InsecureEdgeTerminationPolicyRedirect,
-			},
-		},
-	}
+	return createPassthroughRoute(namespace, "oauth", oauthServiceName)
 }
 
 func getLoadBalancerServiceAddress(c client.Client, ctx context.Context, key client.ObjectKey) (string, error) {
@@ -951,12 +1203,20 @@ func deleteManifests(ctx context.Context, c client.Client, log logr.Logger, name
 			log.Info("deleted manifest", "manifest", manifestName)
 		}
 	}
-	if errs := errors.NewAggregate(applyErrors); errs != nil {
+	if errs := utilerrors.NewAggregate(applyErrors); errs != nil {
 		return fmt.Errorf("failed to delete some manifests: %w", errs)
 	}
 	return nil
 }
 
+func consoleURL(baseDomain string) string {
+	return fmt.Sprintf("https://console-openshift-console.apps.%s", baseDomain)
+}
+
+func oauthCallbackURL(oauthAddress string, oauthPort int32) string {
+	return fmt.Sprintf("https://%s:%d/oauth2callback", oauthAddress, oauthPort)
+}
+
 func clusterBaseDomain(c client.Client, ctx context.Context, hcp *hyperv1.HostedControlPlane) (string, error) {
 	return fmt.Sprintf("%s.%s", hcp.Name, hcp.Spec.DNS.BaseDomain), nil
 }
@@ -1029,7 +1289,7 @@ func applyManifests(ctx context.Context, c client.Client, log logr.Logger, names
 			log.Info("applied manifest", "manifest", manifestName)
 		}
 	}
-	if errs := errors.NewAggregate(applyErrors); errs != nil {
+	if errs := utilerrors.NewAggregate(applyErrors); errs != nil {
 		return fmt.Errorf("failed to apply some manifests: %w", errs)
 	}
 	return nil
@@ -1069,6 +1329,59 @@ func generateKubeadminPassword() (string, error) {
 	return string(pw), nil
 }
 
+// reconcileKubeadminPassword generates the kubeadmin password and the manifest
+// of the guest cluster kubeadmin secret, or removes both if the kubeadmin
+// user is disabled. The password secret is created first: the hosted cluster
+// config operator removes the kubeadmin user from the guest cluster when the
+// password secret does not exist.
+func (r *HostedControlPlaneReconciler) reconcileKubeadminPassword(ctx context.Context, hcp *hyperv1.HostedControlPlane) error {
+	targetNamespace := hcp.GetNamespace()
+	if kubeadminDisabled(hcp) {
+		for _, obj := range []client.Object{
+			&corev1.Secret{ObjectMeta: metav1.ObjectMeta{Namespace: targetNamespace, Name: kubeadminPasswordSecretName}},
+			&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Namespace: targetNamespace, Name: kubeadminPasswordTargetConfigMapName}},
+		} {
+			if err := r.Delete(ctx, obj); err != nil && !apierrors.IsNotFound(err) {
+				return fmt.Errorf("failed to delete %s: %w", obj.GetName(), err)
+			}
+		}
+		return nil
+	}
+
+	kubeadminPassword, err := generateKubeadminPassword()
+	if err != nil {
+		return fmt.Errorf("failed to generate kubeadmin password: %w", err)
+	}
+
+	kubeadminPasswordSecret := generateKubeadminPasswordSecret(targetNamespace, kubeadminPassword)
+	kubeadminPasswordSecret.OwnerReferences = ensureHCPOwnerRef(hcp, kubeadminPasswordSecret.OwnerReferences)
+	if err := r.Create(ctx, kubeadminPasswordSecret); err != nil {
+		if !apierrors.IsAlreadyExists(err) {
+			return fmt.Errorf("failed to generate kubeadminPasswordSecret: %w", err)
+		}
+		if err := r.Get(ctx, client.ObjectKeyFromObject(kubeadminPasswordSecret), kubeadminPasswordSecret); err != nil {
+			return fmt.Errorf("failed to get kubeadminPasswordSecret: %w", err)
+		}
+		kubeadminPassword = string(kubeadminPasswordSecret.Data["password"])
+	}
+
+	kubeadminPasswordTargetSecret, err := generateKubeadminPasswordTargetSecret(r.Scheme(), kubeadminPassword, targetNamespace)
+	if err != nil {
+		return fmt.Errorf("failed to create kubeadmin secret manifest for target cluster: %w", err)
+	}
+	kubeadminPasswordTargetSecret.OwnerReferences = ensureHCPOwnerRef(hcp, kubeadminPasswordTargetSecret.OwnerReferences)
+	if err := r.Create(ctx, kubeadminPasswordTargetSecret); err != nil && !apierrors.IsAlreadyExists(err) {
+		return fmt.Errorf("failed to generate kubeadminPasswordTargetSecret: %w", err)
+	}
+	return nil
+}
+
+// kubeadminDisabled returns whether the kubeadmin user of the guest cluster
+// is disabled.
+func kubeadminDisabled(hcp *hyperv1.HostedControlPlane) bool {
+	return hcp.Spec.OAuth != nil && hcp.Spec.OAuth.DisableKubeadmin
+}
+
 func generateKubeadminPasswordTargetSecret(scheme *runtime.Scheme, password string, namespace string) (*corev1.ConfigMap, error) {
 	secret := &corev1.Secret{}
 	secret.APIVersion = "v1"
@@ -1087,7 +1400,7 @@ func generateKubeadminPasswordTargetSecret(scheme *runtime.Scheme, password stri
 	}
 	configMap := &corev1.ConfigMap{}
 	configMap.Namespace = namespace
-	configMap.Name = "user-manifest-kubeadmin-password"
+	configMap.Name = kubeadminPasswordTargetConfigMapName
 	configMap.Data = map[string]string{"data": string(secretBytes)}
 	return configMap, nil
 }
@@ -1095,7 +1408,7 @@ func generateKubeadminPasswordTargetSecret(scheme *runtime.Scheme, password stri
 func generateKubeadminPasswordSecret(namespace, password string) *corev1.Secret {
 	secret := &corev1.Secret{}
 	secret.Namespace = namespace
-	secret.Name = "kubeadmin-password"
+	secret.Name = kubeadminPasswordSecretName
 	secret.Data = map[string][]byte{"password": []byte(password)}
 	return secret
 }
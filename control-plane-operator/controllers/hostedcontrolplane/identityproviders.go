@@ -0,0 +1,311 @@
+package hostedcontrolplane
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	configv1 "github.com/openshift/api/config/v1"
+	osinv1 "github.com/openshift/api/osin/v1"
+	"golang.org/x/net/http/httpproxy"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	hyperv1 "github.com/openshift/hypershift/api/v1alpha1"
+	"github.com/openshift/hypershift/control-plane-operator/controllers/hostedcontrolplane/render"
+	hyperconfig "github.com/openshift/hypershift/support/config"
+)
+
+const (
+	openIDDiscoveryTimeout = 10 * time.Second
+
+	// openIDDiscoveryInterval is how long the discovered endpoints of an
+	// OpenID issuer are used before they are discovered again.
+	openIDDiscoveryInterval = time.Hour
+
+	// openIDDiscoveryConfigMapName is the config map which caches the
+	// discovered endpoints of the OpenID issuers of the HostedControlPlane.
+	openIDDiscoveryConfigMapName = "openid-discovery"
+)
+
+// openIDDiscovery is the cached discovery of the endpoints of an OpenID
+// issuer.
+type openIDDiscovery struct {
+	Issuer        string            `json:"issuer"`
+	URLs          osinv1.OpenIDURLs `json:"urls"`
+	DiscoveryTime metav1.Time       `json:"discoveryTime"`
+}
+
+// reconcileIdentityProviderParams sets the identity provider configuration of
+// the oauth server from the identity providers of the HostedControlPlane,
+// together with a hash of the data they reference so that the oauth server
+// is rolled out whenever it changes.
+func (r *HostedControlPlaneReconciler) reconcileIdentityProviderParams(ctx context.Context, hcp *hyperv1.HostedControlPlane, params *render.ClusterParams) error {
+	if hcp.Spec.OAuth == nil || len(hcp.Spec.OAuth.IdentityProviders) == 0 {
+		setCondition(hcp, hyperv1.IdentityProvidersDiscovered, metav1.ConditionTrue, "AsExpected", "The endpoints of all identity providers were discovered")
+		return nil
+	}
+	idps := hcp.Spec.OAuth.IdentityProviders
+	if errs := hyperconfig.ValidateIdentityProviders(idps, field.NewPath("spec", "oauth", "identityProviders")); len(errs) > 0 {
+		return invalidConfiguration(errs.ToAggregate())
+	}
+
+	data := map[string][]byte{}
+	for i := range idps {
+		for _, ref := range render.IdentityProviderReferences(&idps[i]) {
+			value, err := r.identityProviderData(ctx, hcp.Namespace, ref)
+			if err != nil {
+				return fmt.Errorf("identity provider %s: %w", idps[i].Name, err)
+			}
+			data[identityProviderDataKey(ref)] = value
+		}
+	}
+
+	openIDURLs, err := r.reconcileOpenIDURLs(ctx, hcp, idps, data, params)
+	if err != nil {
+		return err
+	}
+	// Identity providers whose endpoints were never discovered are left out
+	// until they are.
+	var discovered []configv1.IdentityProvider
+	for _, idp := range idps {
+		if _, hasURLs := openIDURLs[idp.Name]; idp.OpenID == nil || hasURLs {
+			discovered = append(discovered, idp)
+		}
+	}
+	idps = discovered
+
+	identityProviders, volumes, err := render.OAuthServerIdentityProviders(idps, openIDURLs)
+	if err != nil {
+		return err
+	}
+	params.IdentityProviders = identityProviders
+	params.IdentityProviderVolumes = volumes
+	params.IdentityProvidersHash = render.IdentityProvidersHash(identityProviders, data)
+	return nil
+}
+
+// identityProviderData returns the data of a secret or config map referenced
+// by an identity provider.
+func (r *HostedControlPlaneReconciler) identityProviderData(ctx context.Context, namespace string, ref render.IdentityProviderReference) ([]byte, error) {
+	key := client.ObjectKey{Namespace: namespace, Name: *ref.Name}
+	var value []byte
+	if ref.ConfigMap {
+		configMap := &corev1.ConfigMap{}
+		if err := r.Get(ctx, key, configMap); err != nil {
+			return nil, fmt.Errorf("failed to get config map %s: %w", key.Name, err)
+		}
+		value = []byte(configMap.Data[ref.Key])
+	} else {
+		secret := &corev1.Secret{}
+		if err := r.Get(ctx, key, secret); err != nil {
+			return nil, fmt.Errorf("failed to get secret %s: %w", key.Name, err)
+		}
+		value = secret.Data[ref.Key]
+	}
+	if len(value) == 0 {
+		return nil, invalidConfiguration(fmt.Errorf("%s is missing the %s key", key.Name, ref.Key))
+	}
+	return value, nil
+}
+
+// identityProviderDataKey identifies the data of a reference.
+func identityProviderDataKey(ref render.IdentityProviderReference) string {
+	if ref.ConfigMap {
+		return "configmap/" + *ref.Name
+	}
+	return "secret/" + *ref.Name
+}
+
+// reconcileOpenIDURLs returns the endpoints of the OpenID identity providers
+// of the HostedControlPlane by name. The endpoints are cached in a config map
+// by issuer, and are only discovered again once they are older than
+// openIDDiscoveryInterval. A failed discovery falls back to the cached
+// endpoints and is reported by the IdentityProvidersDiscovered condition
+// rather than failing the reconcile, so an unreachable issuer does not block
+// the rest of the control plane. An identity provider which was never
+// discovered has no endpoints.
+func (r *HostedControlPlaneReconciler) reconcileOpenIDURLs(ctx context.Context, hcp *hyperv1.HostedControlPlane, idps []configv1.IdentityProvider, data map[string][]byte, params *render.ClusterParams) (map[string]osinv1.OpenIDURLs, error) {
+	cache := &corev1.ConfigMap{}
+	cache.Namespace = hcp.Namespace
+	cache.Name = openIDDiscoveryConfigMapName
+	if err := r.Get(ctx, client.ObjectKeyFromObject(cache), cache); err != nil && !apierrors.IsNotFound(err) {
+		return nil, fmt.Errorf("failed to get openid discovery cache: %w", err)
+	}
+	cached := map[string]openIDDiscovery{}
+	for _, value := range cache.Data {
+		var discovery openIDDiscovery
+		if err := json.Unmarshal([]byte(value), &discovery); err == nil {
+			cached[discovery.Issuer] = discovery
+		}
+	}
+
+	urls := map[string]osinv1.OpenIDURLs{}
+	discovered := map[string]openIDDiscovery{}
+	var failures []string
+	for _, idp := range idps {
+		if idp.OpenID == nil {
+			continue
+		}
+		issuer := idp.OpenID.Issuer
+		discovery, isCached := cached[issuer]
+		if !isCached || time.Since(discovery.DiscoveryTime.Time) >= openIDDiscoveryInterval {
+			ca := data[identityProviderDataKey(render.IdentityProviderReference{ConfigMap: true, Name: &idp.OpenID.CA.Name})]
+			discoveredURLs, err := discoverOpenIDURLs(ctx, openIDDiscoveryClient(ca, params), issuer)
+			if err != nil {
+				failures = append(failures, fmt.Sprintf("%s: %v", idp.Name, err))
+			} else {
+				discovery = openIDDiscovery{Issuer: issuer, URLs: discoveredURLs, DiscoveryTime: metav1.Now()}
+				isCached = true
+			}
+		}
+		if isCached {
+			urls[idp.Name] = discovery.URLs
+			discovered[issuer] = discovery
+		}
+	}
+
+	if len(failures) > 0 {
+		setCondition(hcp, hyperv1.IdentityProvidersDiscovered, metav1.ConditionFalse, "DiscoveryFailed", "Failed to discover the endpoints of identity providers, which use their last discovered endpoints if any: "+strings.Join(failures, "; "))
+	} else {
+		setCondition(hcp, hyperv1.IdentityProvidersDiscovered, metav1.ConditionTrue, "AsExpected", "The endpoints of all identity providers were discovered")
+	}
+
+	// The cache only holds the issuers in use.
+	cacheData := map[string]string{}
+	for issuer, discovery := range discovered {
+		value, err := json.Marshal(discovery)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode the discovery of %s: %w", issuer, err)
+		}
+		cacheData[fmt.Sprintf("%x", sha256.Sum256([]byte(issuer)))] = string(value)
+	}
+	if len(cacheData) > 0 || len(cache.Data) > 0 {
+		if _, err := controllerutil.CreateOrUpdate(ctx, r.Client, cache, func() error {
+			cache.OwnerReferences = ensureHCPOwnerRef(hcp, cache.OwnerReferences)
+			cache.Data = cacheData
+			return nil
+		}); err != nil {
+			// The endpoints are discovered again without the cache.
+			r.Log.Error(err, "failed to update openid discovery cache")
+		}
+	}
+	return urls, nil
+}
+
+// openIDDiscoveryClient returns an HTTP client which reaches OpenID issuers
+// like the OAuth server does: through the proxy of the cluster, trusting the
+// CA bundle of the identity provider, or the system trust store otherwise,
+// together with the additional trust bundle of the cluster.
+func openIDDiscoveryClient(caBundle []byte, params *render.ClusterParams) *http.Client {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	var pool *x509.CertPool
+	if len(caBundle) > 0 {
+		pool = x509.NewCertPool()
+		pool.AppendCertsFromPEM(caBundle)
+	} else if systemPool, err := x509.SystemCertPool(); err == nil {
+		pool = systemPool
+	} else {
+		pool = x509.NewCertPool()
+	}
+	if len(params.AdditionalTrustBundleData) > 0 {
+		pool.AppendCertsFromPEM([]byte(params.AdditionalTrustBundleData))
+	}
+	transport.TLSClientConfig = &tls.Config{RootCAs: pool}
+	if params.HasProxy() {
+		proxyFunc := (&httpproxy.Config{
+			HTTPProxy:  params.HTTPProxy,
+			HTTPSProxy: params.HTTPSProxy,
+			NoProxy:    params.ControlPlaneNoProxy(),
+		}).ProxyFunc()
+		transport.Proxy = func(req *http.Request) (*url.URL, error) {
+			return proxyFunc(req.URL)
+		}
+	}
+	return &http.Client{Transport: transport, Timeout: openIDDiscoveryTimeout}
+}
+
+// discoverOpenIDURLs looks up the endpoints of an OpenID issuer from its
+// discovery document.
+func discoverOpenIDURLs(ctx context.Context, httpClient *http.Client, issuer string) (osinv1.OpenIDURLs, error) {
+	wellKnown := strings.TrimSuffix(issuer, "/") + "/.well-known/openid-configuration"
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, wellKnown, nil)
+	if err != nil {
+		return osinv1.OpenIDURLs{}, err
+	}
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return osinv1.OpenIDURLs{}, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return osinv1.OpenIDURLs{}, fmt.Errorf("unexpected status %s from %s", resp.Status, wellKnown)
+	}
+	metadata := struct {
+		AuthorizationEndpoint string `json:"authorization_endpoint"`
+		TokenEndpoint         string `json:"token_endpoint"`
+		UserInfoEndpoint      string `json:"userinfo_endpoint"`
+	}{}
+	if err := json.NewDecoder(resp.Body).Decode(&metadata); err != nil {
+		return osinv1.OpenIDURLs{}, fmt.Errorf("failed to decode %s: %w", wellKnown, err)
+	}
+	for name, url := range map[string]string{"authorization": metadata.AuthorizationEndpoint, "token": metadata.TokenEndpoint} {
+		if !strings.HasPrefix(url, "https://") {
+			return osinv1.OpenIDURLs{}, fmt.Errorf("the %s endpoint %q of %s is not an https URL", name, url, wellKnown)
+		}
+	}
+	return osinv1.OpenIDURLs{
+		Authorize: metadata.AuthorizationEndpoint,
+		Token:     metadata.TokenEndpoint,
+		UserInfo:  metadata.UserInfoEndpoint,
+	}, nil
+}
+
+// enqueueHostedControlPlanesForIdentityProviderObject maps a secret or config
+// map to the HostedControlPlanes in its namespace whose identity providers
+// reference it, so that the oauth server is rolled out when it changes.
+func (r *HostedControlPlaneReconciler) enqueueHostedControlPlanesForIdentityProviderObject(obj client.Object) []reconcile.Request {
+	hcpList := &hyperv1.HostedControlPlaneList{}
+	if err := r.List(context.Background(), hcpList, client.InNamespace(obj.GetNamespace())); err != nil {
+		ctrl.Log.Error(err, "failed to list hosted control planes", "namespace", obj.GetNamespace())
+		return nil
+	}
+	_, isConfigMap := obj.(*corev1.ConfigMap)
+	var requests []reconcile.Request
+	for i := range hcpList.Items {
+		hcp := &hcpList.Items[i]
+		if hcp.Spec.OAuth == nil {
+			continue
+		}
+		if identityProvidersReference(hcp.Spec.OAuth.IdentityProviders, obj.GetName(), isConfigMap) {
+			requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(hcp)})
+		}
+	}
+	return requests
+}
+
+// identityProvidersReference returns whether any of the given identity
+// providers references the named secret or config map.
+func identityProvidersReference(idps []configv1.IdentityProvider, name string, configMap bool) bool {
+	for i := range idps {
+		for _, ref := range render.IdentityProviderReferences(&idps[i]) {
+			if ref.ConfigMap == configMap && *ref.Name == name {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,119 @@
+package hostedcontrolplane
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	configv1 "github.com/openshift/api/config/v1"
+	osinv1 "github.com/openshift/api/osin/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	hyperapi "github.com/openshift/hypershift/api"
+	hyperv1 "github.com/openshift/hypershift/api/v1alpha1"
+	"github.com/openshift/hypershift/control-plane-operator/controllers/hostedcontrolplane/render"
+)
+
+// unreachableIssuer is an OpenID issuer which refuses connections.
+const unreachableIssuer = "https://127.0.0.1:1"
+
+func TestReconcileOpenIDURLs(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		fmt.Fprintf(w, `{"authorization_endpoint": "https://%[1]s/authorize", "token_endpoint": "https://%[1]s/token"}`, req.Host)
+	}))
+	defer server.Close()
+	serverURLs := osinv1.OpenIDURLs{Authorize: server.URL + "/authorize", Token: server.URL + "/token"}
+	cachedURLs := osinv1.OpenIDURLs{Authorize: "https://cached.example.com/authorize", Token: "https://cached.example.com/token"}
+
+	tests := map[string]struct {
+		Issuer            string
+		CachedTime        time.Time
+		ExpectedURLs      map[string]osinv1.OpenIDURLs
+		ExpectedCondition metav1.ConditionStatus
+	}{
+		"discovered issuer": {
+			Issuer:            server.URL,
+			ExpectedURLs:      map[string]osinv1.OpenIDURLs{"openid": serverURLs},
+			ExpectedCondition: metav1.ConditionTrue,
+		},
+		"cached issuer": {
+			Issuer:            unreachableIssuer,
+			CachedTime:        time.Now(),
+			ExpectedURLs:      map[string]osinv1.OpenIDURLs{"openid": cachedURLs},
+			ExpectedCondition: metav1.ConditionTrue,
+		},
+		"stale cache of an unreachable issuer": {
+			Issuer:            unreachableIssuer,
+			CachedTime:        time.Now().Add(-2 * openIDDiscoveryInterval),
+			ExpectedURLs:      map[string]osinv1.OpenIDURLs{"openid": cachedURLs},
+			ExpectedCondition: metav1.ConditionFalse,
+		},
+		"unreachable issuer": {
+			Issuer:            unreachableIssuer,
+			ExpectedURLs:      map[string]osinv1.OpenIDURLs{},
+			ExpectedCondition: metav1.ConditionFalse,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			hcp := &hyperv1.HostedControlPlane{ObjectMeta: metav1.ObjectMeta{Namespace: "hcp", Name: "example"}}
+			builder := fake.NewClientBuilder().WithScheme(hyperapi.Scheme)
+			if !test.CachedTime.IsZero() {
+				value, err := json.Marshal(openIDDiscovery{Issuer: test.Issuer, URLs: cachedURLs, DiscoveryTime: metav1.NewTime(test.CachedTime)})
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				builder = builder.WithObjects(&corev1.ConfigMap{
+					ObjectMeta: metav1.ObjectMeta{Namespace: "hcp", Name: openIDDiscoveryConfigMapName},
+					Data:       map[string]string{fmt.Sprintf("%x", sha256.Sum256([]byte(test.Issuer))): string(value)},
+				})
+			}
+			r := &HostedControlPlaneReconciler{Client: builder.Build(), Log: ctrl.Log}
+
+			caName := "openid-ca"
+			idps := []configv1.IdentityProvider{{
+				Name: "openid",
+				IdentityProviderConfig: configv1.IdentityProviderConfig{
+					Type: configv1.IdentityProviderTypeOpenID,
+					OpenID: &configv1.OpenIDIdentityProvider{
+						Issuer: test.Issuer,
+						CA:     configv1.ConfigMapNameReference{Name: caName},
+					},
+				},
+			}}
+			ca := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+			data := map[string][]byte{identityProviderDataKey(render.IdentityProviderReference{ConfigMap: true, Name: &caName}): ca}
+
+			urls, err := r.reconcileOpenIDURLs(context.Background(), hcp, idps, data, &render.ClusterParams{})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if diff := cmp.Diff(test.ExpectedURLs, urls); diff != "" {
+				t.Errorf("unexpected urls (-want +got): %s", diff)
+			}
+			condition := meta.FindStatusCondition(hcp.Status.Conditions, string(hyperv1.IdentityProvidersDiscovered))
+			if condition == nil || condition.Status != test.ExpectedCondition {
+				t.Errorf("expected condition status %s, got %v", test.ExpectedCondition, condition)
+			}
+
+			// The endpoints in use are cached.
+			cache := &corev1.ConfigMap{}
+			err = r.Get(context.Background(), client.ObjectKey{Namespace: "hcp", Name: openIDDiscoveryConfigMapName}, cache)
+			if len(test.ExpectedURLs) > 0 && (err != nil || len(cache.Data) != 1) {
+				t.Errorf("expected the discovery to be cached, got %v: %v", cache.Data, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,67 @@
+package hostedcontrolplane
+
+import (
+	"context"
+	"crypto/md5"
+	"encoding/json"
+	"fmt"
+
+	operatorv1alpha1 "github.com/openshift/api/operator/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	hyperv1 "github.com/openshift/hypershift/api/v1alpha1"
+	"github.com/openshift/hypershift/control-plane-operator/controllers/hostedcontrolplane/render"
+)
+
+// imageContentSourcesHashAnnotation is set on the manifests bootstrapper pod
+// to the hash of the registry mirrors it configures in the guest cluster.
+const imageContentSourcesHashAnnotation = "hypershift.openshift.io/image-content-sources-hash"
+
+// imageContentSourcesHash returns a hash of the registry mirrors of the
+// HostedControlPlane, or an empty string without registry mirrors.
+func imageContentSourcesHash(hcp *hyperv1.HostedControlPlane) string {
+	if len(hcp.Spec.ImageContentSources) == 0 {
+		return ""
+	}
+	b, err := json.Marshal(hcp.Spec.ImageContentSources)
+	if err != nil {
+		return ""
+	}
+	return fmt.Sprintf("%x", md5.Sum(b))
+}
+
+// reconcileImageContentSourcesParams sets the registry mirrors of the guest
+// cluster in the parameters of the control plane manifests.
+func reconcileImageContentSourcesParams(hcp *hyperv1.HostedControlPlane, params *render.ClusterParams) {
+	for _, source := range hcp.Spec.ImageContentSources {
+		params.ImageContentSources = append(params.ImageContentSources, operatorv1alpha1.RepositoryDigestMirrors{
+			Source:  source.Source,
+			Mirrors: append([]string(nil), source.Mirrors...),
+		})
+	}
+	params.ImageContentSourcesHash = imageContentSourcesHash(hcp)
+}
+
+// restartManifestsBootstrapperForImageContentSources deletes the manifests
+// bootstrapper pod when it configured other registry mirrors in the guest
+// cluster, for it to be rendered again and configure the current ones.
+func (r *HostedControlPlaneReconciler) restartManifestsBootstrapperForImageContentSources(ctx context.Context, hcp *hyperv1.HostedControlPlane) error {
+	return r.restartManifestsBootstrapperOnChange(ctx, hcp.Namespace, imageContentSourcesHashAnnotation, "", imageContentSourcesHash(hcp), "registry mirrors")
+}
+
+// deleteUnusedImageContentSources deletes the config maps which configure the
+// registry mirrors of the guest cluster and its nodes once it has none.
+func (r *HostedControlPlaneReconciler) deleteUnusedImageContentSources(ctx context.Context, hcp *hyperv1.HostedControlPlane) error {
+	if len(hcp.Spec.ImageContentSources) > 0 {
+		return nil
+	}
+	for _, name := range []string{"user-manifest-image-content-source-policy", "ignition-config-image-content-source-policy"} {
+		configMap := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Namespace: hcp.Namespace, Name: name}}
+		if err := r.Delete(ctx, configMap); err != nil && !apierrors.IsNotFound(err) {
+			return fmt.Errorf("failed to delete %s: %w", name, err)
+		}
+	}
+	return nil
+}
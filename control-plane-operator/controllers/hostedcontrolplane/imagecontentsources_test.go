@@ -0,0 +1,106 @@
+package hostedcontrolplane
+
+import (
+	"context"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	hyperapi "github.com/openshift/hypershift/api"
+	hyperv1 "github.com/openshift/hypershift/api/v1alpha1"
+)
+
+var testImageContentSources = []hyperv1.ImageContentSource{
+	{Source: "quay.io/openshift-release-dev/ocp-release", Mirrors: []string{"mirror.example.com/ocp/release"}},
+}
+
+func TestRestartManifestsBootstrapperForImageContentSources(t *testing.T) {
+	sourcesHash := imageContentSourcesHash(&hyperv1.HostedControlPlane{Spec: hyperv1.HostedControlPlaneSpec{ImageContentSources: testImageContentSources}})
+	tests := map[string]struct {
+		Annotation    string
+		Sources       []hyperv1.ImageContentSource
+		ExpectDeleted bool
+	}{
+		"no registry mirrors": {},
+		"same registry mirrors": {
+			Annotation: sourcesHash,
+			Sources:    testImageContentSources,
+		},
+		"registry mirrors added": {
+			Sources:       testImageContentSources,
+			ExpectDeleted: true,
+		},
+		"registry mirrors changed": {
+			Annotation:    "stale",
+			Sources:       testImageContentSources,
+			ExpectDeleted: true,
+		},
+		"registry mirrors removed": {
+			Annotation:    sourcesHash,
+			ExpectDeleted: true,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			pod := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Namespace: "hcp", Name: "manifests-bootstrapper"}}
+			if len(test.Annotation) > 0 {
+				pod.Annotations = map[string]string{imageContentSourcesHashAnnotation: test.Annotation}
+			}
+			r := &HostedControlPlaneReconciler{
+				Client: fake.NewClientBuilder().WithScheme(hyperapi.Scheme).WithObjects(pod).Build(),
+				Log:    ctrl.Log,
+			}
+			hcp := &hyperv1.HostedControlPlane{ObjectMeta: metav1.ObjectMeta{Namespace: "hcp", Name: "hcp"}}
+			hcp.Spec.ImageContentSources = test.Sources
+			if err := r.restartManifestsBootstrapperForImageContentSources(context.Background(), hcp); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			err := r.Get(context.Background(), client.ObjectKeyFromObject(pod), &corev1.Pod{})
+			if deleted := apierrors.IsNotFound(err); deleted != test.ExpectDeleted {
+				t.Errorf("expected pod deleted to be %t, got %t (%v)", test.ExpectDeleted, deleted, err)
+			}
+		})
+	}
+}
+
+func TestDeleteUnusedImageContentSources(t *testing.T) {
+	tests := map[string]struct {
+		Sources       []hyperv1.ImageContentSource
+		ExpectDeleted bool
+	}{
+		"registry mirrors": {
+			Sources: testImageContentSources,
+		},
+		"no registry mirrors": {
+			ExpectDeleted: true,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			configMaps := []client.Object{
+				&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Namespace: "hcp", Name: "user-manifest-image-content-source-policy"}},
+				&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Namespace: "hcp", Name: "ignition-config-image-content-source-policy"}},
+			}
+			r := &HostedControlPlaneReconciler{
+				Client: fake.NewClientBuilder().WithScheme(hyperapi.Scheme).WithObjects(configMaps...).Build(),
+				Log:    ctrl.Log,
+			}
+			hcp := &hyperv1.HostedControlPlane{ObjectMeta: metav1.ObjectMeta{Namespace: "hcp", Name: "hcp"}}
+			hcp.Spec.ImageContentSources = test.Sources
+			if err := r.deleteUnusedImageContentSources(context.Background(), hcp); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			for _, configMap := range configMaps {
+				err := r.Get(context.Background(), client.ObjectKeyFromObject(configMap), &corev1.ConfigMap{})
+				if deleted := apierrors.IsNotFound(err); deleted != test.ExpectDeleted {
+					t.Errorf("expected %s deleted to be %t, got %t (%v)", configMap.GetName(), test.ExpectDeleted, deleted, err)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,172 @@
+package hostedcontrolplane
+
+import (
+	"context"
+	"fmt"
+
+	routev1 "github.com/openshift/api/route/v1"
+	securityv1 "github.com/openshift/api/security/v1"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	hyperv1 "github.com/openshift/hypershift/api/v1alpha1"
+	"github.com/openshift/hypershift/control-plane-operator/controllers/hostedcontrolplane/render"
+	"github.com/openshift/hypershift/control-plane-operator/controllers/hostedcontrolplane/render/pki"
+	hyperutil "github.com/openshift/hypershift/support/util"
+)
+
+const (
+	konnectivityServerServiceName = "konnectivity-server"
+
+	// konnectivityAgentPort is the port of the Konnectivity server to which
+	// the agents connect.
+	konnectivityAgentPort = 8091
+
+	// nodeConnectivityAnnotation is set on the manifests bootstrapper pod to
+	// the data path whose guest cluster resources it applies.
+	nodeConnectivityAnnotation = "hypershift.openshift.io/node-connectivity"
+)
+
+func createKonnectivityServerService(client client.Client, hcp *hyperv1.HostedControlPlane, namespace string, strategy hyperv1.ServicePublishingStrategy) (*corev1.Service, error) {
+	svc := &corev1.Service{}
+	svc.Namespace = namespace
+	svc.Name = konnectivityServerServiceName
+	svc.Spec.Selector = map[string]string{"app": "kube-apiserver"}
+	svc.Spec.Ports = []corev1.ServicePort{
+		{
+			Port:       konnectivityAgentPort,
+			Protocol:   corev1.ProtocolTCP,
+			TargetPort: intstr.FromInt(konnectivityAgentPort),
+		},
+	}
+	applyPublishingStrategy(svc, strategy)
+	svc.OwnerReferences = ensureHCPOwnerRef(hcp, svc.OwnerReferences)
+	if err := client.Create(context.TODO(), svc); err != nil {
+		if !apierrors.IsAlreadyExists(err) {
+			return nil, fmt.Errorf("failed to create konnectivity server service: %w", err)
+		}
+	}
+	return svc, nil
+}
+
+// createKonnectivityServerRoute returns the route of the Konnectivity server.
+// Its connections are balanced across the Kube API server pods, because each
+// agent connects to every Konnectivity server.
+func createKonnectivityServerRoute(namespace string) *routev1.Route {
+	route := createPassthroughRoute(namespace, konnectivityServerServiceName, konnectivityServerServiceName)
+	route.Annotations = map[string]string{"haproxy.router.openshift.io/balance": "roundrobin"}
+	return route
+}
+
+// nodeConnectivityObjects returns the objects in the control plane namespace
+// which only one of the data paths to the guest cluster uses. The manifests of
+// the guest cluster resources are included, so that the manifests
+// bootstrapper stops applying them.
+func nodeConnectivityObjects(namespace string, connectivity hyperv1.NodeConnectivityType) []client.Object {
+	meta := func(name string) metav1.ObjectMeta {
+		return metav1.ObjectMeta{Namespace: namespace, Name: name}
+	}
+	if connectivity == hyperv1.Konnectivity {
+		return []client.Object{
+			&corev1.Service{ObjectMeta: meta(konnectivityServerServiceName)},
+			&routev1.Route{ObjectMeta: meta(konnectivityServerServiceName)},
+			&corev1.Service{ObjectMeta: meta("konnectivity-server-local")},
+			&corev1.Secret{ObjectMeta: meta("konnectivity-server")},
+			&corev1.Secret{ObjectMeta: meta("konnectivity-cluster")},
+			&corev1.ConfigMap{ObjectMeta: meta("konnectivity-ca")},
+			&appsv1.Deployment{ObjectMeta: meta("konnectivity-agent")},
+			&corev1.Secret{ObjectMeta: meta("konnectivity-agent")},
+			&corev1.ConfigMap{ObjectMeta: meta("user-manifest-konnectivity-agent-daemonset")},
+			&corev1.ConfigMap{ObjectMeta: meta("user-manifest-konnectivity-agent-secret")},
+		}
+	}
+	return []client.Object{
+		&corev1.Service{ObjectMeta: meta(vpnServiceName)},
+		&appsv1.Deployment{ObjectMeta: meta("openvpn-server")},
+		&corev1.ConfigMap{ObjectMeta: meta("openvpn-server")},
+		&corev1.ConfigMap{ObjectMeta: meta("openvpn-ccd")},
+		&corev1.Secret{ObjectMeta: meta("openvpn-server")},
+		&corev1.ConfigMap{ObjectMeta: meta("kube-apiserver-vpnclient-config")},
+		&corev1.Secret{ObjectMeta: meta("kube-apiserver-vpnclient-secret")},
+		&corev1.ServiceAccount{ObjectMeta: meta(vpnServiceAccountName)},
+		&corev1.ConfigMap{ObjectMeta: meta("user-manifest-openvpn-client-deployment")},
+		&corev1.ConfigMap{ObjectMeta: meta("user-manifest-openvpn-client-configmap")},
+		&corev1.ConfigMap{ObjectMeta: meta("user-manifest-openvpn-client-secret")},
+	}
+}
+
+// deleteUnusedNodeConnectivity deletes the control plane resources of the
+// data path to the guest cluster which the HostedControlPlane does not use,
+// which remain after it is migrated to the other one. It is called once the
+// resources of the data path in use are applied.
+func (r *HostedControlPlaneReconciler) deleteUnusedNodeConnectivity(ctx context.Context, hcp *hyperv1.HostedControlPlane) error {
+	unused := hyperv1.Konnectivity
+	if hyperutil.NodeConnectivity(hcp.Spec.NodeConnectivity) == hyperv1.Konnectivity {
+		unused = hyperv1.OpenVPN
+		if err := removeVPNSCCUser(r, hcp.Namespace); err != nil {
+			return err
+		}
+	}
+	for _, obj := range nodeConnectivityObjects(hcp.Namespace, unused) {
+		if err := r.Delete(ctx, obj); err != nil && !apierrors.IsNotFound(err) {
+			return fmt.Errorf("failed to delete %s of the %s data path: %w", obj.GetName(), unused, err)
+		}
+	}
+	return nil
+}
+
+// removeVPNSCCUser removes the VPN service account of the namespace from the
+// users of the privileged SCC, which ensureVPNSCC adds it to.
+func removeVPNSCCUser(c client.Client, namespace string) error {
+	scc := &securityv1.SecurityContextConstraints{}
+	if err := c.Get(context.TODO(), client.ObjectKey{Name: "privileged"}, scc); err != nil {
+		return fmt.Errorf("failed to get privileged scc: %w", err)
+	}
+	userSet := sets.NewString(scc.Users...)
+	svcAccount := fmt.Sprintf("system:serviceaccount:%s:%s", namespace, vpnServiceAccountName)
+	if !userSet.Has(svcAccount) {
+		return nil
+	}
+	userSet.Delete(svcAccount)
+	scc.Users = userSet.List()
+	if err := c.Update(context.TODO(), scc); err != nil {
+		return fmt.Errorf("failed to update privileged scc: %w", err)
+	}
+	return nil
+}
+
+// restartManifestsBootstrapperForNodeConnectivity deletes the manifests
+// bootstrapper pod when it applied the guest cluster resources of another data
+// path, for it to be rendered again and apply those of the current one.
+func (r *HostedControlPlaneReconciler) restartManifestsBootstrapperForNodeConnectivity(ctx context.Context, hcp *hyperv1.HostedControlPlane) error {
+	// Pods rendered before the annotation existed used OpenVPN.
+	latest := string(hyperutil.NodeConnectivity(hcp.Spec.NodeConnectivity))
+	return r.restartManifestsBootstrapperOnChange(ctx, hcp.Namespace, nodeConnectivityAnnotation, string(hyperv1.OpenVPN), latest, "node connectivity")
+}
+
+// ensureKonnectivityPKI adds the Konnectivity PKI to the PKI of a cluster
+// which was created before it used Konnectivity. The PKI secret is updated,
+// since the rest of it is generated once.
+func (r *HostedControlPlaneReconciler) ensureKonnectivityPKI(ctx context.Context, pkiSecret *corev1.Secret, params *render.PKIParams) error {
+	if len(pkiSecret.Data["konnectivity-ca.crt"]) > 0 {
+		return nil
+	}
+	r.Log.Info("generating Konnectivity PKI secret data")
+	data, err := pki.GenerateKonnectivityPKI(params)
+	if err != nil {
+		return fmt.Errorf("failed to generate Konnectivity PKI data: %w", err)
+	}
+	for k, v := range data {
+		pkiSecret.Data[k] = v
+	}
+	if err := r.Update(ctx, pkiSecret); err != nil {
+		return fmt.Errorf("failed to update pki secret: %w", err)
+	}
+	r.Log.Info("added Konnectivity PKI to pki secret")
+	return nil
+}
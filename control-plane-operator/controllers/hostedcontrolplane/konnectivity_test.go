@@ -0,0 +1,134 @@
+package hostedcontrolplane
+
+import (
+	"context"
+	"testing"
+
+	securityv1 "github.com/openshift/api/security/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	hyperapi "github.com/openshift/hypershift/api"
+	hyperv1 "github.com/openshift/hypershift/api/v1alpha1"
+)
+
+func TestDeleteUnusedNodeConnectivity(t *testing.T) {
+	vpnUser := "system:serviceaccount:hcp:" + vpnServiceAccountName
+	tests := map[string]struct {
+		NodeConnectivity hyperv1.NodeConnectivityType
+		Used             hyperv1.NodeConnectivityType
+		Unused           hyperv1.NodeConnectivityType
+	}{
+		"default": {
+			Used:   hyperv1.OpenVPN,
+			Unused: hyperv1.Konnectivity,
+		},
+		"openvpn": {
+			NodeConnectivity: hyperv1.OpenVPN,
+			Used:             hyperv1.OpenVPN,
+			Unused:           hyperv1.Konnectivity,
+		},
+		"konnectivity": {
+			NodeConnectivity: hyperv1.Konnectivity,
+			Used:             hyperv1.Konnectivity,
+			Unused:           hyperv1.OpenVPN,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			objects := append(nodeConnectivityObjects("hcp", hyperv1.OpenVPN), nodeConnectivityObjects("hcp", hyperv1.Konnectivity)...)
+			objects = append(objects, &securityv1.SecurityContextConstraints{
+				ObjectMeta: metav1.ObjectMeta{Name: "privileged"},
+				Users:      []string{"system:admin", vpnUser},
+			})
+			r := &HostedControlPlaneReconciler{
+				Client: fake.NewClientBuilder().WithScheme(hyperapi.Scheme).WithObjects(objects...).Build(),
+				Log:    ctrl.Log,
+			}
+			hcp := &hyperv1.HostedControlPlane{ObjectMeta: metav1.ObjectMeta{Namespace: "hcp", Name: "hcp"}}
+			hcp.Spec.NodeConnectivity = test.NodeConnectivity
+			if err := r.deleteUnusedNodeConnectivity(context.Background(), hcp); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			for _, obj := range nodeConnectivityObjects("hcp", test.Used) {
+				if err := r.Get(context.Background(), client.ObjectKeyFromObject(obj), obj); err != nil {
+					t.Errorf("expected %T %s of the %s data path to remain: %v", obj, obj.GetName(), test.Used, err)
+				}
+			}
+			for _, obj := range nodeConnectivityObjects("hcp", test.Unused) {
+				if err := r.Get(context.Background(), client.ObjectKeyFromObject(obj), obj); !apierrors.IsNotFound(err) {
+					t.Errorf("expected %T %s of the %s data path to be deleted, got %v", obj, obj.GetName(), test.Unused, err)
+				}
+			}
+
+			scc := &securityv1.SecurityContextConstraints{}
+			if err := r.Get(context.Background(), client.ObjectKey{Name: "privileged"}, scc); err != nil {
+				t.Fatalf("failed to get privileged scc: %v", err)
+			}
+			hasVPNUser := false
+			for _, user := range scc.Users {
+				if user == vpnUser {
+					hasVPNUser = true
+				}
+			}
+			if expected := test.Used == hyperv1.OpenVPN; hasVPNUser != expected {
+				t.Errorf("expected vpn user in privileged scc to be %t, got %t", expected, hasVPNUser)
+			}
+		})
+	}
+}
+
+func TestRestartManifestsBootstrapperForNodeConnectivity(t *testing.T) {
+	tests := map[string]struct {
+		Annotation       string
+		NodeConnectivity hyperv1.NodeConnectivityType
+		ExpectDeleted    bool
+	}{
+		"pod rendered before the annotation with openvpn": {
+			NodeConnectivity: hyperv1.OpenVPN,
+		},
+		"pod rendered before the annotation with konnectivity": {
+			NodeConnectivity: hyperv1.Konnectivity,
+			ExpectDeleted:    true,
+		},
+		"same data path": {
+			Annotation:       string(hyperv1.Konnectivity),
+			NodeConnectivity: hyperv1.Konnectivity,
+		},
+		"migrated to konnectivity": {
+			Annotation:       string(hyperv1.OpenVPN),
+			NodeConnectivity: hyperv1.Konnectivity,
+			ExpectDeleted:    true,
+		},
+		"migrated back to openvpn": {
+			Annotation:    string(hyperv1.Konnectivity),
+			ExpectDeleted: true,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			pod := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Namespace: "hcp", Name: "manifests-bootstrapper"}}
+			if len(test.Annotation) > 0 {
+				pod.Annotations = map[string]string{nodeConnectivityAnnotation: test.Annotation}
+			}
+			r := &HostedControlPlaneReconciler{
+				Client: fake.NewClientBuilder().WithScheme(hyperapi.Scheme).WithObjects(pod).Build(),
+				Log:    ctrl.Log,
+			}
+			hcp := &hyperv1.HostedControlPlane{ObjectMeta: metav1.ObjectMeta{Namespace: "hcp", Name: "hcp"}}
+			hcp.Spec.NodeConnectivity = test.NodeConnectivity
+			if err := r.restartManifestsBootstrapperForNodeConnectivity(context.Background(), hcp); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			err := r.Get(context.Background(), client.ObjectKeyFromObject(pod), &corev1.Pod{})
+			if deleted := apierrors.IsNotFound(err); deleted != test.ExpectDeleted {
+				t.Errorf("expected pod deleted to be %t, got %t (%v)", test.ExpectDeleted, deleted, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,99 @@
+package hostedcontrolplane
+
+import (
+	"encoding/json"
+	"fmt"
+
+	jsonpatch "github.com/evanphx/json-patch"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/apimachinery/pkg/util/strategicpatch"
+	utilyaml "k8s.io/apimachinery/pkg/util/yaml"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/yaml"
+
+	hyperv1 "github.com/openshift/hypershift/api/v1alpha1"
+)
+
+// applyControlPlaneOverrides applies the overrides to the rendered manifests
+// in order. An override which fails to apply leaves its manifest unchanged
+// and its error is returned along with those of the other overrides.
+func applyControlPlaneOverrides(manifests map[string][]byte, overrides []hyperv1.ControlPlaneOverride) error {
+	var errs []error
+	for i, override := range overrides {
+		content, ok := manifests[override.Manifest]
+		if !ok {
+			errs = append(errs, fmt.Errorf("override %d: manifest %s does not exist", i, override.Manifest))
+			continue
+		}
+		patched, err := patchManifest(content, override)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("override %d: failed to patch manifest %s: %w", i, override.Manifest, err))
+			continue
+		}
+		manifests[override.Manifest] = patched
+	}
+	return utilerrors.NewAggregate(errs)
+}
+
+func patchManifest(content []byte, override hyperv1.ControlPlaneOverride) ([]byte, error) {
+	original, err := utilyaml.ToJSON(content)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode manifest: %w", err)
+	}
+	patch, err := yaml.YAMLToJSON([]byte(override.Patch))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode patch: %w", err)
+	}
+
+	var patched []byte
+	switch override.Type {
+	case hyperv1.JSONPatchType:
+		jsonPatch, err := jsonpatch.DecodePatch(patch)
+		if err != nil {
+			return nil, fmt.Errorf("invalid JSON patch: %w", err)
+		}
+		if patched, err = jsonPatch.Apply(original); err != nil {
+			return nil, err
+		}
+	case hyperv1.StrategicMergePatchType, "":
+		if patched, err = strategicMergePatch(original, patch); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, fmt.Errorf("unknown patch type %q", override.Type)
+	}
+
+	obj := &unstructured.Unstructured{}
+	if err := obj.UnmarshalJSON(patched); err != nil {
+		return nil, fmt.Errorf("patched manifest is invalid: %w", err)
+	}
+	return yaml.JSONToYAML(patched)
+}
+
+// strategicMergePatch applies a strategic merge patch to a manifest of a
+// built-in kind, and a JSON merge patch to a manifest of any other kind.
+func strategicMergePatch(original, patch []byte) ([]byte, error) {
+	obj := &unstructured.Unstructured{}
+	if err := obj.UnmarshalJSON(original); err != nil {
+		return nil, fmt.Errorf("failed to decode manifest: %w", err)
+	}
+	typed, err := scheme.Scheme.New(obj.GroupVersionKind())
+	if runtime.IsNotRegisteredError(err) {
+		return jsonpatch.MergePatch(original, patch)
+	}
+	if err != nil {
+		return nil, err
+	}
+	patched, err := strategicpatch.StrategicMergePatch(original, patch, typed)
+	if err != nil {
+		return nil, err
+	}
+	// The patch must not change the manifest into one which no longer
+	// decodes into its kind.
+	if err := json.Unmarshal(patched, typed); err != nil {
+		return nil, fmt.Errorf("patched manifest is invalid: %w", err)
+	}
+	return patched, nil
+}
@@ -0,0 +1,158 @@
+package hostedcontrolplane
+
+import (
+	"testing"
+
+	appsv1 "k8s.io/api/apps/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/yaml"
+
+	hyperv1 "github.com/openshift/hypershift/api/v1alpha1"
+)
+
+const testDeploymentManifest = `apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: kube-apiserver
+spec:
+  template:
+    spec:
+      containers:
+      - name: kube-apiserver
+        args:
+        - --v=2
+        env:
+        - name: A
+          value: a
+      - name: openvpn-client
+`
+
+const testEtcdClusterManifest = `apiVersion: etcd.database.coreos.com/v1beta2
+kind: EtcdCluster
+metadata:
+  name: etcd
+spec:
+  size: 1
+  version: 3.4.9
+`
+
+func TestApplyControlPlaneOverrides(t *testing.T) {
+	tests := map[string]struct {
+		Overrides     []hyperv1.ControlPlaneOverride
+		ExpectedError bool
+		Validate      func(t *testing.T, manifests map[string][]byte)
+	}{
+		"strategic merge patch merges containers by name": {
+			Overrides: []hyperv1.ControlPlaneOverride{{
+				Manifest: "kube-apiserver-deployment.yaml",
+				Type:     hyperv1.StrategicMergePatchType,
+				Patch: `spec:
+  template:
+    spec:
+      containers:
+      - name: kube-apiserver
+        env:
+        - name: B
+          value: b
+`,
+			}},
+			Validate: func(t *testing.T, manifests map[string][]byte) {
+				deployment := testDeployment(t, manifests)
+				containers := deployment.Spec.Template.Spec.Containers
+				if len(containers) != 2 {
+					t.Fatalf("expected 2 containers, got %d", len(containers))
+				}
+				if env := containers[0].Env; len(env) != 2 {
+					t.Errorf("expected env B to be added to the kube-apiserver container, got %v", env)
+				}
+			},
+		},
+		"JSON patch": {
+			Overrides: []hyperv1.ControlPlaneOverride{{
+				Manifest: "kube-apiserver-deployment.yaml",
+				Type:     hyperv1.JSONPatchType,
+				Patch:    `[{"op": "add", "path": "/spec/template/spec/containers/0/args/-", "value": "--audit-log-maxsize=200"}]`,
+			}},
+			Validate: func(t *testing.T, manifests map[string][]byte) {
+				args := testDeployment(t, manifests).Spec.Template.Spec.Containers[0].Args
+				if len(args) != 2 || args[1] != "--audit-log-maxsize=200" {
+					t.Errorf("expected the flag to be added, got %v", args)
+				}
+			},
+		},
+		"merge patch of a kind without a strategic merge schema": {
+			Overrides: []hyperv1.ControlPlaneOverride{{
+				Manifest: "etcd-cluster.yaml",
+				Patch:    `{"spec": {"version": "3.4.14"}}`,
+			}},
+			Validate: func(t *testing.T, manifests map[string][]byte) {
+				etcdCluster := &unstructured.Unstructured{}
+				if err := yaml.Unmarshal(manifests["etcd-cluster.yaml"], &etcdCluster.Object); err != nil {
+					t.Fatalf("etcd cluster is not valid yaml: %v", err)
+				}
+				if version, _, _ := unstructured.NestedString(etcdCluster.Object, "spec", "version"); version != "3.4.14" {
+					t.Errorf("expected version 3.4.14, got %s", version)
+				}
+			},
+		},
+		"invalid overrides are skipped": {
+			Overrides: []hyperv1.ControlPlaneOverride{
+				{
+					Manifest: "missing.yaml",
+					Patch:    `{"spec": {}}`,
+				},
+				{
+					Manifest: "kube-apiserver-deployment.yaml",
+					Type:     hyperv1.JSONPatchType,
+					Patch:    `[{"op": "replace", "path": "/spec/missing/0", "value": 1}]`,
+				},
+				{
+					Manifest: "kube-apiserver-deployment.yaml",
+					Patch:    `{"spec": {"replicas": "three"}}`,
+				},
+				{
+					Manifest: "kube-apiserver-deployment.yaml",
+					Type:     hyperv1.JSONPatchType,
+					Patch:    `[{"op": "add", "path": "/spec/template/spec/containers/0/args/-", "value": "--v=4"}]`,
+				},
+			},
+			ExpectedError: true,
+			Validate: func(t *testing.T, manifests map[string][]byte) {
+				deployment := testDeployment(t, manifests)
+				if deployment.Spec.Replicas != nil {
+					t.Errorf("expected the invalid replicas patch to be skipped")
+				}
+				if args := deployment.Spec.Template.Spec.Containers[0].Args; len(args) != 2 {
+					t.Errorf("expected the valid override to be applied, got %v", args)
+				}
+				if _, ok := manifests["missing.yaml"]; ok {
+					t.Errorf("expected no manifest to be created")
+				}
+			},
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			manifests := map[string][]byte{
+				"kube-apiserver-deployment.yaml": []byte(testDeploymentManifest),
+				"etcd-cluster.yaml":              []byte(testEtcdClusterManifest),
+			}
+			err := applyControlPlaneOverrides(manifests, test.Overrides)
+			if test.ExpectedError && err == nil {
+				t.Errorf("expected an error")
+			}
+			if !test.ExpectedError && err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+			test.Validate(t, manifests)
+		})
+	}
+}
+
+func testDeployment(t *testing.T, manifests map[string][]byte) *appsv1.Deployment {
+	deployment := &appsv1.Deployment{}
+	if err := yaml.Unmarshal(manifests["kube-apiserver-deployment.yaml"], deployment); err != nil {
+		t.Fatalf("deployment is not valid yaml: %v", err)
+	}
+	return deployment
+}
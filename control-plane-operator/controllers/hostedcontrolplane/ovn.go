@@ -0,0 +1,59 @@
+package hostedcontrolplane
+
+import (
+	"context"
+	"fmt"
+
+	routev1 "github.com/openshift/api/route/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	hyperv1 "github.com/openshift/hypershift/api/v1alpha1"
+)
+
+const (
+	ovnSbDbServiceName = "ovnkube-sbdb"
+
+	// ovnSbDbPort is the port of the OVN southbound database to which the
+	// ovn-controller of the nodes connects.
+	ovnSbDbPort = 9642
+)
+
+// createOVNSbDbService creates the service which publishes the OVN southbound
+// database of ovnkube-master, which the cluster network operator of the
+// control plane runs in the control plane namespace.
+func createOVNSbDbService(client client.Client, hcp *hyperv1.HostedControlPlane, namespace string, strategy hyperv1.ServicePublishingStrategy) (*corev1.Service, error) {
+	svc := &corev1.Service{}
+	svc.Namespace = namespace
+	svc.Name = ovnSbDbServiceName
+	svc.Spec.Selector = map[string]string{"app": "ovnkube-master"}
+	svc.Spec.Ports = []corev1.ServicePort{
+		{
+			Port:       ovnSbDbPort,
+			Protocol:   corev1.ProtocolTCP,
+			TargetPort: intstr.FromInt(ovnSbDbPort),
+		},
+	}
+	applyPublishingStrategy(svc, strategy)
+	svc.OwnerReferences = ensureHCPOwnerRef(hcp, svc.OwnerReferences)
+	if err := client.Create(context.TODO(), svc); err != nil {
+		if !apierrors.IsAlreadyExists(err) {
+			return nil, fmt.Errorf("failed to create ovn sbdb service: %w", err)
+		}
+	}
+	return svc, nil
+}
+
+// createOVNSbDbRoute returns the route of the OVN southbound database, whose
+// TLS connections are passed through to ovnkube-master.
+func createOVNSbDbRoute(namespace string) *routev1.Route {
+	return createPassthroughRoute(namespace, ovnSbDbServiceName, ovnSbDbServiceName)
+}
+
+// usesOVNKubernetes returns whether the guest cluster of the
+// HostedControlPlane uses the OVNKubernetes network type.
+func usesOVNKubernetes(hcp *hyperv1.HostedControlPlane) bool {
+	return hcp.Spec.NetworkType == hyperv1.OVNKubernetes
+}
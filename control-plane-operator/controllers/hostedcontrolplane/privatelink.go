@@ -0,0 +1,494 @@
+package hostedcontrolplane
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/aws/aws-sdk-go/service/ec2/ec2iface"
+	"github.com/aws/aws-sdk-go/service/elbv2"
+	"github.com/aws/aws-sdk-go/service/elbv2/elbv2iface"
+	"github.com/aws/aws-sdk-go/service/sts"
+	"github.com/aws/aws-sdk-go/service/sts/stsiface"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	hyperv1 "github.com/openshift/hypershift/api/v1alpha1"
+	hyperutil "github.com/openshift/hypershift/support/util"
+)
+
+const (
+	privateKubeAPIServerServiceName = "kube-apiserver-private"
+
+	// privateAPIPort is the port of the private Kube API server load balancer.
+	privateAPIPort = 6443
+)
+
+// privateAPIHostname returns the hostname of the record of the private zone of
+// the HostedControlPlane which points to the VPC endpoint of the Kube API
+// server.
+func privateAPIHostname(hcp *hyperv1.HostedControlPlane) string {
+	return fmt.Sprintf("api.%s.%s", hcp.Name, hcp.Spec.DNS.BaseDomain)
+}
+
+// createPrivateKubeAPIServerService creates the service which publishes the
+// Kube API server on an internal network load balancer, which backs the VPC
+// endpoint service of the HostedControlPlane.
+func createPrivateKubeAPIServerService(c client.Client, hcp *hyperv1.HostedControlPlane, namespace string) (*corev1.Service, error) {
+	svc := &corev1.Service{}
+	svc.Namespace = namespace
+	svc.Name = privateKubeAPIServerServiceName
+	svc.Annotations = map[string]string{
+		"service.beta.kubernetes.io/aws-load-balancer-type":     "nlb",
+		"service.beta.kubernetes.io/aws-load-balancer-internal": "true",
+	}
+	svc.Spec.Type = corev1.ServiceTypeLoadBalancer
+	svc.Spec.Selector = map[string]string{"app": "kube-apiserver"}
+	svc.Spec.Ports = []corev1.ServicePort{
+		{
+			Port:       privateAPIPort,
+			Protocol:   corev1.ProtocolTCP,
+			TargetPort: intstr.FromInt(6443),
+		},
+	}
+	svc.OwnerReferences = ensureHCPOwnerRef(hcp, svc.OwnerReferences)
+	if err := c.Create(context.TODO(), svc); err != nil {
+		if !apierrors.IsAlreadyExists(err) {
+			return nil, fmt.Errorf("failed to create private api server service: %w", err)
+		}
+	}
+	return svc, nil
+}
+
+// privateLinkClients are the AWS clients used to expose the private Kube API
+// server load balancer to the VPC of the HostedControlPlane.
+type privateLinkClients struct {
+	ec2 ec2iface.EC2API
+	elb elbv2iface.ELBV2API
+	sts stsiface.STSAPI
+
+	// credentialsVersion is the resource version of the credentials secret
+	// and region the clients were created for.
+	credentialsVersion string
+}
+
+// privateLinkClientsFor returns the AWS clients for the control plane operator
+// credentials of the HostedControlPlane. The clients are reused until the
+// credentials or region change.
+func (r *HostedControlPlaneReconciler) privateLinkClientsFor(ctx context.Context, hcp *hyperv1.HostedControlPlane) (*privateLinkClients, error) {
+	secret := &corev1.Secret{}
+	if err := r.Get(ctx, client.ObjectKey{Namespace: hcp.Namespace, Name: hcp.Spec.Platform.AWS.ControlPlaneOperatorCreds.Name}, secret); err != nil {
+		return nil, fmt.Errorf("failed to get control plane operator credentials: %w", err)
+	}
+	credentialsVersion := fmt.Sprintf("%s/%s/%s", secret.UID, secret.ResourceVersion, hcp.Spec.Platform.AWS.Region)
+	if r.privateLinkClients != nil && r.privateLinkClients.credentialsVersion == credentialsVersion {
+		return r.privateLinkClients, nil
+	}
+	credentials, hasCredentials := secret.Data["credentials"]
+	if !hasCredentials {
+		return nil, fmt.Errorf("control plane operator credentials secret %q is missing the credentials key", secret.Name)
+	}
+	s, err := newAWSSession(credentials, hcp.Spec.Platform.AWS.Region)
+	if err != nil {
+		return nil, err
+	}
+	r.privateLinkClients = &privateLinkClients{ec2: ec2.New(s), elb: elbv2.New(s), sts: sts.New(s), credentialsVersion: credentialsVersion}
+	return r.privateLinkClients, nil
+}
+
+// ensurePrivateAPI returns the private hostname of the Kube API server once
+// the VPC endpoint towards its internal load balancer is available in the VPC
+// of the HostedControlPlane, and points the hostname to the VPC endpoint in
+// the private zone.
+func (r *HostedControlPlaneReconciler) ensurePrivateAPI(ctx context.Context, hcp *hyperv1.HostedControlPlane, serviceKey client.ObjectKey) (string, error) {
+	svc := &corev1.Service{}
+	if err := r.Get(ctx, serviceKey, svc); err != nil {
+		return "", fmt.Errorf("failed to get private api server service: %w", err)
+	}
+	lbHostname := loadBalancerHostname(svc)
+	if len(lbHostname) == 0 {
+		return "", nil
+	}
+	clients, err := r.privateLinkClientsFor(ctx, hcp)
+	if err != nil {
+		return "", err
+	}
+	var subnetIDs, securityGroupIDs []string
+	if defaults := hcp.Spec.Platform.AWS.NodePoolDefaults; defaults != nil {
+		if defaults.Subnet != nil && defaults.Subnet.ID != nil {
+			subnetIDs = append(subnetIDs, *defaults.Subnet.ID)
+		}
+		for _, group := range defaults.SecurityGroups {
+			if group.ID != nil {
+				securityGroupIDs = append(securityGroupIDs, *group.ID)
+			}
+		}
+	}
+	if hcp.Status.PrivateLink == nil {
+		hcp.Status.PrivateLink = &hyperv1.PrivateLinkStatus{}
+	}
+	endpointHostname, err := ensureVPCEndpoint(ctx, clients, hcp.Status.PrivateLink, lbHostname, hcp.Spec.Platform.AWS.VPC, subnetIDs, securityGroupIDs)
+	if err != nil || len(endpointHostname) == 0 {
+		return "", err
+	}
+	dns, err := r.dnsManagerFor(ctx, hcp)
+	if err != nil {
+		return "", err
+	}
+	if dns == nil {
+		return "", fmt.Errorf("dns management credentials are required to publish the private api hostname")
+	}
+	hostname := privateAPIHostname(hcp)
+	if err := dns.ensureRecord(ctx, hcp.Spec.DNS.PrivateZoneID, hostname, endpointHostname); err != nil {
+		return "", fmt.Errorf("failed to ensure dns record %s in zone %s: %w", hostname, hcp.Spec.DNS.PrivateZoneID, err)
+	}
+	return hostname, nil
+}
+
+// deletePrivateAPI deletes the VPC endpoint service of the private Kube API
+// server load balancer together with its VPC endpoints, and the private
+// record of the Kube API server.
+func (r *HostedControlPlaneReconciler) deletePrivateAPI(ctx context.Context, hcp *hyperv1.HostedControlPlane) error {
+	if hyperutil.EndpointAccess(hcp.Spec.Platform) == hyperv1.Public {
+		return nil
+	}
+	svc := &corev1.Service{}
+	err := r.Get(ctx, client.ObjectKey{Namespace: hcp.Namespace, Name: privateKubeAPIServerServiceName}, svc)
+	if err != nil && !apierrors.IsNotFound(err) {
+		return fmt.Errorf("failed to get private api server service: %w", err)
+	}
+	lbHostname := loadBalancerHostname(svc)
+	status := hcp.Status.PrivateLink
+	if status == nil {
+		status = &hyperv1.PrivateLinkStatus{}
+	}
+	if len(lbHostname) > 0 || len(status.EndpointServiceID) > 0 {
+		clients, err := r.privateLinkClientsFor(ctx, hcp)
+		if apierrors.IsNotFound(err) {
+			// The credentials are removed with the control plane namespace.
+			r.Log.Info("Control plane operator credentials not found, skipping the deletion of the vpc endpoint service")
+		} else if err != nil {
+			return err
+		} else if err := deleteVPCEndpointService(ctx, clients, status, lbHostname); err != nil {
+			return err
+		}
+	}
+	dns, err := r.dnsManagerFor(ctx, hcp)
+	if apierrors.IsNotFound(err) {
+		return nil
+	}
+	if err != nil || dns == nil {
+		return err
+	}
+	hostname := privateAPIHostname(hcp)
+	if err := dns.deleteRecord(ctx, hcp.Spec.DNS.PrivateZoneID, hostname); err != nil {
+		return fmt.Errorf("failed to delete dns record %s from zone %s: %w", hostname, hcp.Spec.DNS.PrivateZoneID, err)
+	}
+	return nil
+}
+
+// loadBalancerHostname returns the hostname of the load balancer of the
+// service, if it has one.
+func loadBalancerHostname(svc *corev1.Service) string {
+	for _, ingress := range svc.Status.LoadBalancer.Ingress {
+		if len(ingress.Hostname) > 0 {
+			return ingress.Hostname
+		}
+	}
+	return ""
+}
+
+// ensureVPCEndpoint exposes the network load balancer of the given hostname
+// with a VPC endpoint service, which only the account of the credentials may
+// connect to, and creates an interface VPC endpoint towards it in the VPC. It
+// returns the hostname of the VPC endpoint once it is available.
+//
+// The IDs of the VPC endpoint service and VPC endpoint are recorded in the
+// status, and once recorded they are described by ID: the load balancers and
+// VPC endpoint services are only searched when nothing is recorded or the
+// recorded resources are gone.
+func ensureVPCEndpoint(ctx context.Context, clients *privateLinkClients, status *hyperv1.PrivateLinkStatus, lbHostname, vpcID string, subnetIDs, securityGroupIDs []string) (string, error) {
+	if len(status.EndpointID) > 0 {
+		endpoint, err := describeVPCEndpoint(ctx, clients.ec2, status.EndpointID)
+		if err != nil {
+			return "", err
+		}
+		if endpoint != nil {
+			return vpcEndpointHostname(endpoint), nil
+		}
+		status.EndpointID = ""
+	}
+
+	var service *ec2.ServiceConfiguration
+	if len(status.EndpointServiceID) > 0 {
+		var err error
+		service, err = describeVPCEndpointService(ctx, clients.ec2, status.EndpointServiceID)
+		if err != nil {
+			return "", err
+		}
+	}
+	if service == nil {
+		lbARN, err := findLoadBalancerARN(ctx, clients.elb, lbHostname)
+		if err != nil {
+			return "", err
+		}
+		if len(lbARN) == 0 {
+			return "", fmt.Errorf("load balancer %s not found", lbHostname)
+		}
+		service, err = findVPCEndpointService(ctx, clients.ec2, lbARN)
+		if err != nil {
+			return "", err
+		}
+		if service == nil {
+			output, err := clients.ec2.CreateVpcEndpointServiceConfigurationWithContext(ctx, &ec2.CreateVpcEndpointServiceConfigurationInput{
+				AcceptanceRequired:      aws.Bool(false),
+				NetworkLoadBalancerArns: []*string{aws.String(lbARN)},
+			})
+			if err != nil {
+				return "", fmt.Errorf("failed to create vpc endpoint service: %w", err)
+			}
+			service = output.ServiceConfiguration
+		}
+	}
+	status.EndpointServiceID = aws.StringValue(service.ServiceId)
+
+	identity, err := clients.sts.GetCallerIdentityWithContext(ctx, &sts.GetCallerIdentityInput{})
+	if err != nil {
+		return "", fmt.Errorf("failed to get caller identity: %w", err)
+	}
+	principal := fmt.Sprintf("arn:aws:iam::%s:root", aws.StringValue(identity.Account))
+	permissions, err := clients.ec2.DescribeVpcEndpointServicePermissionsWithContext(ctx, &ec2.DescribeVpcEndpointServicePermissionsInput{
+		ServiceId: service.ServiceId,
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to describe vpc endpoint service permissions: %w", err)
+	}
+	allowed := false
+	for _, allowedPrincipal := range permissions.AllowedPrincipals {
+		if aws.StringValue(allowedPrincipal.Principal) == principal {
+			allowed = true
+		}
+	}
+	if !allowed {
+		if _, err := clients.ec2.ModifyVpcEndpointServicePermissionsWithContext(ctx, &ec2.ModifyVpcEndpointServicePermissionsInput{
+			ServiceId:            service.ServiceId,
+			AddAllowedPrincipals: []*string{aws.String(principal)},
+		}); err != nil {
+			return "", fmt.Errorf("failed to allow %s to connect to the vpc endpoint service: %w", principal, err)
+		}
+	}
+
+	endpoints, err := findVPCEndpoints(ctx, clients.ec2, aws.StringValue(service.ServiceName), vpcID)
+	if err != nil {
+		return "", err
+	}
+	if len(endpoints) == 0 {
+		output, err := clients.ec2.CreateVpcEndpointWithContext(ctx, &ec2.CreateVpcEndpointInput{
+			VpcEndpointType:  aws.String(ec2.VpcEndpointTypeInterface),
+			ServiceName:      service.ServiceName,
+			VpcId:            aws.String(vpcID),
+			SubnetIds:        aws.StringSlice(subnetIDs),
+			SecurityGroupIds: aws.StringSlice(securityGroupIDs),
+		})
+		if err != nil {
+			return "", fmt.Errorf("failed to create vpc endpoint: %w", err)
+		}
+		endpoints = append(endpoints, output.VpcEndpoint)
+	}
+	status.EndpointID = aws.StringValue(endpoints[0].VpcEndpointId)
+	return vpcEndpointHostname(endpoints[0]), nil
+}
+
+// vpcEndpointHostname returns the hostname of the VPC endpoint once it is
+// available.
+func vpcEndpointHostname(endpoint *ec2.VpcEndpoint) string {
+	if !strings.EqualFold(aws.StringValue(endpoint.State), ec2.StateAvailable) || len(endpoint.DnsEntries) == 0 {
+		return ""
+	}
+	// The first entry is the regional hostname of the VPC endpoint.
+	return aws.StringValue(endpoint.DnsEntries[0].DnsName)
+}
+
+// deleteVPCEndpointService deletes the VPC endpoints of the VPC endpoint
+// service recorded in the status, or of the network load balancer of the
+// given hostname when none is recorded, and the VPC endpoint service once
+// they are gone.
+func deleteVPCEndpointService(ctx context.Context, clients *privateLinkClients, status *hyperv1.PrivateLinkStatus, lbHostname string) error {
+	var service *ec2.ServiceConfiguration
+	if len(status.EndpointServiceID) > 0 {
+		var err error
+		service, err = describeVPCEndpointService(ctx, clients.ec2, status.EndpointServiceID)
+		if err != nil {
+			return err
+		}
+	} else if len(lbHostname) > 0 {
+		lbARN, err := findLoadBalancerARN(ctx, clients.elb, lbHostname)
+		if err != nil || len(lbARN) == 0 {
+			return err
+		}
+		service, err = findVPCEndpointService(ctx, clients.ec2, lbARN)
+		if err != nil {
+			return err
+		}
+	}
+	if service == nil {
+		return nil
+	}
+	endpoints, err := findVPCEndpoints(ctx, clients.ec2, aws.StringValue(service.ServiceName), "")
+	if err != nil {
+		return err
+	}
+	if len(endpoints) > 0 {
+		var endpointIDs []*string
+		for _, endpoint := range endpoints {
+			if !strings.EqualFold(aws.StringValue(endpoint.State), ec2.StateDeleting) {
+				endpointIDs = append(endpointIDs, endpoint.VpcEndpointId)
+			}
+		}
+		if len(endpointIDs) > 0 {
+			output, err := clients.ec2.DeleteVpcEndpointsWithContext(ctx, &ec2.DeleteVpcEndpointsInput{VpcEndpointIds: endpointIDs})
+			if err != nil {
+				return fmt.Errorf("failed to delete vpc endpoints: %w", err)
+			}
+			if len(output.Unsuccessful) > 0 {
+				return fmt.Errorf("failed to delete vpc endpoint %s: %s", aws.StringValue(output.Unsuccessful[0].ResourceId), aws.StringValue(output.Unsuccessful[0].Error.Message))
+			}
+		}
+		return fmt.Errorf("waiting for the vpc endpoints of vpc endpoint service %s to be deleted", aws.StringValue(service.ServiceId))
+	}
+	output, err := clients.ec2.DeleteVpcEndpointServiceConfigurationsWithContext(ctx, &ec2.DeleteVpcEndpointServiceConfigurationsInput{
+		ServiceIds: []*string{service.ServiceId},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to delete vpc endpoint service: %w", err)
+	}
+	if len(output.Unsuccessful) > 0 {
+		return fmt.Errorf("failed to delete vpc endpoint service %s: %s", aws.StringValue(output.Unsuccessful[0].ResourceId), aws.StringValue(output.Unsuccessful[0].Error.Message))
+	}
+	return nil
+}
+
+// findLoadBalancerARN returns the ARN of the load balancer of the given
+// hostname, or an empty string when there is none.
+func findLoadBalancerARN(ctx context.Context, c elbv2iface.ELBV2API, lbHostname string) (string, error) {
+	var arn string
+	err := c.DescribeLoadBalancersPagesWithContext(ctx, &elbv2.DescribeLoadBalancersInput{}, func(output *elbv2.DescribeLoadBalancersOutput, lastPage bool) bool {
+		for _, lb := range output.LoadBalancers {
+			if strings.EqualFold(aws.StringValue(lb.DNSName), lbHostname) {
+				arn = aws.StringValue(lb.LoadBalancerArn)
+				return false
+			}
+		}
+		return true
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to describe load balancers: %w", err)
+	}
+	return arn, nil
+}
+
+// findVPCEndpointService returns the VPC endpoint service of the network load
+// balancer of the given ARN, or nil when there is none.
+func findVPCEndpointService(ctx context.Context, c ec2iface.EC2API, lbARN string) (*ec2.ServiceConfiguration, error) {
+	var result *ec2.ServiceConfiguration
+	err := c.DescribeVpcEndpointServiceConfigurationsPagesWithContext(ctx, &ec2.DescribeVpcEndpointServiceConfigurationsInput{}, func(output *ec2.DescribeVpcEndpointServiceConfigurationsOutput, lastPage bool) bool {
+		for _, service := range output.ServiceConfigurations {
+			for _, arn := range service.NetworkLoadBalancerArns {
+				if aws.StringValue(arn) == lbARN {
+					result = service
+					return false
+				}
+			}
+		}
+		return true
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to describe vpc endpoint services: %w", err)
+	}
+	return result, nil
+}
+
+// describeVPCEndpointService returns the VPC endpoint service of the given ID,
+// or nil when it does not exist.
+func describeVPCEndpointService(ctx context.Context, c ec2iface.EC2API, serviceID string) (*ec2.ServiceConfiguration, error) {
+	output, err := c.DescribeVpcEndpointServiceConfigurationsWithContext(ctx, &ec2.DescribeVpcEndpointServiceConfigurationsInput{
+		ServiceIds: []*string{aws.String(serviceID)},
+	})
+	if isAWSNotFound(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to describe vpc endpoint service %s: %w", serviceID, err)
+	}
+	for _, service := range output.ServiceConfigurations {
+		if !strings.EqualFold(aws.StringValue(service.ServiceState), ec2.ServiceStateDeleted) && !strings.EqualFold(aws.StringValue(service.ServiceState), ec2.ServiceStateFailed) {
+			return service, nil
+		}
+	}
+	return nil, nil
+}
+
+// describeVPCEndpoint returns the VPC endpoint of the given ID, or nil when it
+// does not exist or is deleted.
+func describeVPCEndpoint(ctx context.Context, c ec2iface.EC2API, endpointID string) (*ec2.VpcEndpoint, error) {
+	output, err := c.DescribeVpcEndpointsWithContext(ctx, &ec2.DescribeVpcEndpointsInput{
+		VpcEndpointIds: []*string{aws.String(endpointID)},
+	})
+	if isAWSNotFound(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to describe vpc endpoint %s: %w", endpointID, err)
+	}
+	for _, endpoint := range output.VpcEndpoints {
+		if !vpcEndpointGone(endpoint) {
+			return endpoint, nil
+		}
+	}
+	return nil, nil
+}
+
+// isAWSNotFound returns whether the error is an AWS error for a resource of a
+// given ID which does not exist.
+func isAWSNotFound(err error) bool {
+	var awsErr awserr.Error
+	return errors.As(err, &awsErr) && strings.HasSuffix(awsErr.Code(), ".NotFound")
+}
+
+// vpcEndpointGone returns whether the VPC endpoint is deleted or can no
+// longer be used.
+func vpcEndpointGone(endpoint *ec2.VpcEndpoint) bool {
+	switch strings.ToLower(aws.StringValue(endpoint.State)) {
+	case strings.ToLower(ec2.StateDeleted), strings.ToLower(ec2.StateFailed), strings.ToLower(ec2.StateRejected):
+		return true
+	}
+	return false
+}
+
+// findVPCEndpoints returns the VPC endpoints towards the VPC endpoint service
+// of the given name, in the VPC when one is given, which are not deleted.
+func findVPCEndpoints(ctx context.Context, c ec2iface.EC2API, serviceName, vpcID string) ([]*ec2.VpcEndpoint, error) {
+	filters := []*ec2.Filter{{Name: aws.String("service-name"), Values: []*string{aws.String(serviceName)}}}
+	if len(vpcID) > 0 {
+		filters = append(filters, &ec2.Filter{Name: aws.String("vpc-id"), Values: []*string{aws.String(vpcID)}})
+	}
+	var endpoints []*ec2.VpcEndpoint
+	err := c.DescribeVpcEndpointsPagesWithContext(ctx, &ec2.DescribeVpcEndpointsInput{Filters: filters}, func(output *ec2.DescribeVpcEndpointsOutput, lastPage bool) bool {
+		for _, endpoint := range output.VpcEndpoints {
+			if !vpcEndpointGone(endpoint) {
+				endpoints = append(endpoints, endpoint)
+			}
+		}
+		return true
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to describe vpc endpoints: %w", err)
+	}
+	return endpoints, nil
+}
@@ -0,0 +1,263 @@
+package hostedcontrolplane
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/aws/aws-sdk-go/service/ec2/ec2iface"
+	"github.com/aws/aws-sdk-go/service/elbv2"
+	"github.com/aws/aws-sdk-go/service/elbv2/elbv2iface"
+	"github.com/aws/aws-sdk-go/service/sts"
+	"github.com/aws/aws-sdk-go/service/sts/stsiface"
+	"github.com/google/go-cmp/cmp"
+
+	hyperv1 "github.com/openshift/hypershift/api/v1alpha1"
+)
+
+const (
+	testLoadBalancerHostname = "private-lb.elb.us-east-1.amazonaws.com"
+	testLoadBalancerARN      = "arn:aws:elasticloadbalancing:us-east-1:123456789012:loadbalancer/net/private-lb/1"
+	testPrincipal            = "arn:aws:iam::123456789012:root"
+)
+
+type fakeELB struct {
+	elbv2iface.ELBV2API
+	described bool
+}
+
+func (f *fakeELB) DescribeLoadBalancersPagesWithContext(ctx aws.Context, input *elbv2.DescribeLoadBalancersInput, fn func(*elbv2.DescribeLoadBalancersOutput, bool) bool, opts ...request.Option) error {
+	f.described = true
+	fn(&elbv2.DescribeLoadBalancersOutput{LoadBalancers: []*elbv2.LoadBalancer{
+		{DNSName: aws.String("other-lb.elb.us-east-1.amazonaws.com"), LoadBalancerArn: aws.String("other")},
+		{DNSName: aws.String(testLoadBalancerHostname), LoadBalancerArn: aws.String(testLoadBalancerARN)},
+	}}, true)
+	return nil
+}
+
+type fakeSTS struct {
+	stsiface.STSAPI
+}
+
+func (f *fakeSTS) GetCallerIdentityWithContext(ctx aws.Context, input *sts.GetCallerIdentityInput, opts ...request.Option) (*sts.GetCallerIdentityOutput, error) {
+	return &sts.GetCallerIdentityOutput{Account: aws.String("123456789012")}, nil
+}
+
+// fakeEC2 records the VPC endpoint services and VPC endpoints which exist and
+// the calls made to change them.
+type fakeEC2 struct {
+	ec2iface.EC2API
+	services   []*ec2.ServiceConfiguration
+	principals []string
+	endpoints  []*ec2.VpcEndpoint
+	calls      []string
+}
+
+func (f *fakeEC2) DescribeVpcEndpointServiceConfigurationsPagesWithContext(ctx aws.Context, input *ec2.DescribeVpcEndpointServiceConfigurationsInput, fn func(*ec2.DescribeVpcEndpointServiceConfigurationsOutput, bool) bool, opts ...request.Option) error {
+	fn(&ec2.DescribeVpcEndpointServiceConfigurationsOutput{ServiceConfigurations: f.services}, true)
+	return nil
+}
+
+func (f *fakeEC2) DescribeVpcEndpointServiceConfigurationsWithContext(ctx aws.Context, input *ec2.DescribeVpcEndpointServiceConfigurationsInput, opts ...request.Option) (*ec2.DescribeVpcEndpointServiceConfigurationsOutput, error) {
+	for _, service := range f.services {
+		if aws.StringValue(service.ServiceId) == aws.StringValue(input.ServiceIds[0]) {
+			return &ec2.DescribeVpcEndpointServiceConfigurationsOutput{ServiceConfigurations: []*ec2.ServiceConfiguration{service}}, nil
+		}
+	}
+	return nil, awserr.New("InvalidVpcEndpointServiceId.NotFound", "not found", nil)
+}
+
+func (f *fakeEC2) CreateVpcEndpointServiceConfigurationWithContext(ctx aws.Context, input *ec2.CreateVpcEndpointServiceConfigurationInput, opts ...request.Option) (*ec2.CreateVpcEndpointServiceConfigurationOutput, error) {
+	f.calls = append(f.calls, "CreateVpcEndpointServiceConfiguration")
+	service := &ec2.ServiceConfiguration{
+		ServiceId:               aws.String("vpce-svc-1"),
+		ServiceName:             aws.String("com.amazonaws.vpce.us-east-1.vpce-svc-1"),
+		NetworkLoadBalancerArns: input.NetworkLoadBalancerArns,
+	}
+	f.services = append(f.services, service)
+	return &ec2.CreateVpcEndpointServiceConfigurationOutput{ServiceConfiguration: service}, nil
+}
+
+func (f *fakeEC2) DescribeVpcEndpointServicePermissionsWithContext(ctx aws.Context, input *ec2.DescribeVpcEndpointServicePermissionsInput, opts ...request.Option) (*ec2.DescribeVpcEndpointServicePermissionsOutput, error) {
+	output := &ec2.DescribeVpcEndpointServicePermissionsOutput{}
+	for _, principal := range f.principals {
+		output.AllowedPrincipals = append(output.AllowedPrincipals, &ec2.AllowedPrincipal{Principal: aws.String(principal)})
+	}
+	return output, nil
+}
+
+func (f *fakeEC2) ModifyVpcEndpointServicePermissionsWithContext(ctx aws.Context, input *ec2.ModifyVpcEndpointServicePermissionsInput, opts ...request.Option) (*ec2.ModifyVpcEndpointServicePermissionsOutput, error) {
+	f.calls = append(f.calls, "ModifyVpcEndpointServicePermissions")
+	f.principals = append(f.principals, aws.StringValueSlice(input.AddAllowedPrincipals)...)
+	return &ec2.ModifyVpcEndpointServicePermissionsOutput{}, nil
+}
+
+func (f *fakeEC2) DescribeVpcEndpointsPagesWithContext(ctx aws.Context, input *ec2.DescribeVpcEndpointsInput, fn func(*ec2.DescribeVpcEndpointsOutput, bool) bool, opts ...request.Option) error {
+	fn(&ec2.DescribeVpcEndpointsOutput{VpcEndpoints: f.endpoints}, true)
+	return nil
+}
+
+func (f *fakeEC2) DescribeVpcEndpointsWithContext(ctx aws.Context, input *ec2.DescribeVpcEndpointsInput, opts ...request.Option) (*ec2.DescribeVpcEndpointsOutput, error) {
+	for _, endpoint := range f.endpoints {
+		if aws.StringValue(endpoint.VpcEndpointId) == aws.StringValue(input.VpcEndpointIds[0]) {
+			return &ec2.DescribeVpcEndpointsOutput{VpcEndpoints: []*ec2.VpcEndpoint{endpoint}}, nil
+		}
+	}
+	return nil, awserr.New("InvalidVpcEndpointId.NotFound", "not found", nil)
+}
+
+func (f *fakeEC2) CreateVpcEndpointWithContext(ctx aws.Context, input *ec2.CreateVpcEndpointInput, opts ...request.Option) (*ec2.CreateVpcEndpointOutput, error) {
+	f.calls = append(f.calls, "CreateVpcEndpoint")
+	endpoint := &ec2.VpcEndpoint{
+		VpcEndpointId: aws.String("vpce-1"),
+		ServiceName:   input.ServiceName,
+		VpcId:         input.VpcId,
+		State:         aws.String("pending"),
+	}
+	f.endpoints = append(f.endpoints, endpoint)
+	return &ec2.CreateVpcEndpointOutput{VpcEndpoint: endpoint}, nil
+}
+
+func (f *fakeEC2) DeleteVpcEndpointsWithContext(ctx aws.Context, input *ec2.DeleteVpcEndpointsInput, opts ...request.Option) (*ec2.DeleteVpcEndpointsOutput, error) {
+	f.calls = append(f.calls, "DeleteVpcEndpoints")
+	for _, endpoint := range f.endpoints {
+		endpoint.State = aws.String("deleting")
+	}
+	return &ec2.DeleteVpcEndpointsOutput{}, nil
+}
+
+func (f *fakeEC2) DeleteVpcEndpointServiceConfigurationsWithContext(ctx aws.Context, input *ec2.DeleteVpcEndpointServiceConfigurationsInput, opts ...request.Option) (*ec2.DeleteVpcEndpointServiceConfigurationsOutput, error) {
+	f.calls = append(f.calls, "DeleteVpcEndpointServiceConfigurations")
+	f.services = nil
+	return &ec2.DeleteVpcEndpointServiceConfigurationsOutput{}, nil
+}
+
+func testVPCEndpointService() *ec2.ServiceConfiguration {
+	return &ec2.ServiceConfiguration{
+		ServiceId:               aws.String("vpce-svc-1"),
+		ServiceName:             aws.String("com.amazonaws.vpce.us-east-1.vpce-svc-1"),
+		NetworkLoadBalancerArns: []*string{aws.String(testLoadBalancerARN)},
+	}
+}
+
+func testVPCEndpoint(state string) *ec2.VpcEndpoint {
+	return &ec2.VpcEndpoint{
+		VpcEndpointId: aws.String("vpce-1"),
+		ServiceName:   aws.String("com.amazonaws.vpce.us-east-1.vpce-svc-1"),
+		VpcId:         aws.String("vpc-1"),
+		State:         aws.String(state),
+		DnsEntries:    []*ec2.DnsEntry{{DnsName: aws.String("vpce-1.vpce-svc-1.us-east-1.vpce.amazonaws.com")}},
+	}
+}
+
+func TestEnsureVPCEndpoint(t *testing.T) {
+	tests := map[string]struct {
+		Services         []*ec2.ServiceConfiguration
+		Principals       []string
+		Endpoints        []*ec2.VpcEndpoint
+		Status           hyperv1.PrivateLinkStatus
+		ExpectedHostname string
+		ExpectedCalls    []string
+		// ExpectedDiscovery is whether the load balancers are searched.
+		ExpectedDiscovery bool
+	}{
+		"new load balancer": {
+			ExpectedCalls:     []string{"CreateVpcEndpointServiceConfiguration", "ModifyVpcEndpointServicePermissions", "CreateVpcEndpoint"},
+			ExpectedDiscovery: true,
+		},
+		"pending vpc endpoint": {
+			Services:          []*ec2.ServiceConfiguration{testVPCEndpointService()},
+			Principals:        []string{testPrincipal},
+			Endpoints:         []*ec2.VpcEndpoint{testVPCEndpoint("pending")},
+			ExpectedDiscovery: true,
+		},
+		"available vpc endpoint": {
+			Services:          []*ec2.ServiceConfiguration{testVPCEndpointService()},
+			Principals:        []string{testPrincipal},
+			Endpoints:         []*ec2.VpcEndpoint{testVPCEndpoint("available")},
+			ExpectedHostname:  "vpce-1.vpce-svc-1.us-east-1.vpce.amazonaws.com",
+			ExpectedDiscovery: true,
+		},
+		"deleted vpc endpoint": {
+			Services:          []*ec2.ServiceConfiguration{testVPCEndpointService()},
+			Principals:        []string{testPrincipal},
+			Endpoints:         []*ec2.VpcEndpoint{testVPCEndpoint("deleted")},
+			ExpectedCalls:     []string{"CreateVpcEndpoint"},
+			ExpectedDiscovery: true,
+		},
+		"recorded vpc endpoint": {
+			Services:         []*ec2.ServiceConfiguration{testVPCEndpointService()},
+			Principals:       []string{testPrincipal},
+			Endpoints:        []*ec2.VpcEndpoint{testVPCEndpoint("available")},
+			Status:           hyperv1.PrivateLinkStatus{EndpointServiceID: "vpce-svc-1", EndpointID: "vpce-1"},
+			ExpectedHostname: "vpce-1.vpce-svc-1.us-east-1.vpce.amazonaws.com",
+		},
+		"recorded vpc endpoint which was deleted": {
+			Services:      []*ec2.ServiceConfiguration{testVPCEndpointService()},
+			Principals:    []string{testPrincipal},
+			Endpoints:     []*ec2.VpcEndpoint{testVPCEndpoint("deleted")},
+			Status:        hyperv1.PrivateLinkStatus{EndpointServiceID: "vpce-svc-1", EndpointID: "vpce-1"},
+			ExpectedCalls: []string{"CreateVpcEndpoint"},
+		},
+		"recorded vpc endpoint service which was deleted": {
+			Status:            hyperv1.PrivateLinkStatus{EndpointServiceID: "vpce-svc-0", EndpointID: "vpce-0"},
+			ExpectedCalls:     []string{"CreateVpcEndpointServiceConfiguration", "ModifyVpcEndpointServicePermissions", "CreateVpcEndpoint"},
+			ExpectedDiscovery: true,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			ec2Client := &fakeEC2{services: test.Services, principals: test.Principals, endpoints: test.Endpoints}
+			elbClient := &fakeELB{}
+			clients := &privateLinkClients{ec2: ec2Client, elb: elbClient, sts: &fakeSTS{}}
+			status := test.Status
+			hostname, err := ensureVPCEndpoint(context.Background(), clients, &status, testLoadBalancerHostname, "vpc-1", []string{"subnet-1"}, []string{"sg-1"})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if hostname != test.ExpectedHostname {
+				t.Errorf("expected hostname %q, got %q", test.ExpectedHostname, hostname)
+			}
+			if diff := cmp.Diff(test.ExpectedCalls, ec2Client.calls); diff != "" {
+				t.Errorf("unexpected calls (-want +got): %s", diff)
+			}
+			if elbClient.described != test.ExpectedDiscovery {
+				t.Errorf("expected load balancer discovery %t, got %t", test.ExpectedDiscovery, elbClient.described)
+			}
+			expectedStatus := hyperv1.PrivateLinkStatus{EndpointServiceID: "vpce-svc-1", EndpointID: "vpce-1"}
+			if diff := cmp.Diff(expectedStatus, status); diff != "" {
+				t.Errorf("unexpected status (-want +got): %s", diff)
+			}
+		})
+	}
+}
+
+func TestDeleteVPCEndpointService(t *testing.T) {
+	ec2Client := &fakeEC2{
+		services:  []*ec2.ServiceConfiguration{testVPCEndpointService()},
+		endpoints: []*ec2.VpcEndpoint{testVPCEndpoint("available")},
+	}
+	clients := &privateLinkClients{ec2: ec2Client, elb: &fakeELB{}, sts: &fakeSTS{}}
+
+	// The VPC endpoint service is deleted once its VPC endpoints are gone.
+	if err := deleteVPCEndpointService(context.Background(), clients, &hyperv1.PrivateLinkStatus{}, testLoadBalancerHostname); err == nil {
+		t.Fatalf("expected an error while the vpc endpoints are being deleted")
+	}
+	if err := deleteVPCEndpointService(context.Background(), clients, &hyperv1.PrivateLinkStatus{}, testLoadBalancerHostname); err == nil {
+		t.Fatalf("expected an error while the vpc endpoints are being deleted")
+	}
+	ec2Client.endpoints[0].State = aws.String("deleted")
+	if err := deleteVPCEndpointService(context.Background(), clients, &hyperv1.PrivateLinkStatus{}, testLoadBalancerHostname); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := deleteVPCEndpointService(context.Background(), clients, &hyperv1.PrivateLinkStatus{}, testLoadBalancerHostname); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := []string{"DeleteVpcEndpoints", "DeleteVpcEndpointServiceConfigurations"}
+	if diff := cmp.Diff(expected, ec2Client.calls); diff != "" {
+		t.Errorf("unexpected calls (-want +got): %s", diff)
+	}
+}
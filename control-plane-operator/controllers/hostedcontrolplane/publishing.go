@@ -0,0 +1,91 @@
+package hostedcontrolplane
+
+import (
+	"context"
+	"fmt"
+
+	routev1 "github.com/openshift/api/route/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	hyperv1 "github.com/openshift/hypershift/api/v1alpha1"
+)
+
+// routerPort is the port on which the router of the management cluster serves
+// the routes of the services published with the Route strategy.
+const routerPort = 443
+
+// applyPublishingStrategy sets the type of a service, and its node port when
+// the strategy has one, for the service to be published with the strategy.
+// A service published through a route only needs a cluster IP.
+func applyPublishingStrategy(svc *corev1.Service, strategy hyperv1.ServicePublishingStrategy) {
+	switch strategy.Type {
+	case hyperv1.LoadBalancer:
+		svc.Spec.Type = corev1.ServiceTypeLoadBalancer
+	case hyperv1.NodePort:
+		svc.Spec.Type = corev1.ServiceTypeNodePort
+		if strategy.NodePort != nil && strategy.NodePort.Port > 0 {
+			svc.Spec.Ports[0].NodePort = strategy.NodePort.Port
+		}
+	default:
+		svc.Spec.Type = corev1.ServiceTypeClusterIP
+	}
+}
+
+// createPassthroughRoute returns a route which passes TLS connections through
+// to a service.
+func createPassthroughRoute(namespace, name, serviceName string) *routev1.Route {
+	return &routev1.Route{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: namespace,
+			Name:      name,
+		},
+		Spec: routev1.RouteSpec{
+			To: routev1.RouteTargetReference{
+				Kind: "Service",
+				Name: serviceName,
+			},
+			TLS: &routev1.TLSConfig{
+				Termination:                   routev1.TLSTerminationPassthrough,
+				InsecureEdgeTerminationPolicy: routev1.InsecureEdgeTerminationPolicyRedirect,
+			},
+		},
+	}
+}
+
+// getPublishedServiceAddress returns the address and port through which a
+// service published with the strategy is reached. The address is empty until
+// it is known. The route of a service published with the Route strategy is
+// identified by routeKey.
+func getPublishedServiceAddress(c client.Client, ctx context.Context, serviceKey, routeKey client.ObjectKey, strategy hyperv1.ServicePublishingStrategy) (string, int32, error) {
+	switch strategy.Type {
+	case hyperv1.Route:
+		addr, err := getRouteAddress(c, ctx, routeKey)
+		if err != nil {
+			return "", 0, err
+		}
+		return addr, routerPort, nil
+	case hyperv1.LoadBalancer, hyperv1.NodePort:
+		svc := &corev1.Service{}
+		if err := c.Get(ctx, serviceKey, svc); err != nil {
+			return "", 0, fmt.Errorf("failed to get service: %w", err)
+		}
+		if len(svc.Spec.Ports) == 0 {
+			return "", 0, fmt.Errorf("service %s has no ports", serviceKey.Name)
+		}
+		if strategy.Type == hyperv1.NodePort {
+			if strategy.NodePort == nil {
+				return "", 0, fmt.Errorf("the NodePort strategy of service %s has no address", serviceKey.Name)
+			}
+			return strategy.NodePort.Address, svc.Spec.Ports[0].NodePort, nil
+		}
+		addr, err := getLoadBalancerServiceAddress(c, ctx, serviceKey)
+		if err != nil {
+			return "", 0, err
+		}
+		return addr, svc.Spec.Ports[0].Port, nil
+	default:
+		return "", 0, fmt.Errorf("unknown publishing strategy %q for service %s", strategy.Type, serviceKey.Name)
+	}
+}
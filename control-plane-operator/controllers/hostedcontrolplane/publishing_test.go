@@ -0,0 +1,116 @@
+package hostedcontrolplane
+
+import (
+	"context"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	hyperapi "github.com/openshift/hypershift/api"
+	hyperv1 "github.com/openshift/hypershift/api/v1alpha1"
+)
+
+func TestGetPublishedServiceAddress(t *testing.T) {
+	service := func(serviceType corev1.ServiceType) *corev1.Service {
+		return &corev1.Service{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "hcp", Name: kubeAPIServerServiceName},
+			Spec: corev1.ServiceSpec{
+				Type:  serviceType,
+				Ports: []corev1.ServicePort{{Port: 6443, TargetPort: intstr.FromInt(6443), NodePort: 30443}},
+			},
+		}
+	}
+	loadBalancer := service(corev1.ServiceTypeLoadBalancer)
+	loadBalancer.Status.LoadBalancer.Ingress = []corev1.LoadBalancerIngress{{Hostname: "lb.example.com"}}
+	route := createPassthroughRoute("hcp", kubeAPIServerServiceName, kubeAPIServerServiceName)
+	route.Spec.Host = "api-hcp.apps.example.com"
+
+	tests := map[string]struct {
+		Strategy        hyperv1.ServicePublishingStrategy
+		Objects         []client.Object
+		ExpectedAddress string
+		ExpectedPort    int32
+	}{
+		"load balancer": {
+			Strategy:        hyperv1.ServicePublishingStrategy{Type: hyperv1.LoadBalancer},
+			Objects:         []client.Object{loadBalancer},
+			ExpectedAddress: "lb.example.com",
+			ExpectedPort:    6443,
+		},
+		"load balancer which is not provisioned yet": {
+			Strategy: hyperv1.ServicePublishingStrategy{Type: hyperv1.LoadBalancer},
+			Objects:  []client.Object{service(corev1.ServiceTypeLoadBalancer)},
+			// The service port is known before the load balancer address.
+			ExpectedPort: 6443,
+		},
+		"node port": {
+			Strategy: hyperv1.ServicePublishingStrategy{
+				Type:     hyperv1.NodePort,
+				NodePort: &hyperv1.NodePortPublishingStrategy{Address: "nodes.example.com"},
+			},
+			Objects:         []client.Object{service(corev1.ServiceTypeNodePort)},
+			ExpectedAddress: "nodes.example.com",
+			ExpectedPort:    30443,
+		},
+		"route": {
+			Strategy:        hyperv1.ServicePublishingStrategy{Type: hyperv1.Route},
+			Objects:         []client.Object{service(corev1.ServiceTypeClusterIP), route},
+			ExpectedAddress: "api-hcp.apps.example.com",
+			ExpectedPort:    routerPort,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			c := fake.NewClientBuilder().WithScheme(hyperapi.Scheme).WithObjects(test.Objects...).Build()
+			key := client.ObjectKey{Namespace: "hcp", Name: kubeAPIServerServiceName}
+			address, port, err := getPublishedServiceAddress(c, context.Background(), key, key, test.Strategy)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if address != test.ExpectedAddress || port != test.ExpectedPort {
+				t.Errorf("expected %s:%d, got %s:%d", test.ExpectedAddress, test.ExpectedPort, address, port)
+			}
+		})
+	}
+}
+
+func TestApplyPublishingStrategy(t *testing.T) {
+	tests := map[string]struct {
+		Strategy         hyperv1.ServicePublishingStrategy
+		ExpectedType     corev1.ServiceType
+		ExpectedNodePort int32
+	}{
+		"load balancer": {
+			Strategy:     hyperv1.ServicePublishingStrategy{Type: hyperv1.LoadBalancer},
+			ExpectedType: corev1.ServiceTypeLoadBalancer,
+		},
+		"node port with an explicit port": {
+			Strategy: hyperv1.ServicePublishingStrategy{
+				Type:     hyperv1.NodePort,
+				NodePort: &hyperv1.NodePortPublishingStrategy{Address: "nodes.example.com", Port: 30443},
+			},
+			ExpectedType:     corev1.ServiceTypeNodePort,
+			ExpectedNodePort: 30443,
+		},
+		"route": {
+			Strategy:     hyperv1.ServicePublishingStrategy{Type: hyperv1.Route},
+			ExpectedType: corev1.ServiceTypeClusterIP,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			svc := &corev1.Service{Spec: corev1.ServiceSpec{Ports: []corev1.ServicePort{{Port: 6443}}}}
+			applyPublishingStrategy(svc, test.Strategy)
+			if svc.Spec.Type != test.ExpectedType {
+				t.Errorf("expected service type %s, got %s", test.ExpectedType, svc.Spec.Type)
+			}
+			if svc.Spec.Ports[0].NodePort != test.ExpectedNodePort {
+				t.Errorf("expected node port %d, got %d", test.ExpectedNodePort, svc.Spec.Ports[0].NodePort)
+			}
+		})
+	}
+}
@@ -0,0 +1,112 @@
+package render
+
+import (
+	"reflect"
+	"sort"
+	"strings"
+
+	configv1 "github.com/openshift/api/config/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	hyperconfig "github.com/openshift/hypershift/support/config"
+)
+
+const (
+	// GlobalConfigMapName is the name of the ConfigMap holding the global
+	// configuration resources of the guest cluster, keyed by GuestConfigKey.
+	GlobalConfigMapName = "global-config"
+
+	// UserCABundleName is the name of the config map in the openshift-config
+	// namespace of the guest cluster holding the additional trust bundle.
+	UserCABundleName = "user-ca-bundle"
+
+	defaultHostPrefix = 23
+)
+
+// guestConfigObjects returns the global configuration resources of the guest
+// cluster. The network and proxy configuration always exist, and the fields
+// of the network and proxy configuration owned by the HostedCluster take
+// precedence over those specified. The proxy configuration trusts the
+// additional trust bundle, if any. The status of the proxy configuration is
+// only read by the machine config server, since it is not applied to the
+// guest cluster.
+func guestConfigObjects(p *ClusterParams) []runtime.Object {
+	c := p.GlobalConfig
+	network := &configv1.Network{}
+	if c.Network != nil {
+		network = c.Network.DeepCopy()
+	}
+	network.Spec.ClusterNetwork = nil
+	for _, entry := range p.ClusterNetworks() {
+		network.Spec.ClusterNetwork = append(network.Spec.ClusterNetwork, configv1.ClusterNetworkEntry{CIDR: entry.CIDR, HostPrefix: uint32(entry.HostPrefix)})
+	}
+	network.Spec.ServiceNetwork = p.ServiceCIDRs()
+	network.Spec.NetworkType = p.NetworkType
+	if network.Spec.ExternalIP == nil {
+		network.Spec.ExternalIP = &configv1.ExternalIPConfig{Policy: &configv1.ExternalIPPolicy{}}
+	}
+	proxy := &configv1.Proxy{}
+	if c.Proxy != nil {
+		proxy = c.Proxy.DeepCopy()
+	}
+	if p.HasProxy() {
+		proxy.Spec.HTTPProxy = p.HTTPProxy
+		proxy.Spec.HTTPSProxy = p.HTTPSProxy
+		proxy.Spec.NoProxy = p.NoProxy
+		proxy.Status = configv1.ProxyStatus{
+			HTTPProxy:  p.HTTPProxy,
+			HTTPSProxy: p.HTTPSProxy,
+			NoProxy:    p.guestNoProxy(),
+		}
+	}
+	if len(p.AdditionalTrustBundle) > 0 {
+		proxy.Spec.TrustedCA.Name = UserCABundleName
+	}
+	objs := []runtime.Object{guestConfigObject(network, "Network"), guestConfigObject(proxy, "Proxy")}
+
+	if c.Ingress != nil {
+		ingress := c.Ingress.DeepCopy()
+		if len(ingress.Spec.Domain) == 0 {
+			ingress.Spec.Domain = p.IngressSubdomain
+		}
+		objs = append(objs, guestConfigObject(ingress, "Ingress"))
+	}
+	for kind, obj := range map[string]runtime.Object{
+		"APIServer":   c.APIServer,
+		"OAuth":       c.OAuth,
+		"Image":       c.Image,
+		"Scheduler":   c.Scheduler,
+		"FeatureGate": c.FeatureGate,
+		"Build":       c.Build,
+	} {
+		if !reflect.ValueOf(obj).IsNil() {
+			objs = append(objs, guestConfigObject(obj.DeepCopyObject(), kind))
+		}
+	}
+	sort.Slice(objs, func(i, j int) bool { return GuestConfigKey(objs[i]) < GuestConfigKey(objs[j]) })
+	return objs
+}
+
+// guestConfigObject strips the metadata of a configuration resource down to
+// what is applied to the guest cluster.
+func guestConfigObject(obj runtime.Object, kind string) runtime.Object {
+	obj.GetObjectKind().SetGroupVersionKind(configv1.GroupVersion.WithKind(kind))
+	accessor := obj.(metav1.Object)
+	accessor.SetName(hyperconfig.GlobalConfigName)
+	accessor.SetNamespace("")
+	accessor.SetUID("")
+	accessor.SetResourceVersion("")
+	accessor.SetGeneration(0)
+	accessor.SetCreationTimestamp(metav1.Time{})
+	accessor.SetOwnerReferences(nil)
+	accessor.SetFinalizers(nil)
+	accessor.SetManagedFields(nil)
+	return obj
+}
+
+// GuestConfigKey returns the key of a configuration resource in the global
+// configuration ConfigMap.
+func GuestConfigKey(obj runtime.Object) string {
+	return strings.ToLower(obj.GetObjectKind().GroupVersionKind().Kind) + ".yaml"
+}
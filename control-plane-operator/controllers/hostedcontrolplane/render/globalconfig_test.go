@@ -0,0 +1,248 @@
+package render
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	buildv1 "github.com/openshift/api/build/v1"
+	configv1 "github.com/openshift/api/config/v1"
+	openshiftcontrolplanev1 "github.com/openshift/api/openshiftcontrolplane/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/yaml"
+
+	hyperconfig "github.com/openshift/hypershift/support/config"
+)
+
+func rawItems(items ...string) []runtime.RawExtension {
+	var result []runtime.RawExtension
+	for _, item := range items {
+		raw, err := yaml.YAMLToJSON([]byte(item))
+		if err != nil {
+			panic(err)
+		}
+		result = append(result, runtime.RawExtension{Raw: raw})
+	}
+	return result
+}
+
+const (
+	apiServerItem = `
+apiVersion: config.openshift.io/v1
+kind: APIServer
+metadata:
+  name: cluster
+spec:
+  additionalCORSAllowedOrigins:
+  - "//example\\.com(:|$)"
+  tlsSecurityProfile:
+    type: Old
+`
+	networkItem = `
+apiVersion: config.openshift.io/v1
+kind: Network
+metadata:
+  name: cluster
+spec:
+  serviceNetwork:
+  - 10.0.0.0/8
+  externalIP:
+    autoAssignCIDRs:
+    - 192.168.0.0/24
+    policy:
+      allowedCIDRs:
+      - 192.168.0.0/16
+      rejectedCIDRs:
+      - 192.168.1.0/24
+`
+	imageItem = `
+apiVersion: config.openshift.io/v1
+kind: Image
+metadata:
+  name: cluster
+spec:
+  allowedRegistriesForImport:
+  - domainName: quay.io
+  - domainName: registry.example.com:5000
+    insecure: true
+  externalRegistryHostnames:
+  - registry.apps.example.com
+`
+	buildItem = `
+apiVersion: config.openshift.io/v1
+kind: Build
+metadata:
+  name: cluster
+spec:
+  buildDefaults:
+    defaultProxy:
+      httpProxy: http://proxy.example.com:3128
+    env:
+    - name: FOO
+      value: bar
+    imageLabels:
+    - name: vendor
+      value: example
+  buildOverrides:
+    forcePull: true
+    nodeSelector:
+      node-role.kubernetes.io/builder: ""
+`
+)
+
+func TestGuestConfigObjects(t *testing.T) {
+	config, errs := hyperconfig.ParseGlobalConfig(rawItems(apiServerItem, networkItem), field.NewPath("items"))
+	if len(errs) > 0 {
+		t.Fatalf("unexpected errors: %v", errs)
+	}
+	params := &ClusterParams{
+		PodCIDR:      "10.132.0.0/14",
+		ServiceCIDR:  "172.31.0.0/16",
+		NetworkType:  "OpenShiftSDN",
+		GlobalConfig: config,
+	}
+	objs := guestConfigObjects(params)
+	var keys []string
+	for _, obj := range objs {
+		keys = append(keys, GuestConfigKey(obj))
+	}
+	if diff := cmp.Diff([]string{"apiserver.yaml", "network.yaml", "proxy.yaml"}, keys); diff != "" {
+		t.Fatalf("unexpected guest config objects (-want +got): %s", diff)
+	}
+	network := objs[1].(*configv1.Network)
+	if diff := cmp.Diff([]string{"172.31.0.0/16"}, network.Spec.ServiceNetwork); diff != "" {
+		t.Errorf("expected the cluster service network to take precedence (-want +got): %s", diff)
+	}
+	if network.Spec.ExternalIP == nil || len(network.Spec.ExternalIP.AutoAssignCIDRs) != 1 {
+		t.Errorf("expected the external ip configuration to be preserved, got %v", network.Spec.ExternalIP)
+	}
+	if config.Network.Spec.ServiceNetwork[0] != "10.0.0.0/8" {
+		t.Errorf("expected the parsed configuration not to be modified")
+	}
+}
+
+func TestRenderKubeAPIServerGlobalConfig(t *testing.T) {
+	config, errs := hyperconfig.ParseGlobalConfig(rawItems(apiServerItem, networkItem), field.NewPath("items"))
+	if len(errs) > 0 {
+		t.Fatalf("unexpected errors: %v", errs)
+	}
+	params := &KubeAPIServerParams{GlobalConfig: config}
+	ctx := NewKubeAPIServerManifestContext(params)
+	content, err := ctx.substituteParams(params, "kube-apiserver/config.yaml")
+	if err != nil {
+		t.Fatalf("failed to render: %v", err)
+	}
+	rendered := struct {
+		CORSAllowedOrigins []string `json:"corsAllowedOrigins"`
+		ServingInfo        struct {
+			CipherSuites  []string `json:"cipherSuites"`
+			MinTLSVersion string   `json:"minTLSVersion"`
+		} `json:"servingInfo"`
+	}{}
+	if err := yaml.Unmarshal(content, &rendered); err != nil {
+		t.Fatalf("rendered config is not valid yaml: %v\n%s", err, content)
+	}
+	if diff := cmp.Diff([]string{"//127\\.0\\.0\\.1(:|$)", "//localhost(:|$)", "//example\\.com(:|$)"}, rendered.CORSAllowedOrigins); diff != "" {
+		t.Errorf("unexpected cors allowed origins (-want +got): %s", diff)
+	}
+	if rendered.ServingInfo.MinTLSVersion != string(configv1.VersionTLS10) {
+		t.Errorf("expected the tls profile min version, got %s", rendered.ServingInfo.MinTLSVersion)
+	}
+	if diff := cmp.Diff(config.TLSProfile().Ciphers, rendered.ServingInfo.CipherSuites); diff != "" {
+		t.Errorf("unexpected cipher suites (-want +got): %s", diff)
+	}
+}
+
+func TestRenderOpenShiftAPIServerGlobalConfig(t *testing.T) {
+	config, errs := hyperconfig.ParseGlobalConfig(rawItems(imageItem), field.NewPath("items"))
+	if len(errs) > 0 {
+		t.Fatalf("unexpected errors: %v", errs)
+	}
+	params := &ClusterParams{GlobalConfig: config}
+	ctx := newClusterManifestContext(nil, map[string]string{"release": "4.8.0"}, params, nil, nil)
+	type imagePolicyConfig struct {
+		ImagePolicyConfig map[string]interface{} `json:"imagePolicyConfig"`
+	}
+	content, err := ctx.substituteParams(params, "openshift-apiserver/config.yaml")
+	if err != nil {
+		t.Fatalf("failed to render: %v", err)
+	}
+	rendered := struct {
+		ImagePolicyConfig openshiftcontrolplanev1.ImagePolicyConfig `json:"imagePolicyConfig"`
+	}{}
+	if err := yaml.Unmarshal(content, &rendered); err != nil {
+		t.Fatalf("rendered config is not valid yaml: %v\n%s", err, content)
+	}
+	expectedRegistries := openshiftcontrolplanev1.AllowedRegistries{
+		{DomainName: "quay.io"},
+		{DomainName: "registry.example.com:5000", Insecure: true},
+	}
+	if diff := cmp.Diff(expectedRegistries, rendered.ImagePolicyConfig.AllowedRegistriesForImport); diff != "" {
+		t.Errorf("unexpected allowed registries for import (-want +got): %s", diff)
+	}
+	if diff := cmp.Diff([]string{"registry.apps.example.com"}, rendered.ImagePolicyConfig.ExternalRegistryHostnames); diff != "" {
+		t.Errorf("unexpected external registry hostnames (-want +got): %s", diff)
+	}
+
+	content, err = ctx.substituteParams(params, "kube-apiserver/config.yaml")
+	if err != nil {
+		t.Fatalf("failed to render: %v", err)
+	}
+	kasConfig := imagePolicyConfig{}
+	if err := yaml.Unmarshal(content, &kasConfig); err != nil {
+		t.Fatalf("rendered config is not valid yaml: %v\n%s", err, content)
+	}
+	if _, hasRegistries := kasConfig.ImagePolicyConfig["allowedRegistriesForImport"]; hasRegistries {
+		t.Errorf("expected no allowed registries for import in the kube-apiserver config")
+	}
+
+	if len(config.OpenShiftAPIServerConfigHash()) == 0 {
+		t.Errorf("expected an openshift apiserver config hash from the image configuration")
+	}
+	if hash := config.OpenShiftControllerManagerConfigHash(); len(hash) != 0 {
+		t.Errorf("expected no openshift controller manager config hash without build configuration, got %s", hash)
+	}
+}
+
+func TestRenderOpenShiftControllerManagerBuildConfig(t *testing.T) {
+	config, errs := hyperconfig.ParseGlobalConfig(rawItems(buildItem), field.NewPath("items"))
+	if len(errs) > 0 {
+		t.Fatalf("unexpected errors: %v", errs)
+	}
+	params := &ClusterParams{GlobalConfig: config}
+	ctx := newClusterManifestContext(nil, map[string]string{"release": "4.8.0"}, params, nil, nil)
+	content, err := ctx.substituteParams(params, "openshift-controller-manager/config.yaml")
+	if err != nil {
+		t.Fatalf("failed to render: %v", err)
+	}
+	rendered := openshiftcontrolplanev1.OpenShiftControllerManagerConfig{}
+	if err := yaml.Unmarshal(content, &rendered); err != nil {
+		t.Fatalf("rendered config is not valid yaml: %v\n%s", err, content)
+	}
+	defaults := rendered.Build.BuildDefaults
+	if defaults == nil || defaults.GitHTTPProxy != "http://proxy.example.com:3128" {
+		t.Fatalf("expected the default proxy to be the git proxy, got %v", defaults)
+	}
+	expectedEnv := []corev1.EnvVar{
+		{Name: "HTTP_PROXY", Value: "http://proxy.example.com:3128"},
+		{Name: "http_proxy", Value: "http://proxy.example.com:3128"},
+		{Name: "FOO", Value: "bar"},
+	}
+	if diff := cmp.Diff(expectedEnv, defaults.Env); diff != "" {
+		t.Errorf("unexpected build env (-want +got): %s", diff)
+	}
+	if diff := cmp.Diff([]buildv1.ImageLabel{{Name: "vendor", Value: "example"}}, defaults.ImageLabels); diff != "" {
+		t.Errorf("unexpected build image labels (-want +got): %s", diff)
+	}
+	overrides := rendered.Build.BuildOverrides
+	if overrides == nil || overrides.ForcePull == nil || !*overrides.ForcePull {
+		t.Fatalf("expected builds to force pulls, got %v", overrides)
+	}
+	if diff := cmp.Diff(map[string]string{"node-role.kubernetes.io/builder": ""}, overrides.NodeSelector); diff != "" {
+		t.Errorf("unexpected build node selector (-want +got): %s", diff)
+	}
+	if len(config.OpenShiftControllerManagerConfigHash()) == 0 {
+		t.Errorf("expected an openshift controller manager config hash from the build configuration")
+	}
+}
@@ -0,0 +1,329 @@
+package render
+
+import (
+	"crypto/md5"
+	"encoding/json"
+	"fmt"
+	"path"
+	"strings"
+
+	configv1 "github.com/openshift/api/config/v1"
+	osinv1 "github.com/openshift/api/osin/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"sigs.k8s.io/yaml"
+
+	hyperconfig "github.com/openshift/hypershift/support/config"
+)
+
+const (
+	// identityProviderMountPath is the directory under which the secrets and
+	// config maps referenced by identity providers are mounted in the oauth
+	// server.
+	identityProviderMountPath = "/etc/oauth-openshift-idp"
+
+	// caKey, tlsCertKey and tlsKeyKey are the keys of CA bundles and client
+	// certificates referenced by identity providers.
+	caKey      = "ca.crt"
+	tlsCertKey = "tls.crt"
+	tlsKeyKey  = "tls.key"
+)
+
+var osinScheme = runtime.NewScheme()
+
+func init() {
+	utilruntime.Must(osinv1.Install(osinScheme))
+}
+
+// IdentityProviderReference is a secret or config map referenced by an
+// identity provider.
+type IdentityProviderReference struct {
+	// ConfigMap is true when the reference is to a config map rather than to
+	// a secret.
+	ConfigMap bool
+	// Name points to the name of the referenced object in the identity
+	// provider, so that the reference can be rewritten.
+	Name *string
+	// Key is the key the referenced object must contain.
+	Key string
+	// Field is the name of the identity provider field holding the reference.
+	Field string
+	// Path is the path of the reference relative to the identity provider.
+	Path string
+}
+
+// IdentityProviderReferences returns the secrets and config maps referenced by
+// the given identity provider. Optional references which are not set are
+// omitted.
+func IdentityProviderReferences(idp *configv1.IdentityProvider) []IdentityProviderReference {
+	var refs []IdentityProviderReference
+	configPath := hyperconfig.IdentityProviderConfigPath(idp.Type)
+	secret := func(field string, ref *configv1.SecretNameReference, key string) {
+		if len(ref.Name) > 0 {
+			refs = append(refs, IdentityProviderReference{Name: &ref.Name, Key: key, Field: field, Path: configPath + "." + field})
+		}
+	}
+	configMap := func(field string, ref *configv1.ConfigMapNameReference, key string) {
+		if len(ref.Name) > 0 {
+			refs = append(refs, IdentityProviderReference{ConfigMap: true, Name: &ref.Name, Key: key, Field: field, Path: configPath + "." + field})
+		}
+	}
+	remoteConnection := func(info *configv1.OAuthRemoteConnectionInfo) {
+		configMap("ca", &info.CA, caKey)
+		secret("tlsClientCert", &info.TLSClientCert, tlsCertKey)
+		secret("tlsClientKey", &info.TLSClientKey, tlsKeyKey)
+	}
+	switch idp.Type {
+	case configv1.IdentityProviderTypeBasicAuth:
+		if idp.BasicAuth != nil {
+			remoteConnection(&idp.BasicAuth.OAuthRemoteConnectionInfo)
+		}
+	case configv1.IdentityProviderTypeGitHub:
+		if idp.GitHub != nil {
+			secret("clientSecret", &idp.GitHub.ClientSecret, configv1.ClientSecretKey)
+			configMap("ca", &idp.GitHub.CA, caKey)
+		}
+	case configv1.IdentityProviderTypeGitLab:
+		if idp.GitLab != nil {
+			secret("clientSecret", &idp.GitLab.ClientSecret, configv1.ClientSecretKey)
+			configMap("ca", &idp.GitLab.CA, caKey)
+		}
+	case configv1.IdentityProviderTypeGoogle:
+		if idp.Google != nil {
+			secret("clientSecret", &idp.Google.ClientSecret, configv1.ClientSecretKey)
+		}
+	case configv1.IdentityProviderTypeHTPasswd:
+		if idp.HTPasswd != nil {
+			secret("fileData", &idp.HTPasswd.FileData, configv1.HTPasswdDataKey)
+		}
+	case configv1.IdentityProviderTypeKeystone:
+		if idp.Keystone != nil {
+			remoteConnection(&idp.Keystone.OAuthRemoteConnectionInfo)
+		}
+	case configv1.IdentityProviderTypeLDAP:
+		if idp.LDAP != nil {
+			secret("bindPassword", &idp.LDAP.BindPassword, configv1.BindPasswordKey)
+			configMap("ca", &idp.LDAP.CA, caKey)
+		}
+	case configv1.IdentityProviderTypeOpenID:
+		if idp.OpenID != nil {
+			secret("clientSecret", &idp.OpenID.ClientSecret, configv1.ClientSecretKey)
+			configMap("ca", &idp.OpenID.CA, caKey)
+		}
+	case configv1.IdentityProviderTypeRequestHeader:
+		if idp.RequestHeader != nil {
+			configMap("ca", &idp.RequestHeader.ClientCA, caKey)
+		}
+	}
+	return refs
+}
+
+// IdentityProviderObjectName is the name of the object in the control plane
+// namespace which holds the data of the given reference of the identity
+// provider at the given index.
+func IdentityProviderObjectName(index int, ref IdentityProviderReference) string {
+	return fmt.Sprintf("idp-%d-%s", index, strings.ToLower(ref.Field))
+}
+
+// IdentityProviderVolume is a secret or config map referenced by an identity
+// provider which is mounted in the oauth server.
+type IdentityProviderVolume struct {
+	Name          string
+	SecretName    string
+	ConfigMapName string
+	MountPath     string
+}
+
+// OAuthServerIdentityProviders converts the identity providers of a cluster,
+// whose references have been resolved to objects in the control plane
+// namespace, to the identity providers of the oauth server configuration. It
+// returns their serialized form and the volumes holding the data they
+// reference. OpenID providers require the URLs discovered from their issuer,
+// keyed by identity provider name.
+func OAuthServerIdentityProviders(idps []configv1.IdentityProvider, openIDURLs map[string]osinv1.OpenIDURLs) (string, []IdentityProviderVolume, error) {
+	if len(idps) == 0 {
+		return "", nil, nil
+	}
+	var volumes []IdentityProviderVolume
+	var result []osinv1.IdentityProvider
+	for i := range idps {
+		idp := &idps[i]
+		files := map[string]string{}
+		for _, ref := range IdentityProviderReferences(idp) {
+			volume := IdentityProviderVolume{
+				Name:      IdentityProviderObjectName(i, ref),
+				MountPath: path.Join(identityProviderMountPath, IdentityProviderObjectName(i, ref)),
+			}
+			if ref.ConfigMap {
+				volume.ConfigMapName = *ref.Name
+			} else {
+				volume.SecretName = *ref.Name
+			}
+			volumes = append(volumes, volume)
+			files[ref.Field] = path.Join(volume.MountPath, ref.Key)
+		}
+
+		provider, challenge, login, err := osinIdentityProvider(idp, files, openIDURLs)
+		if err != nil {
+			return "", nil, fmt.Errorf("failed to convert identity provider %s: %w", idp.Name, err)
+		}
+		raw, err := json.Marshal(provider)
+		if err != nil {
+			return "", nil, fmt.Errorf("failed to serialize identity provider %s: %w", idp.Name, err)
+		}
+		mappingMethod := idp.MappingMethod
+		if len(mappingMethod) == 0 {
+			mappingMethod = configv1.MappingMethodClaim
+		}
+		result = append(result, osinv1.IdentityProvider{
+			Name:            idp.Name,
+			UseAsChallenger: challenge,
+			UseAsLogin:      login,
+			MappingMethod:   string(mappingMethod),
+			Provider:        runtime.RawExtension{Raw: raw},
+		})
+	}
+	out, err := yaml.Marshal(result)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to serialize identity providers: %w", err)
+	}
+	return string(out), volumes, nil
+}
+
+// osinIdentityProvider returns the oauth server provider configuration of an
+// identity provider, and whether it should be used for challenges and logins.
+// The files map holds the path of the data of each reference by field name.
+func osinIdentityProvider(idp *configv1.IdentityProvider, files map[string]string, openIDURLs map[string]osinv1.OpenIDURLs) (runtime.Object, bool, bool, error) {
+	remoteConnection := func(info *configv1.OAuthRemoteConnectionInfo) configv1.RemoteConnectionInfo {
+		return configv1.RemoteConnectionInfo{
+			URL: info.URL,
+			CA:  files["ca"],
+			CertInfo: configv1.CertInfo{
+				CertFile: files["tlsClientCert"],
+				KeyFile:  files["tlsClientKey"],
+			},
+		}
+	}
+	fileSource := func(file string) configv1.StringSource {
+		return configv1.StringSource{StringSourceSpec: configv1.StringSourceSpec{File: file}}
+	}
+	var provider runtime.Object
+	challenge, login := true, true
+	switch idp.Type {
+	case configv1.IdentityProviderTypeBasicAuth:
+		provider = &osinv1.BasicAuthPasswordIdentityProvider{
+			RemoteConnectionInfo: remoteConnection(&idp.BasicAuth.OAuthRemoteConnectionInfo),
+		}
+	case configv1.IdentityProviderTypeGitHub:
+		provider = &osinv1.GitHubIdentityProvider{
+			ClientID:      idp.GitHub.ClientID,
+			ClientSecret:  fileSource(files["clientSecret"]),
+			Organizations: idp.GitHub.Organizations,
+			Teams:         idp.GitHub.Teams,
+			Hostname:      idp.GitHub.Hostname,
+			CA:            files["ca"],
+		}
+		challenge = false
+	case configv1.IdentityProviderTypeGitLab:
+		legacy := false
+		provider = &osinv1.GitLabIdentityProvider{
+			CA:           files["ca"],
+			URL:          idp.GitLab.URL,
+			ClientID:     idp.GitLab.ClientID,
+			ClientSecret: fileSource(files["clientSecret"]),
+			Legacy:       &legacy,
+		}
+	case configv1.IdentityProviderTypeGoogle:
+		provider = &osinv1.GoogleIdentityProvider{
+			ClientID:     idp.Google.ClientID,
+			ClientSecret: fileSource(files["clientSecret"]),
+			HostedDomain: idp.Google.HostedDomain,
+		}
+		challenge = false
+	case configv1.IdentityProviderTypeHTPasswd:
+		provider = &osinv1.HTPasswdPasswordIdentityProvider{
+			File: files["fileData"],
+		}
+	case configv1.IdentityProviderTypeKeystone:
+		provider = &osinv1.KeystonePasswordIdentityProvider{
+			RemoteConnectionInfo: remoteConnection(&idp.Keystone.OAuthRemoteConnectionInfo),
+			DomainName:           idp.Keystone.DomainName,
+			UseKeystoneIdentity:  true,
+		}
+	case configv1.IdentityProviderTypeLDAP:
+		ldap := &osinv1.LDAPPasswordIdentityProvider{
+			URL:      idp.LDAP.URL,
+			BindDN:   idp.LDAP.BindDN,
+			Insecure: idp.LDAP.Insecure,
+			CA:       files["ca"],
+			Attributes: osinv1.LDAPAttributeMapping{
+				ID:                idp.LDAP.Attributes.ID,
+				PreferredUsername: idp.LDAP.Attributes.PreferredUsername,
+				Name:              idp.LDAP.Attributes.Name,
+				Email:             idp.LDAP.Attributes.Email,
+			},
+		}
+		if file, ok := files["bindPassword"]; ok {
+			ldap.BindPassword = fileSource(file)
+		}
+		provider = ldap
+	case configv1.IdentityProviderTypeOpenID:
+		urls, ok := openIDURLs[idp.Name]
+		if !ok {
+			return nil, false, false, fmt.Errorf("the endpoints of issuer %s are not known", idp.OpenID.Issuer)
+		}
+		provider = &osinv1.OpenIDIdentityProvider{
+			CA:                       files["ca"],
+			ClientID:                 idp.OpenID.ClientID,
+			ClientSecret:             fileSource(files["clientSecret"]),
+			ExtraScopes:              idp.OpenID.ExtraScopes,
+			ExtraAuthorizeParameters: idp.OpenID.ExtraAuthorizeParameters,
+			URLs:                     urls,
+			Claims: osinv1.OpenIDClaims{
+				ID:                []string{"sub"},
+				PreferredUsername: idp.OpenID.Claims.PreferredUsername,
+				Name:              idp.OpenID.Claims.Name,
+				Email:             idp.OpenID.Claims.Email,
+			},
+		}
+		challenge = false
+	case configv1.IdentityProviderTypeRequestHeader:
+		provider = &osinv1.RequestHeaderIdentityProvider{
+			LoginURL:                 idp.RequestHeader.LoginURL,
+			ChallengeURL:             idp.RequestHeader.ChallengeURL,
+			ClientCA:                 files["ca"],
+			ClientCommonNames:        idp.RequestHeader.ClientCommonNames,
+			Headers:                  idp.RequestHeader.Headers,
+			PreferredUsernameHeaders: idp.RequestHeader.PreferredUsernameHeaders,
+			NameHeaders:              idp.RequestHeader.NameHeaders,
+			EmailHeaders:             idp.RequestHeader.EmailHeaders,
+		}
+		challenge = len(idp.RequestHeader.ChallengeURL) > 0
+		login = len(idp.RequestHeader.LoginURL) > 0
+	default:
+		return nil, false, false, fmt.Errorf("unsupported identity provider type %s", idp.Type)
+	}
+	gvks, _, err := osinScheme.ObjectKinds(provider)
+	if err != nil {
+		return nil, false, false, err
+	}
+	provider.GetObjectKind().SetGroupVersionKind(gvks[0])
+	return provider, challenge, login, nil
+}
+
+// IdentityProvidersHash returns a hash of the oauth server identity provider
+// configuration and of the data it references, keyed by object name, or an
+// empty string without identity providers.
+func IdentityProvidersHash(identityProviders string, data map[string][]byte) string {
+	if len(identityProviders) == 0 {
+		return ""
+	}
+	b, err := json.Marshal(struct {
+		IdentityProviders string
+		Data              map[string][]byte
+	}{identityProviders, data})
+	if err != nil {
+		return ""
+	}
+	return fmt.Sprintf("%x", md5.Sum(b))
+}
@@ -0,0 +1,165 @@
+package render
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	configv1 "github.com/openshift/api/config/v1"
+	osinv1 "github.com/openshift/api/osin/v1"
+	appsv1 "k8s.io/api/apps/v1"
+	"sigs.k8s.io/yaml"
+)
+
+func htpasswdIdentityProvider(name, secretName string) configv1.IdentityProvider {
+	return configv1.IdentityProvider{
+		Name: name,
+		IdentityProviderConfig: configv1.IdentityProviderConfig{
+			Type:     configv1.IdentityProviderTypeHTPasswd,
+			HTPasswd: &configv1.HTPasswdIdentityProvider{FileData: configv1.SecretNameReference{Name: secretName}},
+		},
+	}
+}
+
+func ldapIdentityProvider(name string) configv1.IdentityProvider {
+	return configv1.IdentityProvider{
+		Name: name,
+		IdentityProviderConfig: configv1.IdentityProviderConfig{
+			Type: configv1.IdentityProviderTypeLDAP,
+			LDAP: &configv1.LDAPIdentityProvider{
+				URL:          "ldaps://ldap.example.com/ou=users,dc=example,dc=com?uid",
+				BindDN:       "cn=admin,dc=example,dc=com",
+				BindPassword: configv1.SecretNameReference{Name: "ldap-bind"},
+				CA:           configv1.ConfigMapNameReference{Name: "ldap-ca"},
+				Attributes:   configv1.LDAPAttributeMapping{ID: []string{"dn"}, PreferredUsername: []string{"uid"}},
+			},
+		},
+	}
+}
+
+func TestOAuthServerIdentityProviders(t *testing.T) {
+	idps := []configv1.IdentityProvider{htpasswdIdentityProvider("htpasswd", "idp-0-filedata"), ldapIdentityProvider("ldap")}
+	identityProviders, volumes, err := OAuthServerIdentityProviders(idps, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expectedVolumes := []IdentityProviderVolume{
+		{Name: "idp-0-filedata", SecretName: "idp-0-filedata", MountPath: "/etc/oauth-openshift-idp/idp-0-filedata"},
+		{Name: "idp-1-bindpassword", SecretName: "ldap-bind", MountPath: "/etc/oauth-openshift-idp/idp-1-bindpassword"},
+		{Name: "idp-1-ca", ConfigMapName: "ldap-ca", MountPath: "/etc/oauth-openshift-idp/idp-1-ca"},
+	}
+	if diff := cmp.Diff(expectedVolumes, volumes); diff != "" {
+		t.Errorf("unexpected volumes (-want +got): %s", diff)
+	}
+
+	var providers []osinv1.IdentityProvider
+	if err := yaml.Unmarshal([]byte(identityProviders), &providers); err != nil {
+		t.Fatalf("identity providers are not valid yaml: %v\n%s", err, identityProviders)
+	}
+	if len(providers) != 2 {
+		t.Fatalf("expected 2 identity providers, got %d", len(providers))
+	}
+	if providers[0].MappingMethod != string(configv1.MappingMethodClaim) {
+		t.Errorf("expected the claim mapping method by default, got %q", providers[0].MappingMethod)
+	}
+	htpasswd := osinv1.HTPasswdPasswordIdentityProvider{}
+	if err := yaml.Unmarshal(providers[0].Provider.Raw, &htpasswd); err != nil {
+		t.Fatalf("invalid htpasswd provider: %v", err)
+	}
+	if htpasswd.Kind != "HTPasswdPasswordIdentityProvider" {
+		t.Errorf("unexpected htpasswd provider kind %q", htpasswd.Kind)
+	}
+	if htpasswd.File != "/etc/oauth-openshift-idp/idp-0-filedata/htpasswd" {
+		t.Errorf("unexpected htpasswd file %q", htpasswd.File)
+	}
+	ldap := osinv1.LDAPPasswordIdentityProvider{}
+	if err := yaml.Unmarshal(providers[1].Provider.Raw, &ldap); err != nil {
+		t.Fatalf("invalid ldap provider: %v", err)
+	}
+	if ldap.CA != "/etc/oauth-openshift-idp/idp-1-ca/ca.crt" {
+		t.Errorf("unexpected ldap ca %q", ldap.CA)
+	}
+	if ldap.BindPassword.File != "/etc/oauth-openshift-idp/idp-1-bindpassword/bindPassword" {
+		t.Errorf("unexpected ldap bind password file %q", ldap.BindPassword.File)
+	}
+}
+
+func TestOAuthServerIdentityProvidersRequireOpenIDURLs(t *testing.T) {
+	idps := []configv1.IdentityProvider{{
+		Name: "openid",
+		IdentityProviderConfig: configv1.IdentityProviderConfig{
+			Type: configv1.IdentityProviderTypeOpenID,
+			OpenID: &configv1.OpenIDIdentityProvider{
+				ClientID:     "id",
+				ClientSecret: configv1.SecretNameReference{Name: "secret"},
+				Issuer:       "https://issuer.example.com",
+			},
+		},
+	}}
+	if _, _, err := OAuthServerIdentityProviders(idps, nil); err == nil {
+		t.Errorf("expected an error without the discovered urls")
+	}
+	urls := map[string]osinv1.OpenIDURLs{"openid": {Authorize: "https://issuer.example.com/auth", Token: "https://issuer.example.com/token"}}
+	identityProviders, _, err := OAuthServerIdentityProviders(idps, urls)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.Contains(identityProviders, "https://issuer.example.com/token") {
+		t.Errorf("expected the discovered urls in the identity providers:\n%s", identityProviders)
+	}
+}
+
+func TestRenderOAuthServerIdentityProviders(t *testing.T) {
+	idps := []configv1.IdentityProvider{htpasswdIdentityProvider("htpasswd", "idp-0-filedata"), ldapIdentityProvider("ldap")}
+	identityProviders, volumes, err := OAuthServerIdentityProviders(idps, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	params := &ClusterParams{
+		IdentityProviders:       identityProviders,
+		IdentityProviderVolumes: volumes,
+		IdentityProvidersHash:   IdentityProvidersHash(identityProviders, map[string][]byte{"secret/idp-0-filedata": []byte("user:password")}),
+	}
+	ctx := newClusterManifestContext(nil, map[string]string{"release": "4.8.0"}, params, nil, nil)
+
+	content, err := ctx.substituteParams(params, "oauth-openshift/oauth-server-config.yaml")
+	if err != nil {
+		t.Fatalf("failed to render config: %v", err)
+	}
+	config := osinv1.OsinServerConfig{}
+	if err := yaml.Unmarshal(content, &config); err != nil {
+		t.Fatalf("rendered config is not valid yaml: %v\n%s", err, content)
+	}
+	if len(config.OAuthConfig.IdentityProviders) != 2 {
+		t.Errorf("expected 2 identity providers, got %d", len(config.OAuthConfig.IdentityProviders))
+	}
+
+	content, err = ctx.substituteParams(params, "oauth-openshift/oauth-server-deployment.yaml")
+	if err != nil {
+		t.Fatalf("failed to render deployment: %v", err)
+	}
+	deployment := appsv1.Deployment{}
+	if err := yaml.Unmarshal(content, &deployment); err != nil {
+		t.Fatalf("rendered deployment is not valid yaml: %v\n%s", err, content)
+	}
+	if deployment.Spec.Template.Annotations["hypershift.openshift.io/identity-providers-hash"] != params.IdentityProvidersHash {
+		t.Errorf("expected the identity providers hash annotation, got %v", deployment.Spec.Template.Annotations)
+	}
+	volumeNames := map[string]bool{}
+	for _, volume := range deployment.Spec.Template.Spec.Volumes {
+		volumeNames[volume.Name] = true
+	}
+	mountPaths := map[string]bool{}
+	for _, mount := range deployment.Spec.Template.Spec.Containers[0].VolumeMounts {
+		mountPaths[mount.MountPath] = true
+	}
+	for _, volume := range volumes {
+		if !volumeNames[volume.Name] {
+			t.Errorf("expected volume %s in the deployment", volume.Name)
+		}
+		if !mountPaths[volume.MountPath] {
+			t.Errorf("expected a mount at %s in the deployment", volume.MountPath)
+		}
+	}
+}
@@ -0,0 +1,47 @@
+package render
+
+import (
+	operatorv1alpha1 "github.com/openshift/api/operator/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/yaml"
+)
+
+// ImageContentSourcePolicyName is the name of the ImageContentSourcePolicy
+// which configures the registry mirrors of the guest cluster.
+const ImageContentSourcePolicyName = "image-content-sources"
+
+// imageContentSources configures the registry mirrors of the guest cluster,
+// both as a user manifest and as an ignition config from which the machine
+// config server renders the registries configuration of the nodes.
+func (c *clusterManifestContext) imageContentSources() {
+	policy := imageContentSourcePolicy(c.params.(*ClusterParams))
+	if policy == nil {
+		return
+	}
+	content, err := yaml.Marshal(policy)
+	if err != nil {
+		panic(err.Error())
+	}
+	c.addUserManifest("image-content-source-policy.yaml", string(content))
+	c.addIgnitionConfig("image-content-source-policy.yaml", content)
+}
+
+// imageContentSourcePolicy returns the ImageContentSourcePolicy of the guest
+// cluster, or nil when it has no registry mirrors.
+func imageContentSourcePolicy(p *ClusterParams) *operatorv1alpha1.ImageContentSourcePolicy {
+	if len(p.ImageContentSources) == 0 {
+		return nil
+	}
+	return &operatorv1alpha1.ImageContentSourcePolicy{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: operatorv1alpha1.GroupVersion.String(),
+			Kind:       "ImageContentSourcePolicy",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name: ImageContentSourcePolicyName,
+		},
+		Spec: operatorv1alpha1.ImageContentSourcePolicySpec{
+			RepositoryDigestMirrors: p.ImageContentSources,
+		},
+	}
+}
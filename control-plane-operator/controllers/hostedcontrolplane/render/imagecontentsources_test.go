@@ -0,0 +1,66 @@
+package render
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	operatorv1alpha1 "github.com/openshift/api/operator/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/yaml"
+)
+
+func TestImageContentSourcesManifests(t *testing.T) {
+	mirrors := []operatorv1alpha1.RepositoryDigestMirrors{
+		{Source: "quay.io/openshift-release-dev/ocp-release", Mirrors: []string{"mirror.example.com/ocp/release"}},
+		{Source: "quay.io/openshift-release-dev/ocp-v4.0-art-dev", Mirrors: []string{"mirror.example.com/ocp/art-dev", "backup.example.com/ocp/art-dev"}},
+	}
+	tests := map[string]struct {
+		ImageContentSources []operatorv1alpha1.RepositoryDigestMirrors
+		ExpectedManifests   bool
+	}{
+		"no registry mirrors": {},
+		"registry mirrors": {
+			ImageContentSources: mirrors,
+			ExpectedManifests:   true,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			params := &ClusterParams{ImageContentSources: test.ImageContentSources}
+			ctx := newClusterManifestContext(nil, map[string]string{"release": "4.8.0"}, params, nil, nil)
+			ctx.imageContentSources()
+			manifests, err := ctx.renderManifests()
+			if err != nil {
+				t.Fatalf("failed to render: %v", err)
+			}
+			userManifest, hasUserManifest := ctx.userManifests["image-content-source-policy.yaml"]
+			ignitionConfig, hasIgnitionConfig := manifests["ignition-config-image-content-source-policy.yaml"]
+			if hasUserManifest != test.ExpectedManifests || hasIgnitionConfig != test.ExpectedManifests {
+				t.Fatalf("expected the image content source policy manifests to be rendered to be %t, got user manifest %t and ignition config %t", test.ExpectedManifests, hasUserManifest, hasIgnitionConfig)
+			}
+			if !test.ExpectedManifests {
+				return
+			}
+
+			configMap := corev1.ConfigMap{}
+			if err := yaml.Unmarshal(ignitionConfig, &configMap); err != nil {
+				t.Fatalf("ignition config is not valid yaml: %v", err)
+			}
+			if configMap.Labels["ignition-config"] != "true" {
+				t.Errorf("expected the ignition config to be labeled, got %v", configMap.Labels)
+			}
+			for source, content := range map[string]string{"user manifest": userManifest, "ignition config": configMap.Data["data"]} {
+				policy := operatorv1alpha1.ImageContentSourcePolicy{}
+				if err := yaml.Unmarshal([]byte(content), &policy); err != nil {
+					t.Fatalf("%s is not valid yaml: %v", source, err)
+				}
+				if policy.Kind != "ImageContentSourcePolicy" || policy.Name != ImageContentSourcePolicyName {
+					t.Errorf("unexpected %s %s %s", source, policy.Kind, policy.Name)
+				}
+				if diff := cmp.Diff(mirrors, policy.Spec.RepositoryDigestMirrors); diff != "" {
+					t.Errorf("unexpected mirrors in %s (-want +got): %s", source, diff)
+				}
+			}
+		})
+	}
+}
@@ -1,33 +1,46 @@
 package render
 
-import "text/template"
+import (
+	"strings"
+	"text/template"
+
+	hyperconfig "github.com/openshift/hypershift/support/config"
+)
 
 type KubeAPIServerParams struct {
-	PodCIDR               string
-	ServiceCIDR           string
-	ExternalAPIAddress    string
-	APIServerAuditEnabled bool
-	CloudProvider         string
-	EtcdClientName        string
-	DefaultFeatureGates   []string
-	ExtraFeatureGates     []string
-	InfraID               string
-	IngressSubdomain      string
-	IssuerURL             string
-	InternalAPIPort       uint
-	NamedCerts            []NamedCert
-	PKI                   map[string][]byte
-	APIAvailabilityPolicy KubeAPIServerParamsAvailabilityPolicy
-	ClusterID             string
-	Images                map[string]string
-	ApiserverLivenessPath string
-	APINodePort           uint
-	ExternalOauthPort     uint
-	ExternalOauthDNSName  string
-	AWSZone               string
-	AWSVPCID              string
-	AWSRegion             string
-	AWSSubnetID           string
+	PodCIDR                string
+	ServiceCIDR            string
+	ClusterCIDRs           []string
+	ServiceCIDRs           []string
+	ExternalAPIAddress     string
+	APIServerAuditEnabled  bool
+	CloudProvider          string
+	EtcdClientName         string
+	DefaultFeatureGates    []string
+	ExtraFeatureGates      []string
+	InfraID                string
+	IngressSubdomain       string
+	IssuerURL              string
+	InternalAPIPort        uint
+	NamedCerts             []NamedCert
+	PKI                    map[string][]byte
+	APIAvailabilityPolicy  KubeAPIServerParamsAvailabilityPolicy
+	ClusterID              string
+	Images                 map[string]string
+	ApiserverLivenessPath  string
+	APINodePort            uint
+	ExternalOauthPort      uint
+	ExternalOauthDNSName   string
+	AWSZone                string
+	AWSVPCID               string
+	AWSRegion              string
+	AWSSubnetID            string
+	GlobalConfig           hyperconfig.GlobalConfig
+	KubeAPIServerResources []ResourceRequirements
+	// NodeConnectivity is the data path to the guest cluster, either OpenVPN
+	// or Konnectivity. It decides whether the OpenVPN client or the
+	// Konnectivity server runs next to the Kube API server.
+	NodeConnectivity string
 }
 
 type KubeAPIServerParamsAvailabilityPolicy string
@@ -53,19 +66,24 @@ func NewKubeAPIServerManifestContext(params *KubeAPIServerParams) *kubeAPIServer
 		"include_pki": includePKIFunc(params.PKI),
 		"imageFor":    imageFunc(params.Images),
 		"include":     includeFileFunc(params, ctx.renderContext),
+		"join":        strings.Join,
 	})
 	ctx.addManifestFiles(
 		"kube-apiserver/kube-apiserver-deployment.yaml",
 		"kube-apiserver/kube-apiserver-service.yaml",
 		"kube-apiserver/kube-apiserver-config-configmap.yaml",
 		"kube-apiserver/kube-apiserver-oauth-metadata-configmap.yaml",
-		"kube-apiserver/kube-apiserver-vpnclient-config.yaml",
 		"kube-apiserver/kube-apiserver-secret.yaml",
 		"kube-apiserver/kube-apiserver-configmap.yaml",
-		"kube-apiserver/kube-apiserver-vpnclient-secret.yaml",
 		"kube-apiserver/kube-apiserver-default-audit-policy.yaml",
 		"kube-apiserver/kube-apiserver-localhost-kubeconfig-secret.yaml",
 	)
+	if params.NodeConnectivity != Konnectivity {
+		ctx.addManifestFiles(
+			"kube-apiserver/kube-apiserver-vpnclient-config.yaml",
+			"kube-apiserver/kube-apiserver-vpnclient-secret.yaml",
+		)
+	}
 	return ctx
 }
 
@@ -7,6 +7,10 @@ import (
 	"strings"
 	"text/template"
 
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/yaml"
+
 	"github.com/openshift/hypershift/control-plane-operator/controllers/hostedcontrolplane/assets"
 	"github.com/openshift/hypershift/control-plane-operator/releaseinfo"
 )
@@ -33,24 +37,27 @@ func newClusterManifestContext(images, versions map[string]string, params interf
 		userManifests: make(map[string]string),
 	}
 	ctx.setFuncs(template.FuncMap{
-		"version":           versionFunc(versions),
-		"imageFor":          imageFunc(images),
-		"base64String":      base64StringEncode,
-		"indent":            indent,
-		"address":           cidrAddress,
-		"mask":              cidrMask,
-		"include":           includeFileFunc(params, ctx.renderContext),
-		"includeVPN":        includeVPNFunc(true),
-		"dataURLEncode":     dataURLEncode(params, ctx.renderContext),
-		"randomString":      randomString,
-		"includeData":       includeDataFunc(),
-		"trimTrailingSpace": trimTrailingSpace,
-		"pki":               pkiFunc(pki),
-		"include_pki":       includePKIFunc(pki),
-		"pullSecretBase64":  pullSecretBase64(pullSecret),
-		"atleast_version":   atLeastVersionFunc(versions),
-		"lessthan_version":  lessThanVersionFunc(versions),
-		"ini_value":         iniValue,
+		"version":              versionFunc(versions),
+		"imageFor":             imageFunc(images),
+		"base64String":         base64StringEncode,
+		"indent":               indent,
+		"address":              cidrAddress,
+		"mask":                 cidrMask,
+		"isIPv6":               isIPv6CIDR,
+		"join":                 strings.Join,
+		"include":              includeFileFunc(params, ctx.renderContext),
+		"includeVPN":           includeVPNFunc(true),
+		"dataURLEncode":        dataURLEncode(params, ctx.renderContext),
+		"randomString":         randomString,
+		"includeData":          includeDataFunc(),
+		"trimTrailingSpace":    trimTrailingSpace,
+		"pki":                  pkiFunc(pki),
+		"include_pki":          includePKIFunc(pki),
+		"include_guest_config": includeGuestConfigFunc(params),
+		"pullSecretBase64":     pullSecretBase64(pullSecret),
+		"atleast_version":      atLeastVersionFunc(versions),
+		"lessthan_version":     lessThanVersionFunc(versions),
+		"ini_value":            iniValue,
 	})
 	return ctx
 }
@@ -66,12 +73,23 @@ func (c *clusterManifestContext) setupManifests() {
 	c.oauthAPIServer()
 	c.openshiftControllerManager()
 	c.clusterBootstrap()
+	if c.params.(*ClusterParams).NetworkType == OVNKubernetes {
+		c.clusterNetworkOperator()
+	}
+	c.globalConfig()
 	c.oauthOpenshiftServer()
-	c.openVPN()
+	if c.params.(*ClusterParams).NodeConnectivity == Konnectivity {
+		c.konnectivity()
+	} else {
+		c.openVPN()
+	}
 	c.registry()
+	c.additionalTrustBundle()
+	c.imageContentSources()
 	c.userManifestsBootstrapper()
 	c.machineConfigServer()
 	c.ignitionConfigs()
+	c.podDisruptionBudgets()
 }
 
 func (c *clusterManifestContext) hostedClusterConfigOperator() {
@@ -117,6 +135,35 @@ func (c *clusterManifestContext) etcd() {
 	}
 }
 
+// podDisruptionBudgets limits voluntary disruptions of the control plane
+// components to one pod at a time, so that draining management cluster nodes
+// keeps highly available components and the etcd quorum available. They do
+// not block disruptions of single replica components.
+func (c *clusterManifestContext) podDisruptionBudgets() {
+	for _, pdb := range []struct{ name, labelKey, labelValue string }{
+		{name: "etcd", labelKey: "etcd_cluster", labelValue: "etcd"},
+		{name: "kube-apiserver", labelKey: "app", labelValue: "kube-apiserver"},
+		{name: "kube-controller-manager", labelKey: "app", labelValue: "kube-controller-manager"},
+		{name: "kube-scheduler", labelKey: "app", labelValue: "kube-scheduler"},
+		{name: "openshift-apiserver", labelKey: "app", labelValue: "openshift-apiserver"},
+		{name: "openshift-oauth-apiserver", labelKey: "app", labelValue: "openshift-oauth-apiserver"},
+		{name: "oauth-openshift", labelKey: "app", labelValue: "oauth-openshift"},
+		{name: "openshift-controller-manager", labelKey: "app", labelValue: "openshift-controller-manager"},
+		{name: "cluster-policy-controller", labelKey: "app", labelValue: "cluster-policy-controller"},
+	} {
+		params := map[string]string{
+			"name":       pdb.name,
+			"labelKey":   pdb.labelKey,
+			"labelValue": pdb.labelValue,
+		}
+		content, err := c.substituteParams(params, "common/pod-disruption-budget.yaml")
+		if err != nil {
+			panic(err.Error())
+		}
+		c.addManifest(pdb.name+"-pdb.yaml", content)
+	}
+}
+
 func (c *clusterManifestContext) oauthOpenshiftServer() {
 	c.addManifestFiles(
 		"oauth-openshift/oauth-browser-client.yaml",
@@ -238,6 +285,20 @@ func (c *clusterManifestContext) clusterVersionOperator() {
 	)
 }
 
+// clusterNetworkOperator runs the cluster network operator of a cluster with
+// the OVNKubernetes network type in the control plane namespace, where it runs
+// ovnkube-master, since the guest cluster has no master nodes. The hosted
+// cluster config operator stops the cluster version operator from running it
+// in the guest cluster.
+func (c *clusterManifestContext) clusterNetworkOperator() {
+	c.addManifestFiles(
+		"cluster-network-operator/cluster-network-operator-serviceaccount.yaml",
+		"cluster-network-operator/cluster-network-operator-role.yaml",
+		"cluster-network-operator/cluster-network-operator-rolebinding.yaml",
+		"cluster-network-operator/cluster-network-operator-deployment.yaml",
+	)
+}
+
 func (c *clusterManifestContext) registry() {
 	c.addUserManifestFiles("registry/cluster-imageregistry-config.yaml")
 }
@@ -252,6 +313,59 @@ func (c *clusterManifestContext) clusterBootstrap() {
 	}
 }
 
+// globalConfig renders the global configuration resources of the guest
+// cluster, both as user manifests applied when the cluster is bootstrapped and
+// as a ConfigMap which the hosted cluster config operator keeps in sync with
+// the guest cluster.
+func (c *clusterManifestContext) globalConfig() {
+	configMap := &corev1.ConfigMap{
+		TypeMeta:   metav1.TypeMeta{APIVersion: "v1", Kind: "ConfigMap"},
+		ObjectMeta: metav1.ObjectMeta{Name: GlobalConfigMapName},
+		Data:       map[string]string{},
+	}
+	for _, obj := range guestConfigObjects(c.params.(*ClusterParams)) {
+		content, err := yaml.Marshal(obj)
+		if err != nil {
+			panic(err.Error())
+		}
+		key := GuestConfigKey(obj)
+		c.addUserManifest(fmt.Sprintf("cluster-%s-02-config.yaml", strings.TrimSuffix(key, ".yaml")), string(content))
+		configMap.Data[key] = string(content)
+	}
+	content, err := yaml.Marshal(configMap)
+	if err != nil {
+		panic(err.Error())
+	}
+	c.addManifest("global-config-configmap.yaml", content)
+}
+
+// includeGuestConfigFunc includes the global configuration resource of the
+// guest cluster with the given GuestConfigKey.
+func includeGuestConfigFunc(params interface{}) func(string, int) string {
+	return func(key string, indent int) string {
+		for _, obj := range guestConfigObjects(params.(*ClusterParams)) {
+			if GuestConfigKey(obj) != key {
+				continue
+			}
+			content, err := yaml.Marshal(obj)
+			if err != nil {
+				panic(err.Error())
+			}
+			return includeDataFunc()(string(content), indent)
+		}
+		panic(fmt.Sprintf("global configuration %s not found", key))
+	}
+}
+
+// additionalTrustBundle publishes the additional trust bundle as the
+// user-ca-bundle config map of the guest cluster, which its proxy
+// configuration references as trusted CA.
+func (c *clusterManifestContext) additionalTrustBundle() {
+	if len(c.params.(*ClusterParams).AdditionalTrustBundle) > 0 {
+		c.addUserManifestFiles("common/user-ca-bundle-configmap.yaml")
+	}
+}
+
 func (c *clusterManifestContext) machineConfigServer() {
 	c.addManifestFiles(
 		"machine-config-server/machine-config-server-configmap.yaml",
@@ -275,6 +389,21 @@ func (c *clusterManifestContext) openVPN() {
 	)
 }
 
+func (c *clusterManifestContext) konnectivity() {
+	c.addManifestFiles(
+		"konnectivity/konnectivity-server-local-service.yaml",
+		"konnectivity/konnectivity-server-secret.yaml",
+		"konnectivity/konnectivity-cluster-secret.yaml",
+		"konnectivity/konnectivity-ca-configmap.yaml",
+		"konnectivity/konnectivity-agent-secret.yaml",
+		"konnectivity/konnectivity-agent-deployment.yaml",
+		"konnectivity/konnectivity-agent-user-secret.yaml",
+	)
+	c.addUserManifestFiles(
+		"konnectivity/konnectivity-agent-daemonset.yaml",
+	)
+}
+
 func (c *clusterManifestContext) roksMetrics() {
 	c.addUserManifestFiles(
 		"roks-metrics/roks-metrics-00-namespace.yaml",
@@ -346,17 +475,23 @@ func (c *clusterManifestContext) ignitionConfigs() {
 		if err != nil {
 			panic(err)
 		}
-		name := fmt.Sprintf("ignition-config-%s", strings.TrimSuffix(m, ".yaml"))
-		params := map[string]string{
-			"name":    name,
-			"content": string(content),
-		}
-		cm, err := c.substituteParamsInBytes(params, []byte(ignitionConfigTemplate))
-		if err != nil {
-			panic(err)
-		}
-		c.addManifest(name+".yaml", cm)
+		c.addIgnitionConfig(m, content)
+	}
+}
+
+// addIgnitionConfig adds a config map holding a manifest which the machine
+// config server renders into the ignition configuration of the nodes.
+func (c *clusterManifestContext) addIgnitionConfig(file string, content []byte) {
+	name := fmt.Sprintf("ignition-config-%s", strings.TrimSuffix(file, ".yaml"))
+	params := map[string]string{
+		"name":    name,
+		"content": string(content),
+	}
+	cm, err := c.substituteParamsInBytes(params, []byte(ignitionConfigTemplate))
+	if err != nil {
+		panic(err)
 	}
+	c.addManifest(name+".yaml", cm)
 }
 
 func (c *clusterManifestContext) addUserManifestFiles(name ...string) {
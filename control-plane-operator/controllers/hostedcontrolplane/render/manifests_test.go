@@ -0,0 +1,322 @@
+package render
+
+import (
+	"testing"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"sigs.k8s.io/yaml"
+)
+
+func TestAvailabilityPolicy(t *testing.T) {
+	tests := map[string]struct {
+		policy           AvailabilityPolicy
+		expectedReplicas int32
+		expectedEtcdSize int
+	}{
+		"single replica": {
+			policy:           SingleReplica,
+			expectedReplicas: 1,
+			expectedEtcdSize: 1,
+		},
+		"highly available": {
+			policy:           HighlyAvailable,
+			expectedReplicas: 3,
+			expectedEtcdSize: 3,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			params := &ClusterParams{
+				APIAvailabilityPolicy:        test.policy,
+				ControllerAvailabilityPolicy: test.policy,
+			}
+			ctx := newClusterManifestContext(nil, map[string]string{"release": "4.8.0"}, params, nil, nil)
+			for _, file := range []string{
+				"kube-controller-manager/kube-controller-manager-deployment.yaml",
+				"kube-scheduler/kube-scheduler-deployment.yaml",
+				"oauth-apiserver/oauth-apiserver-deployment.yaml",
+				"oauth-openshift/oauth-server-deployment.yaml",
+				"openshift-apiserver/openshift-apiserver-deployment.yaml",
+				"openshift-controller-manager/openshift-controller-manager-deployment.yaml",
+				"openshift-controller-manager/cluster-policy-controller-deployment.yaml",
+			} {
+				content, err := ctx.substituteParams(params, file)
+				if err != nil {
+					t.Fatalf("failed to render %s: %v", file, err)
+				}
+				deployment := appsv1.Deployment{}
+				if err := yaml.Unmarshal(content, &deployment); err != nil {
+					t.Fatalf("%s is not valid yaml: %v", file, err)
+				}
+				if replicas := *deployment.Spec.Replicas; replicas != test.expectedReplicas {
+					t.Errorf("expected %d replicas for %s, got %d", test.expectedReplicas, file, replicas)
+				}
+				if affinity := deployment.Spec.Template.Spec.Affinity; affinity == nil || affinity.PodAntiAffinity == nil {
+					t.Errorf("expected pod anti-affinity for %s", file)
+				} else {
+					checkZoneSpreading(t, file, affinity.PodAntiAffinity)
+				}
+			}
+
+			kasParams := &KubeAPIServerParams{APIAvailabilityPolicy: KubeAPIServerParamsAvailabilityPolicy(test.policy)}
+			kasCtx := NewKubeAPIServerManifestContext(kasParams)
+			content, err := kasCtx.substituteParams(kasParams, "kube-apiserver/kube-apiserver-deployment.yaml")
+			if err != nil {
+				t.Fatalf("failed to render the kube-apiserver deployment: %v", err)
+			}
+			deployment := appsv1.Deployment{}
+			if err := yaml.Unmarshal(content, &deployment); err != nil {
+				t.Fatalf("kube-apiserver deployment is not valid yaml: %v", err)
+			}
+			if replicas := *deployment.Spec.Replicas; replicas != test.expectedReplicas {
+				t.Errorf("expected %d kube-apiserver replicas, got %d", test.expectedReplicas, replicas)
+			}
+			checkZoneSpreading(t, "kube-apiserver deployment", deployment.Spec.Template.Spec.Affinity.PodAntiAffinity)
+
+			content, err = ctx.substituteParams(params, "etcd/etcd-cluster.yaml")
+			if err != nil {
+				t.Fatalf("failed to render the etcd cluster: %v", err)
+			}
+			etcdCluster := struct {
+				Spec struct {
+					Size int `json:"size"`
+				} `json:"spec"`
+			}{}
+			if err := yaml.Unmarshal(content, &etcdCluster); err != nil {
+				t.Fatalf("etcd cluster is not valid yaml: %v", err)
+			}
+			if etcdCluster.Spec.Size != test.expectedEtcdSize {
+				t.Errorf("expected an etcd cluster of size %d, got %d", test.expectedEtcdSize, etcdCluster.Spec.Size)
+			}
+		})
+	}
+}
+
+func TestPodDisruptionBudgets(t *testing.T) {
+	ctx := newClusterManifestContext(nil, map[string]string{"release": "4.8.0"}, &ClusterParams{}, nil, nil)
+	ctx.podDisruptionBudgets()
+	manifests, err := ctx.renderManifests()
+	if err != nil {
+		t.Fatalf("failed to render: %v", err)
+	}
+	content, ok := manifests["etcd-pdb.yaml"]
+	if !ok {
+		t.Fatalf("expected an etcd pod disruption budget, got %d manifests", len(manifests))
+	}
+	pdb := &unstructured.Unstructured{}
+	if err := yaml.Unmarshal(content, &pdb.Object); err != nil {
+		t.Fatalf("etcd pod disruption budget is not valid yaml: %v", err)
+	}
+	if selector, _, _ := unstructured.NestedString(pdb.Object, "spec", "selector", "matchLabels", "etcd_cluster"); selector != "etcd" {
+		t.Errorf("expected the etcd pod disruption budget to select the etcd cluster, got %q", selector)
+	}
+}
+
+// checkZoneSpreading checks that the replicas of a component are required to
+// run on different nodes, and only preferred to run in different zones, so
+// that they can still be scheduled in a management cluster with fewer zones.
+func checkZoneSpreading(t *testing.T, name string, antiAffinity *corev1.PodAntiAffinity) {
+	t.Helper()
+	for _, term := range antiAffinity.RequiredDuringSchedulingIgnoredDuringExecution {
+		if term.TopologyKey != "kubernetes.io/hostname" {
+			t.Errorf("expected only the hostname anti-affinity of %s to be required, got %s", name, term.TopologyKey)
+		}
+	}
+	preferred := antiAffinity.PreferredDuringSchedulingIgnoredDuringExecution
+	if len(preferred) != 1 || preferred[0].PodAffinityTerm.TopologyKey != "topology.kubernetes.io/zone" {
+		t.Errorf("expected a preferred zone anti-affinity for %s, got %v", name, preferred)
+	}
+}
+
+func TestNetworkTypeNodeCIDRAllocation(t *testing.T) {
+	tests := map[string]struct {
+		networkType string
+		expectedArg string
+	}{
+		"OpenShiftSDN": {
+			networkType: "OpenShiftSDN",
+			expectedArg: "--allocate-node-cidrs=true",
+		},
+		"OVNKubernetes": {
+			networkType: "OVNKubernetes",
+			expectedArg: "--allocate-node-cidrs=false",
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			params := &ClusterParams{NetworkType: test.networkType}
+			ctx := newClusterManifestContext(nil, map[string]string{"release": "4.8.0"}, params, nil, nil)
+			content, err := ctx.substituteParams(params, "kube-controller-manager/kube-controller-manager-deployment.yaml")
+			if err != nil {
+				t.Fatalf("failed to render the kube-controller-manager deployment: %v", err)
+			}
+			deployment := appsv1.Deployment{}
+			if err := yaml.Unmarshal(content, &deployment); err != nil {
+				t.Fatalf("kube-controller-manager deployment is not valid yaml: %v", err)
+			}
+			found := false
+			for _, arg := range deployment.Spec.Template.Spec.Containers[0].Args {
+				if arg == test.expectedArg {
+					found = true
+				}
+			}
+			if !found {
+				t.Errorf("expected argument %s, got %v", test.expectedArg, deployment.Spec.Template.Spec.Containers[0].Args)
+			}
+		})
+	}
+}
+
+func TestNodeConnectivity(t *testing.T) {
+	pki := map[string][]byte{}
+	for _, name := range []string{"konnectivity-ca", "konnectivity-server", "konnectivity-cluster", "konnectivity-client", "konnectivity-agent"} {
+		pki[name+".crt"] = []byte(name + " certificate")
+		pki[name+".key"] = []byte(name + " key")
+	}
+	tests := map[string]struct {
+		nodeConnectivity       string
+		expectedSidecar        string
+		expectedServiceAccount string
+		expectedEgressSelector bool
+	}{
+		"OpenVPN": {
+			nodeConnectivity:       "OpenVPN",
+			expectedSidecar:        "openvpn-client",
+			expectedServiceAccount: "vpn",
+		},
+		"Konnectivity": {
+			nodeConnectivity:       Konnectivity,
+			expectedSidecar:        "konnectivity-server",
+			expectedEgressSelector: true,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			kasParams := &KubeAPIServerParams{NodeConnectivity: test.nodeConnectivity, PKI: pki}
+			kasCtx := NewKubeAPIServerManifestContext(kasParams)
+			content, err := kasCtx.substituteParams(kasParams, "kube-apiserver/kube-apiserver-deployment.yaml")
+			if err != nil {
+				t.Fatalf("failed to render the kube-apiserver deployment: %v", err)
+			}
+			deployment := appsv1.Deployment{}
+			if err := yaml.Unmarshal(content, &deployment); err != nil {
+				t.Fatalf("kube-apiserver deployment is not valid yaml: %v", err)
+			}
+			containers := deployment.Spec.Template.Spec.Containers
+			if sidecar := containers[len(containers)-1].Name; sidecar != test.expectedSidecar {
+				t.Errorf("expected the %s sidecar, got %s", test.expectedSidecar, sidecar)
+			}
+			if serviceAccount := deployment.Spec.Template.Spec.ServiceAccountName; serviceAccount != test.expectedServiceAccount {
+				t.Errorf("expected service account %q, got %q", test.expectedServiceAccount, serviceAccount)
+			}
+
+			params := &ClusterParams{NodeConnectivity: test.nodeConnectivity}
+			ctx := newClusterManifestContext(nil, map[string]string{"release": "4.8.0"}, params, nil, pki)
+			for _, file := range []string{"kube-apiserver/config.yaml", "openshift-apiserver/config.yaml"} {
+				content, err := ctx.substituteParams(params, file)
+				if err != nil {
+					t.Fatalf("failed to render %s: %v", file, err)
+				}
+				config := struct {
+					APIServerArguments map[string][]string `json:"apiServerArguments"`
+				}{}
+				if err := yaml.Unmarshal(content, &config); err != nil {
+					t.Fatalf("%s is not valid yaml: %v", file, err)
+				}
+				if _, hasEgressSelector := config.APIServerArguments["egress-selector-config-file"]; hasEgressSelector != test.expectedEgressSelector {
+					t.Errorf("expected an egress selector in %s to be %t", file, test.expectedEgressSelector)
+				}
+			}
+		})
+	}
+}
+
+func TestKonnectivityManifests(t *testing.T) {
+	pki := map[string][]byte{}
+	for _, name := range []string{"konnectivity-ca", "konnectivity-server", "konnectivity-cluster", "konnectivity-agent"} {
+		pki[name+".crt"] = []byte(name + " certificate")
+		pki[name+".key"] = []byte(name + " key")
+	}
+	params := &ClusterParams{
+		NodeConnectivity:            Konnectivity,
+		ExternalKonnectivityAddress: "konnectivity.example.com",
+		ExternalKonnectivityPort:    443,
+		OpenShiftAPIClusterIP:       "172.30.0.10",
+		OauthAPIClusterIP:           "172.30.0.11",
+	}
+	ctx := newClusterManifestContext(nil, map[string]string{"release": "4.8.0"}, params, nil, pki)
+	ctx.konnectivity()
+	manifests, err := ctx.renderManifests()
+	if err != nil {
+		t.Fatalf("failed to render: %v", err)
+	}
+	for name, content := range manifests {
+		obj := &unstructured.Unstructured{}
+		if err := yaml.Unmarshal(content, &obj.Object); err != nil {
+			t.Errorf("%s is not valid yaml: %v", name, err)
+		}
+	}
+
+	content, err := ctx.substituteParams(params, "konnectivity/konnectivity-agent-daemonset.yaml")
+	if err != nil {
+		t.Fatalf("failed to render the konnectivity agent daemonset: %v", err)
+	}
+	daemonSet := appsv1.DaemonSet{}
+	if err := yaml.Unmarshal(content, &daemonSet); err != nil {
+		t.Fatalf("konnectivity agent daemonset is not valid yaml: %v", err)
+	}
+	args := sets.NewString(daemonSet.Spec.Template.Spec.Containers[0].Args...)
+	for _, expected := range []string{"--proxy-server-host=konnectivity.example.com", "--proxy-server-port=443"} {
+		if !args.Has(expected) {
+			t.Errorf("expected argument %s, got %v", expected, args.List())
+		}
+	}
+}
+
+func TestClusterNetworkOperatorManifests(t *testing.T) {
+	params := &ClusterParams{
+		NetworkType:            OVNKubernetes,
+		ExternalOVNSbDbAddress: "ovnkube-sbdb.example.com",
+		ExternalOVNSbDbPort:    443,
+	}
+	images := map[string]string{"cluster-network-operator": "cno", "ovn-kubernetes": "ovn"}
+	ctx := newClusterManifestContext(images, map[string]string{"release": "4.8.0"}, params, nil, nil)
+	ctx.clusterNetworkOperator()
+	manifests, err := ctx.renderManifests()
+	if err != nil {
+		t.Fatalf("failed to render: %v", err)
+	}
+	for name, content := range manifests {
+		obj := &unstructured.Unstructured{}
+		if err := yaml.Unmarshal(content, &obj.Object); err != nil {
+			t.Errorf("%s is not valid yaml: %v", name, err)
+		}
+	}
+
+	content, err := ctx.substituteParams(params, "cluster-network-operator/cluster-network-operator-deployment.yaml")
+	if err != nil {
+		t.Fatalf("failed to render the cluster network operator deployment: %v", err)
+	}
+	deployment := appsv1.Deployment{}
+	if err := yaml.Unmarshal(content, &deployment); err != nil {
+		t.Fatalf("cluster network operator deployment is not valid yaml: %v", err)
+	}
+	env := map[string]string{}
+	for _, v := range deployment.Spec.Template.Spec.Containers[0].Env {
+		env[v.Name] = v.Value
+	}
+	expected := map[string]string{
+		"HYPERSHIFT":          "true",
+		"OVN_SBDB_ROUTE_HOST": "ovnkube-sbdb.example.com",
+		"OVN_SBDB_ROUTE_PORT": "443",
+		"OVN_IMAGE":           "ovn",
+	}
+	for name, value := range expected {
+		if env[name] != value {
+			t.Errorf("expected %s=%q, got %q", name, value, env[name])
+		}
+	}
+}
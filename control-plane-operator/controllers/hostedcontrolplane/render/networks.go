@@ -0,0 +1,71 @@
+package render
+
+import (
+	"net"
+)
+
+// ClusterNetworkEntry is a network from which pod IP addresses are allocated,
+// in subnets of HostPrefix bits per node.
+type ClusterNetworkEntry struct {
+	CIDR       string `json:"cidr"`
+	HostPrefix int32  `json:"hostPrefix"`
+}
+
+// ClusterNetworks returns the cluster networks, defaulting to the pod CIDR.
+func (p *ClusterParams) ClusterNetworks() []ClusterNetworkEntry {
+	if len(p.ClusterNetwork) == 0 && len(p.PodCIDR) > 0 {
+		return []ClusterNetworkEntry{{CIDR: p.PodCIDR, HostPrefix: defaultHostPrefix}}
+	}
+	return p.ClusterNetwork
+}
+
+// ClusterCIDRs returns the CIDRs of the cluster networks.
+func (p *ClusterParams) ClusterCIDRs() []string {
+	var cidrs []string
+	for _, entry := range p.ClusterNetworks() {
+		cidrs = append(cidrs, entry.CIDR)
+	}
+	return cidrs
+}
+
+// ServiceCIDRs returns the service networks, defaulting to the service CIDR.
+func (p *ClusterParams) ServiceCIDRs() []string {
+	return defaultNetworks(p.ServiceNetwork, p.ServiceCIDR)
+}
+
+// MachineCIDRs returns the machine networks, defaulting to the machine CIDR.
+func (p *ClusterParams) MachineCIDRs() []string {
+	return defaultNetworks(p.MachineNetwork, p.MachineCIDR)
+}
+
+// IsDualStack returns whether the services of the cluster have both IPv4 and
+// IPv6 addresses.
+func (p *ClusterParams) IsDualStack() bool {
+	return len(p.ServiceCIDRs()) > 1
+}
+
+// HasIPv6Network returns whether any of the cluster, service or machine
+// networks is an IPv6 network.
+func (p *ClusterParams) HasIPv6Network() bool {
+	for _, networks := range [][]string{p.ClusterCIDRs(), p.ServiceCIDRs(), p.MachineCIDRs()} {
+		for _, cidr := range networks {
+			if isIPv6CIDR(cidr) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func defaultNetworks(networks []string, network string) []string {
+	if len(networks) == 0 && len(network) > 0 {
+		return []string{network}
+	}
+	return networks
+}
+
+// isIPv6CIDR returns whether a CIDR is an IPv6 network.
+func isIPv6CIDR(cidr string) bool {
+	ip, _, err := net.ParseCIDR(cidr)
+	return err == nil && ip.To4() == nil
+}
@@ -0,0 +1,121 @@
+package render
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	configv1 "github.com/openshift/api/config/v1"
+	appsv1 "k8s.io/api/apps/v1"
+	"sigs.k8s.io/yaml"
+)
+
+func dualStackClusterParams() *ClusterParams {
+	return &ClusterParams{
+		PodCIDR:     "10.132.0.0/14",
+		ServiceCIDR: "172.31.0.0/16",
+		MachineCIDR: "10.0.0.0/16",
+		NetworkType: "OVNKubernetes",
+		ClusterNetwork: []ClusterNetworkEntry{
+			{CIDR: "10.132.0.0/14", HostPrefix: 23},
+			{CIDR: "fd01::/48", HostPrefix: 64},
+		},
+		ServiceNetwork: []string{"172.31.0.0/16", "fd02::/112"},
+		MachineNetwork: []string{"10.0.0.0/16", "2600:1f18:1:100::/56"},
+	}
+}
+
+func TestDualStackKubeControllerManager(t *testing.T) {
+	params := dualStackClusterParams()
+	ctx := newClusterManifestContext(nil, map[string]string{"release": "4.8.0"}, params, nil, nil)
+	content, err := ctx.substituteParams(params, "kube-controller-manager/kube-controller-manager-deployment.yaml")
+	if err != nil {
+		t.Fatalf("failed to render the kube-controller-manager deployment: %v", err)
+	}
+	deployment := appsv1.Deployment{}
+	if err := yaml.Unmarshal(content, &deployment); err != nil {
+		t.Fatalf("kube-controller-manager deployment is not valid yaml: %v", err)
+	}
+	args := map[string]bool{}
+	for _, arg := range deployment.Spec.Template.Spec.Containers[0].Args {
+		args[arg] = true
+	}
+	for _, expected := range []string{
+		"--cluster-cidr=10.132.0.0/14,fd01::/48",
+		"--service-cluster-ip-range=172.31.0.0/16,fd02::/112",
+		"--node-cidr-mask-size-ipv4=23",
+		"--node-cidr-mask-size-ipv6=64",
+	} {
+		if !args[expected] {
+			t.Errorf("expected argument %s, got %v", expected, deployment.Spec.Template.Spec.Containers[0].Args)
+		}
+	}
+}
+
+func TestDualStackKubeAPIServerConfig(t *testing.T) {
+	clusterParams := dualStackClusterParams()
+	params := &KubeAPIServerParams{
+		ClusterCIDRs: clusterParams.ClusterCIDRs(),
+		ServiceCIDRs: clusterParams.ServiceCIDRs(),
+	}
+	ctx := NewKubeAPIServerManifestContext(params)
+	content, err := ctx.substituteParams(params, "kube-apiserver/config.yaml")
+	if err != nil {
+		t.Fatalf("failed to render: %v", err)
+	}
+	rendered := struct {
+		AdmissionConfig struct {
+			PluginConfig map[string]struct {
+				Configuration struct {
+					RestrictedCIDRs []string `json:"restrictedCIDRs"`
+				} `json:"configuration"`
+			} `json:"pluginConfig"`
+		} `json:"admission"`
+		ServicesSubnet string `json:"servicesSubnet"`
+	}{}
+	if err := yaml.Unmarshal(content, &rendered); err != nil {
+		t.Fatalf("rendered config is not valid yaml: %v\n%s", err, content)
+	}
+	expectedCIDRs := []string{"10.132.0.0/14", "fd01::/48", "172.31.0.0/16", "fd02::/112"}
+	restrictedCIDRs := rendered.AdmissionConfig.PluginConfig["network.openshift.io/RestrictedEndpointsAdmission"].Configuration.RestrictedCIDRs
+	if diff := cmp.Diff(expectedCIDRs, restrictedCIDRs); diff != "" {
+		t.Errorf("unexpected restricted cidrs (-want +got): %s", diff)
+	}
+	if rendered.ServicesSubnet != "172.31.0.0/16,fd02::/112" {
+		t.Errorf("unexpected services subnet %s", rendered.ServicesSubnet)
+	}
+}
+
+func TestDualStackGuestNetwork(t *testing.T) {
+	network := guestConfigObjects(dualStackClusterParams())[0].(*configv1.Network)
+	expected := []configv1.ClusterNetworkEntry{
+		{CIDR: "10.132.0.0/14", HostPrefix: 23},
+		{CIDR: "fd01::/48", HostPrefix: 64},
+	}
+	if diff := cmp.Diff(expected, network.Spec.ClusterNetwork); diff != "" {
+		t.Errorf("unexpected cluster networks (-want +got): %s", diff)
+	}
+	if diff := cmp.Diff([]string{"172.31.0.0/16", "fd02::/112"}, network.Spec.ServiceNetwork); diff != "" {
+		t.Errorf("unexpected service networks (-want +got): %s", diff)
+	}
+}
+
+func TestDualStackOpenVPNRoutes(t *testing.T) {
+	params := dualStackClusterParams()
+	ctx := newClusterManifestContext(nil, map[string]string{"release": "4.8.0"}, params, nil, nil)
+	content, err := ctx.substituteParams(params, "openvpn/worker")
+	if err != nil {
+		t.Fatalf("failed to render the openvpn worker configuration: %v", err)
+	}
+	expected := []string{
+		"iroute 172.31.0.0 255.255.0.0",
+		"iroute-ipv6 fd02::/112",
+		"iroute 10.132.0.0 255.252.0.0",
+		"iroute-ipv6 fd01::/48",
+		"iroute 10.0.0.0 255.255.0.0",
+		"iroute-ipv6 2600:1f18:1:100::/56",
+	}
+	if diff := cmp.Diff(expected, strings.Split(strings.TrimSpace(string(content)), "\n")); diff != "" {
+		t.Errorf("unexpected routes (-want +got): %s", diff)
+	}
+}
@@ -0,0 +1,67 @@
+package pki
+
+import (
+	"fmt"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/openshift/hypershift/control-plane-operator/controllers/hostedcontrolplane/render"
+)
+
+// GenerateKonnectivityPKI generates the CA and certificates of the
+// Konnectivity server, its clients and its agents. They are generated apart
+// from the rest of the PKI so that they can be added to the PKI of an existing
+// cluster when it is migrated to Konnectivity.
+func GenerateKonnectivityPKI(params *render.PKIParams) (map[string][]byte, error) {
+	log.Info("Generating Konnectivity PKI artifacts")
+
+	cas := []caSpec{
+		ca("konnectivity-ca", "konnectivity-ca", "openshift"),
+	}
+
+	// The agents on the workers reach the server through its external
+	// address, and the agent in the control plane through its local service.
+	var clusterHostNames, clusterIPs []string
+	if isNumericIP(params.ExternalKonnectivityAddress) {
+		clusterIPs = append(clusterIPs, params.ExternalKonnectivityAddress)
+	} else {
+		clusterHostNames = append(clusterHostNames, params.ExternalKonnectivityAddress)
+	}
+	clusterHostNames = append(clusterHostNames,
+		"konnectivity-server",
+		fmt.Sprintf("konnectivity-server.%s.svc", params.Namespace),
+		fmt.Sprintf("konnectivity-server.%s.svc.cluster.local", params.Namespace),
+		"konnectivity-server-local",
+		fmt.Sprintf("konnectivity-server-local.%s.svc", params.Namespace),
+		fmt.Sprintf("konnectivity-server-local.%s.svc.cluster.local", params.Namespace),
+	)
+
+	certs := []certSpec{
+		// Served to the Kube API server on localhost, and to the OpenShift API
+		// server through the local service.
+		cert("konnectivity-server", "konnectivity-ca", "konnectivity-server", "openshift",
+			[]string{
+				"localhost",
+				"konnectivity-server-local",
+				fmt.Sprintf("konnectivity-server-local.%s.svc", params.Namespace),
+				fmt.Sprintf("konnectivity-server-local.%s.svc.cluster.local", params.Namespace),
+			}, []string{"127.0.0.1"}),
+		// Served to the agents.
+		cert("konnectivity-cluster", "konnectivity-ca", "konnectivity-cluster", "openshift", clusterHostNames, clusterIPs),
+		cert("konnectivity-client", "konnectivity-ca", "konnectivity-client", "openshift", nil, nil),
+		cert("konnectivity-agent", "konnectivity-ca", "konnectivity-agent", "openshift", nil, nil),
+	}
+
+	caMap, err := generateCAs(cas)
+	if err != nil {
+		return nil, err
+	}
+	certMap, err := generateCerts(certs, caMap)
+	if err != nil {
+		return nil, err
+	}
+	result := map[string][]byte{}
+	serializeCAs(caMap, result)
+	serializeCerts(certMap, result)
+	return result, nil
+}
@@ -21,18 +21,30 @@ func GeneratePKI(params *render.PKIParams) (map[string][]byte, error) {
 
 	externalAPIServerAddress := fmt.Sprintf("https://%s:%d", params.ExternalAPIAddress, params.ExternalAPIPort)
 	internalAPIServerAddress := fmt.Sprintf("https://kube-apiserver:%d", params.InternalAPIPort)
+	nodeAPIServerAddress := externalAPIServerAddress
+	if len(params.NodeAPIAddress) > 0 {
+		nodeAPIServerAddress = fmt.Sprintf("https://%s:%d", params.NodeAPIAddress, params.NodeAPIPort)
+	}
 	kubeconfigs := []kubeconfigSpec{
 		kubeconfig("admin", externalAPIServerAddress, "root-ca", "system:admin", "system:masters"),
 		kubeconfig("internal-admin", internalAPIServerAddress, "root-ca", "system:admin", "system:masters"),
 		kubeconfig("localhost-admin", "https://localhost:6443", "root-ca", "system:admin", "system:masters"),
-		kubeconfig("kubelet-bootstrap", externalAPIServerAddress, "cluster-signer", "system:bootstrapper", "system:bootstrappers"),
+		kubeconfig("kubelet-bootstrap", nodeAPIServerAddress, "cluster-signer", "system:bootstrapper", "system:bootstrappers"),
 	}
 
-	_, serviceIPNet, err := net.ParseCIDR(params.ServiceCIDR)
-	if err != nil {
-		return nil, errors.Wrapf(err, "failed to parse service CIDR: %q", params.ServiceCIDR)
+	serviceNetworks := params.ServiceNetwork
+	if len(serviceNetworks) == 0 {
+		serviceNetworks = []string{params.ServiceCIDR}
+	}
+	// The Kube service has an IP address in each of the service networks.
+	var kubeIPs []string
+	for _, serviceNetwork := range serviceNetworks {
+		_, serviceIPNet, err := net.ParseCIDR(serviceNetwork)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to parse service CIDR: %q", serviceNetwork)
+		}
+		kubeIPs = append(kubeIPs, firstIP(serviceIPNet).String())
 	}
-	kubeIP := firstIP(serviceIPNet)
 	apiServerHostNames := []string{
 		"localhost",
 		"kubernetes",
@@ -42,15 +54,18 @@ func GeneratePKI(params *render.PKIParams) (map[string][]byte, error) {
 		fmt.Sprintf("kube-apiserver.%s.svc", params.Namespace),
 		fmt.Sprintf("kube-apiserver.%s.svc.cluster.local", params.Namespace),
 	}
-	apiServerIPs := []string{
-		"127.0.0.1",
-		kubeIP.String(),
-		params.NodeInternalAPIServerIP,
+	apiServerIPs := append([]string{"127.0.0.1"}, kubeIPs...)
+	apiServerIPs = append(apiServerIPs, params.NodeInternalAPIServerIP)
+	apiServerAddresses := []string{params.ExternalAPIAddress}
+	if len(params.NodeAPIAddress) > 0 && params.NodeAPIAddress != params.ExternalAPIAddress {
+		apiServerAddresses = append(apiServerAddresses, params.NodeAPIAddress)
 	}
-	if isNumericIP(params.ExternalAPIAddress) {
-		apiServerIPs = append(apiServerIPs, params.ExternalAPIAddress)
-	} else {
-		apiServerHostNames = append(apiServerHostNames, params.ExternalAPIAddress)
+	for _, address := range apiServerAddresses {
+		if isNumericIP(address) {
+			apiServerIPs = append(apiServerIPs, address)
+		} else {
+			apiServerHostNames = append(apiServerHostNames, address)
+		}
 	}
 	var ingressNumericIPs, ingressHostNames []string
 	if isNumericIP(params.ExternalOauthAddress) {
@@ -155,6 +170,16 @@ func GeneratePKI(params *render.PKIParams) (map[string][]byte, error) {
 	if err := serializeCombinedCA([]string{"root-ca", "cluster-signer"}, caMap, "combined-ca.crt", result); err != nil {
 		return nil, err
 	}
+
+	if len(params.ExternalKonnectivityAddress) > 0 {
+		konnectivity, err := GenerateKonnectivityPKI(params)
+		if err != nil {
+			return nil, err
+		}
+		for k, v := range konnectivity {
+			result[k] = v
+		}
+	}
 	return result, nil
 }
 
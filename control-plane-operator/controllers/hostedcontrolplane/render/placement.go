@@ -0,0 +1,176 @@
+package render
+
+import (
+	"bytes"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	utilyaml "k8s.io/apimachinery/pkg/util/yaml"
+	"sigs.k8s.io/yaml"
+)
+
+// PodPlacement holds the scheduling constraints added to the control plane
+// pods.
+type PodPlacement struct {
+	NodeSelector              map[string]string
+	Tolerations               []corev1.Toleration
+	PriorityClassName         string
+	TopologySpreadConstraints []corev1.TopologySpreadConstraint
+}
+
+// IsEmpty returns whether the placement adds no constraints.
+func (p *PodPlacement) IsEmpty() bool {
+	return p == nil || (len(p.NodeSelector) == 0 && len(p.Tolerations) == 0 && len(p.PriorityClassName) == 0 && len(p.TopologySpreadConstraints) == 0)
+}
+
+// ApplyTo adds the placement to the spec of a pod with the given selector.
+// The node selector is merged and the tolerations are appended, while the
+// priority class and the topology spread constraints replace those of the
+// spec. Topology spread constraints without a label selector are given the
+// pod selector.
+func (p *PodPlacement) ApplyTo(spec *corev1.PodSpec, podSelector map[string]string) {
+	if p.IsEmpty() {
+		return
+	}
+	if len(p.NodeSelector) > 0 {
+		if spec.NodeSelector == nil {
+			spec.NodeSelector = map[string]string{}
+		}
+		for key, value := range p.NodeSelector {
+			spec.NodeSelector[key] = value
+		}
+	}
+	for _, toleration := range p.Tolerations {
+		if !hasToleration(spec.Tolerations, toleration) {
+			spec.Tolerations = append(spec.Tolerations, toleration)
+		}
+	}
+	if len(p.PriorityClassName) > 0 {
+		spec.PriorityClassName = p.PriorityClassName
+	}
+	if len(p.TopologySpreadConstraints) > 0 {
+		spec.TopologySpreadConstraints = nil
+		for _, constraint := range p.TopologySpreadConstraints {
+			constraint = *constraint.DeepCopy()
+			if constraint.LabelSelector == nil && len(podSelector) > 0 {
+				constraint.LabelSelector = &metav1.LabelSelector{MatchLabels: podSelector}
+			}
+			spec.TopologySpreadConstraints = append(spec.TopologySpreadConstraints, constraint)
+		}
+	}
+}
+
+func hasToleration(tolerations []corev1.Toleration, toleration corev1.Toleration) bool {
+	for _, t := range tolerations {
+		if t.MatchToleration(&toleration) && t.Value == toleration.Value && t.TolerationSeconds == toleration.TolerationSeconds {
+			return true
+		}
+	}
+	return false
+}
+
+// ApplyPodPlacement adds the placement to the pods of the deployments,
+// statefulsets and pods among the rendered manifests. The etcd members only
+// support the node selector and tolerations.
+func ApplyPodPlacement(manifests map[string][]byte, placement *PodPlacement) error {
+	if placement.IsEmpty() {
+		return nil
+	}
+	for name, content := range manifests {
+		obj := &unstructured.Unstructured{}
+		if err := utilyaml.NewYAMLOrJSONDecoder(bytes.NewReader(content), 100).Decode(&obj.Object); err != nil {
+			return fmt.Errorf("failed to decode manifest %s: %w", name, err)
+		}
+		var err error
+		switch obj.GetKind() {
+		case "Deployment", "StatefulSet":
+			err = applyPodTemplatePlacement(obj, placement)
+		case "Pod":
+			err = applyPodPlacement(obj, placement)
+		case "EtcdCluster":
+			err = applyEtcdPlacement(obj, placement)
+		default:
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("failed to apply the pod placement to manifest %s: %w", name, err)
+		}
+		if manifests[name], err = yaml.Marshal(obj.Object); err != nil {
+			return fmt.Errorf("failed to serialize manifest %s: %w", name, err)
+		}
+	}
+	return nil
+}
+
+func applyPodTemplatePlacement(obj *unstructured.Unstructured, placement *PodPlacement) error {
+	podSelector, _, err := unstructured.NestedStringMap(obj.Object, "spec", "selector", "matchLabels")
+	if err != nil {
+		return err
+	}
+	rawTemplate, _, err := unstructured.NestedMap(obj.Object, "spec", "template")
+	if err != nil {
+		return err
+	}
+	template := &corev1.PodTemplateSpec{}
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(rawTemplate, template); err != nil {
+		return err
+	}
+	placement.ApplyTo(&template.Spec, podSelector)
+	if rawTemplate, err = runtime.DefaultUnstructuredConverter.ToUnstructured(template); err != nil {
+		return err
+	}
+	return unstructured.SetNestedMap(obj.Object, rawTemplate, "spec", "template")
+}
+
+func applyPodPlacement(obj *unstructured.Unstructured, placement *PodPlacement) error {
+	rawSpec, _, err := unstructured.NestedMap(obj.Object, "spec")
+	if err != nil {
+		return err
+	}
+	spec := &corev1.PodSpec{}
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(rawSpec, spec); err != nil {
+		return err
+	}
+	placement.ApplyTo(spec, obj.GetLabels())
+	if rawSpec, err = runtime.DefaultUnstructuredConverter.ToUnstructured(spec); err != nil {
+		return err
+	}
+	return unstructured.SetNestedMap(obj.Object, rawSpec, "spec")
+}
+
+func applyEtcdPlacement(obj *unstructured.Unstructured, placement *PodPlacement) error {
+	spec := &corev1.PodSpec{}
+	nodeSelector, _, err := unstructured.NestedStringMap(obj.Object, "spec", "pod", "nodeSelector")
+	if err != nil {
+		return err
+	}
+	spec.NodeSelector = nodeSelector
+	rawTolerations, _, err := unstructured.NestedSlice(obj.Object, "spec", "pod", "tolerations")
+	if err != nil {
+		return err
+	}
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(map[string]interface{}{"tolerations": rawTolerations}, spec); err != nil {
+		return err
+	}
+	etcdPlacement := &PodPlacement{NodeSelector: placement.NodeSelector, Tolerations: placement.Tolerations}
+	etcdPlacement.ApplyTo(spec, nil)
+
+	if len(spec.NodeSelector) > 0 {
+		if err := unstructured.SetNestedStringMap(obj.Object, spec.NodeSelector, "spec", "pod", "nodeSelector"); err != nil {
+			return err
+		}
+	}
+	if len(spec.Tolerations) > 0 {
+		raw, err := runtime.DefaultUnstructuredConverter.ToUnstructured(&corev1.PodSpec{Tolerations: spec.Tolerations})
+		if err != nil {
+			return err
+		}
+		if err := unstructured.SetNestedField(obj.Object, raw["tolerations"], "spec", "pod", "tolerations"); err != nil {
+			return err
+		}
+	}
+	return nil
+}
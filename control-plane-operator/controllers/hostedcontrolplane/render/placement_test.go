@@ -0,0 +1,127 @@
+package render
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/yaml"
+)
+
+func testPodPlacement() *PodPlacement {
+	return &PodPlacement{
+		NodeSelector: map[string]string{"node-role.kubernetes.io/infra": ""},
+		Tolerations: []corev1.Toleration{{
+			Key:      "node-role.kubernetes.io/infra",
+			Operator: corev1.TolerationOpExists,
+			Effect:   corev1.TaintEffectNoSchedule,
+		}},
+		PriorityClassName: "hypershift-control-plane",
+		TopologySpreadConstraints: []corev1.TopologySpreadConstraint{{
+			MaxSkew:           1,
+			TopologyKey:       "topology.kubernetes.io/zone",
+			WhenUnsatisfiable: corev1.ScheduleAnyway,
+		}},
+	}
+}
+
+func TestPodPlacementApplyTo(t *testing.T) {
+	placement := testPodPlacement()
+	spec := &corev1.PodSpec{
+		NodeSelector: map[string]string{"kubernetes.io/os": "linux"},
+		Tolerations: []corev1.Toleration{{
+			Key:      "node-role.kubernetes.io/infra",
+			Operator: corev1.TolerationOpExists,
+			Effect:   corev1.TaintEffectNoSchedule,
+		}},
+	}
+	placement.ApplyTo(spec, map[string]string{"app": "kube-apiserver"})
+
+	expected := &corev1.PodSpec{
+		NodeSelector: map[string]string{
+			"kubernetes.io/os":              "linux",
+			"node-role.kubernetes.io/infra": "",
+		},
+		Tolerations:       placement.Tolerations,
+		PriorityClassName: "hypershift-control-plane",
+		TopologySpreadConstraints: []corev1.TopologySpreadConstraint{{
+			MaxSkew:           1,
+			TopologyKey:       "topology.kubernetes.io/zone",
+			WhenUnsatisfiable: corev1.ScheduleAnyway,
+			LabelSelector:     &metav1.LabelSelector{MatchLabels: map[string]string{"app": "kube-apiserver"}},
+		}},
+	}
+	if diff := cmp.Diff(expected, spec); diff != "" {
+		t.Errorf("unexpected pod spec (-want +got):\n%s", diff)
+	}
+	if placement.TopologySpreadConstraints[0].LabelSelector != nil {
+		t.Errorf("expected the placement to be left unchanged")
+	}
+}
+
+func TestApplyPodPlacement(t *testing.T) {
+	params := &ClusterParams{}
+	ctx := newClusterManifestContext(nil, map[string]string{"release": "4.8.0"}, params, nil, nil)
+	manifests := map[string][]byte{}
+	for name, file := range map[string]string{
+		"kube-scheduler-deployment.yaml": "kube-scheduler/kube-scheduler-deployment.yaml",
+		"etcd-cluster.yaml":              "etcd/etcd-cluster.yaml",
+	} {
+		content, err := ctx.substituteParams(params, file)
+		if err != nil {
+			t.Fatalf("failed to render %s: %v", file, err)
+		}
+		manifests[name] = content
+	}
+	manifests["configmap.yaml"] = []byte("apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: test\n")
+
+	placement := testPodPlacement()
+	if err := ApplyPodPlacement(manifests, placement); err != nil {
+		t.Fatalf("failed to apply the placement: %v", err)
+	}
+
+	deployment := appsv1.Deployment{}
+	if err := yaml.Unmarshal(manifests["kube-scheduler-deployment.yaml"], &deployment); err != nil {
+		t.Fatalf("kube-scheduler deployment is not valid yaml: %v", err)
+	}
+	podSpec := deployment.Spec.Template.Spec
+	if _, ok := podSpec.NodeSelector["node-role.kubernetes.io/infra"]; !ok {
+		t.Errorf("expected the node selector to be set, got %v", podSpec.NodeSelector)
+	}
+	if podSpec.PriorityClassName != placement.PriorityClassName {
+		t.Errorf("expected priority class %q, got %q", placement.PriorityClassName, podSpec.PriorityClassName)
+	}
+	if len(podSpec.TopologySpreadConstraints) != 1 || podSpec.TopologySpreadConstraints[0].LabelSelector == nil {
+		t.Fatalf("expected a topology spread constraint with a label selector, got %v", podSpec.TopologySpreadConstraints)
+	}
+	if diff := cmp.Diff(deployment.Spec.Selector.MatchLabels, podSpec.TopologySpreadConstraints[0].LabelSelector.MatchLabels); diff != "" {
+		t.Errorf("expected the topology spread constraint to select the deployment pods (-want +got):\n%s", diff)
+	}
+
+	etcdCluster := struct {
+		Spec struct {
+			Pod struct {
+				NodeSelector      map[string]string   `json:"nodeSelector"`
+				Tolerations       []corev1.Toleration `json:"tolerations"`
+				PriorityClassName string              `json:"priorityClassName"`
+			} `json:"pod"`
+		} `json:"spec"`
+	}{}
+	if err := yaml.Unmarshal(manifests["etcd-cluster.yaml"], &etcdCluster); err != nil {
+		t.Fatalf("etcd cluster is not valid yaml: %v", err)
+	}
+	if diff := cmp.Diff(placement.NodeSelector, etcdCluster.Spec.Pod.NodeSelector); diff != "" {
+		t.Errorf("unexpected etcd node selector (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff(placement.Tolerations, etcdCluster.Spec.Pod.Tolerations); diff != "" {
+		t.Errorf("unexpected etcd tolerations (-want +got):\n%s", diff)
+	}
+	if etcdCluster.Spec.Pod.PriorityClassName != "" {
+		t.Errorf("expected no etcd priority class, got %q", etcdCluster.Spec.Pod.PriorityClassName)
+	}
+	if string(manifests["configmap.yaml"]) != "apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: test\n" {
+		t.Errorf("expected the configmap to be left unchanged, got %s", manifests["configmap.yaml"])
+	}
+}
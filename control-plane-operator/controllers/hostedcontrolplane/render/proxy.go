@@ -0,0 +1,30 @@
+package render
+
+import (
+	hyperutil "github.com/openshift/hypershift/support/util"
+)
+
+// HasProxy returns whether the guest cluster has a cluster-wide proxy.
+func (p *ClusterParams) HasProxy() bool {
+	return len(p.HTTPProxy) > 0 || len(p.HTTPSProxy) > 0
+}
+
+// ControlPlaneNoProxy returns the hosts which control plane components reach
+// without the proxy. Next to NoProxy, these are the services of the control
+// plane namespace, which are reached through their short names.
+func (p *ClusterParams) ControlPlaneNoProxy() string {
+	return hyperutil.JoinNoProxy(p.NoProxy, "localhost", "127.0.0.1", ".svc", ".cluster.local", "kube-apiserver", p.EtcdClientName)
+}
+
+// guestNoProxy returns the hosts which the guest cluster reaches without the
+// proxy, as the network operator reports them in the status of the proxy
+// configuration. The machine config server renders the proxy settings of the
+// nodes from it.
+func (p *ClusterParams) guestNoProxy() string {
+	entries := []string{p.NoProxy, "localhost", "127.0.0.1", ".svc", ".cluster.local", p.NodeAPIDNSName}
+	if p.PlatformType == "AWS" {
+		// The instance metadata service.
+		entries = append(entries, "169.254.169.254")
+	}
+	return hyperutil.JoinNoProxy(entries...)
+}
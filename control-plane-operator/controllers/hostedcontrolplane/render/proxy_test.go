@@ -0,0 +1,127 @@
+package render
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	configv1 "github.com/openshift/api/config/v1"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/yaml"
+)
+
+func proxyClusterParams() *ClusterParams {
+	return &ClusterParams{
+		PodCIDR:        "10.132.0.0/14",
+		ServiceCIDR:    "172.31.0.0/16",
+		MachineCIDR:    "10.0.0.0/16",
+		NetworkType:    "OpenShiftSDN",
+		CloudProvider:  "aws",
+		PlatformType:   "AWS",
+		EtcdClientName: "etcd-client",
+		NodeAPIDNSName: "api.example.com",
+		HTTPProxy:      "http://proxy.example.com:3128",
+		NoProxy:        ".example.com,10.132.0.0/14,172.31.0.0/16,10.0.0.0/16",
+	}
+}
+
+func TestProxyEnv(t *testing.T) {
+	tests := map[string]struct {
+		params      *ClusterParams
+		expectedEnv []corev1.EnvVar
+	}{
+		"no proxy": {
+			params: &ClusterParams{EtcdClientName: "etcd-client"},
+		},
+		"proxy": {
+			params: proxyClusterParams(),
+			expectedEnv: []corev1.EnvVar{
+				{Name: "HTTP_PROXY", Value: "http://proxy.example.com:3128"},
+				{Name: "NO_PROXY", Value: ".example.com,10.132.0.0/14,172.31.0.0/16,10.0.0.0/16,localhost,127.0.0.1,.svc,.cluster.local,kube-apiserver,etcd-client"},
+			},
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			ctx := newClusterManifestContext(nil, map[string]string{"release": "4.8.0"}, test.params, nil, nil)
+			for _, file := range []string{
+				"kube-controller-manager/kube-controller-manager-deployment.yaml",
+				"openshift-apiserver/openshift-apiserver-deployment.yaml",
+			} {
+				content, err := ctx.substituteParams(test.params, file)
+				if err != nil {
+					t.Fatalf("failed to render %s: %v", file, err)
+				}
+				deployment := appsv1.Deployment{}
+				if err := yaml.Unmarshal(content, &deployment); err != nil {
+					t.Fatalf("%s is not valid yaml: %v", file, err)
+				}
+				var proxyEnv []corev1.EnvVar
+				for _, env := range deployment.Spec.Template.Spec.Containers[0].Env {
+					switch env.Name {
+					case "HTTP_PROXY", "HTTPS_PROXY", "NO_PROXY":
+						proxyEnv = append(proxyEnv, env)
+					}
+				}
+				if diff := cmp.Diff(test.expectedEnv, proxyEnv); diff != "" {
+					t.Errorf("unexpected proxy env of %s (-want +got): %s", file, diff)
+				}
+			}
+		})
+	}
+}
+
+func TestGuestProxyConfig(t *testing.T) {
+	params := proxyClusterParams()
+	var proxy *configv1.Proxy
+	for _, obj := range guestConfigObjects(params) {
+		if GuestConfigKey(obj) == "proxy.yaml" {
+			proxy = obj.(*configv1.Proxy)
+		}
+	}
+	if proxy == nil {
+		t.Fatalf("expected a proxy configuration")
+	}
+	expectedSpec := configv1.ProxySpec{
+		HTTPProxy: "http://proxy.example.com:3128",
+		NoProxy:   ".example.com,10.132.0.0/14,172.31.0.0/16,10.0.0.0/16",
+	}
+	if diff := cmp.Diff(expectedSpec, proxy.Spec); diff != "" {
+		t.Errorf("unexpected proxy spec (-want +got): %s", diff)
+	}
+	expectedNoProxy := ".example.com,10.132.0.0/14,172.31.0.0/16,10.0.0.0/16,localhost,127.0.0.1,.svc,.cluster.local,api.example.com,169.254.169.254"
+	if proxy.Status.NoProxy != expectedNoProxy {
+		t.Errorf("expected status noProxy %s, got %s", expectedNoProxy, proxy.Status.NoProxy)
+	}
+}
+
+func TestMachineConfigServerConfigMap(t *testing.T) {
+	params := proxyClusterParams()
+	pki := map[string][]byte{
+		"root-ca.crt":     []byte("root-ca"),
+		"combined-ca.crt": []byte("combined-ca"),
+	}
+	ctx := newClusterManifestContext(nil, map[string]string{"release": "4.8.0"}, params, []byte("{}"), pki)
+	content, err := ctx.substituteParams(params, "machine-config-server/machine-config-server-configmap.yaml")
+	if err != nil {
+		t.Fatalf("failed to render the machine config server configmap: %v", err)
+	}
+	configMap := corev1.ConfigMap{}
+	if err := yaml.Unmarshal(content, &configMap); err != nil {
+		t.Fatalf("machine config server configmap is not valid yaml: %v", err)
+	}
+	proxy := configv1.Proxy{}
+	if err := yaml.Unmarshal([]byte(configMap.Data["cluster-proxy-01-config.yaml"]), &proxy); err != nil {
+		t.Fatalf("proxy configuration is not valid yaml: %v", err)
+	}
+	if proxy.Status.HTTPProxy != params.HTTPProxy {
+		t.Errorf("expected the proxy status to be rendered for the nodes, got %v", proxy.Status)
+	}
+	network := configv1.Network{}
+	if err := yaml.Unmarshal([]byte(configMap.Data["cluster-network-02-config.yaml"]), &network); err != nil {
+		t.Fatalf("network configuration is not valid yaml: %v", err)
+	}
+	if network.Spec.NetworkType != "OpenShiftSDN" {
+		t.Errorf("expected the network configuration, got %v", network.Spec)
+	}
+}
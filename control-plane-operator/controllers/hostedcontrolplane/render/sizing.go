@@ -0,0 +1,134 @@
+package render
+
+import (
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+
+	hyperconfig "github.com/openshift/hypershift/support/config"
+)
+
+// Names of the sizing profiles.
+const (
+	SmallSizingProfile  = "Small"
+	MediumSizingProfile = "Medium"
+	LargeSizingProfile  = "Large"
+)
+
+// smallProfileRequests are the resource requests of the components in the
+// Small profile. Larger profiles scale them by sizingProfileScale.
+var smallProfileRequests = map[string]corev1.ResourceList{
+	hyperconfig.EtcdComponent:                       requests("300m", "600Mi"),
+	hyperconfig.KubeAPIServerComponent:              requests("350m", "2Gi"),
+	hyperconfig.KubeControllerManagerComponent:      requests("100m", "300Mi"),
+	hyperconfig.KubeSchedulerComponent:              requests("25m", "150Mi"),
+	hyperconfig.OpenshiftAPIServerComponent:         requests("100m", "500Mi"),
+	hyperconfig.OAuthAPIServerComponent:             requests("25m", "80Mi"),
+	hyperconfig.OpenshiftControllerManagerComponent: requests("100m", "200Mi"),
+	hyperconfig.ClusterPolicyControllerComponent:    requests("10m", "200Mi"),
+	hyperconfig.OAuthServerComponent:                requests("25m", "40Mi"),
+	hyperconfig.ClusterVersionOperatorComponent:     requests("20m", "70Mi"),
+}
+
+var sizingProfileScale = map[string]int64{
+	SmallSizingProfile:  1,
+	MediumSizingProfile: 2,
+	LargeSizingProfile:  4,
+}
+
+func requests(cpu, memory string) corev1.ResourceList {
+	return corev1.ResourceList{
+		corev1.ResourceCPU:    resource.MustParse(cpu),
+		corev1.ResourceMemory: resource.MustParse(memory),
+	}
+}
+
+// ComponentResources returns the resource requirements of the sized control
+// plane components for a profile, keyed by component name. An empty profile
+// is the Small profile. The requests and limits of an override replace those
+// of the profile for the same resource. A request of the profile above the
+// limit of an override is lowered to the limit, since the profile of an
+// automatically sized control plane changes with its nodes.
+func ComponentResources(profile string, overrides map[string]corev1.ResourceRequirements) (map[string]corev1.ResourceRequirements, error) {
+	if len(profile) == 0 {
+		profile = SmallSizingProfile
+	}
+	scale, ok := sizingProfileScale[profile]
+	if !ok {
+		return nil, fmt.Errorf("unknown sizing profile %q", profile)
+	}
+	result := map[string]corev1.ResourceRequirements{}
+	for component, small := range smallProfileRequests {
+		requirements := corev1.ResourceRequirements{Requests: corev1.ResourceList{}}
+		for name, quantity := range small {
+			requirements.Requests[name] = *resource.NewMilliQuantity(quantity.MilliValue()*scale, quantity.Format)
+		}
+		result[component] = requirements
+	}
+	for component, override := range overrides {
+		requirements, ok := result[component]
+		if !ok {
+			return nil, fmt.Errorf("unknown control plane component %q", component)
+		}
+		for name, quantity := range override.Requests {
+			requirements.Requests[name] = quantity
+		}
+		for name, quantity := range override.Limits {
+			if requirements.Limits == nil {
+				requirements.Limits = corev1.ResourceList{}
+			}
+			requirements.Limits[name] = quantity
+			if request, ok := requirements.Requests[name]; ok && request.Cmp(quantity) > 0 {
+				requirements.Requests[name] = quantity
+			}
+		}
+		result[component] = requirements
+	}
+	return result, nil
+}
+
+// SetComponentResources sets the resources of the sized control plane
+// components.
+func (p *ClusterParams) SetComponentResources(resources map[string]corev1.ResourceRequirements) {
+	p.EtcdResources = resourceRequirements(resources[hyperconfig.EtcdComponent])
+	p.KubeAPIServerResources = resourceRequirements(resources[hyperconfig.KubeAPIServerComponent])
+	p.KubeControllerManagerResources = resourceRequirements(resources[hyperconfig.KubeControllerManagerComponent])
+	p.KubeSchedulerResources = resourceRequirements(resources[hyperconfig.KubeSchedulerComponent])
+	p.OpenshiftAPIServerResources = resourceRequirements(resources[hyperconfig.OpenshiftAPIServerComponent])
+	p.OAuthAPIServerResources = resourceRequirements(resources[hyperconfig.OAuthAPIServerComponent])
+	p.OpenshiftControllerManagerResources = resourceRequirements(resources[hyperconfig.OpenshiftControllerManagerComponent])
+	p.ClusterPolicyControllerResources = resourceRequirements(resources[hyperconfig.ClusterPolicyControllerComponent])
+	p.OAuthServerResources = resourceRequirements(resources[hyperconfig.OAuthServerComponent])
+	p.ClusterVersionOperatorResources = resourceRequirements(resources[hyperconfig.ClusterVersionOperatorComponent])
+}
+
+// resourceRequirements converts resource requirements to the form expected
+// by the manifest templates.
+func resourceRequirements(requirements corev1.ResourceRequirements) []ResourceRequirements {
+	if len(requirements.Requests) == 0 && len(requirements.Limits) == 0 {
+		return nil
+	}
+	result := ResourceRequirements{}
+	if len(requirements.Requests) > 0 {
+		result.ResourceRequest = []ResourceRequest{{
+			CPU:    quantityString(requirements.Requests, corev1.ResourceCPU),
+			Memory: quantityString(requirements.Requests, corev1.ResourceMemory),
+		}}
+	}
+	if len(requirements.Limits) > 0 {
+		result.ResourceLimit = []ResourceLimit{{
+			CPU:    quantityString(requirements.Limits, corev1.ResourceCPU),
+			Memory: quantityString(requirements.Limits, corev1.ResourceMemory),
+		}}
+	}
+	return []ResourceRequirements{result}
+}
+
+func quantityString(resources corev1.ResourceList, name corev1.ResourceName) string {
+	quantity, ok := resources[name]
+	if !ok {
+		return ""
+	}
+	return quantity.String()
+}
@@ -0,0 +1,167 @@
+package render
+
+import (
+	"testing"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"sigs.k8s.io/yaml"
+
+	hyperconfig "github.com/openshift/hypershift/support/config"
+)
+
+func TestSizingComponents(t *testing.T) {
+	if len(smallProfileRequests) != len(hyperconfig.SizingComponents) {
+		t.Errorf("expected requests for %d components, got %d", len(hyperconfig.SizingComponents), len(smallProfileRequests))
+	}
+	for _, component := range hyperconfig.SizingComponents {
+		if _, ok := smallProfileRequests[component]; !ok {
+			t.Errorf("missing requests for sized component %s", component)
+		}
+	}
+}
+
+func TestComponentResources(t *testing.T) {
+	tests := map[string]struct {
+		profile           string
+		overrides         map[string]corev1.ResourceRequirements
+		expectedAPIServer corev1.ResourceRequirements
+		expectError       bool
+	}{
+		"default profile": {
+			expectedAPIServer: corev1.ResourceRequirements{Requests: requests("350m", "2Gi")},
+		},
+		"large profile": {
+			profile:           LargeSizingProfile,
+			expectedAPIServer: corev1.ResourceRequirements{Requests: requests("1400m", "8Gi")},
+		},
+		"override": {
+			profile: MediumSizingProfile,
+			overrides: map[string]corev1.ResourceRequirements{
+				hyperconfig.KubeAPIServerComponent: {
+					Requests: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("6Gi")},
+					Limits:   corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("12Gi")},
+				},
+			},
+			expectedAPIServer: corev1.ResourceRequirements{
+				Requests: requests("700m", "6Gi"),
+				Limits:   corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("12Gi")},
+			},
+		},
+		"limit below the request of the profile": {
+			profile: MediumSizingProfile,
+			overrides: map[string]corev1.ResourceRequirements{
+				hyperconfig.KubeAPIServerComponent: {
+					Limits: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("500m")},
+				},
+			},
+			expectedAPIServer: corev1.ResourceRequirements{
+				Requests: requests("500m", "4Gi"),
+				Limits:   corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("500m")},
+			},
+		},
+		"unknown profile": {
+			profile:     "Huge",
+			expectError: true,
+		},
+		"unknown component": {
+			overrides:   map[string]corev1.ResourceRequirements{"openvpn-server": {}},
+			expectError: true,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			resources, err := ComponentResources(test.profile, test.overrides)
+			if test.expectError {
+				if err == nil {
+					t.Errorf("expected an error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			actual := resources[hyperconfig.KubeAPIServerComponent]
+			for _, list := range []struct{ expected, actual corev1.ResourceList }{
+				{test.expectedAPIServer.Requests, actual.Requests},
+				{test.expectedAPIServer.Limits, actual.Limits},
+			} {
+				if len(list.expected) != len(list.actual) {
+					t.Fatalf("expected %v, got %v", test.expectedAPIServer, actual)
+				}
+				for name, quantity := range list.expected {
+					if actualQuantity := list.actual[name]; quantity.Cmp(actualQuantity) != 0 {
+						t.Errorf("expected %s of %s, got %s", name, quantity.String(), actualQuantity.String())
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestRenderComponentResources(t *testing.T) {
+	resources, err := ComponentResources(MediumSizingProfile, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	params := &ClusterParams{}
+	params.SetComponentResources(resources)
+	ctx := newClusterManifestContext(nil, map[string]string{"release": "4.8.0"}, params, nil, nil)
+	for file, component := range map[string]string{
+		"kube-controller-manager/kube-controller-manager-deployment.yaml":   hyperconfig.KubeControllerManagerComponent,
+		"oauth-apiserver/oauth-apiserver-deployment.yaml":                   hyperconfig.OAuthAPIServerComponent,
+		"cluster-version-operator/cluster-version-operator-deployment.yaml": hyperconfig.ClusterVersionOperatorComponent,
+	} {
+		content, err := ctx.substituteParams(params, file)
+		if err != nil {
+			t.Fatalf("failed to render %s: %v", file, err)
+		}
+		deployment := appsv1.Deployment{}
+		if err := yaml.Unmarshal(content, &deployment); err != nil {
+			t.Fatalf("%s is not valid yaml: %v", file, err)
+		}
+		expected := resources[component].Requests[corev1.ResourceMemory]
+		if actual := deployment.Spec.Template.Spec.Containers[0].Resources.Requests[corev1.ResourceMemory]; expected.Cmp(actual) != 0 {
+			t.Errorf("expected a memory request of %s in %s, got %s", expected.String(), file, actual.String())
+		}
+	}
+
+	kasParams := &KubeAPIServerParams{KubeAPIServerResources: params.KubeAPIServerResources}
+	content, err := NewKubeAPIServerManifestContext(kasParams).substituteParams(kasParams, "kube-apiserver/kube-apiserver-deployment.yaml")
+	if err != nil {
+		t.Fatalf("failed to render the kube-apiserver deployment: %v", err)
+	}
+	deployment := appsv1.Deployment{}
+	if err := yaml.Unmarshal(content, &deployment); err != nil {
+		t.Fatalf("kube-apiserver deployment is not valid yaml: %v", err)
+	}
+	for _, container := range deployment.Spec.Template.Spec.Containers {
+		if container.Name != "kube-apiserver" {
+			continue
+		}
+		expected := resources[hyperconfig.KubeAPIServerComponent].Requests[corev1.ResourceMemory]
+		if actual := container.Resources.Requests[corev1.ResourceMemory]; expected.Cmp(actual) != 0 {
+			t.Errorf("expected a kube-apiserver memory request of %s, got %s", expected.String(), actual.String())
+		}
+	}
+
+	content, err = ctx.substituteParams(params, "etcd/etcd-cluster.yaml")
+	if err != nil {
+		t.Fatalf("failed to render the etcd cluster: %v", err)
+	}
+	etcdCluster := struct {
+		Spec struct {
+			Pod struct {
+				Resources corev1.ResourceRequirements `json:"resources"`
+			} `json:"pod"`
+		} `json:"spec"`
+	}{}
+	if err := yaml.Unmarshal(content, &etcdCluster); err != nil {
+		t.Fatalf("etcd cluster is not valid yaml: %v", err)
+	}
+	expected := resources[hyperconfig.EtcdComponent].Requests[corev1.ResourceCPU]
+	if actual := etcdCluster.Spec.Pod.Resources.Requests[corev1.ResourceCPU]; expected.Cmp(actual) != 0 {
+		t.Errorf("expected an etcd cpu request of %s, got %s", expected.String(), actual.String())
+	}
+}
@@ -0,0 +1,145 @@
+package render
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	configv1 "github.com/openshift/api/config/v1"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/yaml"
+)
+
+const testTrustBundle = `-----BEGIN CERTIFICATE-----
+MIIBcert
+-----END CERTIFICATE-----`
+
+func trustBundleClusterParams() *ClusterParams {
+	params := proxyClusterParams()
+	params.AdditionalTrustBundle = "user-ca-bundle"
+	params.AdditionalTrustBundleData = testTrustBundle
+	params.AdditionalTrustBundleHash = "abc123"
+	return params
+}
+
+func TestAdditionalTrustBundleDeployments(t *testing.T) {
+	tests := map[string]struct {
+		params        *ClusterParams
+		expectTrusted bool
+	}{
+		"no trust bundle": {
+			params: proxyClusterParams(),
+		},
+		"trust bundle": {
+			params:        trustBundleClusterParams(),
+			expectTrusted: true,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			ctx := newClusterManifestContext(nil, map[string]string{"release": "4.8.0"}, test.params, nil, nil)
+			for _, file := range []string{
+				"kube-controller-manager/kube-controller-manager-deployment.yaml",
+				"oauth-openshift/oauth-server-deployment.yaml",
+				"openshift-apiserver/openshift-apiserver-deployment.yaml",
+			} {
+				content, err := ctx.substituteParams(test.params, file)
+				if err != nil {
+					t.Fatalf("failed to render %s: %v", file, err)
+				}
+				deployment := appsv1.Deployment{}
+				if err := yaml.Unmarshal(content, &deployment); err != nil {
+					t.Fatalf("%s is not valid yaml: %v", file, err)
+				}
+				podSpec := deployment.Spec.Template.Spec
+				var certDir string
+				for _, env := range podSpec.Containers[0].Env {
+					if env.Name == "SSL_CERT_DIR" {
+						certDir = env.Value
+					}
+				}
+				var mountPath string
+				for _, mount := range podSpec.Containers[0].VolumeMounts {
+					if mount.Name == "additional-trust-bundle" {
+						mountPath = mount.MountPath
+					}
+				}
+				var configMap string
+				for _, volume := range podSpec.Volumes {
+					if volume.Name == "additional-trust-bundle" && volume.ConfigMap != nil {
+						configMap = volume.ConfigMap.Name
+					}
+				}
+				hash := deployment.Spec.Template.Annotations["hypershift.openshift.io/trust-bundle-hash"]
+				if !test.expectTrusted {
+					if len(certDir) > 0 || len(mountPath) > 0 || len(configMap) > 0 || len(hash) > 0 {
+						t.Errorf("expected %s not to trust an additional trust bundle", file)
+					}
+					continue
+				}
+				if certDir != "/etc/pki/additional-trust-bundle" || mountPath != certDir {
+					t.Errorf("expected %s to trust the certificates mounted at %s, got SSL_CERT_DIR %q", file, mountPath, certDir)
+				}
+				if configMap != "user-ca-bundle" {
+					t.Errorf("expected %s to mount the user-ca-bundle config map, got %q", file, configMap)
+				}
+				if hash != "abc123" {
+					t.Errorf("expected %s to roll on changes of the trust bundle, got hash %q", file, hash)
+				}
+			}
+		})
+	}
+}
+
+func TestAdditionalTrustBundleGuestConfig(t *testing.T) {
+	params := trustBundleClusterParams()
+	for _, obj := range guestConfigObjects(params) {
+		if proxy, isProxy := obj.(*configv1.Proxy); isProxy {
+			if proxy.Spec.TrustedCA.Name != UserCABundleName {
+				t.Errorf("expected the proxy to trust %s, got %q", UserCABundleName, proxy.Spec.TrustedCA.Name)
+			}
+		}
+	}
+
+	pki := map[string][]byte{
+		"root-ca.crt":     []byte("root-ca"),
+		"combined-ca.crt": []byte("combined-ca"),
+	}
+	ctx := newClusterManifestContext(nil, map[string]string{"release": "4.8.0"}, params, []byte("{}"), pki)
+	ctx.additionalTrustBundle()
+	if diff := cmp.Diff([]string{"common/user-ca-bundle-configmap.yaml"}, ctx.userManifestFiles); diff != "" {
+		t.Errorf("unexpected user manifests (-want +got): %s", diff)
+	}
+
+	expected := map[string]string{"ca-bundle.crt": testTrustBundle}
+	content, err := ctx.substituteParams(params, "common/user-ca-bundle-configmap.yaml")
+	if err != nil {
+		t.Fatalf("failed to render the user-ca-bundle config map: %v", err)
+	}
+	userCABundle := corev1.ConfigMap{}
+	if err := yaml.Unmarshal(content, &userCABundle); err != nil {
+		t.Fatalf("user-ca-bundle config map is not valid yaml: %v", err)
+	}
+	if userCABundle.Namespace != "openshift-config" || userCABundle.Name != UserCABundleName {
+		t.Errorf("unexpected user-ca-bundle config map %s/%s", userCABundle.Namespace, userCABundle.Name)
+	}
+	if diff := cmp.Diff(expected, userCABundle.Data); diff != "" {
+		t.Errorf("unexpected user-ca-bundle data (-want +got): %s", diff)
+	}
+
+	content, err = ctx.substituteParams(params, "machine-config-server/machine-config-server-configmap.yaml")
+	if err != nil {
+		t.Fatalf("failed to render the machine config server configmap: %v", err)
+	}
+	configMap := corev1.ConfigMap{}
+	if err := yaml.Unmarshal(content, &configMap); err != nil {
+		t.Fatalf("machine config server configmap is not valid yaml: %v", err)
+	}
+	nodeCABundle := corev1.ConfigMap{}
+	if err := yaml.Unmarshal([]byte(configMap.Data["user-ca-bundle-config.yaml"]), &nodeCABundle); err != nil {
+		t.Fatalf("node trust bundle is not valid yaml: %v", err)
+	}
+	if diff := cmp.Diff(expected, nodeCABundle.Data); diff != "" {
+		t.Errorf("unexpected node trust bundle (-want +got): %s", diff)
+	}
+}
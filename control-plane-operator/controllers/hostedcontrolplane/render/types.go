@@ -1,6 +1,11 @@
 package render
 
-import "github.com/google/uuid"
+import (
+	"github.com/google/uuid"
+	operatorv1alpha1 "github.com/openshift/api/operator/v1alpha1"
+
+	hyperconfig "github.com/openshift/hypershift/support/config"
+)
 
 // NewClusterParams returns a new default cluster params struct
 func NewClusterParams() *ClusterParams {
@@ -17,14 +22,17 @@ func NewClusterParams() *ClusterParams {
 
 type PKIParams struct {
 	// API Server
-	ExternalAPIAddress      string // An externally accessible DNS name or IP for the API server. Currently obtained from the load balancer DNS name.
-	NodeInternalAPIServerIP string // A fixed IP that pods on worker nodes will use to communicate with the API server - 172.20.0.1
-	ExternalAPIPort         uint   // External API server port - fixed at 6443. This is used for kubeconfig generation.
-	InternalAPIPort         uint   // Internal API server network (on service network of host) - fixed at 6443. Used for kubeconfig generation.
-	ServiceCIDR             string // Used to determine the internal IP address of the Kube service and generate an IP for it.
+	ExternalAPIAddress      string   // An externally accessible DNS name or IP for the API server. Its hostname when it has one, and otherwise the address it is published on.
+	NodeInternalAPIServerIP string   // A fixed IP that pods on worker nodes will use to communicate with the API server - 172.20.0.1
+	ExternalAPIPort         uint     // External API server port - fixed at 6443. This is used for kubeconfig generation.
+	NodeAPIAddress          string   // The DNS name or IP through which nodes reach the API server. Its private hostname when it is published privately, and otherwise ExternalAPIAddress.
+	NodeAPIPort             uint     // The API server port through which nodes reach the API server. Used for the kubelet bootstrap kubeconfig.
+	InternalAPIPort         uint     // Internal API server network (on service network of host) - fixed at 6443. Used for kubeconfig generation.
+	ServiceCIDR             string   // Used to determine the internal IP address of the Kube service and generate an IP for it.
+	ServiceNetwork          []string // The service networks of a dual-stack cluster, whose first entry is ServiceCIDR. The Kube service has an IP address in each.
 
 	// OAuth Server address
-	ExternalOauthAddress string // An externally accessible DNS name or IP for the Oauth server. Currently obtained from Oauth load balancer DNS name.
+	ExternalOauthAddress string // An externally accessible DNS name or IP for the Oauth server. Its hostname when it has one, and otherwise the address it is published on.
 
 	// Ingress
 	IngressSubdomain string // Subdomain for cluster ingress. Used to generate the wildcard certificate for ingress.
@@ -35,35 +43,47 @@ type PKIParams struct {
 	// VPN Server
 	ExternalOpenVPNAddress string // An externally accessible DNS name or IP for the VPN Server. Currently obtained from VPN load balancer DNS name.
 
+	// Konnectivity Server
+	ExternalKonnectivityAddress string // An externally accessible DNS name or IP for the Konnectivity server, to which the agents on the workers connect. The Konnectivity PKI is only generated when it is set.
+
 	// Common
 	Namespace string // Used to generate internal DNS names for services.
 }
 
 type ClusterParams struct {
-	Namespace               string      `json:"namespace"`
-	ExternalAPIDNSName      string      `json:"externalAPIDNSName"`
-	ExternalAPIAddress      string      `json:"externalAPIAddress"`
-	ExternalAPIPort         uint        `json:"externalAPIPort"`
-	ExternalOpenVPNAddress  string      `json:"externalVPNAddress"`
-	ExternalOpenVPNPort     uint        `json:"externalVPNPort"`
-	ExternalOauthDNSName    string      `json:"externalOauthDNSName"`
-	ExternalOauthPort       uint        `json:"externalOauthPort"`
-	IdentityProviders       string      `json:"identityProviders"`
-	ServiceCIDR             string      `json:"serviceCIDR"`
-	MachineCIDR             string      `json:"machineCIDR"`
-	NamedCerts              []NamedCert `json:"namedCerts,omitempty"`
-	PodCIDR                 string      `json:"podCIDR"`
-	ReleaseImage            string      `json:"releaseImage"`
-	IngressSubdomain        string      `json:"ingressSubdomain"`
-	OpenShiftAPIClusterIP   string      `json:"openshiftAPIClusterIP"`
-	OauthAPIClusterIP       string      `json:"oauthAPIClusterIP"`
-	ImageRegistryHTTPSecret string      `json:"imageRegistryHTTPSecret"`
-	RouterNodePortHTTP      string      `json:"routerNodePortHTTP"`
-	RouterNodePortHTTPS     string      `json:"routerNodePortHTTPS"`
-	BaseDomain              string      `json:"baseDomain"`
-	PublicZoneID            string      `json:"publicZoneID,omitempty"`
-	PrivateZoneID           string      `json:"PrivateZoneID,omitempty"`
-	NetworkType             string      `json:"networkType"`
+	Namespace              string      `json:"namespace"`
+	ExternalAPIDNSName     string      `json:"externalAPIDNSName"`
+	ExternalAPIAddress     string      `json:"externalAPIAddress"`
+	ExternalAPIPort        uint        `json:"externalAPIPort"`
+	NodeAPIDNSName         string      `json:"nodeAPIDNSName"`
+	NodeAPIPort            uint        `json:"nodeAPIPort"`
+	ExternalOpenVPNAddress string      `json:"externalVPNAddress"`
+	ExternalOpenVPNPort    uint        `json:"externalVPNPort"`
+	ExternalOauthDNSName   string      `json:"externalOauthDNSName"`
+	ExternalOauthPort      uint        `json:"externalOauthPort"`
+	IdentityProviders      string      `json:"identityProviders"`
+	ServiceCIDR            string      `json:"serviceCIDR"`
+	MachineCIDR            string      `json:"machineCIDR"`
+	NamedCerts             []NamedCert `json:"namedCerts,omitempty"`
+	PodCIDR                string      `json:"podCIDR"`
+	// ClusterNetwork, ServiceNetwork and MachineNetwork are the networks of
+	// the pods, services and machines, whose first entries are PodCIDR,
+	// ServiceCIDR and MachineCIDR. Use the ClusterNetworks, ServiceCIDRs and
+	// MachineCIDRs methods, which default to the single networks.
+	ClusterNetwork          []ClusterNetworkEntry `json:"clusterNetwork,omitempty"`
+	ServiceNetwork          []string              `json:"serviceNetwork,omitempty"`
+	MachineNetwork          []string              `json:"machineNetwork,omitempty"`
+	ReleaseImage            string                `json:"releaseImage"`
+	IngressSubdomain        string                `json:"ingressSubdomain"`
+	OpenShiftAPIClusterIP   string                `json:"openshiftAPIClusterIP"`
+	OauthAPIClusterIP       string                `json:"oauthAPIClusterIP"`
+	ImageRegistryHTTPSecret string                `json:"imageRegistryHTTPSecret"`
+	RouterNodePortHTTP      string                `json:"routerNodePortHTTP"`
+	RouterNodePortHTTPS     string                `json:"routerNodePortHTTPS"`
+	BaseDomain              string                `json:"baseDomain"`
+	PublicZoneID            string                `json:"publicZoneID,omitempty"`
+	PrivateZoneID           string                `json:"PrivateZoneID,omitempty"`
+	NetworkType             string                `json:"networkType"`
 	// APIAvailabilityPolicy defines the availability of components that support end-user facing API requests
 	APIAvailabilityPolicy AvailabilityPolicy `json:"apiAvailabilityPolicy"`
 	// ControllerAvailabilityPolicy defines the availability of controller components for the cluster
@@ -89,6 +109,8 @@ type ClusterParams struct {
 	AutoApproverResources                  []ResourceRequirements `json:"autoApproverResources"`
 	OpenVPNClientResources                 []ResourceRequirements `json:"openVPNClientResources"`
 	OpenVPNServerResources                 []ResourceRequirements `json:"openVPNServerResources"`
+	OAuthAPIServerResources                []ResourceRequirements `json:"oauthAPIServerResources"`
+	EtcdResources                          []ResourceRequirements `json:"etcdResources"`
 	APIServerAuditEnabled                  bool                   `json:"apiServerAuditEnabled"`
 	RestartDate                            string                 `json:"restartDate"`
 	HostedClusterConfigOperatorControllers []string               `json:"hostedClusterConfigOperatorControllers"`
@@ -105,6 +127,48 @@ type ClusterParams struct {
 	InfraID                                string                 `json:"infraID"`
 	ProviderCredsSecretName                string                 `json:"providerCredsSecretName"`
 	DefaultFeatureGates                    []string
+	// GlobalConfig holds the global configuration of the guest cluster
+	GlobalConfig hyperconfig.GlobalConfig `json:"globalConfig"`
+	// IdentityProviderVolumes are the secrets and config maps referenced by
+	// IdentityProviders, which are mounted in the oauth server
+	IdentityProviderVolumes []IdentityProviderVolume `json:"identityProviderVolumes"`
+	// IdentityProvidersHash changes whenever the identity providers or the
+	// data they reference change
+	IdentityProvidersHash string `json:"identityProvidersHash"`
+
+	// NodeConnectivity is the data path from the control plane to the guest
+	// cluster, either OpenVPN or Konnectivity. With Konnectivity, the agents
+	// on the workers connect to the Konnectivity server on
+	// ExternalKonnectivityAddress and ExternalKonnectivityPort.
+	NodeConnectivity            string `json:"nodeConnectivity"`
+	ExternalKonnectivityAddress string `json:"externalKonnectivityAddress"`
+	ExternalKonnectivityPort    uint   `json:"externalKonnectivityPort"`
+
+	// ExternalOVNSbDbAddress and ExternalOVNSbDbPort are the address of the
+	// OVN southbound database to which the nodes of a cluster with the
+	// OVNKubernetes network type connect.
+	ExternalOVNSbDbAddress string `json:"externalOVNSbDbAddress,omitempty"`
+	ExternalOVNSbDbPort    uint   `json:"externalOVNSbDbPort,omitempty"`
+
+	// HTTPProxy, HTTPSProxy and NoProxy configure the cluster-wide proxy of
+	// the guest cluster. NoProxy includes the cluster, service and machine
+	// networks. The proxy is not configured when both URLs are empty.
+	HTTPProxy  string `json:"httpProxy,omitempty"`
+	HTTPSProxy string `json:"httpsProxy,omitempty"`
+	NoProxy    string `json:"noProxy,omitempty"`
+
+	// AdditionalTrustBundle is the name of the config map in the control
+	// plane namespace which holds the additional trust bundle, and
+	// AdditionalTrustBundleData its certificates. AdditionalTrustBundleHash
+	// changes whenever the certificates do, rolling their consumers.
+	AdditionalTrustBundle     string `json:"additionalTrustBundle,omitempty"`
+	AdditionalTrustBundleData string `json:"additionalTrustBundleData,omitempty"`
+	AdditionalTrustBundleHash string `json:"additionalTrustBundleHash,omitempty"`
+
+	// ImageContentSources are the registry mirrors of the guest cluster.
+	// ImageContentSourcesHash changes whenever they do.
+	ImageContentSources     []operatorv1alpha1.RepositoryDigestMirrors `json:"imageContentSources,omitempty"`
+	ImageContentSourcesHash string                                     `json:"imageContentSourcesHash,omitempty"`
 
 	// AWS params
 	AWSZone     string `json:"awsZone"`
@@ -146,6 +210,16 @@ type ResourceRequest struct {
 
 type AvailabilityPolicy string
 
+// OVNKubernetes is the NetworkType of a cluster whose ovnkube-master is run
+// in the control plane namespace by the cluster network operator of the
+// control plane.
+const OVNKubernetes = "OVNKubernetes"
+
+// Konnectivity is the NodeConnectivity of a cluster whose control plane
+// reaches the guest cluster through the Konnectivity server. Any other value
+// is OpenVPN.
+const Konnectivity = "Konnectivity"
+
 const (
 	HighlyAvailable AvailabilityPolicy = "HighlyAvailable"
 	SingleReplica   AvailabilityPolicy = "SingleReplica"
@@ -0,0 +1,111 @@
+package hostedcontrolplane
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/tools/clientcmd"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	hyperv1 "github.com/openshift/hypershift/api/v1alpha1"
+	"github.com/openshift/hypershift/control-plane-operator/controllers/hostedcontrolplane/render"
+	hyperutil "github.com/openshift/hypershift/support/util"
+)
+
+const (
+	// kubeAPIServerNamedCertPrefix is the path prefix of the serving
+	// certificate of the Kube API server hostname, which is mounted from the
+	// kube-apiserver secret.
+	kubeAPIServerNamedCertPrefix = "/etc/kubernetes/secret/named-0"
+
+	// servingCertCAKey is the optional key of a serving certificate secret
+	// with the certificate authority which signed it.
+	servingCertCAKey = "ca.crt"
+)
+
+// getServingCert returns the serving certificate secret of a service
+// published with the strategy, or nil when it has none.
+func (r *HostedControlPlaneReconciler) getServingCert(ctx context.Context, namespace string, strategy hyperv1.ServicePublishingStrategy) (*corev1.Secret, error) {
+	if strategy.ServingCert == nil {
+		return nil, nil
+	}
+	secret := &corev1.Secret{}
+	if err := r.Get(ctx, client.ObjectKey{Namespace: namespace, Name: strategy.ServingCert.Name}, secret); err != nil {
+		return nil, fmt.Errorf("failed to get serving cert %s: %w", strategy.ServingCert.Name, err)
+	}
+	for _, key := range []string{corev1.TLSCertKey, corev1.TLSPrivateKeyKey} {
+		if len(secret.Data[key]) == 0 {
+			return nil, fmt.Errorf("serving cert secret %s is missing the %s key", secret.Name, key)
+		}
+	}
+	return secret, nil
+}
+
+// applyServingCerts adds the serving certificates of the Kube API server and
+// OAuth server hostnames to the PKI used to render the control plane. The
+// Kube API server serves its certificate for its hostname next to the
+// generated one, and the OAuth server serves its certificate instead of the
+// generated one.
+func (r *HostedControlPlaneReconciler) applyServingCerts(ctx context.Context, hcp *hyperv1.HostedControlPlane, params *render.ClusterParams, pki map[string][]byte) error {
+	apiStrategy := hyperutil.ServicePublishingStrategy(hcp.Spec.Services, hyperv1.APIServer)
+	apiCert, err := r.getServingCert(ctx, hcp.Namespace, apiStrategy)
+	if err != nil {
+		return err
+	}
+	if apiCert != nil {
+		params.NamedCerts = []render.NamedCert{{
+			NamedCertPrefix: kubeAPIServerNamedCertPrefix,
+			NamedCertDomain: apiStrategy.Hostname,
+		}}
+		pki["kube-apiserver-named-0.crt"] = apiCert.Data[corev1.TLSCertKey]
+		pki["kube-apiserver-named-0.key"] = apiCert.Data[corev1.TLSPrivateKeyKey]
+		kubeconfigs := []string{"admin.kubeconfig"}
+		if hyperutil.EndpointAccess(hcp.Spec.Platform) == hyperv1.Public {
+			// Otherwise nodes reach the Kube API server with its private
+			// hostname, which the generated certificate is valid for.
+			kubeconfigs = append(kubeconfigs, "kubelet-bootstrap.kubeconfig")
+		}
+		for _, name := range kubeconfigs {
+			kubeconfig, err := servingCertKubeconfig(pki[name], apiCert)
+			if err != nil {
+				return fmt.Errorf("failed to update %s for the serving cert: %w", name, err)
+			}
+			pki[name] = kubeconfig
+		}
+	}
+
+	oauthCert, err := r.getServingCert(ctx, hcp.Namespace, hyperutil.ServicePublishingStrategy(hcp.Spec.Services, hyperv1.OAuthServer))
+	if err != nil {
+		return err
+	}
+	if oauthCert != nil {
+		pki["oauth-openshift-server.crt"] = oauthCert.Data[corev1.TLSCertKey]
+		pki["oauth-openshift-server.key"] = oauthCert.Data[corev1.TLSPrivateKeyKey]
+	} else {
+		pki["oauth-openshift-server.crt"] = pki["ingress-openshift.crt"]
+		pki["oauth-openshift-server.key"] = pki["ingress-openshift.key"]
+	}
+	return nil
+}
+
+// servingCertKubeconfig returns a kubeconfig for the Kube API server hostname
+// which trusts the certificate authority of its serving certificate, next to
+// the generated root CA which the other addresses of the Kube API server use.
+// Without a certificate authority in the secret, the system trust store is
+// used instead.
+func servingCertKubeconfig(kubeconfig []byte, servingCert *corev1.Secret) ([]byte, error) {
+	config, err := clientcmd.Load(kubeconfig)
+	if err != nil {
+		return nil, err
+	}
+	ca, hasCA := servingCert.Data[servingCertCAKey]
+	for _, cluster := range config.Clusters {
+		if hasCA {
+			cluster.CertificateAuthorityData = append(append(append([]byte(nil), cluster.CertificateAuthorityData...), '\n'), ca...)
+		} else {
+			cluster.CertificateAuthorityData = nil
+		}
+	}
+	return clientcmd.Write(*config)
+}
@@ -0,0 +1,53 @@
+package hostedcontrolplane
+
+import (
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/tools/clientcmd"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
+)
+
+func TestServingCertKubeconfig(t *testing.T) {
+	config := clientcmdapi.NewConfig()
+	config.Clusters["default"] = &clientcmdapi.Cluster{
+		Server:                   "https://api.example.com:6443",
+		CertificateAuthorityData: []byte("root-ca"),
+	}
+	kubeconfig, err := clientcmd.Write(*config)
+	if err != nil {
+		t.Fatalf("failed to write kubeconfig: %v", err)
+	}
+
+	tests := map[string]struct {
+		ServingCert *corev1.Secret
+		ExpectedCA  string
+	}{
+		"serving cert with a certificate authority": {
+			ServingCert: &corev1.Secret{Data: map[string][]byte{"ca.crt": []byte("serving-ca")}},
+			ExpectedCA:  "root-ca\nserving-ca",
+		},
+		"serving cert trusted by the system": {
+			ServingCert: &corev1.Secret{Data: map[string][]byte{}},
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			result, err := servingCertKubeconfig(kubeconfig, test.ServingCert)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			updated, err := clientcmd.Load(result)
+			if err != nil {
+				t.Fatalf("result is not a kubeconfig: %v", err)
+			}
+			cluster := updated.Clusters["default"]
+			if cluster.Server != "https://api.example.com:6443" {
+				t.Errorf("unexpected server %s", cluster.Server)
+			}
+			if ca := string(cluster.CertificateAuthorityData); ca != test.ExpectedCA {
+				t.Errorf("expected certificate authority %q, got %q", test.ExpectedCA, ca)
+			}
+		})
+	}
+}
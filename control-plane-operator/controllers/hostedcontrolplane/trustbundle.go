@@ -0,0 +1,119 @@
+package hostedcontrolplane
+
+import (
+	"context"
+	"crypto/md5"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	hyperv1 "github.com/openshift/hypershift/api/v1alpha1"
+	"github.com/openshift/hypershift/control-plane-operator/controllers/hostedcontrolplane/render"
+)
+
+const (
+	// trustBundleKey is the key of the additional trust bundle config map
+	// which holds the PEM encoded certificates.
+	trustBundleKey = "ca-bundle.crt"
+
+	// trustBundleHashAnnotation is set on the manifests bootstrapper pod to
+	// the hash of the additional trust bundle it publishes in the guest
+	// cluster.
+	trustBundleHashAnnotation = "hypershift.openshift.io/trust-bundle-hash"
+)
+
+// additionalTrustBundle returns the certificates of the additional trust
+// bundle of the HostedControlPlane and their hash, or empty strings without
+// an additional trust bundle.
+func (r *HostedControlPlaneReconciler) additionalTrustBundle(ctx context.Context, hcp *hyperv1.HostedControlPlane) (string, string, error) {
+	if hcp.Spec.AdditionalTrustBundle == nil {
+		return "", "", nil
+	}
+	configMap := &corev1.ConfigMap{}
+	if err := r.Get(ctx, types.NamespacedName{Namespace: hcp.Namespace, Name: hcp.Spec.AdditionalTrustBundle.Name}, configMap); err != nil {
+		return "", "", fmt.Errorf("failed to get additional trust bundle %s: %w", hcp.Spec.AdditionalTrustBundle.Name, err)
+	}
+	data := configMap.Data[trustBundleKey]
+	if len(data) == 0 {
+		return "", "", invalidConfiguration(fmt.Errorf("additional trust bundle %s is missing the %s key", configMap.Name, trustBundleKey))
+	}
+	return data, fmt.Sprintf("%x", md5.Sum([]byte(data))), nil
+}
+
+// reconcileAdditionalTrustBundleParams sets the additional trust bundle
+// parameters of the control plane manifests.
+func (r *HostedControlPlaneReconciler) reconcileAdditionalTrustBundleParams(ctx context.Context, hcp *hyperv1.HostedControlPlane, params *render.ClusterParams) error {
+	data, hash, err := r.additionalTrustBundle(ctx, hcp)
+	if err != nil {
+		return err
+	}
+	if len(data) == 0 {
+		return nil
+	}
+	params.AdditionalTrustBundle = hcp.Spec.AdditionalTrustBundle.Name
+	params.AdditionalTrustBundleData = data
+	params.AdditionalTrustBundleHash = hash
+	return nil
+}
+
+// restartManifestsBootstrapperForAdditionalTrustBundle deletes the manifests
+// bootstrapper pod when it published another additional trust bundle in the
+// guest cluster, for it to be rendered again and publish the current one.
+func (r *HostedControlPlaneReconciler) restartManifestsBootstrapperForAdditionalTrustBundle(ctx context.Context, hcp *hyperv1.HostedControlPlane) error {
+	_, latest, err := r.additionalTrustBundle(ctx, hcp)
+	if err != nil {
+		return err
+	}
+	return r.restartManifestsBootstrapperOnChange(ctx, hcp.Namespace, trustBundleHashAnnotation, "", latest, "additional trust bundle")
+}
+
+// restartManifestsBootstrapperOnChange deletes the manifests bootstrapper pod
+// when the given annotation differs from its latest value, for it to be
+// rendered again and publish the guest cluster resources the annotation
+// identifies. A pod without the annotation is assumed to have the
+// unannotated value.
+func (r *HostedControlPlaneReconciler) restartManifestsBootstrapperOnChange(ctx context.Context, namespace, annotation, unannotated, latest, reason string) error {
+	var bootstrapPod corev1.Pod
+	if err := r.Get(ctx, types.NamespacedName{Namespace: namespace, Name: "manifests-bootstrapper"}, &bootstrapPod); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil
+		}
+		return fmt.Errorf("failed to get manifests bootstrapper pod: %w", err)
+	}
+	current, hasAnnotation := bootstrapPod.Annotations[annotation]
+	if !hasAnnotation {
+		current = unannotated
+	}
+	if current == latest {
+		return nil
+	}
+	if err := r.Delete(ctx, &bootstrapPod); err != nil && !apierrors.IsNotFound(err) {
+		return fmt.Errorf("failed to delete manifests bootstrapper pod: %w", err)
+	}
+	r.Log.Info("deleted manifests bootstrapper pod to publish the latest "+reason, "pod", bootstrapPod.Name, "from", current, "to", latest)
+	return nil
+}
+
+// enqueueHostedControlPlanesForAdditionalTrustBundle enqueues the
+// HostedControlPlanes which reference a config map as additional trust
+// bundle, so that changes to it roll the components which trust it.
+func (r *HostedControlPlaneReconciler) enqueueHostedControlPlanesForAdditionalTrustBundle(obj client.Object) []reconcile.Request {
+	hcpList := &hyperv1.HostedControlPlaneList{}
+	if err := r.List(context.Background(), hcpList, client.InNamespace(obj.GetNamespace())); err != nil {
+		ctrl.Log.Error(err, "failed to list hosted control planes", "namespace", obj.GetNamespace())
+		return nil
+	}
+	var requests []reconcile.Request
+	for i := range hcpList.Items {
+		hcp := &hcpList.Items[i]
+		if hcp.Spec.AdditionalTrustBundle != nil && hcp.Spec.AdditionalTrustBundle.Name == obj.GetName() {
+			requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(hcp)})
+		}
+	}
+	return requests
+}
@@ -0,0 +1,115 @@
+package hostedcontrolplane
+
+import (
+	"context"
+	"crypto/md5"
+	"fmt"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	hyperapi "github.com/openshift/hypershift/api"
+	hyperv1 "github.com/openshift/hypershift/api/v1alpha1"
+	"github.com/openshift/hypershift/control-plane-operator/controllers/hostedcontrolplane/render"
+)
+
+func TestRestartManifestsBootstrapperForAdditionalTrustBundle(t *testing.T) {
+	bundleHash := fmt.Sprintf("%x", md5.Sum([]byte("certs")))
+	tests := map[string]struct {
+		Annotation    string
+		TrustBundle   bool
+		ExpectDeleted bool
+	}{
+		"no trust bundle": {},
+		"same trust bundle": {
+			Annotation:  bundleHash,
+			TrustBundle: true,
+		},
+		"trust bundle added": {
+			TrustBundle:   true,
+			ExpectDeleted: true,
+		},
+		"trust bundle changed": {
+			Annotation:    "stale",
+			TrustBundle:   true,
+			ExpectDeleted: true,
+		},
+		"trust bundle removed": {
+			Annotation:    bundleHash,
+			ExpectDeleted: true,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			pod := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Namespace: "hcp", Name: "manifests-bootstrapper"}}
+			if len(test.Annotation) > 0 {
+				pod.Annotations = map[string]string{trustBundleHashAnnotation: test.Annotation}
+			}
+			bundle := &corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "hcp", Name: "user-ca-bundle"},
+				Data:       map[string]string{trustBundleKey: "certs"},
+			}
+			r := &HostedControlPlaneReconciler{
+				Client: fake.NewClientBuilder().WithScheme(hyperapi.Scheme).WithObjects(pod, bundle).Build(),
+				Log:    ctrl.Log,
+			}
+			hcp := &hyperv1.HostedControlPlane{ObjectMeta: metav1.ObjectMeta{Namespace: "hcp", Name: "hcp"}}
+			if test.TrustBundle {
+				hcp.Spec.AdditionalTrustBundle = &corev1.LocalObjectReference{Name: bundle.Name}
+			}
+			if err := r.restartManifestsBootstrapperForAdditionalTrustBundle(context.Background(), hcp); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			err := r.Get(context.Background(), client.ObjectKeyFromObject(pod), &corev1.Pod{})
+			if deleted := apierrors.IsNotFound(err); deleted != test.ExpectDeleted {
+				t.Errorf("expected pod deleted to be %t, got %t (%v)", test.ExpectDeleted, deleted, err)
+			}
+		})
+	}
+}
+
+func TestReconcileAdditionalTrustBundleParams(t *testing.T) {
+	tests := map[string]struct {
+		Data          map[string]string
+		ExpectedError bool
+		Expected      string
+	}{
+		"trust bundle": {
+			Data:     map[string]string{trustBundleKey: "certs"},
+			Expected: "certs",
+		},
+		"trust bundle without its key": {
+			Data:          map[string]string{"ca.crt": "certs"},
+			ExpectedError: true,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			r := &HostedControlPlaneReconciler{
+				Client: fake.NewClientBuilder().WithScheme(hyperapi.Scheme).WithObjects(&corev1.ConfigMap{
+					ObjectMeta: metav1.ObjectMeta{Namespace: "hcp", Name: "user-ca-bundle"},
+					Data:       test.Data,
+				}).Build(),
+				Log: ctrl.Log,
+			}
+			hcp := &hyperv1.HostedControlPlane{ObjectMeta: metav1.ObjectMeta{Namespace: "hcp", Name: "hcp"}}
+			hcp.Spec.AdditionalTrustBundle = &corev1.LocalObjectReference{Name: "user-ca-bundle"}
+			params := &render.ClusterParams{}
+			err := r.reconcileAdditionalTrustBundleParams(context.Background(), hcp, params)
+			if (err != nil) != test.ExpectedError {
+				t.Fatalf("expected error to be %t, got %v", test.ExpectedError, err)
+			}
+			if params.AdditionalTrustBundleData != test.Expected {
+				t.Errorf("expected trust bundle %q, got %q", test.Expected, params.AdditionalTrustBundleData)
+			}
+			if hasHash := len(params.AdditionalTrustBundleHash) > 0; hasHash != (len(test.Expected) > 0) {
+				t.Errorf("unexpected trust bundle hash %q", params.AdditionalTrustBundleHash)
+			}
+		})
+	}
+}
@@ -0,0 +1,37 @@
+package releaseinfo
+
+import (
+	"context"
+	"sync"
+)
+
+var _ Provider = (*CachedProvider)(nil)
+
+// CachedProvider decorates another Provider to look up each release image
+// only once. Release images are immutable, so the results of the Delegate are
+// kept for the lifetime of the process. Lookup returns copies of the cached
+// results, which callers are free to modify.
+type CachedProvider struct {
+	Delegate Provider
+
+	lock  sync.Mutex
+	cache map[string]*ReleaseImage
+}
+
+func (p *CachedProvider) Lookup(ctx context.Context, image string) (*ReleaseImage, error) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	if releaseImage, ok := p.cache[image]; ok {
+		return &ReleaseImage{ImageStream: releaseImage.ImageStream.DeepCopy()}, nil
+	}
+	releaseImage, err := p.Delegate.Lookup(ctx, image)
+	if err != nil {
+		return nil, err
+	}
+	if p.cache == nil {
+		p.cache = map[string]*ReleaseImage{}
+	}
+	p.cache[image] = &ReleaseImage{ImageStream: releaseImage.ImageStream.DeepCopy()}
+	return releaseImage, nil
+}
@@ -0,0 +1,41 @@
+package releaseinfo
+
+import (
+	"context"
+	"testing"
+)
+
+type countingProvider struct {
+	fakeProvider
+	lookups int
+}
+
+func (p *countingProvider) Lookup(ctx context.Context, image string) (*ReleaseImage, error) {
+	p.lookups++
+	return p.fakeProvider.Lookup(ctx, image)
+}
+
+func TestCachedProvider(t *testing.T) {
+	delegate := &countingProvider{}
+	provider := &CachedProvider{Delegate: delegate}
+	for i := 0; i < 3; i++ {
+		releaseImage, err := provider.Lookup(context.Background(), "quay.io/openshift-release-dev/ocp-release:4.8.0-x86_64")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(releaseImage.Spec.Tags) != 2 {
+			t.Fatalf("expected the tags of the release image, got %v", releaseImage.Spec.Tags)
+		}
+		// Modifications by callers must not leak into the cache.
+		releaseImage.Spec.Tags = append(releaseImage.Spec.Tags, releaseImage.Spec.Tags[0])
+	}
+	if delegate.lookups != 1 {
+		t.Errorf("expected the release image to be looked up once, got %d lookups", delegate.lookups)
+	}
+	if _, err := provider.Lookup(context.Background(), "quay.io/openshift-release-dev/ocp-release:4.8.1-x86_64"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if delegate.lookups != 2 {
+		t.Errorf("expected another release image to be looked up, got %d lookups", delegate.lookups)
+	}
+}
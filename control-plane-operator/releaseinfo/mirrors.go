@@ -0,0 +1,68 @@
+package releaseinfo
+
+import (
+	"context"
+	"strings"
+
+	hyperv1 "github.com/openshift/hypershift/api/v1alpha1"
+)
+
+var _ Provider = (*RegistryMirrorProviderDecorator)(nil)
+
+// RegistryMirrorProviderDecorator decorates another Provider to pull the
+// images of mirrored repositories from their mirrors. The Lookup
+// implementation will resolve the mirrored release image with the given
+// Delegate, and will then point the component images of the Delegate's results
+// to their mirrors.
+type RegistryMirrorProviderDecorator struct {
+	Delegate            Provider
+	ImageContentSources []hyperv1.ImageContentSource
+}
+
+func (p *RegistryMirrorProviderDecorator) Lookup(ctx context.Context, image string) (*ReleaseImage, error) {
+	releaseImage, err := p.Delegate.Lookup(ctx, MirroredImage(image, p.ImageContentSources))
+	if err != nil {
+		return nil, err
+	}
+	if len(p.ImageContentSources) == 0 {
+		return releaseImage, nil
+	}
+	mirrored := &ReleaseImage{ImageStream: releaseImage.ImageStream.DeepCopy()}
+	for i, tag := range mirrored.Spec.Tags {
+		if tag.From != nil {
+			mirrored.Spec.Tags[i].From.Name = MirroredImage(tag.From.Name, p.ImageContentSources)
+		}
+	}
+	return mirrored, nil
+}
+
+// MirroredImage returns the pullspec of an image in the first mirror of the
+// repository it belongs to, or the image itself when its repository is not
+// mirrored. Tags and digests are preserved.
+func MirroredImage(image string, sources []hyperv1.ImageContentSource) string {
+	repository, suffix := splitImage(image)
+	for _, source := range sources {
+		if len(source.Mirrors) == 0 {
+			continue
+		}
+		if repository == source.Source || strings.HasPrefix(repository, source.Source+"/") {
+			return source.Mirrors[0] + strings.TrimPrefix(repository, source.Source) + suffix
+		}
+	}
+	return image
+}
+
+// splitImage splits the pullspec of an image into its repository and its tag
+// and digest, if any.
+func splitImage(image string) (string, string) {
+	repository := image
+	if i := strings.Index(repository, "@"); i >= 0 {
+		repository = repository[:i]
+	}
+	// A colon after the last slash separates the tag, while one before it
+	// separates the port of the registry.
+	if i := strings.LastIndex(repository, ":"); i > strings.LastIndex(repository, "/") {
+		repository = repository[:i]
+	}
+	return repository, image[len(repository):]
+}
@@ -0,0 +1,100 @@
+package releaseinfo
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	imageapi "github.com/openshift/api/image/v1"
+	corev1 "k8s.io/api/core/v1"
+
+	hyperv1 "github.com/openshift/hypershift/api/v1alpha1"
+)
+
+var testImageContentSources = []hyperv1.ImageContentSource{
+	{
+		Source:  "quay.io/openshift-release-dev/ocp-v4.0-art-dev",
+		Mirrors: []string{"mirror.example.com:5000/ocp/art-dev", "backup.example.com/ocp/art-dev"},
+	},
+	{
+		Source:  "quay.io/openshift-release-dev/ocp-release",
+		Mirrors: []string{"mirror.example.com:5000/ocp/release"},
+	},
+	{
+		Source: "k8s.gcr.io",
+	},
+}
+
+func TestMirroredImage(t *testing.T) {
+	tests := map[string]struct {
+		Image    string
+		Expected string
+	}{
+		"digest": {
+			Image:    "quay.io/openshift-release-dev/ocp-v4.0-art-dev@sha256:0123",
+			Expected: "mirror.example.com:5000/ocp/art-dev@sha256:0123",
+		},
+		"tag": {
+			Image:    "quay.io/openshift-release-dev/ocp-release:4.8.0-x86_64",
+			Expected: "mirror.example.com:5000/ocp/release:4.8.0-x86_64",
+		},
+		"nested repository": {
+			Image:    "quay.io/openshift-release-dev/ocp-release/nested:latest",
+			Expected: "mirror.example.com:5000/ocp/release/nested:latest",
+		},
+		"repository sharing a prefix": {
+			Image:    "quay.io/openshift-release-dev/ocp-release-nightly:4.8",
+			Expected: "quay.io/openshift-release-dev/ocp-release-nightly:4.8",
+		},
+		"source without mirrors": {
+			Image:    "k8s.gcr.io/autoscaling/cluster-autoscaler:v1.20.0",
+			Expected: "k8s.gcr.io/autoscaling/cluster-autoscaler:v1.20.0",
+		},
+		"unmirrored registry with a port": {
+			Image:    "registry.example.com:5000/image",
+			Expected: "registry.example.com:5000/image",
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			if actual := MirroredImage(test.Image, testImageContentSources); actual != test.Expected {
+				t.Errorf("expected %s, got %s", test.Expected, actual)
+			}
+		})
+	}
+}
+
+type fakeProvider struct {
+	lookedUp string
+}
+
+func (p *fakeProvider) Lookup(_ context.Context, image string) (*ReleaseImage, error) {
+	p.lookedUp = image
+	return &ReleaseImage{ImageStream: &imageapi.ImageStream{
+		Spec: imageapi.ImageStreamSpec{
+			Tags: []imageapi.TagReference{
+				{Name: "cli", From: &corev1.ObjectReference{Name: "quay.io/openshift-release-dev/ocp-v4.0-art-dev@sha256:0123"}},
+				{Name: "hosted-cluster-config-operator", From: &corev1.ObjectReference{Name: "quay.io/hypershift/hypershift:latest"}},
+			},
+		},
+	}}, nil
+}
+
+func TestRegistryMirrorProviderDecorator(t *testing.T) {
+	delegate := &fakeProvider{}
+	provider := &RegistryMirrorProviderDecorator{Delegate: delegate, ImageContentSources: testImageContentSources}
+	releaseImage, err := provider.Lookup(context.Background(), "quay.io/openshift-release-dev/ocp-release:4.8.0-x86_64")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if expected := "mirror.example.com:5000/ocp/release:4.8.0-x86_64"; delegate.lookedUp != expected {
+		t.Errorf("expected the release image to be resolved from %s, got %s", expected, delegate.lookedUp)
+	}
+	expected := map[string]string{
+		"cli":                            "mirror.example.com:5000/ocp/art-dev@sha256:0123",
+		"hosted-cluster-config-operator": "quay.io/hypershift/hypershift:latest",
+	}
+	if diff := cmp.Diff(expected, releaseImage.ComponentImages()); diff != "" {
+		t.Errorf("unexpected component images (-want +got): %s", diff)
+	}
+}
@@ -6,6 +6,7 @@ require (
 	github.com/aws/aws-sdk-go v1.35.0
 	github.com/blang/semver v3.5.1+incompatible
 	github.com/bombsimon/logrusr v1.0.0
+	github.com/evanphx/json-patch v4.9.0+incompatible
 	github.com/go-logr/logr v0.4.0
 	github.com/gogo/protobuf v1.3.2 // indirect
 	github.com/google/go-cmp v0.5.2
@@ -19,6 +20,7 @@ require (
 	github.com/stretchr/testify v1.6.1
 	github.com/vincent-petithory/dataurl v0.0.0-20191104211930-d1553a71de50
 	golang.org/x/crypto v0.0.0-20201002170205-7f63de1d35b0
+	golang.org/x/net v0.0.0-20201110031124-69a78807bb2b
 	gopkg.in/ini.v1 v1.51.0
 	gopkg.in/square/go-jose.v2 v2.2.2
 	k8s.io/api v0.20.2
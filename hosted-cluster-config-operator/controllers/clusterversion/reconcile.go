@@ -5,18 +5,34 @@ import (
 	"fmt"
 
 	"github.com/go-logr/logr"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kubeclient "k8s.io/client-go/kubernetes"
 
 	ctrl "sigs.k8s.io/controller-runtime"
 
+	configv1 "github.com/openshift/api/config/v1"
 	configclient "github.com/openshift/client-go/config/clientset/versioned"
 	configlister "github.com/openshift/client-go/config/listers/config/v1"
 )
 
+// networkOperatorOverride stops the cluster version operator from running the
+// cluster network operator in a guest cluster with the OVNKubernetes network
+// type, whose cluster network operator runs in the control plane namespace.
+var networkOperatorOverride = configv1.ComponentOverride{
+	Kind:      "Deployment",
+	Group:     "apps",
+	Namespace: "openshift-network-operator",
+	Name:      "network-operator",
+	Unmanaged: true,
+}
+
 type ClusterVersionReconciler struct {
-	Client configclient.Interface
-	Lister configlister.ClusterVersionLister
-	Log    logr.Logger
+	Client        configclient.Interface
+	KubeClient    kubeclient.Interface
+	Lister        configlister.ClusterVersionLister
+	NetworkLister configlister.NetworkLister
+	Log           logr.Logger
 }
 
 func (r *ClusterVersionReconciler) Reconcile(_ context.Context, req ctrl.Request) (ctrl.Result, error) {
@@ -40,10 +56,37 @@ func (r *ClusterVersionReconciler) Reconcile(_ context.Context, req ctrl.Request
 		clusterVersion.Spec.DesiredUpdate = nil
 		updateNeeded = true
 	}
+	network, err := r.NetworkLister.Get("cluster")
+	if err != nil {
+		return ctrl.Result{}, fmt.Errorf("cannot fetch network config: %v", err)
+	}
+	hostedNetworkOperator := network.Spec.NetworkType == "OVNKubernetes"
+	if hostedNetworkOperator && !hasOverride(clusterVersion.Spec.Overrides, networkOperatorOverride) {
+		clusterVersion.Spec.Overrides = append(clusterVersion.Spec.Overrides, networkOperatorOverride)
+		updateNeeded = true
+	}
 	if updateNeeded {
 		r.Log.Info("Updating clusterversion resource to desired values")
 		_, err := r.Client.ConfigV1().ClusterVersions().Update(context.TODO(), clusterVersion, metav1.UpdateOptions{})
 		return ctrl.Result{}, err
 	}
+	// The cluster network operator which the cluster version operator started
+	// before it was overridden is removed.
+	if hostedNetworkOperator {
+		err := r.KubeClient.AppsV1().Deployments(networkOperatorOverride.Namespace).Delete(context.TODO(), networkOperatorOverride.Name, metav1.DeleteOptions{})
+		if err != nil && !apierrors.IsNotFound(err) {
+			return ctrl.Result{}, fmt.Errorf("cannot delete the guest cluster network operator: %v", err)
+		}
+	}
 	return ctrl.Result{}, nil
 }
+
+// hasOverride returns whether the overrides include the override.
+func hasOverride(overrides []configv1.ComponentOverride, override configv1.ComponentOverride) bool {
+	for _, o := range overrides {
+		if o == override {
+			return true
+		}
+	}
+	return false
+}
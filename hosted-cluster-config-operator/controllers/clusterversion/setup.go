@@ -26,10 +26,13 @@ func Setup(cfg *operator.HostedClusterConfigOperatorConfig) error {
 		return nil
 	}))
 	clusterVersions := informerFactory.Config().V1().ClusterVersions()
+	networks := informerFactory.Config().V1().Networks()
 	reconciler := &ClusterVersionReconciler{
-		Client: openshiftClient,
-		Lister: clusterVersions.Lister(),
-		Log:    cfg.Logger().WithName("ClusterVersion"),
+		Client:        openshiftClient,
+		KubeClient:    cfg.TargetKubeClient(),
+		Lister:        clusterVersions.Lister(),
+		NetworkLister: networks.Lister(),
+		Log:           cfg.Logger().WithName("ClusterVersion"),
 	}
 	c, err := controller.New("cluster-version", cfg.Manager(), controller.Options{Reconciler: reconciler})
 	if err != nil {
@@ -0,0 +1,83 @@
+package globalconfig
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/go-logr/logr"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	corelisters "k8s.io/client-go/listers/core/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+)
+
+// GlobalConfigReconciler keeps the spec of the config.openshift.io resources
+// of the guest cluster in sync with the global configuration rendered by the
+// control plane operator.
+type GlobalConfigReconciler struct {
+	// TargetClient is a client for the guest cluster
+	TargetClient client.Client
+
+	// ConfigMapLister is a lister for configmaps in the control plane namespace
+	ConfigMapLister corelisters.ConfigMapLister
+
+	// Namespace is the namespace where the control plane of the cluster
+	// lives on the management server
+	Namespace string
+
+	// Log is the logger for this controller
+	Log logr.Logger
+}
+
+func (r *GlobalConfigReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	if req.Namespace != r.Namespace || req.Name != globalConfigMap {
+		return ctrl.Result{}, nil
+	}
+	configMap, err := r.ConfigMapLister.ConfigMaps(r.Namespace).Get(globalConfigMap)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return ctrl.Result{}, nil
+		}
+		return ctrl.Result{}, err
+	}
+
+	keys := make([]string, 0, len(configMap.Data))
+	for key := range configMap.Data {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		desired := &unstructured.Unstructured{}
+		if err := yaml.Unmarshal([]byte(configMap.Data[key]), &desired.Object); err != nil {
+			return ctrl.Result{}, fmt.Errorf("cannot decode %s: %w", key, err)
+		}
+		if err := r.apply(ctx, desired); err != nil {
+			return ctrl.Result{}, fmt.Errorf("cannot apply %s: %w", key, err)
+		}
+	}
+	return ctrl.Result{}, nil
+}
+
+// apply creates the given resource in the guest cluster, or updates the spec
+// of the existing resource to match it.
+func (r *GlobalConfigReconciler) apply(ctx context.Context, desired *unstructured.Unstructured) error {
+	existing := &unstructured.Unstructured{}
+	existing.SetGroupVersionKind(desired.GroupVersionKind())
+	if err := r.TargetClient.Get(ctx, client.ObjectKeyFromObject(desired), existing); err != nil {
+		if !errors.IsNotFound(err) {
+			return err
+		}
+		r.Log.Info("Creating configuration", "kind", desired.GetKind())
+		return r.TargetClient.Create(ctx, desired)
+	}
+	if equality.Semantic.DeepEqual(existing.Object["spec"], desired.Object["spec"]) {
+		return nil
+	}
+	existing.Object["spec"] = desired.Object["spec"]
+	r.Log.Info("Updating configuration", "kind", desired.GetKind())
+	return r.TargetClient.Update(ctx, existing)
+}
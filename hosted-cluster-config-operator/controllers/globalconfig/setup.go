@@ -0,0 +1,74 @@
+package globalconfig
+
+import (
+	"context"
+
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/informers"
+	"k8s.io/client-go/tools/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	"github.com/openshift/hypershift/hosted-cluster-config-operator/controllers"
+	"github.com/openshift/hypershift/hosted-cluster-config-operator/operator"
+)
+
+const (
+	// globalConfigMap is rendered by the control plane operator and contains
+	// the config.openshift.io resources of the guest cluster.
+	globalConfigMap = "global-config"
+)
+
+func Setup(cfg *operator.HostedClusterConfigOperatorConfig) error {
+	informerFactory := informers.NewSharedInformerFactoryWithOptions(cfg.KubeClient(), controllers.DefaultResync, informers.WithNamespace(cfg.Namespace()))
+	cfg.Manager().Add(manager.RunnableFunc(func(ctx context.Context) error {
+		informerFactory.Start(ctx.Done())
+		return nil
+	}))
+	configMaps := informerFactory.Core().V1().ConfigMaps()
+
+	targetClient, err := client.New(cfg.TargetConfig(), client.Options{})
+	if err != nil {
+		return err
+	}
+	reconciler := &GlobalConfigReconciler{
+		TargetClient:    targetClient,
+		ConfigMapLister: configMaps.Lister(),
+		Namespace:       cfg.Namespace(),
+		Log:             cfg.Logger().WithName("GlobalConfig"),
+	}
+	c, err := controller.New("global-config", cfg.Manager(), controller.Options{Reconciler: reconciler})
+	if err != nil {
+		return err
+	}
+	if err := c.Watch(&source.Informer{Informer: configMaps.Informer()}, controllers.NamedResourceHandler(globalConfigMap)); err != nil {
+		return err
+	}
+
+	// Changes made to the configuration resources in the guest cluster are
+	// reverted to the configuration of the HostedCluster.
+	enqueueConfigMap := handler.EnqueueRequestsFromMapFunc(func(client.Object) []reconcile.Request {
+		return []reconcile.Request{{NamespacedName: types.NamespacedName{Namespace: cfg.Namespace(), Name: globalConfigMap}}}
+	})
+	configInformers := cfg.TargetConfigInformers().Config().V1()
+	for _, informer := range []cache.SharedIndexInformer{
+		configInformers.APIServers().Informer(),
+		configInformers.OAuths().Informer(),
+		configInformers.Proxies().Informer(),
+		configInformers.Images().Informer(),
+		configInformers.Networks().Informer(),
+		configInformers.Schedulers().Informer(),
+		configInformers.Ingresses().Informer(),
+		configInformers.FeatureGates().Informer(),
+		configInformers.Builds().Informer(),
+	} {
+		if err := c.Watch(&source.Informer{Informer: informer}, enqueueConfigMap); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,74 @@
+package kubeadminpwd
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-logr/logr"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kubeclient "k8s.io/client-go/kubernetes"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	hyperv1 "github.com/openshift/hypershift/api/v1alpha1"
+)
+
+const (
+	// KubeadminPasswordSecret is the secret holding the kubeadmin password in
+	// the control plane namespace. The control plane operator removes it when
+	// the kubeadmin user is disabled.
+	KubeadminPasswordSecret = "kubeadmin-password"
+)
+
+// KubeadminRemover removes the kubeadmin user from the target cluster once the
+// HostedControlPlane explicitly disables it. A missing kubeadmin password
+// secret alone never removes the kubeadmin user, since it may have been
+// deleted by accident.
+type KubeadminRemover struct {
+	// Client is a client that allows access to the HostedControlPlane in the
+	// management cluster
+	Client client.Reader
+
+	// TargetClient is a client that allows access to the target cluster
+	TargetClient kubeclient.Interface
+
+	// Log is the logger for this controller
+	Log logr.Logger
+
+	// Namespace is the namespace where the control plane of the cluster
+	// lives on the management server
+	Namespace string
+}
+
+func (k *KubeadminRemover) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	disabled, err := k.kubeadminDisabled(ctx)
+	if err != nil || !disabled {
+		return ctrl.Result{}, err
+	}
+	err = k.TargetClient.CoreV1().Secrets(metav1.NamespaceSystem).Delete(ctx, KubeAdminSecret, metav1.DeleteOptions{})
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return ctrl.Result{}, nil
+		}
+		return ctrl.Result{}, err
+	}
+	k.Log.Info("Removed the kubeadmin user")
+	return ctrl.Result{}, nil
+}
+
+// kubeadminDisabled returns whether the HostedControlPlane of the namespace
+// disables the kubeadmin user.
+func (k *KubeadminRemover) kubeadminDisabled(ctx context.Context) (bool, error) {
+	hcpList := &hyperv1.HostedControlPlaneList{}
+	if err := k.Client.List(ctx, hcpList, client.InNamespace(k.Namespace)); err != nil {
+		return false, fmt.Errorf("failed to list hosted control planes: %w", err)
+	}
+	for _, hcp := range hcpList.Items {
+		if hcp.Spec.OAuth != nil && hcp.Spec.OAuth.DisableKubeadmin {
+			return true, nil
+		}
+	}
+	return false, nil
+}
@@ -0,0 +1,85 @@
+package kubeadminpwd
+
+import (
+	"context"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/fake"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	crfake "sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/stretchr/testify/assert"
+
+	hyperapi "github.com/openshift/hypershift/api"
+	hyperv1 "github.com/openshift/hypershift/api/v1alpha1"
+)
+
+func TestKubeadminRemover(t *testing.T) {
+	tests := []struct {
+		name          string
+		hcp           *hyperv1.HostedControlPlane
+		expectRemoved bool
+	}{
+		{
+			name:          "kubeadmin enabled",
+			hcp:           &hyperv1.HostedControlPlane{},
+			expectRemoved: false,
+		},
+		{
+			name: "kubeadmin explicitly enabled",
+			hcp: &hyperv1.HostedControlPlane{
+				Spec: hyperv1.HostedControlPlaneSpec{OAuth: &hyperv1.OAuthSpec{DisableKubeadmin: false}},
+			},
+			expectRemoved: false,
+		},
+		{
+			name: "kubeadmin disabled",
+			hcp: &hyperv1.HostedControlPlane{
+				Spec: hyperv1.HostedControlPlaneSpec{OAuth: &hyperv1.OAuthSpec{DisableKubeadmin: true}},
+			},
+			expectRemoved: true,
+		},
+		{
+			name:          "no hosted control plane",
+			expectRemoved: false,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// The kubeadmin password secret is missing in every case: only the
+			// HostedControlPlane decides whether the kubeadmin user is removed.
+			var hostObjects []client.Object
+			if test.hcp != nil {
+				test.hcp.Namespace = hostedNamespace
+				test.hcp.Name = "hcp"
+				hostObjects = append(hostObjects, test.hcp)
+			}
+			targetClient := fake.NewSimpleClientset(fakeSecret("12345"))
+			remover := &KubeadminRemover{
+				Client:       crfake.NewClientBuilder().WithScheme(hyperapi.Scheme).WithObjects(hostObjects...).Build(),
+				TargetClient: targetClient,
+				Log:          ctrl.Log.WithName("reconcile-test"),
+				Namespace:    hostedNamespace,
+			}
+			req := ctrl.Request{
+				NamespacedName: types.NamespacedName{
+					Namespace: metav1.NamespaceSystem,
+					Name:      KubeAdminSecret,
+				},
+			}
+			_, err := remover.Reconcile(context.TODO(), req)
+			assert.NoError(t, err, "Unexpected error")
+			_, err = targetClient.CoreV1().Secrets(metav1.NamespaceSystem).Get(context.TODO(), KubeAdminSecret, metav1.GetOptions{})
+			assert.Equal(t, test.expectRemoved, errors.IsNotFound(err), "unexpected kubeadmin secret state: %v", err)
+
+			// Reconciling again once the secret is gone is a no-op
+			_, err = remover.Reconcile(context.TODO(), req)
+			assert.NoError(t, err, "Unexpected error")
+		})
+	}
+}
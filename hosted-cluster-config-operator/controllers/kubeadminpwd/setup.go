@@ -38,5 +38,28 @@ func Setup(cfg *operator.HostedClusterConfigOperatorConfig) error {
 	if err := c.Watch(&source.Informer{Informer: secrets.Informer()}, &handler.EnqueueRequestForObject{}); err != nil {
 		return err
 	}
+
+	hostInformerFactory := informers.NewSharedInformerFactoryWithOptions(cfg.KubeClient(), controllers.DefaultResync, informers.WithNamespace(cfg.Namespace()))
+	cfg.Manager().Add(manager.RunnableFunc(func(ctx context.Context) error {
+		hostInformerFactory.Start(ctx.Done())
+		return nil
+	}))
+	hostSecrets := hostInformerFactory.Core().V1().Secrets()
+	remover := &KubeadminRemover{
+		Client:       cfg.HyperClient(),
+		TargetClient: cfg.TargetKubeClient(),
+		Namespace:    cfg.Namespace(),
+		Log:          cfg.Logger().WithName("KubeadminRemover"),
+	}
+	c, err = controller.New("kubeadmin-remover", cfg.Manager(), controller.Options{Reconciler: remover})
+	if err != nil {
+		return err
+	}
+	if err := c.Watch(&source.Informer{Informer: secrets.Informer()}, controllers.NamedResourceHandler(KubeAdminSecret)); err != nil {
+		return err
+	}
+	if err := c.Watch(&source.Informer{Informer: hostSecrets.Informer()}, controllers.NamedResourceHandler(KubeadminPasswordSecret)); err != nil {
+		return err
+	}
 	return nil
 }
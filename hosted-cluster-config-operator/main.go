@@ -17,6 +17,7 @@ import (
 	"github.com/openshift/hypershift/hosted-cluster-config-operator/controllers/clusteroperator"
 	"github.com/openshift/hypershift/hosted-cluster-config-operator/controllers/clusterversion"
 	"github.com/openshift/hypershift/hosted-cluster-config-operator/controllers/cmca"
+	"github.com/openshift/hypershift/hosted-cluster-config-operator/controllers/globalconfig"
 	"github.com/openshift/hypershift/hosted-cluster-config-operator/controllers/infrastatus"
 	"github.com/openshift/hypershift/hosted-cluster-config-operator/controllers/kubeadminpwd"
 	"github.com/openshift/hypershift/hosted-cluster-config-operator/controllers/kubeletservingca"
@@ -43,6 +44,7 @@ var controllerFuncs = map[string]operator.ControllerSetupFunc{
 	"cluster-operator":      clusteroperator.Setup,
 	"auto-approver":         autoapprover.Setup,
 	"kubeadmin-password":    kubeadminpwd.Setup,
+	"global-config":         globalconfig.Setup,
 	"cluster-version":       clusterversion.Setup,
 	"kubelet-serving-ca":    kubeletservingca.Setup,
 	// TODO: non-essential, can't statically link to operator
@@ -14,11 +14,13 @@ import (
 	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/clientcmd"
 	ctrl "sigs.k8s.io/controller-runtime"
+	crclient "sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
 
 	configclient "github.com/openshift/client-go/config/clientset/versioned"
 	configinformers "github.com/openshift/client-go/config/informers/externalversions"
 
+	hyperapi "github.com/openshift/hypershift/api"
 	common "github.com/openshift/hypershift/hosted-cluster-config-operator/controllers"
 )
 
@@ -41,6 +43,7 @@ type HostedClusterConfigOperatorConfig struct {
 	targetConfig     *rest.Config
 	targetKubeClient kubeclient.Interface
 	kubeClient       kubeclient.Interface
+	hyperClient      crclient.Client
 	logger           logr.Logger
 	scheme           *runtime.Scheme
 
@@ -168,6 +171,19 @@ func (c *HostedClusterConfigOperatorConfig) KubeClient() kubeclient.Interface {
 	return c.kubeClient
 }
 
+// HyperClient returns a client of the management cluster which can read the
+// HyperShift resources of the control plane namespace.
+func (c *HostedClusterConfigOperatorConfig) HyperClient() crclient.Client {
+	if c.hyperClient == nil {
+		var err error
+		c.hyperClient, err = crclient.New(c.Config(), crclient.Options{Scheme: hyperapi.Scheme})
+		if err != nil {
+			c.Fatal(err, "cannot get management hypershift client")
+		}
+	}
+	return c.hyperClient
+}
+
 func (c *HostedClusterConfigOperatorConfig) Versions() map[string]string {
 	return c.versions
 }
@@ -0,0 +1,69 @@
+package hostedcluster
+
+import (
+	"fmt"
+
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	hyperv1 "github.com/openshift/hypershift/api/v1alpha1"
+)
+
+// autoscalerArgs returns the arguments of the cluster autoscaler which apply
+// the autoscaling settings of a HostedCluster. Unset settings keep the
+// defaults of the cluster autoscaler.
+func autoscalerArgs(autoscaling *hyperv1.ClusterAutoscaling) []string {
+	if autoscaling == nil {
+		return nil
+	}
+	var args []string
+	if autoscaling.ScaleDownDelayAfterAdd != nil {
+		args = append(args, fmt.Sprintf("--scale-down-delay-after-add=%s", autoscaling.ScaleDownDelayAfterAdd.Duration))
+	}
+	if autoscaling.ScaleDownUtilizationThreshold != nil {
+		args = append(args, fmt.Sprintf("--scale-down-utilization-threshold=%s", *autoscaling.ScaleDownUtilizationThreshold))
+	}
+	if autoscaling.MaxNodesTotal != nil {
+		args = append(args, fmt.Sprintf("--max-nodes-total=%d", *autoscaling.MaxNodesTotal))
+	}
+	if len(autoscaling.Expander) > 0 {
+		args = append(args, fmt.Sprintf("--expander=%s", autoscaling.Expander))
+	}
+	if autoscaling.BalanceSimilarNodeGroups {
+		args = append(args, "--balance-similar-node-groups")
+	}
+	if autoscaling.MaxPodGracePeriod != nil {
+		args = append(args, fmt.Sprintf("--max-graceful-termination-sec=%d", *autoscaling.MaxPodGracePeriod))
+	}
+	return args
+}
+
+// computeAutoscalingActiveCondition determines the AutoscalingActive condition
+// for the given HostedCluster from its NodePools and its autoscaler deployment,
+// which is nil when it does not exist yet.
+func computeAutoscalingActiveCondition(hcluster *hyperv1.HostedCluster, nodePools []hyperv1.NodePool, deployment *appsv1.Deployment) metav1.Condition {
+	condition := metav1.Condition{
+		Type:               string(hyperv1.AutoscalingActive),
+		Status:             metav1.ConditionFalse,
+		ObservedGeneration: hcluster.Generation,
+	}
+	var autoscaledNodePools int
+	for _, nodePool := range nodePools {
+		if nodePool.Spec.AutoScaling != nil {
+			autoscaledNodePools++
+		}
+	}
+	switch {
+	case autoscaledNodePools == 0:
+		condition.Reason = "NoAutoscaledNodePools"
+		condition.Message = "No NodePool has autoscaling enabled"
+	case deployment == nil || deployment.Status.AvailableReplicas == 0:
+		condition.Reason = "AutoscalerUnavailable"
+		condition.Message = "The cluster autoscaler is not available"
+	default:
+		condition.Status = metav1.ConditionTrue
+		condition.Reason = "AutoscalingActive"
+		condition.Message = fmt.Sprintf("The cluster autoscaler scales %d NodePools", autoscaledNodePools)
+	}
+	return condition
+}
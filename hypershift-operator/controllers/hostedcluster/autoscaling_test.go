@@ -0,0 +1,113 @@
+package hostedcluster
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8sutilspointer "k8s.io/utils/pointer"
+
+	hyperv1 "github.com/openshift/hypershift/api/v1alpha1"
+)
+
+func TestAutoscalerArgs(t *testing.T) {
+	tests := map[string]struct {
+		Autoscaling *hyperv1.ClusterAutoscaling
+		Expected    []string
+	}{
+		"no autoscaling settings": {},
+		"empty autoscaling settings": {
+			Autoscaling: &hyperv1.ClusterAutoscaling{},
+		},
+		"all autoscaling settings": {
+			Autoscaling: &hyperv1.ClusterAutoscaling{
+				ScaleDownDelayAfterAdd:        &metav1.Duration{Duration: 5 * time.Minute},
+				ScaleDownUtilizationThreshold: k8sutilspointer.StringPtr("0.4"),
+				MaxNodesTotal:                 k8sutilspointer.Int32Ptr(20),
+				Expander:                      hyperv1.LeastWasteExpander,
+				BalanceSimilarNodeGroups:      true,
+				MaxPodGracePeriod:             k8sutilspointer.Int32Ptr(300),
+			},
+			Expected: []string{
+				"--scale-down-delay-after-add=5m0s",
+				"--scale-down-utilization-threshold=0.4",
+				"--max-nodes-total=20",
+				"--expander=least-waste",
+				"--balance-similar-node-groups",
+				"--max-graceful-termination-sec=300",
+			},
+		},
+		"zero max nodes total": {
+			Autoscaling: &hyperv1.ClusterAutoscaling{MaxNodesTotal: k8sutilspointer.Int32Ptr(0)},
+			Expected:    []string{"--max-nodes-total=0"},
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			if diff := cmp.Diff(test.Expected, autoscalerArgs(test.Autoscaling)); diff != "" {
+				t.Errorf("unexpected args (-want +got): %s", diff)
+			}
+		})
+	}
+}
+
+func TestComputeAutoscalingActiveCondition(t *testing.T) {
+	autoscaledNodePool := hyperv1.NodePool{
+		ObjectMeta: metav1.ObjectMeta{Name: "autoscaled"},
+		Spec:       hyperv1.NodePoolSpec{AutoScaling: &hyperv1.NodePoolAutoScaling{}},
+	}
+	fixedNodePool := hyperv1.NodePool{
+		ObjectMeta: metav1.ObjectMeta{Name: "fixed"},
+		Spec:       hyperv1.NodePoolSpec{NodeCount: k8sutilspointer.Int32Ptr(2)},
+	}
+	availableDeployment := &appsv1.Deployment{Status: appsv1.DeploymentStatus{AvailableReplicas: 1}}
+	tests := map[string]struct {
+		NodePools      []hyperv1.NodePool
+		Deployment     *appsv1.Deployment
+		ExpectedStatus metav1.ConditionStatus
+		ExpectedReason string
+	}{
+		"no nodepools": {
+			Deployment:     availableDeployment,
+			ExpectedStatus: metav1.ConditionFalse,
+			ExpectedReason: "NoAutoscaledNodePools",
+		},
+		"no autoscaled nodepools": {
+			NodePools:      []hyperv1.NodePool{fixedNodePool},
+			Deployment:     availableDeployment,
+			ExpectedStatus: metav1.ConditionFalse,
+			ExpectedReason: "NoAutoscaledNodePools",
+		},
+		"missing autoscaler": {
+			NodePools:      []hyperv1.NodePool{fixedNodePool, autoscaledNodePool},
+			ExpectedStatus: metav1.ConditionFalse,
+			ExpectedReason: "AutoscalerUnavailable",
+		},
+		"unavailable autoscaler": {
+			NodePools:      []hyperv1.NodePool{autoscaledNodePool},
+			Deployment:     &appsv1.Deployment{},
+			ExpectedStatus: metav1.ConditionFalse,
+			ExpectedReason: "AutoscalerUnavailable",
+		},
+		"active autoscaling": {
+			NodePools:      []hyperv1.NodePool{fixedNodePool, autoscaledNodePool},
+			Deployment:     availableDeployment,
+			ExpectedStatus: metav1.ConditionTrue,
+			ExpectedReason: "AutoscalingActive",
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			hcluster := &hyperv1.HostedCluster{ObjectMeta: metav1.ObjectMeta{Generation: 3}}
+			condition := computeAutoscalingActiveCondition(hcluster, test.NodePools, test.Deployment)
+			if condition.Type != string(hyperv1.AutoscalingActive) || condition.ObservedGeneration != hcluster.Generation {
+				t.Errorf("unexpected condition %s for generation %d", condition.Type, condition.ObservedGeneration)
+			}
+			if condition.Status != test.ExpectedStatus || condition.Reason != test.ExpectedReason {
+				t.Errorf("expected %s %s, got %s %s", test.ExpectedStatus, test.ExpectedReason, condition.Status, condition.Reason)
+			}
+		})
+	}
+}
@@ -19,6 +19,7 @@ package hostedcluster
 import (
 	"context"
 	"fmt"
+	"sort"
 	"strings"
 	"time"
 
@@ -30,8 +31,11 @@ import (
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/clock"
 	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/tools/record"
 	"k8s.io/client-go/util/workqueue"
 	k8sutilspointer "k8s.io/utils/pointer"
 	ctrl "sigs.k8s.io/controller-runtime"
@@ -44,11 +48,13 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/source"
 
 	hyperv1 "github.com/openshift/hypershift/api/v1alpha1"
+	"github.com/openshift/hypershift/control-plane-operator/releaseinfo"
 	"github.com/openshift/hypershift/hypershift-operator/controllers/manifests"
 	"github.com/openshift/hypershift/hypershift-operator/controllers/manifests/autoscaler"
 	"github.com/openshift/hypershift/hypershift-operator/controllers/manifests/clusterapi"
 	"github.com/openshift/hypershift/hypershift-operator/controllers/manifests/controlplaneoperator"
-	hyperutil "github.com/openshift/hypershift/hypershift-operator/controllers/util"
+	"github.com/openshift/hypershift/hypershift-operator/webhook"
+	hyperutil "github.com/openshift/hypershift/support/util"
 	capiv1 "github.com/openshift/hypershift/thirdparty/clusterapi/api/v1alpha4"
 )
 
@@ -56,6 +62,10 @@ const (
 	finalizer                      = "hypershift.openshift.io/finalizer"
 	hostedClusterAnnotation        = "hypershift.openshift.io/cluster"
 	clusterDeletionRequeueDuration = time.Duration(5 * time.Second)
+
+	// NodePoolClusterNameIndex indexes NodePools by the name of the
+	// HostedCluster they belong to.
+	NodePoolClusterNameIndex = "spec.clusterName"
 )
 
 // NoopReconcile is just a default mutation function that does nothing.
@@ -68,6 +78,24 @@ type HostedClusterReconciler struct {
 	Log           logr.Logger
 	OperatorImage string
 	Clock         clock.Clock
+
+	// ImageOverrides maps the names of the components managed next to each
+	// control plane to the images which replace their default images.
+	ImageOverrides map[string]string
+
+	// AllowedImageAnnotations are the names of the components whose images
+	// may be overridden per HostedCluster with an annotation.
+	AllowedImageAnnotations sets.String
+
+	// ReleaseProvider looks up the release payloads which ship some of the
+	// components managed next to each control plane.
+	ReleaseProvider releaseinfo.Provider
+
+	recorder record.EventRecorder
+
+	// nodePoolReader reads NodePools from the manager cache, which is the
+	// only reader supporting the NodePoolClusterNameIndex.
+	nodePoolReader client.Reader
 }
 
 // +kubebuilder:rbac:groups=hypershift.openshift.io,resources=hostedclusters,verbs=get;list;watch;create;update;patch;delete
@@ -77,11 +105,22 @@ func (r *HostedClusterReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	if r.Clock == nil {
 		r.Clock = clock.RealClock{}
 	}
+	r.recorder = mgr.GetEventRecorderFor("hostedcluster-controller")
+	r.nodePoolReader = mgr.GetCache()
+	if err := mgr.GetFieldIndexer().IndexField(context.Background(), &hyperv1.NodePool{}, NodePoolClusterNameIndex, func(obj client.Object) []string {
+		return []string{obj.(*hyperv1.NodePool).Spec.ClusterName}
+	}); err != nil {
+		return fmt.Errorf("failed to index nodepools by cluster name: %w", err)
+	}
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&hyperv1.HostedCluster{}).
 		Watches(&source.Kind{Type: &hyperv1.ExternalInfraCluster{}}, handler.EnqueueRequestsFromMapFunc(enqueueParentHostedCluster)).
 		Watches(&source.Kind{Type: &hyperv1.HostedControlPlane{}}, handler.EnqueueRequestsFromMapFunc(enqueueParentHostedCluster)).
 		Watches(&source.Kind{Type: &capiv1.Cluster{}}, handler.EnqueueRequestsFromMapFunc(enqueueParentHostedCluster)).
+		Watches(&source.Kind{Type: &corev1.Secret{}}, handler.EnqueueRequestsFromMapFunc(r.enqueueHostedClustersForReferencedResource)).
+		Watches(&source.Kind{Type: &corev1.ConfigMap{}}, handler.EnqueueRequestsFromMapFunc(r.enqueueHostedClustersForReferencedResource)).
+		Watches(&source.Kind{Type: &hyperv1.NodePool{}}, handler.EnqueueRequestsFromMapFunc(enqueueNodePoolHostedCluster)).
+		Watches(&source.Kind{Type: &appsv1.Deployment{}}, handler.EnqueueRequestsFromMapFunc(enqueueParentHostedCluster)).
 		WithOptions(controller.Options{
 			RateLimiter: workqueue.NewItemExponentialFailureRateLimiter(1*time.Second, 10*time.Second),
 		}).
@@ -144,36 +183,86 @@ func (r *HostedClusterReconciler) Reconcile(ctx context.Context, req ctrl.Reques
 		}
 	}
 
-	// Set version status
+	// Set kubeadmin password status
 	{
-		controlPlaneNamespace := manifests.HostedControlPlaneNamespace(hcluster.Namespace, hcluster.Name)
-		hcp := controlplaneoperator.HostedControlPlane(controlPlaneNamespace.Name, hcluster.Name)
-		err := r.Client.Get(ctx, client.ObjectKeyFromObject(hcp), hcp)
+		kubeadminPasswordSecret := manifests.KubeadminPasswordSecret(hcluster.Namespace, hcluster.Name)
+		err := r.Client.Get(ctx, client.ObjectKeyFromObject(kubeadminPasswordSecret), kubeadminPasswordSecret)
 		if err != nil {
-			if apierrors.IsNotFound(err) {
-				hcp = nil
-			} else {
-				return ctrl.Result{}, fmt.Errorf("failed to get hostedcontrolplane: %w", err)
+			if !apierrors.IsNotFound(err) {
+				return ctrl.Result{}, fmt.Errorf("failed to get kubeadmin password secret: %w", err)
 			}
+			hcluster.Status.KubeadminPassword = nil
+		} else {
+			hcluster.Status.KubeadminPassword = &corev1.LocalObjectReference{Name: kubeadminPasswordSecret.Name}
+		}
+	}
+
+	// Get the hosted control plane, which reports part of the status. It does
+	// not exist until the cluster is first reconciled.
+	currentHCP := controlplaneoperator.HostedControlPlane(manifests.HostedControlPlaneNamespace(hcluster.Namespace, hcluster.Name).Name, hcluster.Name)
+	if err := r.Client.Get(ctx, client.ObjectKeyFromObject(currentHCP), currentHCP); err != nil {
+		if !apierrors.IsNotFound(err) {
+			return ctrl.Result{}, fmt.Errorf("failed to get hostedcontrolplane: %w", err)
 		}
-		hcluster.Status.Version = computeClusterVersionStatus(r.Clock, hcluster, hcp)
+		currentHCP = nil
 	}
 
-	// Set the Available condition
+	// Set version status
+	hcluster.Status.Version = computeClusterVersionStatus(r.Clock, hcluster, currentHCP)
+
+	// Set the nodepool status
 	{
-		controlPlaneNamespace := manifests.HostedControlPlaneNamespace(hcluster.Namespace, hcluster.Name)
-		hcp := controlplaneoperator.HostedControlPlane(controlPlaneNamespace.Name, hcluster.Name)
-		err := r.Client.Get(ctx, client.ObjectKeyFromObject(hcp), hcp)
+		nodePools, err := r.listNodePools(ctx, hcluster.Namespace, hcluster.Name)
 		if err != nil {
-			if apierrors.IsNotFound(err) {
-				hcp = nil
-			} else {
-				return ctrl.Result{}, fmt.Errorf("failed to get hostedcontrolplane: %w", err)
+			return ctrl.Result{}, fmt.Errorf("failed to list nodepools: %w", err)
+		}
+		hcluster.Status.NodePools, hcluster.Status.ReadyNodes = computeNodePoolStatus(nodePools)
+		hcluster.Status.SizingProfile = computeSizingProfile(hcluster.Spec.Sizing, hcluster.Status.NodePools, hcluster.Status.SizingProfile)
+
+		controlPlaneNamespace := manifests.HostedControlPlaneNamespace(hcluster.Namespace, hcluster.Name)
+		autoScalerDeployment := autoscaler.AutoScalerDeployment(controlPlaneNamespace.Name)
+		if err := r.Client.Get(ctx, client.ObjectKeyFromObject(autoScalerDeployment), autoScalerDeployment); err != nil {
+			if !apierrors.IsNotFound(err) {
+				return ctrl.Result{}, fmt.Errorf("failed to get autoscaler deployment: %w", err)
 			}
+			autoScalerDeployment = nil
 		}
-		meta.SetStatusCondition(&hcluster.Status.Conditions, computeHostedClusterAvailability(hcluster, hcp))
+		meta.SetStatusCondition(&hcluster.Status.Conditions, computeAutoscalingActiveCondition(hcluster, nodePools, autoScalerDeployment))
+	}
+
+	// Set the endpoint status reported by the hosted control plane
+	if currentHCP != nil {
+		hcluster.Status.ControlPlaneEndpoint = currentHCP.Status.ControlPlaneEndpoint
+		hcluster.Status.ConsoleURL = currentHCP.Status.ConsoleURL
+		hcluster.Status.OAuthCallbackURL = currentHCP.Status.OAuthCallbackURL
 	}
 
+	// Validate the spec
+	validationErrs := webhook.ValidateHostedCluster(hcluster)
+
+	// Set the Available condition, the ValidConfiguration condition and the
+	// conditions bubbled up from the hosted control plane
+	meta.SetStatusCondition(&hcluster.Status.Conditions, computeHostedClusterAvailability(hcluster, currentHCP))
+	for _, condition := range computeHostedControlPlaneConditions(hcluster, currentHCP) {
+		meta.SetStatusCondition(&hcluster.Status.Conditions, condition)
+	}
+	r.setValidationCondition(hcluster, computeValidConfigurationCondition(hcluster, currentHCP, validationErrs))
+
+	// Validate the resources referenced by the spec
+	referenceProblems, err := r.validateReferencedResources(ctx, hcluster)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	r.setValidationCondition(hcluster, computeValidReferencedResourcesCondition(hcluster, referenceProblems))
+	isValid := len(validationErrs) == 0 && len(referenceProblems) == 0
+
+	// Set the ReconciliationPaused condition
+	isPaused, pausedDuration, err := hyperutil.IsReconciliationPaused(r.Clock.Now(), hcluster.Spec.PausedUntil)
+	if err != nil {
+		r.Log.Error(err, "ignoring invalid pausedUntil value")
+	}
+	meta.SetStatusCondition(&hcluster.Status.Conditions, computeReconciliationPausedCondition(hcluster, isPaused))
+
 	// Persist status updates
 	if err := r.Client.Status().Update(ctx, hcluster); err != nil {
 		if apierrors.IsConflict(err) {
@@ -184,6 +273,24 @@ func (r *HostedClusterReconciler) Reconcile(ctx context.Context, req ctrl.Reques
 
 	// Part two: reconcile the state of the world
 
+	// While paused, only propagate the paused state to the resources beneath
+	// the HostedCluster so that their controllers stop reconciling as well.
+	if isPaused {
+		r.Log.Info("reconciliation is paused", "pausedUntil", *hcluster.Spec.PausedUntil)
+		if err := r.pauseControlPlane(ctx, hcluster); err != nil {
+			return ctrl.Result{}, fmt.Errorf("failed to pause control plane: %w", err)
+		}
+		return ctrl.Result{RequeueAfter: pausedDuration}, nil
+	}
+
+	// Don't reconcile an invalid cluster any further. The problems are reported
+	// on the status, and watches on the referenced secrets and the cluster
+	// itself trigger a new attempt once the inputs change.
+	if !isValid {
+		r.Log.Info("hostedcluster failed validation, waiting for its inputs to change")
+		return ctrl.Result{}, nil
+	}
+
 	// Ensure the cluster has a finalizer for cleanup and update right away.
 	if !controllerutil.ContainsFinalizer(hcluster, finalizer) {
 		controllerutil.AddFinalizer(hcluster, finalizer)
@@ -252,6 +359,60 @@ func (r *HostedClusterReconciler) Reconcile(ctx context.Context, req ctrl.Reques
 		})
 	}
 
+	// Reconcile the platform provider DNS management credentials secret, if the
+	// HostedCluster references one, by syncing the secret in the control plane
+	// namespace.
+	if hcluster.Spec.Platform.Type == hyperv1.AWSPlatform && len(hcluster.Spec.Platform.AWS.DNSManagementCreds.Name) > 0 {
+		var src corev1.Secret
+		err = r.Client.Get(ctx, client.ObjectKey{Namespace: hcluster.GetNamespace(), Name: hcluster.Spec.Platform.AWS.DNSManagementCreds.Name}, &src)
+		if err != nil {
+			return ctrl.Result{}, fmt.Errorf("failed to get dns management creds %s: %w", hcluster.Spec.Platform.AWS.DNSManagementCreds.Name, err)
+		}
+		dest := manifests.AWSDNSManagementCreds(controlPlaneNamespace.Name)
+		_, err = controllerutil.CreateOrUpdate(ctx, r.Client, dest, func() error {
+			srcData, srcHasData := src.Data["credentials"]
+			if !srcHasData {
+				return fmt.Errorf("dns management credentials secret %q is missing credentials key", src.Name)
+			}
+			dest.Type = corev1.SecretTypeOpaque
+			if dest.Data == nil {
+				dest.Data = map[string][]byte{}
+			}
+			dest.Data["credentials"] = srcData
+			return nil
+		})
+		if err != nil {
+			return ctrl.Result{}, fmt.Errorf("failed to reconcile dns management creds: %w", err)
+		}
+	}
+
+	// Reconcile the platform provider control plane operator credentials secret,
+	// if the HostedCluster references one, by syncing the secret in the control
+	// plane namespace.
+	if hcluster.Spec.Platform.Type == hyperv1.AWSPlatform && len(hcluster.Spec.Platform.AWS.ControlPlaneOperatorCreds.Name) > 0 {
+		var src corev1.Secret
+		err = r.Client.Get(ctx, client.ObjectKey{Namespace: hcluster.GetNamespace(), Name: hcluster.Spec.Platform.AWS.ControlPlaneOperatorCreds.Name}, &src)
+		if err != nil {
+			return ctrl.Result{}, fmt.Errorf("failed to get control plane operator creds %s: %w", hcluster.Spec.Platform.AWS.ControlPlaneOperatorCreds.Name, err)
+		}
+		dest := manifests.AWSControlPlaneOperatorCreds(controlPlaneNamespace.Name)
+		_, err = controllerutil.CreateOrUpdate(ctx, r.Client, dest, func() error {
+			srcData, srcHasData := src.Data["credentials"]
+			if !srcHasData {
+				return fmt.Errorf("control plane operator credentials secret %q is missing credentials key", src.Name)
+			}
+			dest.Type = corev1.SecretTypeOpaque
+			if dest.Data == nil {
+				dest.Data = map[string][]byte{}
+			}
+			dest.Data["credentials"] = srcData
+			return nil
+		})
+		if err != nil {
+			return ctrl.Result{}, fmt.Errorf("failed to reconcile control plane operator creds: %w", err)
+		}
+	}
+
 	// Reconcile the HostedControlPlane pull secret by resolving the source secret
 	// reference from the HostedCluster and syncing the secret in the control plane namespace.
 	{
@@ -322,6 +483,24 @@ func (r *HostedClusterReconciler) Reconcile(ctx context.Context, req ctrl.Reques
 		}
 	}
 
+	// Reconcile the secrets and config maps referenced by identity providers by
+	// syncing them in the control plane namespace.
+	if err := r.reconcileIdentityProviderObjects(ctx, hcluster, controlPlaneNamespace.Name); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	// Reconcile the serving certificates of the published services by syncing
+	// them in the control plane namespace.
+	if err := r.reconcileServingCerts(ctx, hcluster, controlPlaneNamespace.Name); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	// Reconcile the additional trust bundle by syncing it in the control plane
+	// namespace.
+	if err := r.reconcileAdditionalTrustBundle(ctx, hcluster, controlPlaneNamespace.Name); err != nil {
+		return ctrl.Result{}, err
+	}
+
 	// Reconcile the default node pool
 	// TODO: Is this really a good idea to have on the API? If you want an initial
 	// node pool, create it through whatever user-oriented tool is consuming the
@@ -394,6 +573,42 @@ func (r *HostedClusterReconciler) Reconcile(ctx context.Context, req ctrl.Reques
 		}
 	}
 
+	// Reconcile the HostedControlPlane kubeadmin password if one is reported,
+	// and remove it once the kubeadmin user is disabled
+	if hcp.Status.KubeadminPassword == nil && hcluster.Spec.OAuth != nil && hcluster.Spec.OAuth.DisableKubeadmin {
+		if err := r.Client.Delete(ctx, manifests.KubeadminPasswordSecret(hcluster.Namespace, hcluster.Name)); err != nil && !apierrors.IsNotFound(err) {
+			return ctrl.Result{}, fmt.Errorf("failed to delete hostedcluster kubeadmin password secret: %w", err)
+		}
+	}
+	if hcp.Status.KubeadminPassword != nil {
+		src := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: hcp.Namespace,
+				Name:      hcp.Status.KubeadminPassword.Name,
+			},
+		}
+		err := r.Client.Get(ctx, client.ObjectKeyFromObject(src), src)
+		if err != nil {
+			return ctrl.Result{}, fmt.Errorf("failed to get controlplane kubeadmin password secret %q: %w", client.ObjectKeyFromObject(src), err)
+		}
+		dest := manifests.KubeadminPasswordSecret(hcluster.Namespace, hcluster.Name)
+		_, err = controllerutil.CreateOrUpdate(ctx, r.Client, dest, func() error {
+			srcData, srcHasData := src.Data["password"]
+			if !srcHasData {
+				return fmt.Errorf("controlplane kubeadmin password secret %q must have a password key", client.ObjectKeyFromObject(src))
+			}
+			dest.Type = corev1.SecretTypeOpaque
+			if dest.Data == nil {
+				dest.Data = map[string][]byte{}
+			}
+			dest.Data["password"] = srcData
+			return nil
+		})
+		if err != nil {
+			return ctrl.Result{}, fmt.Errorf("failed to reconcile hostedcluster kubeadmin password secret: %w", err)
+		}
+	}
+
 	// Reconcile the CAPI manager components
 	err = r.reconcileCAPIManager(ctx, hcluster)
 	if err != nil {
@@ -444,8 +659,24 @@ func reconcileHostedControlPlane(hcp *hyperv1.HostedControlPlane, hcluster *hype
 	hcp.Spec.ServiceCIDR = hcluster.Spec.Networking.ServiceCIDR
 	hcp.Spec.PodCIDR = hcluster.Spec.Networking.PodCIDR
 	hcp.Spec.MachineCIDR = hcluster.Spec.Networking.MachineCIDR
+	hcp.Spec.NetworkType = hcluster.Spec.Networking.NetworkType
+	hcp.Spec.ClusterNetwork = hyperutil.ClusterNetworks(hcluster.Spec.Networking.PodCIDR, hcluster.Spec.Networking.ClusterNetwork)
+	hcp.Spec.ServiceNetwork = hyperutil.ServiceNetworks(hcluster.Spec.Networking.ServiceCIDR, hcluster.Spec.Networking.ServiceNetwork)
+	hcp.Spec.MachineNetwork = hyperutil.MachineNetworks(hcluster.Spec.Networking.MachineCIDR, hcluster.Spec.Networking.MachineNetwork)
 	hcp.Spec.InfraID = hcluster.Spec.InfraID
 	hcp.Spec.DNS = hcluster.Spec.DNS
+	hcp.Spec.PausedUntil = hcluster.Spec.PausedUntil
+	hcp.Spec.Configuration = hcluster.Spec.Configuration.DeepCopy()
+	hcp.Spec.Proxy = hcluster.Spec.Proxy.DeepCopy()
+	hcp.Spec.AdditionalTrustBundle = controlPlaneAdditionalTrustBundle(hcp.Namespace, hcluster.Spec.AdditionalTrustBundle)
+	hcp.Spec.ImageContentSources = append([]hyperv1.ImageContentSource(nil), hcluster.Spec.ImageContentSources...)
+	hcp.Spec.OAuth = controlPlaneOAuth(hcluster.Spec.OAuth)
+	hcp.Spec.ControllerAvailabilityPolicy = hcluster.Spec.ControllerAvailabilityPolicy
+	hcp.Spec.Sizing = controlPlaneSizing(hcluster)
+	hcp.Spec.ControlPlanePlacement = hcluster.Spec.ControlPlanePlacement.DeepCopy()
+	hcp.Spec.ControlPlaneOverrides = append([]hyperv1.ControlPlaneOverride(nil), hcluster.Spec.ControlPlaneOverrides...)
+	hcp.Spec.Services = controlPlaneServices(hcp.Namespace, hcluster.Spec.Services)
+	hcp.Spec.NodeConnectivity = hyperutil.NodeConnectivity(hcluster.Spec.NodeConnectivity)
 	hcp.Spec.KubeConfig = &hyperv1.KubeconfigSecretRef{
 		Name: fmt.Sprintf("%s-kubeconfig", hcluster.Spec.InfraID),
 		Key:  "value",
@@ -462,6 +693,16 @@ func reconcileHostedControlPlane(hcp *hyperv1.HostedControlPlane, hcluster *hype
 		hcp.Spec.Platform.AWS.NodePoolManagementCreds = corev1.LocalObjectReference{
 			Name: manifests.AWSNodePoolManagementCreds(hcp.Namespace).Name,
 		}
+		if len(hcluster.Spec.Platform.AWS.DNSManagementCreds.Name) > 0 {
+			hcp.Spec.Platform.AWS.DNSManagementCreds = corev1.LocalObjectReference{
+				Name: manifests.AWSDNSManagementCreds(hcp.Namespace).Name,
+			}
+		}
+		if len(hcluster.Spec.Platform.AWS.ControlPlaneOperatorCreds.Name) > 0 {
+			hcp.Spec.Platform.AWS.ControlPlaneOperatorCreds = corev1.LocalObjectReference{
+				Name: manifests.AWSControlPlaneOperatorCreds(hcp.Namespace).Name,
+			}
+		}
 	}
 
 	// Only update release image (triggering a new rollout) after existing rollouts
@@ -528,9 +769,17 @@ func (r *HostedClusterReconciler) reconcileCAPIManager(ctx context.Context, hclu
 	}
 
 	// Reconcile CAPI manager deployment
+	image, err := r.componentImage(ctx, hcluster, ClusterAPIManagerImageName)
+	if err != nil {
+		return fmt.Errorf("failed to resolve capi manager image: %w", err)
+	}
 	capiManagerDeployment := clusterapi.ClusterAPIManagerDeployment(controlPlaneNamespace.Name)
 	_, err = controllerutil.CreateOrUpdate(ctx, r.Client, capiManagerDeployment, func() error {
-		return reconcileCAPIManagerDeployment(capiManagerDeployment, capiManagerServiceAccount, "quay.io/hypershift/cluster-api:hypershift")
+		if err := reconcileCAPIManagerDeployment(capiManagerDeployment, capiManagerServiceAccount, image); err != nil {
+			return err
+		}
+		applyControlPlanePlacement(capiManagerDeployment, hcluster.Spec.ControlPlanePlacement)
+		return nil
 	})
 	if err != nil {
 		return fmt.Errorf("failed to reconcile capi manager deployment: %w", err)
@@ -574,9 +823,17 @@ func (r *HostedClusterReconciler) reconcileCAPIAWSProvider(ctx context.Context,
 	}
 
 	// Reconcile CAPI AWS provider deployment
+	image, err := r.componentImage(ctx, hcluster, ClusterAPIProviderAWSImageName)
+	if err != nil {
+		return fmt.Errorf("failed to resolve capi aws provider image: %w", err)
+	}
 	capiAwsProviderDeployment := clusterapi.CAPIAWSProviderDeployment(controlPlaneNamespace.Name)
 	_, err = controllerutil.CreateOrUpdate(ctx, r.Client, capiAwsProviderDeployment, func() error {
-		return reconcileCAPIAWSProviderDeployment(capiAwsProviderDeployment, capiAwsProviderServiceAccount, "quay.io/hypershift/cluster-api-provider-aws:master")
+		if err := reconcileCAPIAWSProviderDeployment(capiAwsProviderDeployment, capiAwsProviderServiceAccount, image, capiAWSProviderProxyEnv(hcluster)); err != nil {
+			return err
+		}
+		applyControlPlanePlacement(capiAwsProviderDeployment, hcluster.Spec.ControlPlanePlacement)
+		return nil
 	})
 	if err != nil {
 		return fmt.Errorf("failed to reconcile capi aws provider deployment: %w", err)
@@ -638,9 +895,17 @@ func (r *HostedClusterReconciler) reconcileControlPlaneOperator(ctx context.Cont
 	}
 
 	// Reconcile operator deployment
+	image, err := r.componentImage(ctx, hcluster, ControlPlaneOperatorImageName)
+	if err != nil {
+		return fmt.Errorf("failed to resolve controlplane operator image: %w", err)
+	}
 	controlPlaneOperatorDeployment := controlplaneoperator.OperatorDeployment(controlPlaneNamespace.Name)
 	_, err = controllerutil.CreateOrUpdate(ctx, r.Client, controlPlaneOperatorDeployment, func() error {
-		return reconcileControlPlaneOperatorDeployment(controlPlaneOperatorDeployment, r.OperatorImage, controlPlaneOperatorServiceAccount)
+		if err := reconcileControlPlaneOperatorDeployment(controlPlaneOperatorDeployment, image, controlPlaneOperatorServiceAccount); err != nil {
+			return err
+		}
+		applyControlPlanePlacement(controlPlaneOperatorDeployment, hcluster.Spec.ControlPlanePlacement)
+		return nil
 	})
 	if err != nil {
 		return fmt.Errorf("failed to reconcile controlplane operator deployment: %w", err)
@@ -700,9 +965,17 @@ func (r *HostedClusterReconciler) reconcileAutoscaler(ctx context.Context, hclus
 		}
 
 		// Reconcile autoscaler deployment
+		image, err := r.componentImage(ctx, hcluster, ClusterAutoscalerImageName)
+		if err != nil {
+			return fmt.Errorf("failed to resolve autoscaler image: %w", err)
+		}
 		autoScalerDeployment := autoscaler.AutoScalerDeployment(controlPlaneNamespace.Name)
 		_, err = controllerutil.CreateOrUpdate(ctx, r.Client, autoScalerDeployment, func() error {
-			return reconcileAutoScalerDeployment(autoScalerDeployment, autoScalerServiceAccount, hcpKubeConfigSecret, "k8s.gcr.io/autoscaling/cluster-autoscaler:v1.20.0")
+			if err := reconcileAutoScalerDeployment(autoScalerDeployment, hcluster, autoScalerServiceAccount, hcpKubeConfigSecret, image); err != nil {
+				return err
+			}
+			applyControlPlanePlacement(autoScalerDeployment, hcluster.Spec.ControlPlanePlacement)
+			return nil
 		})
 		if err != nil {
 			return fmt.Errorf("failed to reconcile autoscaler deployment: %w", err)
@@ -858,7 +1131,12 @@ func reconcileControlPlaneOperatorRole(role *rbacv1.Role) error {
 		},
 		{
 			APIGroups: []string{"apps"},
-			Resources: []string{"deployments"},
+			Resources: []string{"deployments", "statefulsets"},
+			Verbs:     []string{"*"},
+		},
+		{
+			APIGroups: []string{"policy"},
+			Resources: []string{"poddisruptionbudgets"},
 			Verbs:     []string{"*"},
 		},
 		{
@@ -913,6 +1191,10 @@ func reconcileExternalInfraCluster(eic *hyperv1.ExternalInfraCluster, hcluster *
 }
 
 func reconcileCAPICluster(cluster *capiv1.Cluster, hcluster *hyperv1.HostedCluster, hcp *hyperv1.HostedControlPlane, eic *hyperv1.ExternalInfraCluster) error {
+	// This is only reached while the HostedCluster is not paused, so resume
+	// the cluster if a previous pause was propagated to it.
+	cluster.Spec.Paused = false
+
 	// We only create this resource once and then let CAPI own it
 	if !cluster.CreationTimestamp.IsZero() {
 		return nil
@@ -1068,7 +1350,33 @@ func reconcileCAPIManagerRoleBinding(binding *rbacv1.RoleBinding, role *rbacv1.R
 	return nil
 }
 
-func reconcileCAPIAWSProviderDeployment(deployment *appsv1.Deployment, sa *corev1.ServiceAccount, image string) error {
+// capiAWSProviderProxyEnv returns the proxy environment variables of the CAPI
+// AWS provider, which reaches the AWS APIs through the proxy of the cluster.
+// The Kube API server of the management cluster is reached directly, through
+// the service address the provider is configured with.
+func capiAWSProviderProxyEnv(hcluster *hyperv1.HostedCluster) []corev1.EnvVar {
+	return hyperutil.ProxyEnvVars(hcluster.Spec.Proxy,
+		clusterNoProxy(hcluster),
+		"localhost,127.0.0.1,.svc,.cluster.local,$(KUBERNETES_SERVICE_HOST)",
+	)
+}
+
+// clusterNoProxy returns the hosts which the cluster reaches without its
+// proxy, including its cluster, service and machine networks.
+func clusterNoProxy(hcluster *hyperv1.HostedCluster) string {
+	networking := hcluster.Spec.Networking
+	var networks []string
+	for _, entry := range hyperutil.ClusterNetworks(networking.PodCIDR, networking.ClusterNetwork) {
+		networks = append(networks, entry.CIDR)
+	}
+	networks = append(networks, hyperutil.ServiceNetworks(networking.ServiceCIDR, networking.ServiceNetwork)...)
+	for _, entry := range hyperutil.MachineNetworks(networking.MachineCIDR, networking.MachineNetwork) {
+		networks = append(networks, entry.CIDR)
+	}
+	return hyperutil.NoProxy(hcluster.Spec.Proxy, networks...)
+}
+
+func reconcileCAPIAWSProviderDeployment(deployment *appsv1.Deployment, sa *corev1.ServiceAccount, image string, proxyEnv []corev1.EnvVar) error {
 	deployment.Spec = appsv1.DeploymentSpec{
 		Replicas: k8sutilspointer.Int32Ptr(1),
 		Selector: &metav1.LabelSelector{
@@ -1112,7 +1420,7 @@ func reconcileCAPIAWSProviderDeployment(deployment *appsv1.Deployment, sa *corev
 								MountPath: "/home/.aws",
 							},
 						},
-						Env: []corev1.EnvVar{
+						Env: append([]corev1.EnvVar{
 							{
 								Name: "MY_NAMESPACE",
 								ValueFrom: &corev1.EnvVarSource{
@@ -1125,7 +1433,7 @@ func reconcileCAPIAWSProviderDeployment(deployment *appsv1.Deployment, sa *corev
 								Name:  "AWS_SHARED_CREDENTIALS_FILE",
 								Value: "/home/.aws/credentials",
 							},
-						},
+						}, proxyEnv...),
 						Command: []string{"/manager"},
 						Args:    []string{"--namespace", "$(MY_NAMESPACE)", "--alsologtostderr", "--v=4"},
 						Ports: []corev1.ContainerPort{
@@ -1210,7 +1518,12 @@ func reconcileCAPIAWSProviderRoleBinding(binding *rbacv1.RoleBinding, role *rbac
 	return nil
 }
 
-func reconcileAutoScalerDeployment(deployment *appsv1.Deployment, sa *corev1.ServiceAccount, hcpKubeConfigSecret *corev1.Secret, image string) error {
+func reconcileAutoScalerDeployment(deployment *appsv1.Deployment, hcluster *hyperv1.HostedCluster, sa *corev1.ServiceAccount, hcpKubeConfigSecret *corev1.Secret, image string) error {
+	if deployment.Annotations == nil {
+		deployment.Annotations = map[string]string{}
+	}
+	deployment.Annotations[hostedClusterAnnotation] = ctrlclient.ObjectKeyFromObject(hcluster).String()
+
 	deployment.Spec = appsv1.DeploymentSpec{
 		Replicas: k8sutilspointer.Int32Ptr(1),
 		Selector: &metav1.LabelSelector{
@@ -1272,14 +1585,14 @@ func reconcileAutoScalerDeployment(deployment *appsv1.Deployment, sa *corev1.Ser
 							},
 						},
 						Command: []string{"/cluster-autoscaler"},
-						Args: []string{
+						Args: append([]string{
 							"--cloud-provider=clusterapi",
 							"--node-group-auto-discovery=clusterapi:namespace=$(MY_NAMESPACE)",
 							"--kubeconfig=/mnt/kubeconfig/target-kubeconfig",
 							"--clusterapi-cloud-config-authoritative",
 							"--alsologtostderr",
 							"--v=4",
-						},
+						}, autoscalerArgs(hcluster.Spec.Autoscaling)...),
 					},
 				},
 			},
@@ -1396,15 +1709,7 @@ func computeClusterVersionStatus(clock clock.Clock, hcluster *hyperv1.HostedClus
 // given HostedCluster and returns it.
 func computeHostedClusterAvailability(hcluster *hyperv1.HostedCluster, hcp *hyperv1.HostedControlPlane) metav1.Condition {
 	// Determine whether the hosted control plane is available.
-	hcpAvailable := false
-	if hcp != nil {
-		for _, cond := range hcp.Status.Conditions {
-			if cond.Type == hyperv1.Available && cond.Status == hyperv1.ConditionTrue {
-				hcpAvailable = true
-				break
-			}
-		}
-	}
+	hcpAvailable := hcp != nil && meta.IsStatusConditionTrue(hcp.Status.Conditions, string(hyperv1.Available))
 
 	// Determine whether the kubeconfig is available.
 	// TODO: is it a good idea to compute hc status based on other field within
@@ -1439,28 +1744,162 @@ func computeHostedClusterAvailability(hcluster *hyperv1.HostedCluster, hcp *hype
 	}
 }
 
-func (r *HostedClusterReconciler) listNodePools(clusterNamespace, clusterName string) ([]hyperv1.NodePool, error) {
+// hostedControlPlaneConditions are the conditions of the HostedControlPlane
+// which are reported on its HostedCluster.
+var hostedControlPlaneConditions = []hyperv1.ConditionType{
+	hyperv1.InfrastructureReady,
+	hyperv1.EtcdAvailable,
+	hyperv1.KubeAPIServerAvailable,
+	hyperv1.ReleaseImageValid,
+	hyperv1.ValidControlPlaneOverrides,
+	hyperv1.IdentityProvidersDiscovered,
+	hyperv1.Degraded,
+	hyperv1.Progressing,
+}
+
+// computeHostedControlPlaneConditions copies the hostedControlPlaneConditions
+// of the given HostedControlPlane for use on the HostedCluster.
+func computeHostedControlPlaneConditions(hcluster *hyperv1.HostedCluster, hcp *hyperv1.HostedControlPlane) []metav1.Condition {
+	var conditions []metav1.Condition
+	for _, conditionType := range hostedControlPlaneConditions {
+		conditions = append(conditions, computeHostedControlPlaneCondition(hcluster, hcp, conditionType))
+	}
+	return conditions
+}
+
+// computeHostedControlPlaneCondition copies a condition of the given
+// HostedControlPlane for use on the HostedCluster. A condition the control
+// plane has not reported yet, or has not reported for its current generation,
+// is Unknown.
+func computeHostedControlPlaneCondition(hcluster *hyperv1.HostedCluster, hcp *hyperv1.HostedControlPlane, conditionType hyperv1.ConditionType) metav1.Condition {
+	condition := metav1.Condition{
+		Type:               string(conditionType),
+		Status:             metav1.ConditionUnknown,
+		ObservedGeneration: hcluster.Generation,
+		Reason:             "StatusUnknown",
+	}
+	var hcpCondition *metav1.Condition
+	if hcp != nil {
+		hcpCondition = meta.FindStatusCondition(hcp.Status.Conditions, string(conditionType))
+	}
+	switch {
+	case hcp == nil:
+		condition.Message = "The hosted control plane does not exist yet"
+	case hcpCondition == nil:
+		condition.Message = "The hosted control plane has not reported this condition yet"
+	case hcpCondition.ObservedGeneration != hcp.Generation:
+		condition.Message = "The hosted control plane has not observed its latest generation yet"
+	default:
+		condition.Status = hcpCondition.Status
+		condition.Reason = hcpCondition.Reason
+		condition.Message = hcpCondition.Message
+	}
+	return condition
+}
+
+// computeReconciliationPausedCondition determines the ReconciliationPaused
+// condition for the given HostedCluster and returns it.
+func computeReconciliationPausedCondition(hcluster *hyperv1.HostedCluster, isPaused bool) metav1.Condition {
+	if isPaused {
+		return metav1.Condition{
+			Type:               string(hyperv1.ReconciliationPaused),
+			Status:             metav1.ConditionTrue,
+			ObservedGeneration: hcluster.Generation,
+			Reason:             "ReconciliationPaused",
+			Message:            fmt.Sprintf("Reconciliation is paused until: %s", *hcluster.Spec.PausedUntil),
+		}
+	}
+	return metav1.Condition{
+		Type:               string(hyperv1.ReconciliationPaused),
+		Status:             metav1.ConditionFalse,
+		ObservedGeneration: hcluster.Generation,
+		Reason:             "ReconciliationActive",
+		Message:            "Reconciliation is active",
+	}
+}
+
+// pauseControlPlane propagates the paused state of the HostedCluster to an
+// existing HostedControlPlane and CAPI Cluster. Nothing is created while the
+// HostedCluster is paused.
+func (r *HostedClusterReconciler) pauseControlPlane(ctx context.Context, hcluster *hyperv1.HostedCluster) error {
+	controlPlaneNamespace := manifests.HostedControlPlaneNamespace(hcluster.Namespace, hcluster.Name)
+
+	hcp := controlplaneoperator.HostedControlPlane(controlPlaneNamespace.Name, hcluster.Name)
+	if err := r.Client.Get(ctx, client.ObjectKeyFromObject(hcp), hcp); err != nil {
+		if !apierrors.IsNotFound(err) {
+			return fmt.Errorf("failed to get hostedcontrolplane: %w", err)
+		}
+	} else {
+		original := hcp.DeepCopy()
+		hcp.Spec.PausedUntil = hcluster.Spec.PausedUntil
+		if err := r.Client.Patch(ctx, hcp, client.MergeFrom(original)); err != nil {
+			return fmt.Errorf("failed to pause hostedcontrolplane: %w", err)
+		}
+	}
+
+	if len(hcluster.Spec.InfraID) == 0 {
+		return nil
+	}
+	capiCluster := controlplaneoperator.CAPICluster(controlPlaneNamespace.Name, hcluster.Spec.InfraID)
+	if err := r.Client.Get(ctx, client.ObjectKeyFromObject(capiCluster), capiCluster); err != nil {
+		if !apierrors.IsNotFound(err) {
+			return fmt.Errorf("failed to get capi cluster: %w", err)
+		}
+	} else {
+		original := capiCluster.DeepCopy()
+		capiCluster.Spec.Paused = true
+		if err := r.Client.Patch(ctx, capiCluster, client.MergeFrom(original)); err != nil {
+			return fmt.Errorf("failed to pause capi cluster: %w", err)
+		}
+	}
+	return nil
+}
+
+func (r *HostedClusterReconciler) listNodePools(ctx context.Context, clusterNamespace, clusterName string) ([]hyperv1.NodePool, error) {
 	nodePoolList := &hyperv1.NodePoolList{}
-	if err := r.Client.List(
-		context.TODO(),
-		nodePoolList,
+	if err := r.nodePoolReader.List(ctx, nodePoolList,
+		client.InNamespace(clusterNamespace),
+		client.MatchingFields{NodePoolClusterNameIndex: clusterName},
 	); err != nil {
-		return nil, fmt.Errorf("failed getting nodePool list: %v", err)
+		return nil, fmt.Errorf("failed getting nodePool list: %w", err)
 	}
-	// TODO: do a label association or something
-	filtered := []hyperv1.NodePool{}
-	for i, nodePool := range nodePoolList.Items {
-		if nodePool.Namespace == clusterNamespace && nodePool.Spec.ClusterName == clusterName {
-			filtered = append(filtered, nodePoolList.Items[i])
+	return nodePoolList.Items, nil
+}
+
+// computeNodePoolStatus summarizes the given NodePools and returns the
+// summaries, sorted by name, along with the total number of ready nodes.
+func computeNodePoolStatus(nodePools []hyperv1.NodePool) ([]hyperv1.NodePoolSummary, int32) {
+	var summaries []hyperv1.NodePoolSummary
+	var readyNodes int32
+	for _, nodePool := range nodePools {
+		summary := hyperv1.NodePoolSummary{
+			Name:          nodePool.Name,
+			ReadyReplicas: nodePool.Status.ReadyReplicas,
+			Version:       nodePool.Status.Version,
+			Upgrading:     meta.IsStatusConditionTrue(nodePool.Status.Conditions, hyperv1.NodePoolUpgradingConditionType),
 		}
+		switch {
+		case nodePool.Spec.NodeCount != nil:
+			summary.DesiredReplicas = *nodePool.Spec.NodeCount
+		case nodePool.Spec.AutoScaling != nil:
+			// Until the NodePool controller reports the replicas of the
+			// MachineDeployment the autoscaler won't go below its minimum.
+			summary.DesiredReplicas = nodePool.Status.Replicas
+			if nodePool.Spec.AutoScaling.Min != nil && summary.DesiredReplicas < int32(*nodePool.Spec.AutoScaling.Min) {
+				summary.DesiredReplicas = int32(*nodePool.Spec.AutoScaling.Min)
+			}
+		}
+		readyNodes += summary.ReadyReplicas
+		summaries = append(summaries, summary)
 	}
-	return filtered, nil
+	sort.Slice(summaries, func(i, j int) bool { return summaries[i].Name < summaries[j].Name })
+	return summaries, readyNodes
 }
 
 func (r *HostedClusterReconciler) delete(ctx context.Context, req ctrl.Request, hc *hyperv1.HostedCluster) (bool, error) {
 	controlPlaneNamespace := manifests.HostedControlPlaneNamespace(req.Namespace, req.Name).Name
 
-	nodePools, err := r.listNodePools(req.Namespace, req.Name)
+	nodePools, err := r.listNodePools(ctx, req.Namespace, req.Name)
 	if err != nil {
 		return false, fmt.Errorf("failed to get nodePools by cluster name for cluster %q: %w", req.Name, err)
 	}
@@ -1501,6 +1940,18 @@ func (r *HostedClusterReconciler) delete(ctx context.Context, req ctrl.Request,
 	return true, nil
 }
 
+// enqueueNodePoolHostedCluster maps a NodePool to the HostedCluster it
+// belongs to.
+func enqueueNodePoolHostedCluster(obj ctrlclient.Object) []reconcile.Request {
+	nodePool, ok := obj.(*hyperv1.NodePool)
+	if !ok || len(nodePool.Spec.ClusterName) == 0 {
+		return []reconcile.Request{}
+	}
+	return []reconcile.Request{
+		{NamespacedName: types.NamespacedName{Namespace: nodePool.Namespace, Name: nodePool.Spec.ClusterName}},
+	}
+}
+
 func enqueueParentHostedCluster(obj ctrlclient.Object) []reconcile.Request {
 	var hostedClusterName string
 	if obj.GetAnnotations() != nil {
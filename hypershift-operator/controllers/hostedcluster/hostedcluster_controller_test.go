@@ -8,8 +8,10 @@ import (
 	configv1 "github.com/openshift/api/config/v1"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/clock"
+	k8sutilspointer "k8s.io/utils/pointer"
 
 	hyperv1 "github.com/openshift/hypershift/api/v1alpha1"
 )
@@ -231,8 +233,8 @@ func TestComputeHostedClusterAvailability(t *testing.T) {
 			ControlPlane: &hyperv1.HostedControlPlane{
 				Spec: hyperv1.HostedControlPlaneSpec{},
 				Status: hyperv1.HostedControlPlaneStatus{
-					Conditions: []hyperv1.HostedControlPlaneCondition{
-						{Type: hyperv1.Available, Status: hyperv1.ConditionTrue},
+					Conditions: []metav1.Condition{
+						{Type: string(hyperv1.Available), Status: metav1.ConditionTrue},
 					},
 				},
 			},
@@ -251,8 +253,8 @@ func TestComputeHostedClusterAvailability(t *testing.T) {
 			ControlPlane: &hyperv1.HostedControlPlane{
 				Spec: hyperv1.HostedControlPlaneSpec{ReleaseImage: "a"},
 				Status: hyperv1.HostedControlPlaneStatus{
-					Conditions: []hyperv1.HostedControlPlaneCondition{
-						{Type: hyperv1.Available, Status: hyperv1.ConditionTrue},
+					Conditions: []metav1.Condition{
+						{Type: string(hyperv1.Available), Status: metav1.ConditionTrue},
 					},
 				},
 			},
@@ -275,3 +277,133 @@ func TestComputeHostedClusterAvailability(t *testing.T) {
 		})
 	}
 }
+
+func TestComputeHostedControlPlaneConditions(t *testing.T) {
+	tests := map[string]struct {
+		ControlPlane     *hyperv1.HostedControlPlane
+		ExpectedStatuses map[hyperv1.ConditionType]metav1.ConditionStatus
+	}{
+		"missing hostedcontrolplane should report unknown conditions": {
+			ControlPlane: nil,
+			ExpectedStatuses: map[hyperv1.ConditionType]metav1.ConditionStatus{
+				hyperv1.InfrastructureReady: metav1.ConditionUnknown,
+				hyperv1.Degraded:            metav1.ConditionUnknown,
+			},
+		},
+		"conditions of the current generation are copied": {
+			ControlPlane: &hyperv1.HostedControlPlane{
+				ObjectMeta: metav1.ObjectMeta{Generation: 2},
+				Status: hyperv1.HostedControlPlaneStatus{
+					Conditions: []metav1.Condition{
+						{Type: string(hyperv1.InfrastructureReady), Status: metav1.ConditionTrue, ObservedGeneration: 2, Reason: "AsExpected"},
+						{Type: string(hyperv1.EtcdAvailable), Status: metav1.ConditionFalse, ObservedGeneration: 2, Reason: "EtcdClusterUnavailable"},
+						{Type: string(hyperv1.Degraded), Status: metav1.ConditionFalse, ObservedGeneration: 2, Reason: "AsExpected"},
+					},
+				},
+			},
+			ExpectedStatuses: map[hyperv1.ConditionType]metav1.ConditionStatus{
+				hyperv1.InfrastructureReady:    metav1.ConditionTrue,
+				hyperv1.EtcdAvailable:          metav1.ConditionFalse,
+				hyperv1.Degraded:               metav1.ConditionFalse,
+				hyperv1.KubeAPIServerAvailable: metav1.ConditionUnknown,
+			},
+		},
+		"conditions of a previous generation are unknown": {
+			ControlPlane: &hyperv1.HostedControlPlane{
+				ObjectMeta: metav1.ObjectMeta{Generation: 3},
+				Status: hyperv1.HostedControlPlaneStatus{
+					Conditions: []metav1.Condition{
+						{Type: string(hyperv1.ReleaseImageValid), Status: metav1.ConditionTrue, ObservedGeneration: 2, Reason: "AsExpected"},
+					},
+				},
+			},
+			ExpectedStatuses: map[hyperv1.ConditionType]metav1.ConditionStatus{
+				hyperv1.ReleaseImageValid: metav1.ConditionUnknown,
+			},
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			cluster := &hyperv1.HostedCluster{ObjectMeta: metav1.ObjectMeta{Generation: 5}}
+			actualConditions := computeHostedControlPlaneConditions(cluster, test.ControlPlane)
+			if len(actualConditions) != len(hostedControlPlaneConditions) {
+				t.Fatalf("expected %d conditions, got %d", len(hostedControlPlaneConditions), len(actualConditions))
+			}
+			for conditionType, expectedStatus := range test.ExpectedStatuses {
+				condition := meta.FindStatusCondition(actualConditions, string(conditionType))
+				if condition == nil {
+					t.Fatalf("missing condition %s", conditionType)
+				}
+				if condition.Status != expectedStatus {
+					t.Errorf("expected condition %s to be %s, got %s", conditionType, expectedStatus, condition.Status)
+				}
+				if condition.ObservedGeneration != cluster.Generation {
+					t.Errorf("expected condition %s to have observedGeneration %d, got %d", conditionType, cluster.Generation, condition.ObservedGeneration)
+				}
+			}
+		})
+	}
+}
+
+func TestComputeNodePoolStatus(t *testing.T) {
+	intPtr := func(i int) *int { return &i }
+	tests := map[string]struct {
+		NodePools          []hyperv1.NodePool
+		ExpectedSummaries  []hyperv1.NodePoolSummary
+		ExpectedReadyNodes int32
+	}{
+		"no nodepools": {
+			NodePools:          nil,
+			ExpectedSummaries:  nil,
+			ExpectedReadyNodes: 0,
+		},
+		"fixed size and autoscaled nodepools": {
+			NodePools: []hyperv1.NodePool{
+				{
+					ObjectMeta: metav1.ObjectMeta{Name: "workers-b"},
+					Spec:       hyperv1.NodePoolSpec{AutoScaling: &hyperv1.NodePoolAutoScaling{Min: intPtr(1), Max: intPtr(5)}},
+					Status:     hyperv1.NodePoolStatus{NodeCount: 2, Replicas: 3, ReadyReplicas: 2, Version: "4.7.0"},
+				},
+				{
+					ObjectMeta: metav1.ObjectMeta{Name: "workers-a"},
+					Spec:       hyperv1.NodePoolSpec{NodeCount: k8sutilspointer.Int32Ptr(3)},
+					Status: hyperv1.NodePoolStatus{
+						NodeCount:     1,
+						ReadyReplicas: 1,
+						Version:       "4.7.0",
+						Conditions: []metav1.Condition{
+							{Type: hyperv1.NodePoolUpgradingConditionType, Status: metav1.ConditionTrue},
+						},
+					},
+				},
+			},
+			ExpectedSummaries: []hyperv1.NodePoolSummary{
+				{Name: "workers-a", DesiredReplicas: 3, ReadyReplicas: 1, Version: "4.7.0", Upgrading: true},
+				{Name: "workers-b", DesiredReplicas: 3, ReadyReplicas: 2, Version: "4.7.0", Upgrading: false},
+			},
+			ExpectedReadyNodes: 3,
+		},
+		"autoscaled nodepool without reported replicas": {
+			NodePools: []hyperv1.NodePool{
+				{
+					ObjectMeta: metav1.ObjectMeta{Name: "workers"},
+					Spec:       hyperv1.NodePoolSpec{AutoScaling: &hyperv1.NodePoolAutoScaling{Min: intPtr(2), Max: intPtr(5)}},
+				},
+			},
+			ExpectedSummaries: []hyperv1.NodePoolSummary{
+				{Name: "workers", DesiredReplicas: 2},
+			},
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			actualSummaries, actualReadyNodes := computeNodePoolStatus(test.NodePools)
+			if !equality.Semantic.DeepEqual(test.ExpectedSummaries, actualSummaries) {
+				t.Errorf(cmp.Diff(test.ExpectedSummaries, actualSummaries))
+			}
+			if actualReadyNodes != test.ExpectedReadyNodes {
+				t.Errorf("expected %d ready nodes, got %d", test.ExpectedReadyNodes, actualReadyNodes)
+			}
+		})
+	}
+}
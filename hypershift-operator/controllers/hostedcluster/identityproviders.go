@@ -0,0 +1,137 @@
+package hostedcluster
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	hyperv1 "github.com/openshift/hypershift/api/v1alpha1"
+	"github.com/openshift/hypershift/control-plane-operator/controllers/hostedcontrolplane/render"
+)
+
+// identityProviderObjectLabel marks the secrets and config maps synced into
+// the control plane namespace for identity providers.
+const identityProviderObjectLabel = "hypershift.openshift.io/identity-provider"
+
+// reconcileIdentityProviderObjects syncs the secrets and config maps referenced
+// by the identity providers of the HostedCluster into the control plane
+// namespace, and removes those which are no longer referenced.
+func (r *HostedClusterReconciler) reconcileIdentityProviderObjects(ctx context.Context, hcluster *hyperv1.HostedCluster, controlPlaneNamespace string) error {
+	expected := sets.NewString()
+	if hcluster.Spec.OAuth != nil {
+		for i := range hcluster.Spec.OAuth.IdentityProviders {
+			for _, ref := range render.IdentityProviderReferences(&hcluster.Spec.OAuth.IdentityProviders[i]) {
+				name := render.IdentityProviderObjectName(i, ref)
+				if ref.ConfigMap {
+					if err := r.syncIdentityProviderConfigMap(ctx, hcluster.Namespace, controlPlaneNamespace, name, ref); err != nil {
+						return err
+					}
+					expected.Insert("configmap/" + name)
+				} else {
+					if err := r.syncIdentityProviderSecret(ctx, hcluster.Namespace, controlPlaneNamespace, name, ref); err != nil {
+						return err
+					}
+					expected.Insert("secret/" + name)
+				}
+			}
+		}
+	}
+
+	var secrets corev1.SecretList
+	if err := r.List(ctx, &secrets, client.InNamespace(controlPlaneNamespace), client.HasLabels{identityProviderObjectLabel}); err != nil {
+		return fmt.Errorf("failed to list identity provider secrets: %w", err)
+	}
+	for i := range secrets.Items {
+		if !expected.Has("secret/" + secrets.Items[i].Name) {
+			if err := r.Delete(ctx, &secrets.Items[i]); err != nil && !apierrors.IsNotFound(err) {
+				return fmt.Errorf("failed to delete identity provider secret %s: %w", secrets.Items[i].Name, err)
+			}
+		}
+	}
+	var configMaps corev1.ConfigMapList
+	if err := r.List(ctx, &configMaps, client.InNamespace(controlPlaneNamespace), client.HasLabels{identityProviderObjectLabel}); err != nil {
+		return fmt.Errorf("failed to list identity provider config maps: %w", err)
+	}
+	for i := range configMaps.Items {
+		if !expected.Has("configmap/" + configMaps.Items[i].Name) {
+			if err := r.Delete(ctx, &configMaps.Items[i]); err != nil && !apierrors.IsNotFound(err) {
+				return fmt.Errorf("failed to delete identity provider config map %s: %w", configMaps.Items[i].Name, err)
+			}
+		}
+	}
+	return nil
+}
+
+func (r *HostedClusterReconciler) syncIdentityProviderSecret(ctx context.Context, namespace, controlPlaneNamespace, name string, ref render.IdentityProviderReference) error {
+	var src corev1.Secret
+	if err := r.Get(ctx, client.ObjectKey{Namespace: namespace, Name: *ref.Name}, &src); err != nil {
+		return fmt.Errorf("failed to get identity provider secret %s: %w", *ref.Name, err)
+	}
+	dest := &corev1.Secret{}
+	dest.Namespace = controlPlaneNamespace
+	dest.Name = name
+	_, err := controllerutil.CreateOrUpdate(ctx, r.Client, dest, func() error {
+		srcData, srcHasData := src.Data[ref.Key]
+		if !srcHasData {
+			return fmt.Errorf("identity provider secret %q must have a %s key", src.Name, ref.Key)
+		}
+		if dest.Labels == nil {
+			dest.Labels = map[string]string{}
+		}
+		dest.Labels[identityProviderObjectLabel] = "true"
+		dest.Type = corev1.SecretTypeOpaque
+		dest.Data = map[string][]byte{ref.Key: srcData}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to reconcile identity provider secret %s: %w", name, err)
+	}
+	return nil
+}
+
+func (r *HostedClusterReconciler) syncIdentityProviderConfigMap(ctx context.Context, namespace, controlPlaneNamespace, name string, ref render.IdentityProviderReference) error {
+	var src corev1.ConfigMap
+	if err := r.Get(ctx, client.ObjectKey{Namespace: namespace, Name: *ref.Name}, &src); err != nil {
+		return fmt.Errorf("failed to get identity provider config map %s: %w", *ref.Name, err)
+	}
+	dest := &corev1.ConfigMap{}
+	dest.Namespace = controlPlaneNamespace
+	dest.Name = name
+	_, err := controllerutil.CreateOrUpdate(ctx, r.Client, dest, func() error {
+		srcData, srcHasData := src.Data[ref.Key]
+		if !srcHasData {
+			return fmt.Errorf("identity provider config map %q must have a %s key", src.Name, ref.Key)
+		}
+		if dest.Labels == nil {
+			dest.Labels = map[string]string{}
+		}
+		dest.Labels[identityProviderObjectLabel] = "true"
+		dest.Data = map[string]string{ref.Key: srcData}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to reconcile identity provider config map %s: %w", name, err)
+	}
+	return nil
+}
+
+// controlPlaneOAuth returns the OAuth configuration of the HostedControlPlane,
+// whose identity providers reference the secrets and config maps synced into
+// the control plane namespace.
+func controlPlaneOAuth(oauth *hyperv1.OAuthSpec) *hyperv1.OAuthSpec {
+	if oauth == nil {
+		return nil
+	}
+	result := oauth.DeepCopy()
+	for i := range result.IdentityProviders {
+		for _, ref := range render.IdentityProviderReferences(&result.IdentityProviders[i]) {
+			*ref.Name = render.IdentityProviderObjectName(i, ref)
+		}
+	}
+	return result
+}
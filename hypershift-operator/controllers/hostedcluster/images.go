@@ -0,0 +1,86 @@
+package hostedcluster
+
+import (
+	"context"
+	"fmt"
+
+	hyperv1 "github.com/openshift/hypershift/api/v1alpha1"
+	"github.com/openshift/hypershift/control-plane-operator/releaseinfo"
+)
+
+// Names of the images of the components the hypershift operator manages next
+// to each control plane. They are the keys of the image overrides of the
+// HostedClusterReconciler.
+const (
+	ControlPlaneOperatorImageName  = "control-plane-operator"
+	ClusterAPIManagerImageName     = "cluster-api"
+	ClusterAPIProviderAWSImageName = "cluster-api-provider-aws"
+	ClusterAutoscalerImageName     = "cluster-autoscaler"
+)
+
+// defaultImages are the images of the components which are neither
+// overridden nor shipped in the release payload of the HostedCluster.
+var defaultImages = map[string]string{
+	ClusterAPIManagerImageName:     "quay.io/hypershift/cluster-api:hypershift",
+	ClusterAPIProviderAWSImageName: "quay.io/hypershift/cluster-api-provider-aws:master",
+	ClusterAutoscalerImageName:     "k8s.gcr.io/autoscaling/cluster-autoscaler:v1.20.0",
+}
+
+// imageAnnotations are the HostedCluster annotations which override the image
+// of a component for that HostedCluster only.
+var imageAnnotations = map[string]string{
+	ControlPlaneOperatorImageName:  hyperv1.ControlPlaneOperatorImageAnnotation,
+	ClusterAPIManagerImageName:     hyperv1.ClusterAPIManagerImageAnnotation,
+	ClusterAPIProviderAWSImageName: hyperv1.ClusterAPIProviderAWSImageAnnotation,
+	ClusterAutoscalerImageName:     hyperv1.ClusterAutoscalerImageAnnotation,
+}
+
+// releaseImageTags are the tags of the release payload which ship an
+// equivalent of a component.
+var releaseImageTags = map[string]string{
+	ClusterAutoscalerImageName: "cluster-autoscaler",
+}
+
+// componentImage returns the image of a component of the HostedCluster. In
+// order of precedence, it is the image of the HostedCluster annotation for
+// the component, of the image overrides of the reconciler, of the release
+// payload of the HostedCluster, and the default image of the component. The
+// default images are pulled from the registry mirrors of the HostedCluster,
+// while overrides are used as is.
+//
+// The components run with credentials of the management cluster, so the
+// annotation of a component is ignored unless the reconciler allows it:
+// otherwise anyone who can edit a HostedCluster could run arbitrary images
+// with those credentials.
+func (r *HostedClusterReconciler) componentImage(ctx context.Context, hcluster *hyperv1.HostedCluster, name string) (string, error) {
+	if image := hcluster.Annotations[imageAnnotations[name]]; len(image) > 0 {
+		if r.AllowedImageAnnotations.Has(name) {
+			return image, nil
+		}
+		r.Log.Info("ignoring image annotation of a component not allowed to be overridden per cluster", "annotation", imageAnnotations[name])
+	}
+	if image := r.ImageOverrides[name]; len(image) > 0 {
+		return image, nil
+	}
+	if name == ControlPlaneOperatorImageName {
+		return r.OperatorImage, nil
+	}
+	if tag, hasTag := releaseImageTags[name]; hasTag && r.ReleaseProvider != nil {
+		releaseProvider := &releaseinfo.RegistryMirrorProviderDecorator{
+			Delegate:            r.ReleaseProvider,
+			ImageContentSources: hcluster.Spec.ImageContentSources,
+		}
+		releaseImage, err := releaseProvider.Lookup(ctx, hcluster.Spec.Release.Image)
+		if err != nil {
+			return "", fmt.Errorf("failed to look up release image %s: %w", hcluster.Spec.Release.Image, err)
+		}
+		if image, hasImage := releaseImage.ComponentImages()[tag]; hasImage {
+			return image, nil
+		}
+	}
+	image, hasImage := defaultImages[name]
+	if !hasImage {
+		return "", fmt.Errorf("unknown component image %s", name)
+	}
+	return releaseinfo.MirroredImage(image, hcluster.Spec.ImageContentSources), nil
+}
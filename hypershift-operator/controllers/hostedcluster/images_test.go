@@ -0,0 +1,112 @@
+package hostedcluster
+
+import (
+	"context"
+	"testing"
+
+	imageapi "github.com/openshift/api/image/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	hyperv1 "github.com/openshift/hypershift/api/v1alpha1"
+	"github.com/openshift/hypershift/control-plane-operator/releaseinfo"
+)
+
+type fakeReleaseProvider struct {
+	images map[string]string
+}
+
+func (p *fakeReleaseProvider) Lookup(_ context.Context, image string) (*releaseinfo.ReleaseImage, error) {
+	releaseImage := &releaseinfo.ReleaseImage{ImageStream: &imageapi.ImageStream{}}
+	for name, image := range p.images {
+		releaseImage.Spec.Tags = append(releaseImage.Spec.Tags, imageapi.TagReference{Name: name, From: &corev1.ObjectReference{Name: image}})
+	}
+	return releaseImage, nil
+}
+
+func TestComponentImage(t *testing.T) {
+	tests := map[string]struct {
+		Name                string
+		Annotations         map[string]string
+		ImageOverrides      map[string]string
+		AllowedAnnotations  []string
+		ReleaseImages       map[string]string
+		ImageContentSources []hyperv1.ImageContentSource
+		Expected            string
+	}{
+		"default image": {
+			Name:     ClusterAPIManagerImageName,
+			Expected: "quay.io/hypershift/cluster-api:hypershift",
+		},
+		"mirrored default image": {
+			Name: ClusterAPIProviderAWSImageName,
+			ImageContentSources: []hyperv1.ImageContentSource{
+				{Source: "quay.io/hypershift", Mirrors: []string{"mirror.example.com/hypershift"}},
+			},
+			Expected: "mirror.example.com/hypershift/cluster-api-provider-aws:master",
+		},
+		"image from the release payload": {
+			Name:          ClusterAutoscalerImageName,
+			ReleaseImages: map[string]string{"cluster-autoscaler": "quay.io/openshift-release-dev/ocp-v4.0-art-dev@sha256:0123"},
+			Expected:      "quay.io/openshift-release-dev/ocp-v4.0-art-dev@sha256:0123",
+		},
+		"release payload without the image": {
+			Name:          ClusterAutoscalerImageName,
+			ReleaseImages: map[string]string{"cli": "quay.io/openshift-release-dev/ocp-v4.0-art-dev@sha256:0123"},
+			Expected:      "k8s.gcr.io/autoscaling/cluster-autoscaler:v1.20.0",
+		},
+		"operator override": {
+			Name:           ClusterAutoscalerImageName,
+			ImageOverrides: map[string]string{ClusterAutoscalerImageName: "registry.example.com/autoscaler:v1.21.0"},
+			ReleaseImages:  map[string]string{"cluster-autoscaler": "quay.io/openshift-release-dev/ocp-v4.0-art-dev@sha256:0123"},
+			Expected:       "registry.example.com/autoscaler:v1.21.0",
+		},
+		"annotation override": {
+			Name:               ClusterAPIManagerImageName,
+			Annotations:        map[string]string{hyperv1.ClusterAPIManagerImageAnnotation: "registry.example.com/cluster-api:patched"},
+			ImageOverrides:     map[string]string{ClusterAPIManagerImageName: "registry.example.com/cluster-api:pinned"},
+			AllowedAnnotations: []string{ClusterAPIManagerImageName},
+			Expected:           "registry.example.com/cluster-api:patched",
+		},
+		"annotation override not allowed": {
+			Name:               ClusterAPIManagerImageName,
+			Annotations:        map[string]string{hyperv1.ClusterAPIManagerImageAnnotation: "registry.example.com/cluster-api:patched"},
+			ImageOverrides:     map[string]string{ClusterAPIManagerImageName: "registry.example.com/cluster-api:pinned"},
+			AllowedAnnotations: []string{ClusterAutoscalerImageName},
+			Expected:           "registry.example.com/cluster-api:pinned",
+		},
+		"control plane operator image": {
+			Name:     ControlPlaneOperatorImageName,
+			Expected: "quay.io/hypershift/hypershift:operator",
+		},
+		"control plane operator annotation override": {
+			Name:               ControlPlaneOperatorImageName,
+			Annotations:        map[string]string{hyperv1.ControlPlaneOperatorImageAnnotation: "registry.example.com/hypershift:patched"},
+			AllowedAnnotations: []string{ControlPlaneOperatorImageName},
+			Expected:           "registry.example.com/hypershift:patched",
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			r := &HostedClusterReconciler{
+				Log:                     ctrl.Log,
+				OperatorImage:           "quay.io/hypershift/hypershift:operator",
+				ImageOverrides:          test.ImageOverrides,
+				AllowedImageAnnotations: sets.NewString(test.AllowedAnnotations...),
+				ReleaseProvider:         &fakeReleaseProvider{images: test.ReleaseImages},
+			}
+			hcluster := &hyperv1.HostedCluster{ObjectMeta: metav1.ObjectMeta{Namespace: "clusters", Name: "example", Annotations: test.Annotations}}
+			hcluster.Spec.Release.Image = "quay.io/openshift-release-dev/ocp-release:4.8.0-x86_64"
+			hcluster.Spec.ImageContentSources = test.ImageContentSources
+			image, err := r.componentImage(context.Background(), hcluster, test.Name)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if image != test.Expected {
+				t.Errorf("expected %s, got %s", test.Expected, image)
+			}
+		})
+	}
+}
@@ -0,0 +1,32 @@
+package hostedcluster
+
+import (
+	appsv1 "k8s.io/api/apps/v1"
+
+	hyperv1 "github.com/openshift/hypershift/api/v1alpha1"
+	"github.com/openshift/hypershift/control-plane-operator/controllers/hostedcontrolplane/render"
+)
+
+// controlPlanePodPlacement returns the placement of the control plane pods
+// of a HostedCluster.
+func controlPlanePodPlacement(placement *hyperv1.ControlPlanePlacement) *render.PodPlacement {
+	if placement == nil {
+		return nil
+	}
+	return &render.PodPlacement{
+		NodeSelector:              placement.NodeSelector,
+		Tolerations:               placement.Tolerations,
+		PriorityClassName:         placement.PriorityClassName,
+		TopologySpreadConstraints: placement.TopologySpreadConstraints,
+	}
+}
+
+// applyControlPlanePlacement adds the control plane placement of a
+// HostedCluster to the pods of a deployment in its control plane namespace.
+func applyControlPlanePlacement(deployment *appsv1.Deployment, placement *hyperv1.ControlPlanePlacement) {
+	var podSelector map[string]string
+	if deployment.Spec.Selector != nil {
+		podSelector = deployment.Spec.Selector.MatchLabels
+	}
+	controlPlanePodPlacement(placement).ApplyTo(&deployment.Spec.Template.Spec, podSelector)
+}
@@ -0,0 +1,78 @@
+package hostedcluster
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	hyperv1 "github.com/openshift/hypershift/api/v1alpha1"
+	"github.com/openshift/hypershift/hypershift-operator/controllers/manifests/controlplaneoperator"
+)
+
+// servingCertServices are the published services which may have a serving
+// certificate.
+var servingCertServices = []hyperv1.ServiceType{hyperv1.APIServer, hyperv1.OAuthServer}
+
+// reconcileServingCerts syncs the serving certificates of the published
+// services of the HostedCluster into the control plane namespace, and removes
+// those which are no longer referenced.
+func (r *HostedClusterReconciler) reconcileServingCerts(ctx context.Context, hcluster *hyperv1.HostedCluster, controlPlaneNamespace string) error {
+	for _, service := range servingCertServices {
+		dest := controlplaneoperator.ServingCert(controlPlaneNamespace, service)
+		var ref *corev1.LocalObjectReference
+		for _, mapping := range hcluster.Spec.Services {
+			if mapping.Service == service {
+				ref = mapping.ServingCert
+			}
+		}
+		if ref == nil {
+			if err := r.Delete(ctx, dest); err != nil && !apierrors.IsNotFound(err) {
+				return fmt.Errorf("failed to delete %s serving cert: %w", service, err)
+			}
+			continue
+		}
+		var src corev1.Secret
+		if err := r.Get(ctx, client.ObjectKey{Namespace: hcluster.Namespace, Name: ref.Name}, &src); err != nil {
+			return fmt.Errorf("failed to get %s serving cert %s: %w", service, ref.Name, err)
+		}
+		_, err := controllerutil.CreateOrUpdate(ctx, r.Client, dest, func() error {
+			for _, key := range []string{corev1.TLSCertKey, corev1.TLSPrivateKeyKey} {
+				if _, hasData := src.Data[key]; !hasData {
+					return fmt.Errorf("serving cert secret %q must have a %s key", src.Name, key)
+				}
+			}
+			dest.Type = corev1.SecretTypeOpaque
+			dest.Data = map[string][]byte{
+				corev1.TLSCertKey:       src.Data[corev1.TLSCertKey],
+				corev1.TLSPrivateKeyKey: src.Data[corev1.TLSPrivateKeyKey],
+			}
+			if ca, hasCA := src.Data["ca.crt"]; hasCA {
+				dest.Data["ca.crt"] = ca
+			}
+			return nil
+		})
+		if err != nil {
+			return fmt.Errorf("failed to reconcile %s serving cert: %w", service, err)
+		}
+	}
+	return nil
+}
+
+// controlPlaneServices returns the publishing strategies of the
+// HostedControlPlane, whose serving certificates reference the secrets synced
+// into the control plane namespace.
+func controlPlaneServices(controlPlaneNamespace string, services []hyperv1.ServicePublishingStrategyMapping) []hyperv1.ServicePublishingStrategyMapping {
+	var result []hyperv1.ServicePublishingStrategyMapping
+	for _, service := range services {
+		mapping := service.DeepCopy()
+		if mapping.ServingCert != nil {
+			mapping.ServingCert.Name = controlplaneoperator.ServingCert(controlPlaneNamespace, mapping.Service).Name
+		}
+		result = append(result, *mapping)
+	}
+	return result
+}
@@ -0,0 +1,83 @@
+package hostedcluster
+
+import (
+	hyperv1 "github.com/openshift/hypershift/api/v1alpha1"
+)
+
+// Node counts up to which the control plane of a cluster with automatic
+// sizing uses the Small and Medium profiles.
+const (
+	smallSizingProfileMaxNodes  = 10
+	mediumSizingProfileMaxNodes = 100
+)
+
+// sizingProfileHysteresisPercent is how far below the node count limit of a
+// smaller profile a cluster with automatic sizing must shrink before its
+// control plane moves to that profile. It keeps a cluster whose node count
+// hovers around a limit from rolling out its control plane repeatedly.
+const sizingProfileHysteresisPercent = 20
+
+var sizingProfileOrder = map[hyperv1.ControlPlaneSizingProfile]int{
+	hyperv1.SmallSizingProfile:  0,
+	hyperv1.MediumSizingProfile: 1,
+	hyperv1.LargeSizingProfile:  2,
+}
+
+// computeSizingProfile returns the sizing profile of the control plane. With
+// automatic sizing, it is chosen from the number of nodes of the NodePools,
+// counting the larger of the desired and ready nodes of each NodePool, and
+// is never smaller than the profile of the spec. The control plane only moves
+// from its current profile to a smaller one once the nodes are
+// sizingProfileHysteresisPercent below the limit of the smaller profile.
+func computeSizingProfile(sizing *hyperv1.ControlPlaneSizing, nodePools []hyperv1.NodePoolSummary, current hyperv1.ControlPlaneSizingProfile) hyperv1.ControlPlaneSizingProfile {
+	profile := hyperv1.SmallSizingProfile
+	if sizing == nil {
+		return profile
+	}
+	if len(sizing.Profile) > 0 {
+		profile = sizing.Profile
+	}
+	if !sizing.Auto {
+		return profile
+	}
+	var nodes int32
+	for _, nodePool := range nodePools {
+		if nodePool.ReadyReplicas > nodePool.DesiredReplicas {
+			nodes += nodePool.ReadyReplicas
+		} else {
+			nodes += nodePool.DesiredReplicas
+		}
+	}
+	auto := sizingProfileForNodes(nodes, 0)
+	if sizingProfileOrder[auto] < sizingProfileOrder[current] {
+		auto = sizingProfileForNodes(nodes, sizingProfileHysteresisPercent)
+	}
+	if sizingProfileOrder[auto] > sizingProfileOrder[profile] {
+		return auto
+	}
+	return profile
+}
+
+// sizingProfileForNodes returns the profile for a number of nodes, with the
+// node count limits of the profiles lowered by a percentage.
+func sizingProfileForNodes(nodes int32, lowerPercent int32) hyperv1.ControlPlaneSizingProfile {
+	switch {
+	case nodes <= smallSizingProfileMaxNodes*(100-lowerPercent)/100:
+		return hyperv1.SmallSizingProfile
+	case nodes <= mediumSizingProfileMaxNodes*(100-lowerPercent)/100:
+		return hyperv1.MediumSizingProfile
+	}
+	return hyperv1.LargeSizingProfile
+}
+
+// controlPlaneSizing returns the sizing of the HostedControlPlane, whose
+// profile is the one chosen for the HostedCluster.
+func controlPlaneSizing(hcluster *hyperv1.HostedCluster) *hyperv1.ControlPlaneSizing {
+	sizing := &hyperv1.ControlPlaneSizing{
+		Profile: computeSizingProfile(hcluster.Spec.Sizing, hcluster.Status.NodePools, hcluster.Status.SizingProfile),
+	}
+	if hcluster.Spec.Sizing != nil {
+		sizing.Overrides = hcluster.Spec.Sizing.DeepCopy().Overrides
+	}
+	return sizing
+}
@@ -0,0 +1,83 @@
+package hostedcluster
+
+import (
+	"testing"
+
+	hyperv1 "github.com/openshift/hypershift/api/v1alpha1"
+)
+
+func TestComputeSizingProfile(t *testing.T) {
+	nodePools := func(desired, ready int32) []hyperv1.NodePoolSummary {
+		return []hyperv1.NodePoolSummary{
+			{Name: "a", DesiredReplicas: desired, ReadyReplicas: ready},
+			{Name: "b", DesiredReplicas: 2, ReadyReplicas: 2},
+		}
+	}
+	tests := map[string]struct {
+		Sizing    *hyperv1.ControlPlaneSizing
+		NodePools []hyperv1.NodePoolSummary
+		Current   hyperv1.ControlPlaneSizingProfile
+		Expected  hyperv1.ControlPlaneSizingProfile
+	}{
+		"no sizing": {
+			NodePools: nodePools(200, 200),
+			Expected:  hyperv1.SmallSizingProfile,
+		},
+		"fixed profile": {
+			Sizing:    &hyperv1.ControlPlaneSizing{Profile: hyperv1.MediumSizingProfile},
+			NodePools: nodePools(200, 200),
+			Expected:  hyperv1.MediumSizingProfile,
+		},
+		"auto with few nodes": {
+			Sizing:    &hyperv1.ControlPlaneSizing{Auto: true},
+			NodePools: nodePools(8, 8),
+			Expected:  hyperv1.SmallSizingProfile,
+		},
+		"auto counts ready nodes above the desired count": {
+			Sizing:    &hyperv1.ControlPlaneSizing{Auto: true},
+			NodePools: nodePools(3, 20),
+			Expected:  hyperv1.MediumSizingProfile,
+		},
+		"auto with many nodes": {
+			Sizing:    &hyperv1.ControlPlaneSizing{Auto: true},
+			NodePools: nodePools(150, 100),
+			Expected:  hyperv1.LargeSizingProfile,
+		},
+		"auto never goes below the profile": {
+			Sizing:    &hyperv1.ControlPlaneSizing{Auto: true, Profile: hyperv1.MediumSizingProfile},
+			NodePools: nodePools(1, 1),
+			Expected:  hyperv1.MediumSizingProfile,
+		},
+		"auto keeps the current profile just below its limit": {
+			Sizing:    &hyperv1.ControlPlaneSizing{Auto: true},
+			NodePools: nodePools(7, 7),
+			Current:   hyperv1.MediumSizingProfile,
+			Expected:  hyperv1.MediumSizingProfile,
+		},
+		"auto shrinks well below the limit of the smaller profile": {
+			Sizing:    &hyperv1.ControlPlaneSizing{Auto: true},
+			NodePools: nodePools(6, 6),
+			Current:   hyperv1.MediumSizingProfile,
+			Expected:  hyperv1.SmallSizingProfile,
+		},
+		"auto shrinks to the profile whose hysteresis band the nodes are outside of": {
+			Sizing:    &hyperv1.ControlPlaneSizing{Auto: true},
+			NodePools: nodePools(8, 8),
+			Current:   hyperv1.LargeSizingProfile,
+			Expected:  hyperv1.MediumSizingProfile,
+		},
+		"auto grows past the limit regardless of the current profile": {
+			Sizing:    &hyperv1.ControlPlaneSizing{Auto: true},
+			NodePools: nodePools(9, 9),
+			Current:   hyperv1.SmallSizingProfile,
+			Expected:  hyperv1.MediumSizingProfile,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			if actual := computeSizingProfile(test.Sizing, test.NodePools, test.Current); actual != test.Expected {
+				t.Errorf("expected profile %s, got %s", test.Expected, actual)
+			}
+		})
+	}
+}
@@ -0,0 +1,61 @@
+package hostedcluster
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	hyperv1 "github.com/openshift/hypershift/api/v1alpha1"
+	"github.com/openshift/hypershift/hypershift-operator/controllers/manifests/controlplaneoperator"
+)
+
+// trustBundleKey is the key of the additional trust bundle config map which
+// holds the PEM encoded certificates.
+const trustBundleKey = "ca-bundle.crt"
+
+// reconcileAdditionalTrustBundle syncs the additional trust bundle of the
+// HostedCluster into the control plane namespace, and removes it once it is
+// no longer referenced.
+func (r *HostedClusterReconciler) reconcileAdditionalTrustBundle(ctx context.Context, hcluster *hyperv1.HostedCluster, controlPlaneNamespace string) error {
+	dest := controlplaneoperator.AdditionalTrustBundle(controlPlaneNamespace)
+	if hcluster.Spec.AdditionalTrustBundle == nil {
+		if err := r.Delete(ctx, dest); err != nil && !apierrors.IsNotFound(err) {
+			return fmt.Errorf("failed to delete additional trust bundle: %w", err)
+		}
+		return nil
+	}
+	var src corev1.ConfigMap
+	if err := r.Get(ctx, client.ObjectKey{Namespace: hcluster.Namespace, Name: hcluster.Spec.AdditionalTrustBundle.Name}, &src); err != nil {
+		return fmt.Errorf("failed to get additional trust bundle %s: %w", hcluster.Spec.AdditionalTrustBundle.Name, err)
+	}
+	_, err := controllerutil.CreateOrUpdate(ctx, r.Client, dest, func() error {
+		bundle, hasBundle := src.Data[trustBundleKey]
+		if !hasBundle {
+			return fmt.Errorf("additional trust bundle config map %q must have a %s key", src.Name, trustBundleKey)
+		}
+		dest.Data = map[string]string{trustBundleKey: bundle}
+		if dest.Annotations == nil {
+			dest.Annotations = map[string]string{}
+		}
+		dest.Annotations[hostedClusterAnnotation] = client.ObjectKeyFromObject(hcluster).String()
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to reconcile additional trust bundle: %w", err)
+	}
+	return nil
+}
+
+// controlPlaneAdditionalTrustBundle returns the reference of the
+// HostedControlPlane to the additional trust bundle synced into the control
+// plane namespace.
+func controlPlaneAdditionalTrustBundle(controlPlaneNamespace string, ref *corev1.LocalObjectReference) *corev1.LocalObjectReference {
+	if ref == nil {
+		return nil
+	}
+	return &corev1.LocalObjectReference{Name: controlplaneoperator.AdditionalTrustBundle(controlPlaneNamespace).Name}
+}
@@ -0,0 +1,63 @@
+package hostedcluster
+
+import (
+	"context"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	hyperapi "github.com/openshift/hypershift/api"
+	hyperv1 "github.com/openshift/hypershift/api/v1alpha1"
+	"github.com/openshift/hypershift/hypershift-operator/controllers/manifests/controlplaneoperator"
+)
+
+func TestReconcileAdditionalTrustBundle(t *testing.T) {
+	tests := map[string]struct {
+		Ref            *corev1.LocalObjectReference
+		ExpectedBundle string
+	}{
+		"trust bundle is synced": {
+			Ref:            &corev1.LocalObjectReference{Name: "custom-ca"},
+			ExpectedBundle: "certs",
+		},
+		"unreferenced trust bundle is removed": {},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			stale := controlplaneoperator.AdditionalTrustBundle("clusters-example")
+			stale.Data = map[string]string{trustBundleKey: "stale"}
+			r := &HostedClusterReconciler{
+				Client: fake.NewClientBuilder().WithScheme(hyperapi.Scheme).WithObjects(
+					&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Namespace: "clusters", Name: "custom-ca"}, Data: map[string]string{trustBundleKey: "certs"}},
+					stale,
+				).Build(),
+			}
+			hcluster := &hyperv1.HostedCluster{ObjectMeta: metav1.ObjectMeta{Namespace: "clusters", Name: "example"}}
+			hcluster.Spec.AdditionalTrustBundle = test.Ref
+			if err := r.reconcileAdditionalTrustBundle(context.Background(), hcluster, "clusters-example"); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			bundle := controlplaneoperator.AdditionalTrustBundle("clusters-example")
+			err := r.Get(context.Background(), client.ObjectKeyFromObject(bundle), bundle)
+			if len(test.ExpectedBundle) == 0 {
+				if !apierrors.IsNotFound(err) {
+					t.Errorf("expected the trust bundle to be removed, got %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("failed to get the trust bundle: %v", err)
+			}
+			if actual := bundle.Data[trustBundleKey]; actual != test.ExpectedBundle {
+				t.Errorf("expected trust bundle %q, got %q", test.ExpectedBundle, actual)
+			}
+			if ref := controlPlaneAdditionalTrustBundle("clusters-example", test.Ref); ref.Name != bundle.Name {
+				t.Errorf("expected the control plane to reference %s, got %s", bundle.Name, ref.Name)
+			}
+		})
+	}
+}
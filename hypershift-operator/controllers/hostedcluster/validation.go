@@ -0,0 +1,189 @@
+package hostedcluster
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	hyperv1 "github.com/openshift/hypershift/api/v1alpha1"
+	"github.com/openshift/hypershift/control-plane-operator/controllers/hostedcontrolplane/render"
+)
+
+// resourceReference is a secret or config map referenced from a HostedCluster
+// spec together with the key it is required to contain.
+type resourceReference struct {
+	Path      string
+	Name      string
+	Key       string
+	ConfigMap bool
+}
+
+// referencedResources returns the secrets and config maps in the
+// HostedCluster namespace which the given HostedCluster depends on.
+func referencedResources(hcluster *hyperv1.HostedCluster) []resourceReference {
+	refs := []resourceReference{
+		{Path: "spec.pullSecret", Name: hcluster.Spec.PullSecret.Name, Key: ".dockerconfigjson"},
+		{Path: "spec.signingKey", Name: hcluster.Spec.SigningKey.Name, Key: "key"},
+	}
+	if len(hcluster.Spec.SSHKey.Name) > 0 {
+		refs = append(refs, resourceReference{Path: "spec.sshKey", Name: hcluster.Spec.SSHKey.Name, Key: "id_rsa.pub"})
+	}
+	if hcluster.Spec.Platform.Type == hyperv1.AWSPlatform && hcluster.Spec.Platform.AWS != nil {
+		refs = append(refs,
+			resourceReference{Path: "spec.platform.aws.kubeCloudControllerCreds", Name: hcluster.Spec.Platform.AWS.KubeCloudControllerCreds.Name, Key: "credentials"},
+			resourceReference{Path: "spec.platform.aws.nodePoolManagementCreds", Name: hcluster.Spec.Platform.AWS.NodePoolManagementCreds.Name, Key: "credentials"},
+		)
+		if len(hcluster.Spec.Platform.AWS.DNSManagementCreds.Name) > 0 {
+			refs = append(refs, resourceReference{Path: "spec.platform.aws.dnsManagementCreds", Name: hcluster.Spec.Platform.AWS.DNSManagementCreds.Name, Key: "credentials"})
+		}
+		if len(hcluster.Spec.Platform.AWS.ControlPlaneOperatorCreds.Name) > 0 {
+			refs = append(refs, resourceReference{Path: "spec.platform.aws.controlPlaneOperatorCreds", Name: hcluster.Spec.Platform.AWS.ControlPlaneOperatorCreds.Name, Key: "credentials"})
+		}
+	}
+	for i, service := range hcluster.Spec.Services {
+		if service.ServingCert != nil {
+			path := fmt.Sprintf("spec.services[%d].servicePublishingStrategy.servingCert", i)
+			refs = append(refs,
+				resourceReference{Path: path, Name: service.ServingCert.Name, Key: corev1.TLSCertKey},
+				resourceReference{Path: path, Name: service.ServingCert.Name, Key: corev1.TLSPrivateKeyKey},
+			)
+		}
+	}
+	if hcluster.Spec.AdditionalTrustBundle != nil {
+		refs = append(refs, resourceReference{Path: "spec.additionalTrustBundle", Name: hcluster.Spec.AdditionalTrustBundle.Name, Key: trustBundleKey, ConfigMap: true})
+	}
+	if hcluster.Spec.OAuth != nil {
+		for i := range hcluster.Spec.OAuth.IdentityProviders {
+			for _, ref := range render.IdentityProviderReferences(&hcluster.Spec.OAuth.IdentityProviders[i]) {
+				refs = append(refs, resourceReference{
+					Path:      fmt.Sprintf("spec.oauth.identityProviders[%d].%s", i, ref.Path),
+					Name:      *ref.Name,
+					Key:       ref.Key,
+					ConfigMap: ref.ConfigMap,
+				})
+			}
+		}
+	}
+	return refs
+}
+
+// validateReferencedResources checks that every secret and config map
+// referenced by the HostedCluster exists and contains its expected key, and
+// returns a message for each one which does not. An error is only returned if
+// the resources could not be read.
+func (r *HostedClusterReconciler) validateReferencedResources(ctx context.Context, hcluster *hyperv1.HostedCluster) ([]string, error) {
+	var problems []string
+	for _, ref := range referencedResources(hcluster) {
+		if len(ref.Name) == 0 {
+			problems = append(problems, fmt.Sprintf("%s.name is required", ref.Path))
+			continue
+		}
+		kind := "secret"
+		var hasKey bool
+		var err error
+		if ref.ConfigMap {
+			kind = "config map"
+			configMap := &corev1.ConfigMap{}
+			err = r.Get(ctx, client.ObjectKey{Namespace: hcluster.Namespace, Name: ref.Name}, configMap)
+			hasKey = len(configMap.Data[ref.Key]) > 0
+		} else {
+			secret := &corev1.Secret{}
+			err = r.Get(ctx, client.ObjectKey{Namespace: hcluster.Namespace, Name: ref.Name}, secret)
+			hasKey = len(secret.Data[ref.Key]) > 0
+		}
+		if err != nil {
+			if apierrors.IsNotFound(err) {
+				problems = append(problems, fmt.Sprintf("%s %q referenced by %s does not exist", kind, ref.Name, ref.Path))
+				continue
+			}
+			return nil, fmt.Errorf("failed to get %s %s referenced by %s: %w", kind, ref.Name, ref.Path, err)
+		}
+		if !hasKey {
+			problems = append(problems, fmt.Sprintf("%s %q referenced by %s must have a non-empty %q key", kind, ref.Name, ref.Path, ref.Key))
+		}
+	}
+	return problems, nil
+}
+
+// computeValidReferencedResourcesCondition determines the
+// ValidReferencedResources condition from the problems found with the
+// resources referenced by the given HostedCluster.
+func computeValidReferencedResourcesCondition(hcluster *hyperv1.HostedCluster, problems []string) metav1.Condition {
+	if len(problems) > 0 {
+		return metav1.Condition{
+			Type:               string(hyperv1.ValidReferencedResources),
+			Status:             metav1.ConditionFalse,
+			ObservedGeneration: hcluster.Generation,
+			Reason:             "InvalidReferencedResources",
+			Message:            strings.Join(problems, "; "),
+		}
+	}
+	return metav1.Condition{
+		Type:               string(hyperv1.ValidReferencedResources),
+		Status:             metav1.ConditionTrue,
+		ObservedGeneration: hcluster.Generation,
+		Reason:             "AsExpected",
+		Message:            "All referenced resources are valid",
+	}
+}
+
+// computeValidConfigurationCondition determines the ValidConfiguration
+// condition from the validation errors of the given HostedCluster spec. A
+// valid spec reports the ValidConfiguration condition of the hosted control
+// plane, which validates the configuration it renders.
+func computeValidConfigurationCondition(hcluster *hyperv1.HostedCluster, hcp *hyperv1.HostedControlPlane, errs field.ErrorList) metav1.Condition {
+	if len(errs) > 0 {
+		return metav1.Condition{
+			Type:               string(hyperv1.ValidConfiguration),
+			Status:             metav1.ConditionFalse,
+			ObservedGeneration: hcluster.Generation,
+			Reason:             "InvalidConfiguration",
+			Message:            errs.ToAggregate().Error(),
+		}
+	}
+	return computeHostedControlPlaneCondition(hcluster, hcp, hyperv1.ValidConfiguration)
+}
+
+// setValidationCondition sets a validation condition on the HostedCluster and
+// records a warning event whenever the condition newly reports a problem.
+func (r *HostedClusterReconciler) setValidationCondition(hcluster *hyperv1.HostedCluster, condition metav1.Condition) {
+	previous := meta.FindStatusCondition(hcluster.Status.Conditions, condition.Type)
+	changed := previous == nil || previous.Status != condition.Status || previous.Message != condition.Message
+	if condition.Status == metav1.ConditionFalse && changed && r.recorder != nil {
+		r.recorder.Event(hcluster, corev1.EventTypeWarning, condition.Reason, condition.Message)
+	}
+	meta.SetStatusCondition(&hcluster.Status.Conditions, condition)
+}
+
+// enqueueHostedClustersForReferencedResource maps a secret or config map to
+// the HostedClusters in its namespace which reference it, so that clusters
+// blocked on an invalid reference are reconciled again once the resource is
+// fixed, and so that changes to it are synced.
+func (r *HostedClusterReconciler) enqueueHostedClustersForReferencedResource(obj client.Object) []reconcile.Request {
+	hclusterList := &hyperv1.HostedClusterList{}
+	if err := r.List(context.Background(), hclusterList, client.InNamespace(obj.GetNamespace())); err != nil {
+		ctrl.Log.Error(err, "failed to list hosted clusters", "namespace", obj.GetNamespace())
+		return nil
+	}
+	_, isConfigMap := obj.(*corev1.ConfigMap)
+	var requests []reconcile.Request
+	for i := range hclusterList.Items {
+		hcluster := &hclusterList.Items[i]
+		for _, ref := range referencedResources(hcluster) {
+			if ref.Name == obj.GetName() && ref.ConfigMap == isConfigMap {
+				requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(hcluster)})
+				break
+			}
+		}
+	}
+	return requests
+}
@@ -0,0 +1,312 @@
+package hostedcluster
+
+import (
+	"context"
+	"testing"
+
+	configv1 "github.com/openshift/api/config/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	hyperapi "github.com/openshift/hypershift/api"
+	hyperv1 "github.com/openshift/hypershift/api/v1alpha1"
+)
+
+func TestValidateReferencedSecrets(t *testing.T) {
+	secret := func(name, key string) client.Object {
+		return &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "clusters", Name: name},
+			Data:       map[string][]byte{key: []byte("data")},
+		}
+	}
+	hcluster := &hyperv1.HostedCluster{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "clusters", Name: "example"},
+		Spec: hyperv1.HostedClusterSpec{
+			PullSecret: corev1.LocalObjectReference{Name: "pull-secret"},
+			SigningKey: corev1.LocalObjectReference{Name: "signing-key"},
+			SSHKey:     corev1.LocalObjectReference{Name: "ssh-key"},
+			Platform: hyperv1.PlatformSpec{
+				Type: hyperv1.AWSPlatform,
+				AWS: &hyperv1.AWSPlatformSpec{
+					KubeCloudControllerCreds: corev1.LocalObjectReference{Name: "kcc-creds"},
+					NodePoolManagementCreds:  corev1.LocalObjectReference{Name: "npm-creds"},
+				},
+			},
+		},
+	}
+	tests := map[string]struct {
+		Secrets          []client.Object
+		ExpectedProblems int
+	}{
+		"all secrets valid": {
+			Secrets: []client.Object{
+				secret("pull-secret", ".dockerconfigjson"),
+				secret("signing-key", "key"),
+				secret("ssh-key", "id_rsa.pub"),
+				secret("kcc-creds", "credentials"),
+				secret("npm-creds", "credentials"),
+			},
+			ExpectedProblems: 0,
+		},
+		"missing secrets": {
+			Secrets: []client.Object{
+				secret("pull-secret", ".dockerconfigjson"),
+				secret("signing-key", "key"),
+				secret("ssh-key", "id_rsa.pub"),
+			},
+			ExpectedProblems: 2,
+		},
+		"secret missing its key": {
+			Secrets: []client.Object{
+				secret("pull-secret", "config.json"),
+				secret("signing-key", "key"),
+				secret("ssh-key", "id_rsa.pub"),
+				secret("kcc-creds", "credentials"),
+				secret("npm-creds", "credentials"),
+			},
+			ExpectedProblems: 1,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			r := &HostedClusterReconciler{
+				Client: fake.NewClientBuilder().WithScheme(hyperapi.Scheme).WithObjects(test.Secrets...).Build(),
+			}
+			problems, err := r.validateReferencedResources(context.Background(), hcluster)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if len(problems) != test.ExpectedProblems {
+				t.Errorf("expected %d problems, got %v", test.ExpectedProblems, problems)
+			}
+			condition := computeValidReferencedResourcesCondition(hcluster, problems)
+			if expectedValid := test.ExpectedProblems == 0; (condition.Status == metav1.ConditionTrue) != expectedValid {
+				t.Errorf("expected condition valid=%t, got %s: %s", expectedValid, condition.Status, condition.Message)
+			}
+		})
+	}
+}
+
+func TestValidateReferencedIdentityProviderResources(t *testing.T) {
+	hcluster := &hyperv1.HostedCluster{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "clusters", Name: "example"},
+		Spec: hyperv1.HostedClusterSpec{
+			PullSecret: corev1.LocalObjectReference{Name: "pull-secret"},
+			SigningKey: corev1.LocalObjectReference{Name: "signing-key"},
+			SSHKey:     corev1.LocalObjectReference{Name: "ssh-key"},
+			OAuth: &hyperv1.OAuthSpec{
+				IdentityProviders: []configv1.IdentityProvider{{
+					Name: "ldap",
+					IdentityProviderConfig: configv1.IdentityProviderConfig{
+						Type: configv1.IdentityProviderTypeLDAP,
+						LDAP: &configv1.LDAPIdentityProvider{
+							URL:          "ldaps://ldap.example.com/ou=users,dc=example,dc=com?uid",
+							BindPassword: configv1.SecretNameReference{Name: "ldap-bind"},
+							CA:           configv1.ConfigMapNameReference{Name: "ldap-ca"},
+						},
+					},
+				}},
+			},
+		},
+	}
+	objects := []client.Object{
+		&corev1.Secret{ObjectMeta: metav1.ObjectMeta{Namespace: "clusters", Name: "pull-secret"}, Data: map[string][]byte{".dockerconfigjson": []byte("data")}},
+		&corev1.Secret{ObjectMeta: metav1.ObjectMeta{Namespace: "clusters", Name: "signing-key"}, Data: map[string][]byte{"key": []byte("data")}},
+		&corev1.Secret{ObjectMeta: metav1.ObjectMeta{Namespace: "clusters", Name: "ssh-key"}, Data: map[string][]byte{"id_rsa.pub": []byte("data")}},
+	}
+	tests := map[string]struct {
+		Objects          []client.Object
+		ExpectedProblems int
+	}{
+		"all references valid": {
+			Objects: []client.Object{
+				&corev1.Secret{ObjectMeta: metav1.ObjectMeta{Namespace: "clusters", Name: "ldap-bind"}, Data: map[string][]byte{configv1.BindPasswordKey: []byte("password")}},
+				&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Namespace: "clusters", Name: "ldap-ca"}, Data: map[string]string{"ca.crt": "ca"}},
+			},
+			ExpectedProblems: 0,
+		},
+		"missing config map": {
+			Objects: []client.Object{
+				&corev1.Secret{ObjectMeta: metav1.ObjectMeta{Namespace: "clusters", Name: "ldap-bind"}, Data: map[string][]byte{configv1.BindPasswordKey: []byte("password")}},
+			},
+			ExpectedProblems: 1,
+		},
+		"config map missing its key": {
+			Objects: []client.Object{
+				&corev1.Secret{ObjectMeta: metav1.ObjectMeta{Namespace: "clusters", Name: "ldap-bind"}, Data: map[string][]byte{configv1.BindPasswordKey: []byte("password")}},
+				&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Namespace: "clusters", Name: "ldap-ca"}, Data: map[string]string{"ca.pem": "ca"}},
+			},
+			ExpectedProblems: 1,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			r := &HostedClusterReconciler{
+				Client: fake.NewClientBuilder().WithScheme(hyperapi.Scheme).WithObjects(append(objects, test.Objects...)...).Build(),
+			}
+			problems, err := r.validateReferencedResources(context.Background(), hcluster)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if len(problems) != test.ExpectedProblems {
+				t.Errorf("expected %d problems, got %v", test.ExpectedProblems, problems)
+			}
+		})
+	}
+}
+
+func TestValidateReferencedServingCerts(t *testing.T) {
+	hcluster := &hyperv1.HostedCluster{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "clusters", Name: "example"},
+		Spec: hyperv1.HostedClusterSpec{
+			PullSecret: corev1.LocalObjectReference{Name: "pull-secret"},
+			SigningKey: corev1.LocalObjectReference{Name: "signing-key"},
+			Services: []hyperv1.ServicePublishingStrategyMapping{{
+				Service: hyperv1.APIServer,
+				ServicePublishingStrategy: hyperv1.ServicePublishingStrategy{
+					Type:        hyperv1.LoadBalancer,
+					Hostname:    "api.example.com",
+					ServingCert: &corev1.LocalObjectReference{Name: "api-cert"},
+				},
+			}},
+		},
+	}
+	objects := []client.Object{
+		&corev1.Secret{ObjectMeta: metav1.ObjectMeta{Namespace: "clusters", Name: "pull-secret"}, Data: map[string][]byte{".dockerconfigjson": []byte("data")}},
+		&corev1.Secret{ObjectMeta: metav1.ObjectMeta{Namespace: "clusters", Name: "signing-key"}, Data: map[string][]byte{"key": []byte("data")}},
+	}
+	tests := map[string]struct {
+		Cert             *corev1.Secret
+		ExpectedProblems int
+	}{
+		"valid serving cert": {
+			Cert:             &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Namespace: "clusters", Name: "api-cert"}, Data: map[string][]byte{"tls.crt": []byte("cert"), "tls.key": []byte("key")}},
+			ExpectedProblems: 0,
+		},
+		"serving cert without a key": {
+			Cert:             &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Namespace: "clusters", Name: "api-cert"}, Data: map[string][]byte{"tls.crt": []byte("cert")}},
+			ExpectedProblems: 1,
+		},
+		"missing serving cert": {
+			ExpectedProblems: 2,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			objs := append([]client.Object(nil), objects...)
+			if test.Cert != nil {
+				objs = append(objs, test.Cert)
+			}
+			r := &HostedClusterReconciler{
+				Client: fake.NewClientBuilder().WithScheme(hyperapi.Scheme).WithObjects(objs...).Build(),
+			}
+			problems, err := r.validateReferencedResources(context.Background(), hcluster)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if len(problems) != test.ExpectedProblems {
+				t.Errorf("expected %d problems, got %v", test.ExpectedProblems, problems)
+			}
+		})
+	}
+}
+
+func TestValidateReferencedAdditionalTrustBundle(t *testing.T) {
+	hcluster := &hyperv1.HostedCluster{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "clusters", Name: "example"},
+		Spec: hyperv1.HostedClusterSpec{
+			PullSecret:            corev1.LocalObjectReference{Name: "pull-secret"},
+			SigningKey:            corev1.LocalObjectReference{Name: "signing-key"},
+			AdditionalTrustBundle: &corev1.LocalObjectReference{Name: "user-ca-bundle"},
+		},
+	}
+	objects := []client.Object{
+		&corev1.Secret{ObjectMeta: metav1.ObjectMeta{Namespace: "clusters", Name: "pull-secret"}, Data: map[string][]byte{".dockerconfigjson": []byte("data")}},
+		&corev1.Secret{ObjectMeta: metav1.ObjectMeta{Namespace: "clusters", Name: "signing-key"}, Data: map[string][]byte{"key": []byte("data")}},
+	}
+	tests := map[string]struct {
+		Bundle           client.Object
+		ExpectedProblems int
+	}{
+		"valid trust bundle": {
+			Bundle:           &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Namespace: "clusters", Name: "user-ca-bundle"}, Data: map[string]string{"ca-bundle.crt": "certs"}},
+			ExpectedProblems: 0,
+		},
+		"trust bundle without its key": {
+			Bundle:           &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Namespace: "clusters", Name: "user-ca-bundle"}, Data: map[string]string{"ca.crt": "certs"}},
+			ExpectedProblems: 1,
+		},
+		"trust bundle in a secret": {
+			Bundle:           &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Namespace: "clusters", Name: "user-ca-bundle"}, Data: map[string][]byte{"ca-bundle.crt": []byte("certs")}},
+			ExpectedProblems: 1,
+		},
+		"missing trust bundle": {
+			ExpectedProblems: 1,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			objs := append([]client.Object(nil), objects...)
+			if test.Bundle != nil {
+				objs = append(objs, test.Bundle)
+			}
+			r := &HostedClusterReconciler{
+				Client: fake.NewClientBuilder().WithScheme(hyperapi.Scheme).WithObjects(objs...).Build(),
+			}
+			problems, err := r.validateReferencedResources(context.Background(), hcluster)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if len(problems) != test.ExpectedProblems {
+				t.Errorf("expected %d problems, got %v", test.ExpectedProblems, problems)
+			}
+		})
+	}
+}
+
+func TestComputeValidConfigurationCondition(t *testing.T) {
+	hcp := func(status metav1.ConditionStatus) *hyperv1.HostedControlPlane {
+		return &hyperv1.HostedControlPlane{
+			ObjectMeta: metav1.ObjectMeta{Generation: 2},
+			Status: hyperv1.HostedControlPlaneStatus{
+				Conditions: []metav1.Condition{
+					{Type: string(hyperv1.ValidConfiguration), Status: status, ObservedGeneration: 2, Reason: "AsExpected"},
+				},
+			},
+		}
+	}
+	tests := map[string]struct {
+		ControlPlane   *hyperv1.HostedControlPlane
+		Errors         field.ErrorList
+		ExpectedStatus metav1.ConditionStatus
+	}{
+		"invalid spec": {
+			ControlPlane:   hcp(metav1.ConditionTrue),
+			Errors:         field.ErrorList{field.Required(field.NewPath("spec", "infraID"), "")},
+			ExpectedStatus: metav1.ConditionFalse,
+		},
+		"valid spec with a valid control plane configuration": {
+			ControlPlane:   hcp(metav1.ConditionTrue),
+			ExpectedStatus: metav1.ConditionTrue,
+		},
+		"valid spec with an invalid control plane configuration": {
+			ControlPlane:   hcp(metav1.ConditionFalse),
+			ExpectedStatus: metav1.ConditionFalse,
+		},
+		"valid spec without a control plane": {
+			ExpectedStatus: metav1.ConditionUnknown,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			hcluster := &hyperv1.HostedCluster{ObjectMeta: metav1.ObjectMeta{Generation: 5}}
+			condition := computeValidConfigurationCondition(hcluster, test.ControlPlane, test.Errors)
+			if condition.Status != test.ExpectedStatus {
+				t.Errorf("expected status %s, got %s: %s", test.ExpectedStatus, condition.Status, condition.Message)
+			}
+		})
+	}
+}
@@ -2,7 +2,9 @@ package machineconfigserver
 
 import (
 	"context"
+	"crypto/md5"
 	"encoding/base64"
+	"encoding/json"
 	"fmt"
 	"time"
 
@@ -11,6 +13,7 @@ import (
 	routev1 "github.com/openshift/api/route/v1"
 	hyperv1 "github.com/openshift/hypershift/api/v1alpha1"
 	"github.com/openshift/hypershift/control-plane-operator/releaseinfo"
+	hyperutil "github.com/openshift/hypershift/support/util"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
@@ -21,14 +24,32 @@ import (
 	"k8s.io/client-go/util/workqueue"
 	k8sutilspointer "k8s.io/utils/pointer"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
 	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
 )
 
 const (
 	finalizer = "hypershift.openshift.io/finalizer"
+
+	// configHashAnnotation is set on the machine config server pods to the
+	// hash of their configuration.
+	configHashAnnotation = "hypershift.openshift.io/config-hash"
+
+	// machineConfigServerConfigMapName is the config map rendered by the
+	// control plane operator which holds the cluster configuration the
+	// machine config server bootstraps from.
+	machineConfigServerConfigMapName = "machine-config-server"
+
+	// ignitionConfigLabel marks the config maps rendered by the control plane
+	// operator which hold additional manifests for the machine config server
+	// to render into the ignition configuration of the nodes.
+	ignitionConfigLabel = "ignition-config"
 )
 
 var NoopReconcile controllerutil.MutateFn = func() error { return nil }
@@ -48,11 +69,16 @@ type MachineConfigServerReconciler struct {
 
 func (r *MachineConfigServerReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	_, err := ctrl.NewControllerManagedBy(mgr).
-		For(&hyperv1.MachineConfigServer{}).
+		For(&hyperv1.MachineConfigServer{}, builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		Watches(&source.Kind{Type: &hyperv1.HostedControlPlane{}}, handler.EnqueueRequestsFromMapFunc(r.enqueueNamespaceMachineConfigServers),
+			builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		Watches(&source.Kind{Type: &corev1.ConfigMap{}}, handler.EnqueueRequestsFromMapFunc(r.enqueueNamespaceMachineConfigServers),
+			builder.WithPredicates(predicate.NewPredicateFuncs(func(obj ctrlclient.Object) bool {
+				return obj.GetName() == machineConfigServerConfigMapName || obj.GetLabels()[ignitionConfigLabel] == "true"
+			}))).
 		WithOptions(controller.Options{
 			RateLimiter: workqueue.NewItemExponentialFailureRateLimiter(1*time.Second, 10*time.Second),
 		}).
-		WithEventFilter(predicate.GenerationChangedPredicate{}).
 		Build(r)
 	if err != nil {
 		return fmt.Errorf("failed setting up with a controller manager %w", err)
@@ -76,12 +102,6 @@ func (r *MachineConfigServerReconciler) Reconcile(ctx context.Context, req ctrl.
 		return ctrl.Result{}, err
 	}
 
-	releaseImage, err := r.ReleaseProvider.Lookup(ctx, mcs.Spec.ReleaseImage)
-	if err != nil {
-		return ctrl.Result{}, err
-	}
-	r.Log = r.Log.WithValues("releaseImage", mcs.Spec.ReleaseImage, "version", releaseImage.Version())
-
 	// Generate mcs manifests for the given release
 	mcsServiceAccount := MachineConfigServerServiceAccount(mcs.Namespace, mcs.Name)
 
@@ -130,6 +150,40 @@ func (r *MachineConfigServerReconciler) Reconcile(ctx context.Context, req ctrl.
 		return ctrl.Result{}, nil
 	}
 
+	// Stop here while reconciliation of the control plane is paused. The paused
+	// state is propagated from the HostedCluster to the HostedControlPlane which
+	// shares the machineConfigServer namespace.
+	hcpList := &hyperv1.HostedControlPlaneList{}
+	if err := r.List(ctx, hcpList, ctrlclient.InNamespace(mcs.Namespace)); err != nil {
+		return ctrl.Result{}, fmt.Errorf("failed to list hostedControlPlanes: %w", err)
+	}
+	ignitionStrategy := hyperutil.ServicePublishingStrategy(nil, hyperv1.Ignition)
+	var imageContentSources []hyperv1.ImageContentSource
+	for _, hcp := range hcpList.Items {
+		ignitionStrategy = hyperutil.ServicePublishingStrategy(hcp.Spec.Services, hyperv1.Ignition)
+		imageContentSources = hcp.Spec.ImageContentSources
+		isPaused, pausedDuration, err := hyperutil.IsReconciliationPaused(time.Now(), hcp.Spec.PausedUntil)
+		if err != nil {
+			r.Log.Error(err, "ignoring invalid pausedUntil value")
+		}
+		if isPaused {
+			r.Log.Info("Reconciliation paused", "pausedUntil", *hcp.Spec.PausedUntil)
+			return ctrl.Result{RequeueAfter: pausedDuration}, nil
+		}
+	}
+
+	// The machine config server runs the images of the release, pulled from
+	// their registry mirrors.
+	releaseProvider := &releaseinfo.RegistryMirrorProviderDecorator{
+		Delegate:            r.ReleaseProvider,
+		ImageContentSources: imageContentSources,
+	}
+	releaseImage, err := releaseProvider.Lookup(ctx, mcs.Spec.ReleaseImage)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	r.Log = r.Log.WithValues("releaseImage", mcs.Spec.ReleaseImage, "version", releaseImage.Version())
+
 	// Ensure the machineConfigServer has a finalizer for cleanup
 	if !controllerutil.ContainsFinalizer(mcs, finalizer) {
 		controllerutil.AddFinalizer(mcs, finalizer)
@@ -162,49 +216,50 @@ func (r *MachineConfigServerReconciler) Reconcile(ctx context.Context, req ctrl.
 		return ctrl.Result{}, err
 	}
 
-	_, err = controllerutil.CreateOrUpdate(ctx, r.Client, mcsDeployment, func() error {
-		return reconcileMCSDeployment(mcsDeployment, mcs, mcsServiceAccount, releaseImage.ComponentImages())
-	})
+	// The machine config server only reads its configuration when it starts, so
+	// roll it whenever the configuration changes.
+	configHash, err := r.machineConfigServerConfigHash(ctx, mcs.Namespace)
 	if err != nil {
 		return ctrl.Result{}, err
 	}
 
-	_, err = controllerutil.CreateOrUpdate(ctx, r.Client, mcsService, func() error {
-		mcsService.Spec.Ports = []corev1.ServicePort{
-			{
-				Name:       "http",
-				Protocol:   corev1.ProtocolTCP,
-				Port:       80,
-				TargetPort: intstr.FromInt(8080),
-				NodePort:   0,
-			},
-		}
-		mcsService.Spec.Selector = map[string]string{
-			"app": fmt.Sprintf("machine-config-server-%s", mcs.Name),
-		}
-		mcsService.Spec.Type = corev1.ServiceTypeClusterIP
-		return nil
+	_, err = controllerutil.CreateOrUpdate(ctx, r.Client, mcsDeployment, func() error {
+		return reconcileMCSDeployment(mcsDeployment, mcs, mcsServiceAccount, releaseImage.ComponentImages(), configHash)
 	})
 	if err != nil {
 		return ctrl.Result{}, err
 	}
 
-	r.Log.Info("Creating ignition provider route")
-	_, err = controllerutil.CreateOrUpdate(ctx, r.Client, ignitionRoute, func() error {
-		ignitionRoute.Spec.To = routev1.RouteTargetReference{
-			Kind: "Service",
-			Name: fmt.Sprintf("machine-config-server-%s", mcs.Name),
-		}
-		return nil
+	_, err = controllerutil.CreateOrUpdate(ctx, r.Client, mcsService, func() error {
+		return reconcileMCSService(mcsService, mcs, ignitionStrategy)
 	})
 	if err != nil {
 		return ctrl.Result{}, err
 	}
-	if err := r.Get(ctx, ctrlclient.ObjectKeyFromObject(ignitionRoute), ignitionRoute); err != nil {
-		return ctrl.Result{}, err
+
+	var ignitionHost string
+	switch ignitionStrategy.Type {
+	case hyperv1.Route:
+		r.Log.Info("Creating ignition provider route")
+		_, err = controllerutil.CreateOrUpdate(ctx, r.Client, ignitionRoute, func() error {
+			ignitionRoute.Spec.To = routev1.RouteTargetReference{
+				Kind: "Service",
+				Name: fmt.Sprintf("machine-config-server-%s", mcs.Name),
+			}
+			return nil
+		})
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+		if err := r.Get(ctx, ctrlclient.ObjectKeyFromObject(ignitionRoute), ignitionRoute); err != nil {
+			return ctrl.Result{}, err
+		}
+		ignitionHost = ignitionRoute.Spec.Host
+	default:
+		ignitionHost = ignitionServiceHost(mcsService, ignitionStrategy)
 	}
-	if ignitionRoute.Spec.Host == "" {
-		r.Log.Info("Waiting for ignition route to be available")
+	if ignitionHost == "" {
+		r.Log.Info("Waiting for ignition endpoint to be available", "strategy", ignitionStrategy.Type)
 		return ctrl.Result{Requeue: true}, nil
 	}
 
@@ -226,9 +281,9 @@ func (r *MachineConfigServerReconciler) Reconcile(ctx context.Context, req ctrl.
 		semversion.Pre = nil
 		semversion.Build = nil
 		if semversion.GTE(semver.MustParse("4.6.0")) {
-			userDataValue = []byte(fmt.Sprintf(`{"ignition":{"config":{"merge":[{"source":"http://%s/config/master","verification":{}}]},"security":{},"timeouts":{},"version":"3.1.0"},"networkd":{},"passwd":{},"storage":{},"systemd":{}}`, ignitionRoute.Spec.Host))
+			userDataValue = []byte(fmt.Sprintf(`{"ignition":{"config":{"merge":[{"source":"http://%s/config/master","verification":{}}]},"security":{},"timeouts":{},"version":"3.1.0"},"networkd":{},"passwd":{},"storage":{},"systemd":{}}`, ignitionHost))
 		} else {
-			userDataValue = []byte(fmt.Sprintf(`{"ignition":{"config":{"append":[{"source":"http://%s/config/master","verification":{}}]},"security":{},"timeouts":{},"version":"2.2.0"},"networkd":{},"passwd":{},"storage":{},"systemd":{}}`, ignitionRoute.Spec.Host))
+			userDataValue = []byte(fmt.Sprintf(`{"ignition":{"config":{"append":[{"source":"http://%s/config/master","verification":{}}]},"security":{},"timeouts":{},"version":"2.2.0"},"networkd":{},"passwd":{},"storage":{},"systemd":{}}`, ignitionHost))
 		}
 
 		userDataSecret.Data = map[string][]byte{
@@ -242,7 +297,7 @@ func (r *MachineConfigServerReconciler) Reconcile(ctx context.Context, req ctrl.
 	}
 
 	mcs.Status.Version = releaseImage.Version()
-	mcs.Status.Host = ignitionRoute.Spec.Host
+	mcs.Status.Host = ignitionHost
 	if err := r.Status().Update(ctx, mcs); err != nil {
 		return ctrl.Result{}, err
 	}
@@ -250,10 +305,73 @@ func (r *MachineConfigServerReconciler) Reconcile(ctx context.Context, req ctrl.
 	return ctrl.Result{}, nil
 }
 
-func reconcileMCSDeployment(deployment *appsv1.Deployment, mcs *hyperv1.MachineConfigServer, sa *corev1.ServiceAccount, images map[string]string) error {
+// reconcileMCSService publishes the machine config server with the ignition
+// publishing strategy. A service published through a route only needs a
+// cluster IP.
+func reconcileMCSService(svc *corev1.Service, mcs *hyperv1.MachineConfigServer, strategy hyperv1.ServicePublishingStrategy) error {
+	var nodePort int32
+	if len(svc.Spec.Ports) > 0 {
+		nodePort = svc.Spec.Ports[0].NodePort
+	}
+	switch strategy.Type {
+	case hyperv1.LoadBalancer:
+		svc.Spec.Type = corev1.ServiceTypeLoadBalancer
+	case hyperv1.NodePort:
+		svc.Spec.Type = corev1.ServiceTypeNodePort
+		if strategy.NodePort != nil && strategy.NodePort.Port > 0 {
+			nodePort = strategy.NodePort.Port
+		}
+	default:
+		svc.Spec.Type = corev1.ServiceTypeClusterIP
+		nodePort = 0
+	}
+	svc.Spec.Ports = []corev1.ServicePort{
+		{
+			Name:       "http",
+			Protocol:   corev1.ProtocolTCP,
+			Port:       80,
+			TargetPort: intstr.FromInt(8080),
+			NodePort:   nodePort,
+		},
+	}
+	svc.Spec.Selector = map[string]string{
+		"app": fmt.Sprintf("machine-config-server-%s", mcs.Name),
+	}
+	return nil
+}
+
+// ignitionServiceHost returns the host, with its port unless it is the HTTP
+// port, through which the workers reach the machine config server published
+// with the strategy. It is empty until it is known.
+func ignitionServiceHost(svc *corev1.Service, strategy hyperv1.ServicePublishingStrategy) string {
+	switch strategy.Type {
+	case hyperv1.NodePort:
+		if strategy.NodePort == nil || len(svc.Spec.Ports) == 0 || svc.Spec.Ports[0].NodePort == 0 {
+			return ""
+		}
+		return fmt.Sprintf("%s:%d", strategy.NodePort.Address, svc.Spec.Ports[0].NodePort)
+	case hyperv1.LoadBalancer:
+		for _, ingress := range svc.Status.LoadBalancer.Ingress {
+			if len(ingress.Hostname) > 0 {
+				return ingress.Hostname
+			}
+			if len(ingress.IP) > 0 {
+				return ingress.IP
+			}
+		}
+	}
+	return ""
+}
+
+func reconcileMCSDeployment(deployment *appsv1.Deployment, mcs *hyperv1.MachineConfigServer, sa *corev1.ServiceAccount, images map[string]string, configHash string) error {
 	bootstrapArgs := fmt.Sprintf(`
 mkdir -p /mcc-manifests/bootstrap/manifests
 mkdir -p /mcc-manifests/manifests
+# The additional trust bundle is only rendered when the cluster has one
+ADDITIONAL_TRUST_BUNDLE_ARGS=""
+if [ -f /assets/manifests/user-ca-bundle-config.yaml ]; then
+  ADDITIONAL_TRUST_BUNDLE_ARGS="--additional-trust-bundle-config-file=/assets/manifests/user-ca-bundle-config.yaml"
+fi
 exec machine-config-operator bootstrap \
 --root-ca=/assets/manifests/root-ca.crt \
 --kube-ca=/assets/manifests/combined-ca.crt \
@@ -271,7 +389,8 @@ exec machine-config-operator bootstrap \
 --config-file=/assets/manifests/install-config.yaml \
 --dns-config-file=/assets/manifests/cluster-dns-02-config.yaml \
 --dest-dir=/mcc-manifests \
---pull-secret=/assets/manifests/pull-secret.yaml
+--pull-secret=/assets/manifests/pull-secret.yaml \
+${ADDITIONAL_TRUST_BUNDLE_ARGS}
 
 # Use our own version of configpools that swap master and workers
 mv /mcc-manifests/bootstrap/manifests /mcc-manifests/bootstrap/manifests.tmp
@@ -478,7 +597,7 @@ oc get cm -l ignition-config="true" -n "${NAMESPACE}" --no-headers | awk '{ prin
 						VolumeSource: corev1.VolumeSource{
 							ConfigMap: &corev1.ConfigMapVolumeSource{
 								LocalObjectReference: corev1.LocalObjectReference{
-									Name: "machine-config-server",
+									Name: machineConfigServerConfigMapName,
 								},
 							},
 						},
@@ -487,5 +606,53 @@ oc get cm -l ignition-config="true" -n "${NAMESPACE}" --no-headers | awk '{ prin
 			},
 		},
 	}
+	if len(configHash) > 0 {
+		deployment.Spec.Template.Annotations = map[string]string{
+			configHashAnnotation: configHash,
+		}
+	}
 	return nil
 }
+
+// machineConfigServerConfigHash returns a hash of the configuration of the
+// machine config servers in a namespace, including the additional manifests
+// they render, or an empty string until the control plane operator rendered
+// it.
+func (r *MachineConfigServerReconciler) machineConfigServerConfigHash(ctx context.Context, namespace string) (string, error) {
+	configMap := &corev1.ConfigMap{}
+	if err := r.Get(ctx, ctrlclient.ObjectKey{Namespace: namespace, Name: machineConfigServerConfigMapName}, configMap); err != nil {
+		if apierrors.IsNotFound(err) {
+			return "", nil
+		}
+		return "", fmt.Errorf("failed to get machine config server config map: %w", err)
+	}
+	config := map[string]map[string]string{machineConfigServerConfigMapName: configMap.Data}
+	ignitionConfigs := &corev1.ConfigMapList{}
+	if err := r.List(ctx, ignitionConfigs, ctrlclient.InNamespace(namespace), ctrlclient.MatchingLabels{ignitionConfigLabel: "true"}); err != nil {
+		return "", fmt.Errorf("failed to list ignition config maps: %w", err)
+	}
+	for _, ignitionConfig := range ignitionConfigs.Items {
+		config[ignitionConfig.Name] = ignitionConfig.Data
+	}
+	data, err := json.Marshal(config)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal machine config server config map: %w", err)
+	}
+	return fmt.Sprintf("%x", md5.Sum(data)), nil
+}
+
+// enqueueNamespaceMachineConfigServers enqueues all the machineConfigServers in
+// the namespace of a HostedControlPlane so that changes to the control plane,
+// e.g. resuming reconciliation, reach them.
+func (r *MachineConfigServerReconciler) enqueueNamespaceMachineConfigServers(obj ctrlclient.Object) []reconcile.Request {
+	mcsList := &hyperv1.MachineConfigServerList{}
+	if err := r.List(context.Background(), mcsList, ctrlclient.InNamespace(obj.GetNamespace())); err != nil {
+		ctrl.Log.Error(err, "failed to list machineConfigServers", "namespace", obj.GetNamespace())
+		return []reconcile.Request{}
+	}
+	var requests []reconcile.Request
+	for _, mcs := range mcsList.Items {
+		requests = append(requests, reconcile.Request{NamespacedName: ctrlclient.ObjectKeyFromObject(&mcs)})
+	}
+	return requests
+}
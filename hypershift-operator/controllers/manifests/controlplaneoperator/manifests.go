@@ -1,6 +1,9 @@
 package controlplaneoperator
 
 import (
+	"fmt"
+	"strings"
+
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
@@ -115,3 +118,25 @@ func SSHKey(controlPlaneNamespace string) *corev1.Secret {
 		},
 	}
 }
+
+// ServingCert is the serving certificate of a published service synced into
+// the control plane namespace.
+func ServingCert(controlPlaneNamespace string, service hyperv1.ServiceType) *corev1.Secret {
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: controlPlaneNamespace,
+			Name:      fmt.Sprintf("%s-serving-cert", strings.ToLower(string(service))),
+		},
+	}
+}
+
+// AdditionalTrustBundle is the additional trust bundle of the HostedCluster
+// synced into the control plane namespace.
+func AdditionalTrustBundle(controlPlaneNamespace string) *corev1.ConfigMap {
+	return &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: controlPlaneNamespace,
+			Name:      "user-ca-bundle",
+		},
+	}
+}
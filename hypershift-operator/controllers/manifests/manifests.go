@@ -26,6 +26,15 @@ func KubeConfigSecret(hostedClusterNamespace string, hostedClusterName string) *
 	}
 }
 
+func KubeadminPasswordSecret(hostedClusterNamespace string, hostedClusterName string) *corev1.Secret {
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: hostedClusterNamespace,
+			Name:      hostedClusterName + "-kubeadmin-password",
+		},
+	}
+}
+
 func DefaultNodePool(hostedClusterNamespace, hostedClusterName string) *hyperv1.NodePool {
 	return &hyperv1.NodePool{
 		ObjectMeta: metav1.ObjectMeta{
@@ -52,3 +61,21 @@ func AWSNodePoolManagementCreds(controlPlaneNamespace string) *corev1.Secret {
 		},
 	}
 }
+
+func AWSDNSManagementCreds(controlPlaneNamespace string) *corev1.Secret {
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: controlPlaneNamespace,
+			Name:      "dns-provider-creds",
+		},
+	}
+}
+
+func AWSControlPlaneOperatorCreds(controlPlaneNamespace string) *corev1.Secret {
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: controlPlaneNamespace,
+			Name:      "control-plane-operator-creds",
+		},
+	}
+}
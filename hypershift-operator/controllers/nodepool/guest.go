@@ -0,0 +1,60 @@
+package nodepool
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/clientcmd"
+	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
+
+	hyperv1 "github.com/openshift/hypershift/api/v1alpha1"
+	"github.com/openshift/hypershift/hypershift-operator/controllers/manifests"
+)
+
+// guestClientTimeout bounds the requests to the guest cluster API server, so
+// that an unreachable guest cluster doesn't stall the reconciliation.
+const guestClientTimeout = 10 * time.Second
+
+// guestNodes returns the nodes of the guest cluster by name, read through the
+// kubeconfig of the HostedCluster. It returns nil until the HostedCluster
+// reports its kubeconfig.
+func (r *NodePoolReconciler) guestNodes(ctx context.Context, hcluster *hyperv1.HostedCluster) (map[string]*corev1.Node, error) {
+	if hcluster.Status.KubeConfig == nil {
+		return nil, nil
+	}
+	secret := manifests.KubeConfigSecret(hcluster.Namespace, hcluster.Name)
+	if err := r.Get(ctx, ctrlclient.ObjectKeyFromObject(secret), secret); err != nil {
+		return nil, fmt.Errorf("failed to get hostedcluster kubeconfig secret: %w", err)
+	}
+	restConfig, err := clientcmd.RESTConfigFromKubeConfig(secret.Data["kubeconfig"])
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse hostedcluster kubeconfig: %w", err)
+	}
+	restConfig.Timeout = guestClientTimeout
+	guestClient, err := ctrlclient.New(restConfig, ctrlclient.Options{Scheme: scheme.Scheme})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create guest cluster client: %w", err)
+	}
+	nodeList := &corev1.NodeList{}
+	if err := guestClient.List(ctx, nodeList); err != nil {
+		return nil, fmt.Errorf("failed to list guest cluster nodes: %w", err)
+	}
+	nodes := make(map[string]*corev1.Node, len(nodeList.Items))
+	for i := range nodeList.Items {
+		nodes[nodeList.Items[i].Name] = &nodeList.Items[i]
+	}
+	return nodes, nil
+}
+
+// isNodeReady returns whether the node reports the Ready condition.
+func isNodeReady(node *corev1.Node) bool {
+	for _, condition := range node.Status.Conditions {
+		if condition.Type == corev1.NodeReady {
+			return condition.Status == corev1.ConditionTrue
+		}
+	}
+	return false
+}
@@ -10,9 +10,10 @@ import (
 	"github.com/go-logr/logr"
 	hyperv1 "github.com/openshift/hypershift/api/v1alpha1"
 	"github.com/openshift/hypershift/control-plane-operator/releaseinfo"
+	"github.com/openshift/hypershift/hypershift-operator/controllers/hostedcluster"
 	"github.com/openshift/hypershift/hypershift-operator/controllers/machineimage"
 	"github.com/openshift/hypershift/hypershift-operator/controllers/manifests"
-	hyperutil "github.com/openshift/hypershift/hypershift-operator/controllers/util"
+	hyperutil "github.com/openshift/hypershift/support/util"
 	capiv1 "github.com/openshift/hypershift/thirdparty/clusterapi/api/v1alpha4"
 	"github.com/openshift/hypershift/thirdparty/clusterapi/util"
 	"github.com/openshift/hypershift/thirdparty/clusterapi/util/patch"
@@ -32,7 +33,6 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
-	"sigs.k8s.io/controller-runtime/pkg/predicate"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 	"sigs.k8s.io/controller-runtime/pkg/source"
 )
@@ -50,22 +50,31 @@ type NodePoolReconciler struct {
 	Log             logr.Logger
 	ImageProvider   machineimage.Provider
 	ReleaseProvider releaseinfo.Provider
+
+	// nodePoolReader reads NodePools from the manager cache, which is the
+	// only reader supporting the NodePoolClusterNameIndex registered by the
+	// HostedCluster controller.
+	nodePoolReader ctrlclient.Reader
 }
 
 func (r *NodePoolReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	_, err := ctrl.NewControllerManagedBy(mgr).
 		For(&hyperv1.NodePool{}).
 		Watches(&source.Kind{Type: &capiv1.MachineDeployment{}}, handler.EnqueueRequestsFromMapFunc(enqueueParentNodePool)).
+		Watches(&source.Kind{Type: &capiv1.Machine{}}, handler.EnqueueRequestsFromMapFunc(r.enqueueMachineNodePool)).
+		Watches(&source.Kind{Type: &capiaws.AWSMachine{}}, handler.EnqueueRequestsFromMapFunc(r.enqueueAWSMachineNodePool)).
+		Watches(&source.Kind{Type: &hyperv1.HostedCluster{}}, handler.EnqueueRequestsFromMapFunc(r.enqueueHostedClusterNodePools)).
+		Watches(&source.Kind{Type: &corev1.ConfigMap{}}, handler.EnqueueRequestsFromMapFunc(r.enqueueNodePoolsForAdditionalTrustBundle)).
 		WithOptions(controller.Options{
 			RateLimiter: workqueue.NewItemExponentialFailureRateLimiter(1*time.Second, 10*time.Second),
 		}).
-		WithEventFilter(predicate.GenerationChangedPredicate{}).
 		Build(r)
 	if err != nil {
 		return errors.Wrap(err, "failed setting up with a controller manager")
 	}
 
 	r.recorder = mgr.GetEventRecorderFor("nodepool-controller")
+	r.nodePoolReader = mgr.GetCache()
 
 	return nil
 }
@@ -126,6 +135,16 @@ func (r *NodePoolReconciler) Reconcile(ctx context.Context, req ctrl.Request) (c
 		return ctrl.Result{}, nil
 	}
 
+	// Stop here while reconciliation of the parent HostedCluster is paused.
+	isPaused, pausedDuration, err := hyperutil.IsReconciliationPaused(time.Now(), hcluster.Spec.PausedUntil)
+	if err != nil {
+		r.Log.Error(err, "ignoring invalid pausedUntil value")
+	}
+	if isPaused {
+		r.Log.Info("Reconciliation paused", "pausedUntil", *hcluster.Spec.PausedUntil)
+		return ctrl.Result{RequeueAfter: pausedDuration}, nil
+	}
+
 	// Ensure the nodePool has a finalizer for cleanup
 	if !controllerutil.ContainsFinalizer(nodePool, finalizer) {
 		controllerutil.AddFinalizer(nodePool, finalizer)
@@ -225,7 +244,11 @@ func (r *NodePoolReconciler) reconcile(ctx context.Context, hcluster *hyperv1.Ho
 		nodePool.Spec.Release.Image = hcluster.Status.Version.History[0].Image
 	}
 
-	releaseImage, err := r.ReleaseProvider.Lookup(ctx, nodePool.Spec.Release.Image)
+	releaseProvider := &releaseinfo.RegistryMirrorProviderDecorator{
+		Delegate:            r.ReleaseProvider,
+		ImageContentSources: hcluster.Spec.ImageContentSources,
+	}
+	releaseImage, err := releaseProvider.Lookup(ctx, nodePool.Spec.Release.Image)
 	if err != nil {
 		return ctrl.Result{}, err
 	}
@@ -265,8 +288,17 @@ func (r *NodePoolReconciler) reconcile(ctx context.Context, hcluster *hyperv1.Ho
 		})
 	}
 
+	// The nodes only read the additional trust bundle when they are ignited,
+	// so roll them whenever it changes.
+	trustBundleHash, err := r.additionalTrustBundleHash(ctx, hcluster, targetNamespace)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
 	// Persist machineDeployment
 	if _, err := ctrl.CreateOrUpdate(ctx, r.Client, machineDeployment, func() error {
+		setTrustBundleHash(&machineDeployment.Spec.Template, trustBundleHash)
+
 		// Propagate version to the machineDeployment.
 		if targetVersion == nodePool.Status.Version &&
 			targetVersion != StringPtrDeref(machineDeployment.Spec.Template.Spec.Version) {
@@ -328,19 +360,26 @@ func (r *NodePoolReconciler) reconcile(ctx context.Context, hcluster *hyperv1.Ho
 		return ctrl.Result{}, err
 	}
 
-	// Update Status.nodeCount and conditions
-	nodePool.Status.NodeCount = int(machineDeployment.Status.AvailableReplicas)
+	// Update replica counts, machines and conditions. Changes to the
+	// machineDeployment and its machines trigger a new reconcile through the
+	// watches, so there is no need to requeue while they converge.
+	// The guest cluster may not be reachable, e.g. while its API server
+	// comes up, in which case the readiness of the nodes is left unknown.
+	nodes, err := r.guestNodes(ctx, hcluster)
+	if err != nil {
+		log.Error(err, "failed to read guest cluster nodes")
+	}
+	machines, err := r.machineStatuses(ctx, machineDeployment, nodes)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	setMachineDeploymentStatus(nodePool, machineDeployment, machines)
 	if !isAutoscalingEnabled {
 		meta.SetStatusCondition(&nodePool.Status.Conditions, metav1.Condition{
 			Type:   hyperv1.NodePoolAutoscalingEnabledConditionType,
 			Status: metav1.ConditionFalse,
 			Reason: hyperv1.NodePoolAsExpectedConditionReason,
 		})
-
-		if nodePool.Status.NodeCount != int(*nodePool.Spec.NodeCount) {
-			log.Info("Requeueing nodePool", "expected available nodes", *nodePool.Spec.NodeCount, "current available nodes", nodePool.Status.NodeCount)
-			return ctrl.Result{Requeue: true}, nil
-		}
 		return ctrl.Result{}, nil
 	}
 
@@ -685,6 +724,24 @@ func enqueueParentNodePool(obj ctrlclient.Object) []reconcile.Request {
 	}
 }
 
+// enqueueHostedClusterNodePools enqueues all the nodePools of a HostedCluster
+// so that changes to the cluster, e.g. resuming reconciliation, reach them.
+func (r *NodePoolReconciler) enqueueHostedClusterNodePools(obj ctrlclient.Object) []reconcile.Request {
+	nodePoolList := &hyperv1.NodePoolList{}
+	if err := r.nodePoolReader.List(context.Background(), nodePoolList,
+		ctrlclient.InNamespace(obj.GetNamespace()),
+		ctrlclient.MatchingFields{hostedcluster.NodePoolClusterNameIndex: obj.GetName()},
+	); err != nil {
+		ctrl.Log.Error(err, "failed to list nodePools", "cluster", obj.GetName())
+		return []reconcile.Request{}
+	}
+	var requests []reconcile.Request
+	for _, nodePool := range nodePoolList.Items {
+		requests = append(requests, reconcile.Request{NamespacedName: ctrlclient.ObjectKeyFromObject(&nodePool)})
+	}
+	return requests
+}
+
 func StringPtrDeref(ptr *string) string {
 	if ptr != nil {
 		return *ptr
@@ -0,0 +1,187 @@
+package nodepool
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	hyperv1 "github.com/openshift/hypershift/api/v1alpha1"
+	capiv1 "github.com/openshift/hypershift/thirdparty/clusterapi/api/v1alpha4"
+	capiaws "github.com/openshift/hypershift/thirdparty/clusterapiprovideraws/v1alpha3"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// machineStatuses returns the state of the machines of the given
+// MachineDeployment, sorted by name. Failures reported only on a machine's
+// AWSMachine are included as well, and so is the readiness of the guest
+// cluster node of each machine unless the guest nodes are nil.
+func (r *NodePoolReconciler) machineStatuses(ctx context.Context, machineDeployment *capiv1.MachineDeployment, nodes map[string]*corev1.Node) ([]hyperv1.NodePoolMachineStatus, error) {
+	machines := &capiv1.MachineList{}
+	if err := r.List(ctx, machines,
+		ctrlclient.InNamespace(machineDeployment.Namespace),
+		ctrlclient.MatchingLabels{capiv1.MachineDeploymentLabelName: machineDeployment.Name},
+	); err != nil {
+		return nil, fmt.Errorf("failed to list machines: %w", err)
+	}
+	awsMachines := &capiaws.AWSMachineList{}
+	if err := r.List(ctx, awsMachines, ctrlclient.InNamespace(machineDeployment.Namespace)); err != nil {
+		return nil, fmt.Errorf("failed to list awsmachines: %w", err)
+	}
+	awsMachinesByMachine := map[string]*capiaws.AWSMachine{}
+	for i := range awsMachines.Items {
+		if owner := machineOwner(&awsMachines.Items[i]); len(owner) > 0 {
+			awsMachinesByMachine[owner] = &awsMachines.Items[i]
+		}
+	}
+
+	var statuses []hyperv1.NodePoolMachineStatus
+	for _, machine := range machines.Items {
+		status := hyperv1.NodePoolMachineStatus{
+			Name:           machine.Name,
+			Phase:          machine.Status.Phase,
+			FailureMessage: StringPtrDeref(machine.Status.FailureMessage),
+		}
+		if machine.Status.NodeRef != nil {
+			status.NodeName = machine.Status.NodeRef.Name
+			if nodes != nil {
+				node, ok := nodes[status.NodeName]
+				ready := ok && isNodeReady(node)
+				status.NodeReady = &ready
+			}
+		}
+		if machine.Status.FailureReason != nil {
+			status.FailureReason = string(*machine.Status.FailureReason)
+		}
+		if awsMachine, ok := awsMachinesByMachine[machine.Name]; ok && len(status.FailureReason) == 0 && awsMachine.Status.FailureReason != nil {
+			status.FailureReason = string(*awsMachine.Status.FailureReason)
+			status.FailureMessage = StringPtrDeref(awsMachine.Status.FailureMessage)
+		}
+		statuses = append(statuses, status)
+	}
+	sort.Slice(statuses, func(i, j int) bool { return statuses[i].Name < statuses[j].Name })
+	return statuses, nil
+}
+
+// setMachineDeploymentStatus updates the replica counts, machines and the
+// Ready, ScalingUp and ScalingDown conditions of the NodePool from the state
+// of its MachineDeployment, machines and their guest cluster nodes.
+func setMachineDeploymentStatus(nodePool *hyperv1.NodePool, machineDeployment *capiv1.MachineDeployment, machines []hyperv1.NodePoolMachineStatus) {
+	desired := Int32PtrDerefOr(machineDeployment.Spec.Replicas, 0)
+	status := machineDeployment.Status
+
+	nodePool.Status.NodeCount = int(status.AvailableReplicas)
+	nodePool.Status.Replicas = desired
+	nodePool.Status.ReadyReplicas = status.ReadyReplicas
+	nodePool.Status.AvailableReplicas = status.AvailableReplicas
+	nodePool.Status.UpdatedReplicas = status.UpdatedReplicas
+	nodePool.Status.Machines = machines
+
+	var failures, notReadyNodes []string
+	for _, machine := range machines {
+		if len(machine.FailureReason) > 0 || len(machine.FailureMessage) > 0 {
+			failures = append(failures, fmt.Sprintf("machine %s: %s %s", machine.Name, machine.FailureReason, machine.FailureMessage))
+		}
+		if machine.NodeReady != nil && !*machine.NodeReady {
+			notReadyNodes = append(notReadyNodes, machine.NodeName)
+		}
+	}
+	switch {
+	case len(failures) > 0:
+		meta.SetStatusCondition(&nodePool.Status.Conditions, metav1.Condition{
+			Type:    hyperv1.NodePoolReadyConditionType,
+			Status:  metav1.ConditionFalse,
+			Reason:  hyperv1.NodePoolMachinesFailedReason,
+			Message: strings.Join(failures, "; "),
+		})
+	case status.ObservedGeneration < machineDeployment.Generation || status.AvailableReplicas < desired:
+		meta.SetStatusCondition(&nodePool.Status.Conditions, metav1.Condition{
+			Type:    hyperv1.NodePoolReadyConditionType,
+			Status:  metav1.ConditionFalse,
+			Reason:  hyperv1.NodePoolWaitingForMachinesReason,
+			Message: fmt.Sprintf("%d of %d machines are available", status.AvailableReplicas, desired),
+		})
+	case len(notReadyNodes) > 0:
+		meta.SetStatusCondition(&nodePool.Status.Conditions, metav1.Condition{
+			Type:    hyperv1.NodePoolReadyConditionType,
+			Status:  metav1.ConditionFalse,
+			Reason:  hyperv1.NodePoolWaitingForNodesReason,
+			Message: fmt.Sprintf("Nodes are not ready: %s", strings.Join(notReadyNodes, ", ")),
+		})
+	default:
+		meta.SetStatusCondition(&nodePool.Status.Conditions, metav1.Condition{
+			Type:    hyperv1.NodePoolReadyConditionType,
+			Status:  metav1.ConditionTrue,
+			Reason:  hyperv1.NodePoolAsExpectedConditionReason,
+			Message: fmt.Sprintf("%d of %d machines are available", status.AvailableReplicas, desired),
+		})
+	}
+
+	// Surplus machines during a rolling update are not a scale down.
+	rollingOut := status.UpdatedReplicas < status.Replicas
+	setScalingCondition(nodePool, hyperv1.NodePoolScalingUpConditionType, status.Replicas < desired, status.Replicas, desired)
+	setScalingCondition(nodePool, hyperv1.NodePoolScalingDownConditionType, status.Replicas > desired && !rollingOut, status.Replicas, desired)
+}
+
+func setScalingCondition(nodePool *hyperv1.NodePool, conditionType string, scaling bool, current, desired int32) {
+	condition := metav1.Condition{
+		Type:   conditionType,
+		Status: metav1.ConditionFalse,
+		Reason: hyperv1.NodePoolAsExpectedConditionReason,
+	}
+	if scaling {
+		condition.Status = metav1.ConditionTrue
+		condition.Message = fmt.Sprintf("Scaling from %d to %d machines", current, desired)
+	}
+	meta.SetStatusCondition(&nodePool.Status.Conditions, condition)
+}
+
+// machineOwner returns the name of the Machine owning the given object, if any.
+func machineOwner(obj ctrlclient.Object) string {
+	for _, ref := range obj.GetOwnerReferences() {
+		if ref.Kind == "Machine" {
+			return ref.Name
+		}
+	}
+	return ""
+}
+
+// enqueueMachineNodePool maps a Machine to its NodePool through the
+// MachineDeployment controlling it.
+func (r *NodePoolReconciler) enqueueMachineNodePool(obj ctrlclient.Object) []reconcile.Request {
+	machineDeploymentName := obj.GetLabels()[capiv1.MachineDeploymentLabelName]
+	if len(machineDeploymentName) == 0 {
+		return []reconcile.Request{}
+	}
+	machineDeployment := &capiv1.MachineDeployment{}
+	if err := r.Get(context.Background(), ctrlclient.ObjectKey{Namespace: obj.GetNamespace(), Name: machineDeploymentName}, machineDeployment); err != nil {
+		if !apierrors.IsNotFound(err) {
+			ctrl.Log.Error(err, "failed to get machineDeployment", "machine", obj.GetName())
+		}
+		return []reconcile.Request{}
+	}
+	return enqueueParentNodePool(machineDeployment)
+}
+
+// enqueueAWSMachineNodePool maps an AWSMachine to the NodePool of the Machine
+// owning it.
+func (r *NodePoolReconciler) enqueueAWSMachineNodePool(obj ctrlclient.Object) []reconcile.Request {
+	machineName := machineOwner(obj)
+	if len(machineName) == 0 {
+		return []reconcile.Request{}
+	}
+	machine := &capiv1.Machine{}
+	if err := r.Get(context.Background(), ctrlclient.ObjectKey{Namespace: obj.GetNamespace(), Name: machineName}, machine); err != nil {
+		if !apierrors.IsNotFound(err) {
+			ctrl.Log.Error(err, "failed to get machine", "awsMachine", obj.GetName())
+		}
+		return []reconcile.Request{}
+	}
+	return r.enqueueMachineNodePool(machine)
+}
@@ -0,0 +1,186 @@
+package nodepool
+
+import (
+	"context"
+	"testing"
+
+	hyperapi "github.com/openshift/hypershift/api"
+	hyperv1 "github.com/openshift/hypershift/api/v1alpha1"
+	capiv1 "github.com/openshift/hypershift/thirdparty/clusterapi/api/v1alpha4"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8sutilspointer "k8s.io/utils/pointer"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestSetMachineDeploymentStatus(t *testing.T) {
+	tests := map[string]struct {
+		Replicas           int32
+		Status             capiv1.MachineDeploymentStatus
+		Machines           []hyperv1.NodePoolMachineStatus
+		ExpectedConditions map[string]metav1.ConditionStatus
+		ExpectedReason     string
+	}{
+		"all machines available": {
+			Replicas: 2,
+			Status:   capiv1.MachineDeploymentStatus{Replicas: 2, UpdatedReplicas: 2, ReadyReplicas: 2, AvailableReplicas: 2},
+			ExpectedConditions: map[string]metav1.ConditionStatus{
+				hyperv1.NodePoolReadyConditionType:       metav1.ConditionTrue,
+				hyperv1.NodePoolScalingUpConditionType:   metav1.ConditionFalse,
+				hyperv1.NodePoolScalingDownConditionType: metav1.ConditionFalse,
+			},
+			ExpectedReason: hyperv1.NodePoolAsExpectedConditionReason,
+		},
+		"scaling up": {
+			Replicas: 3,
+			Status:   capiv1.MachineDeploymentStatus{Replicas: 1, UpdatedReplicas: 1, ReadyReplicas: 1, AvailableReplicas: 1},
+			ExpectedConditions: map[string]metav1.ConditionStatus{
+				hyperv1.NodePoolReadyConditionType:       metav1.ConditionFalse,
+				hyperv1.NodePoolScalingUpConditionType:   metav1.ConditionTrue,
+				hyperv1.NodePoolScalingDownConditionType: metav1.ConditionFalse,
+			},
+			ExpectedReason: hyperv1.NodePoolWaitingForMachinesReason,
+		},
+		"scaling down": {
+			Replicas: 1,
+			Status:   capiv1.MachineDeploymentStatus{Replicas: 3, UpdatedReplicas: 3, ReadyReplicas: 3, AvailableReplicas: 3},
+			ExpectedConditions: map[string]metav1.ConditionStatus{
+				hyperv1.NodePoolReadyConditionType:       metav1.ConditionTrue,
+				hyperv1.NodePoolScalingUpConditionType:   metav1.ConditionFalse,
+				hyperv1.NodePoolScalingDownConditionType: metav1.ConditionTrue,
+			},
+			ExpectedReason: hyperv1.NodePoolAsExpectedConditionReason,
+		},
+		"surge during a rolling update is not a scale down": {
+			Replicas: 2,
+			Status:   capiv1.MachineDeploymentStatus{Replicas: 3, UpdatedReplicas: 1, ReadyReplicas: 2, AvailableReplicas: 2},
+			ExpectedConditions: map[string]metav1.ConditionStatus{
+				hyperv1.NodePoolScalingDownConditionType: metav1.ConditionFalse,
+			},
+			ExpectedReason: hyperv1.NodePoolAsExpectedConditionReason,
+		},
+		"failed machine": {
+			Replicas: 2,
+			Status:   capiv1.MachineDeploymentStatus{Replicas: 2, UpdatedReplicas: 2, ReadyReplicas: 1, AvailableReplicas: 1},
+			Machines: []hyperv1.NodePoolMachineStatus{
+				{Name: "a", Phase: "Running", NodeName: "node-a"},
+				{Name: "b", Phase: "Failed", FailureReason: "CreateError", FailureMessage: "instance limit exceeded"},
+			},
+			ExpectedConditions: map[string]metav1.ConditionStatus{
+				hyperv1.NodePoolReadyConditionType: metav1.ConditionFalse,
+			},
+			ExpectedReason: hyperv1.NodePoolMachinesFailedReason,
+		},
+		"guest node not ready": {
+			Replicas: 2,
+			Status:   capiv1.MachineDeploymentStatus{Replicas: 2, UpdatedReplicas: 2, ReadyReplicas: 2, AvailableReplicas: 2},
+			Machines: []hyperv1.NodePoolMachineStatus{
+				{Name: "a", Phase: "Running", NodeName: "node-a", NodeReady: k8sutilspointer.BoolPtr(true)},
+				{Name: "b", Phase: "Running", NodeName: "node-b", NodeReady: k8sutilspointer.BoolPtr(false)},
+			},
+			ExpectedConditions: map[string]metav1.ConditionStatus{
+				hyperv1.NodePoolReadyConditionType: metav1.ConditionFalse,
+			},
+			ExpectedReason: hyperv1.NodePoolWaitingForNodesReason,
+		},
+		"unknown guest node readiness": {
+			Replicas: 1,
+			Status:   capiv1.MachineDeploymentStatus{Replicas: 1, UpdatedReplicas: 1, ReadyReplicas: 1, AvailableReplicas: 1},
+			Machines: []hyperv1.NodePoolMachineStatus{
+				{Name: "a", Phase: "Running", NodeName: "node-a"},
+			},
+			ExpectedConditions: map[string]metav1.ConditionStatus{
+				hyperv1.NodePoolReadyConditionType: metav1.ConditionTrue,
+			},
+			ExpectedReason: hyperv1.NodePoolAsExpectedConditionReason,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			nodePool := &hyperv1.NodePool{}
+			machineDeployment := &capiv1.MachineDeployment{
+				Spec:   capiv1.MachineDeploymentSpec{Replicas: k8sutilspointer.Int32Ptr(test.Replicas)},
+				Status: test.Status,
+			}
+			setMachineDeploymentStatus(nodePool, machineDeployment, test.Machines)
+			for conditionType, expectedStatus := range test.ExpectedConditions {
+				condition := meta.FindStatusCondition(nodePool.Status.Conditions, conditionType)
+				if condition == nil {
+					t.Fatalf("missing condition %s", conditionType)
+				}
+				if condition.Status != expectedStatus {
+					t.Errorf("expected condition %s to be %s, got %s", conditionType, expectedStatus, condition.Status)
+				}
+			}
+			if ready := meta.FindStatusCondition(nodePool.Status.Conditions, hyperv1.NodePoolReadyConditionType); ready.Reason != test.ExpectedReason {
+				t.Errorf("expected ready reason %s, got %s", test.ExpectedReason, ready.Reason)
+			}
+			if nodePool.Status.Replicas != test.Replicas || nodePool.Status.ReadyReplicas != test.Status.ReadyReplicas || nodePool.Status.NodeCount != int(test.Status.AvailableReplicas) {
+				t.Errorf("unexpected replica counts: %+v", nodePool.Status)
+			}
+		})
+	}
+}
+
+func TestMachineStatuses(t *testing.T) {
+	machineDeployment := &capiv1.MachineDeployment{ObjectMeta: metav1.ObjectMeta{Namespace: "clusters-example", Name: "workers"}}
+	machine := func(name, nodeName string) *capiv1.Machine {
+		m := &capiv1.Machine{ObjectMeta: metav1.ObjectMeta{
+			Namespace: "clusters-example",
+			Name:      name,
+			Labels:    map[string]string{capiv1.MachineDeploymentLabelName: "workers"},
+		}}
+		if len(nodeName) > 0 {
+			m.Status.NodeRef = &corev1.ObjectReference{Name: nodeName}
+		}
+		return m
+	}
+	node := func(name string, ready corev1.ConditionStatus) *corev1.Node {
+		return &corev1.Node{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Status:     corev1.NodeStatus{Conditions: []corev1.NodeCondition{{Type: corev1.NodeReady, Status: ready}}},
+		}
+	}
+	r := &NodePoolReconciler{Client: fake.NewClientBuilder().WithScheme(hyperapi.Scheme).WithObjects(
+		machine("a", "node-a"), machine("b", "node-b"), machine("c", "node-c"), machine("d", ""),
+	).Build()}
+
+	tests := map[string]struct {
+		Nodes             map[string]*corev1.Node
+		ExpectedNodeReady map[string]*bool
+	}{
+		"guest nodes read": {
+			Nodes: map[string]*corev1.Node{
+				"node-a": node("node-a", corev1.ConditionTrue),
+				"node-b": node("node-b", corev1.ConditionFalse),
+			},
+			ExpectedNodeReady: map[string]*bool{
+				"a": k8sutilspointer.BoolPtr(true),
+				"b": k8sutilspointer.BoolPtr(false),
+				"c": k8sutilspointer.BoolPtr(false),
+				"d": nil,
+			},
+		},
+		"guest nodes unknown": {
+			ExpectedNodeReady: map[string]*bool{"a": nil, "b": nil, "c": nil, "d": nil},
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			statuses, err := r.machineStatuses(context.Background(), machineDeployment, test.Nodes)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if len(statuses) != len(test.ExpectedNodeReady) {
+				t.Fatalf("expected %d machines, got %d", len(test.ExpectedNodeReady), len(statuses))
+			}
+			for _, status := range statuses {
+				expected := test.ExpectedNodeReady[status.Name]
+				if (expected == nil) != (status.NodeReady == nil) || (expected != nil && *expected != *status.NodeReady) {
+					t.Errorf("unexpected node readiness of machine %s: %v", status.Name, status.NodeReady)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,77 @@
+package nodepool
+
+import (
+	"context"
+	"crypto/md5"
+	"fmt"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	hyperv1 "github.com/openshift/hypershift/api/v1alpha1"
+	"github.com/openshift/hypershift/hypershift-operator/controllers/manifests/controlplaneoperator"
+	hyperutil "github.com/openshift/hypershift/support/util"
+	capiv1 "github.com/openshift/hypershift/thirdparty/clusterapi/api/v1alpha4"
+)
+
+const (
+	// trustBundleKey is the key of the additional trust bundle config map
+	// which holds the PEM encoded certificates.
+	trustBundleKey = "ca-bundle.crt"
+
+	// trustBundleHashAnnotation is set on the machine template of a
+	// MachineDeployment to the hash of the additional trust bundle its nodes
+	// are ignited with, so that changing the bundle rolls the nodes.
+	trustBundleHashAnnotation = "hypershift.openshift.io/trust-bundle-hash"
+
+	// hostedClusterAnnotation is set by the HostedCluster controller on the
+	// additional trust bundle synced into the control plane namespace.
+	hostedClusterAnnotation = "hypershift.openshift.io/cluster"
+)
+
+// additionalTrustBundleHash returns the hash of the additional trust bundle
+// synced into the control plane namespace, or an empty string without one.
+func (r *NodePoolReconciler) additionalTrustBundleHash(ctx context.Context, hcluster *hyperv1.HostedCluster, controlPlaneNamespace string) (string, error) {
+	if hcluster.Spec.AdditionalTrustBundle == nil {
+		return "", nil
+	}
+	configMap := controlplaneoperator.AdditionalTrustBundle(controlPlaneNamespace)
+	if err := r.Get(ctx, ctrlclient.ObjectKeyFromObject(configMap), configMap); err != nil {
+		if apierrors.IsNotFound(err) {
+			return "", nil
+		}
+		return "", fmt.Errorf("failed to get additional trust bundle: %w", err)
+	}
+	return fmt.Sprintf("%x", md5.Sum([]byte(configMap.Data[trustBundleKey]))), nil
+}
+
+// setTrustBundleHash sets the additional trust bundle hash of a machine
+// template, or removes it without an additional trust bundle.
+func setTrustBundleHash(template *capiv1.MachineTemplateSpec, hash string) {
+	if len(hash) == 0 {
+		delete(template.Annotations, trustBundleHashAnnotation)
+		return
+	}
+	if template.Annotations == nil {
+		template.Annotations = map[string]string{}
+	}
+	template.Annotations[trustBundleHashAnnotation] = hash
+}
+
+// enqueueNodePoolsForAdditionalTrustBundle enqueues the nodePools of the
+// HostedCluster whose additional trust bundle was synced into the control
+// plane namespace, so that changes to it roll their nodes.
+func (r *NodePoolReconciler) enqueueNodePoolsForAdditionalTrustBundle(obj ctrlclient.Object) []reconcile.Request {
+	if obj.GetName() != controlplaneoperator.AdditionalTrustBundle(obj.GetNamespace()).Name {
+		return nil
+	}
+	hostedClusterName, hasAnnotation := obj.GetAnnotations()[hostedClusterAnnotation]
+	if !hasAnnotation {
+		return nil
+	}
+	hcluster := &hyperv1.HostedCluster{}
+	key := hyperutil.ParseNamespacedName(hostedClusterName)
+	hcluster.Namespace, hcluster.Name = key.Namespace, key.Name
+	return r.enqueueHostedClusterNodePools(hcluster)
+}
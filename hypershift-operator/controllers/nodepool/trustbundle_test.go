@@ -0,0 +1,139 @@
+package nodepool
+
+import (
+	"context"
+	"testing"
+
+	hyperapi "github.com/openshift/hypershift/api"
+	hyperv1 "github.com/openshift/hypershift/api/v1alpha1"
+	"github.com/openshift/hypershift/hypershift-operator/controllers/hostedcluster"
+	capiv1 "github.com/openshift/hypershift/thirdparty/clusterapi/api/v1alpha4"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+// clusterNameIndexReader emulates the NodePoolClusterNameIndex of the manager
+// cache, which the fake client doesn't support.
+type clusterNameIndexReader struct {
+	client.Reader
+}
+
+func (r *clusterNameIndexReader) List(ctx context.Context, list client.ObjectList, opts ...client.ListOption) error {
+	listOpts := &client.ListOptions{}
+	listOpts.ApplyOptions(opts)
+	if err := r.Reader.List(ctx, list, opts...); err != nil {
+		return err
+	}
+	nodePoolList, ok := list.(*hyperv1.NodePoolList)
+	if !ok || listOpts.FieldSelector == nil {
+		return nil
+	}
+	clusterName, _ := listOpts.FieldSelector.RequiresExactMatch(hostedcluster.NodePoolClusterNameIndex)
+	var items []hyperv1.NodePool
+	for _, nodePool := range nodePoolList.Items {
+		if nodePool.Spec.ClusterName == clusterName {
+			items = append(items, nodePool)
+		}
+	}
+	nodePoolList.Items = items
+	return nil
+}
+
+func TestAdditionalTrustBundleHash(t *testing.T) {
+	bundle := func(data string) *corev1.ConfigMap {
+		return &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "clusters-example", Name: "user-ca-bundle"},
+			Data:       map[string]string{trustBundleKey: data},
+		}
+	}
+	tests := map[string]struct {
+		Bundle         *corev1.ConfigMap
+		Referenced     bool
+		ExpectedHashed bool
+	}{
+		"no additional trust bundle": {
+			Bundle: bundle("stale"),
+		},
+		"additional trust bundle not synced yet": {
+			Referenced: true,
+		},
+		"additional trust bundle": {
+			Bundle:         bundle("ca"),
+			Referenced:     true,
+			ExpectedHashed: true,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			builder := fake.NewClientBuilder().WithScheme(hyperapi.Scheme)
+			if test.Bundle != nil {
+				builder = builder.WithObjects(test.Bundle)
+			}
+			r := &NodePoolReconciler{Client: builder.Build()}
+			hcluster := &hyperv1.HostedCluster{ObjectMeta: metav1.ObjectMeta{Namespace: "clusters", Name: "example"}}
+			if test.Referenced {
+				hcluster.Spec.AdditionalTrustBundle = &corev1.LocalObjectReference{Name: "ca"}
+			}
+			hash, err := r.additionalTrustBundleHash(context.Background(), hcluster, "clusters-example")
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			template := &capiv1.MachineTemplateSpec{ObjectMeta: capiv1.ObjectMeta{
+				Annotations: map[string]string{trustBundleHashAnnotation: "previous"},
+			}}
+			setTrustBundleHash(template, hash)
+			actual, hashed := template.Annotations[trustBundleHashAnnotation]
+			if hashed != test.ExpectedHashed || actual == "previous" {
+				t.Errorf("expected the template to be hashed to be %t, got %q", test.ExpectedHashed, actual)
+			}
+		})
+	}
+}
+
+func TestEnqueueNodePoolsForAdditionalTrustBundle(t *testing.T) {
+	nodePools := []*hyperv1.NodePool{
+		{ObjectMeta: metav1.ObjectMeta{Namespace: "clusters", Name: "a"}, Spec: hyperv1.NodePoolSpec{ClusterName: "example"}},
+		{ObjectMeta: metav1.ObjectMeta{Namespace: "clusters", Name: "b"}, Spec: hyperv1.NodePoolSpec{ClusterName: "other"}},
+	}
+	c := fake.NewClientBuilder().WithScheme(hyperapi.Scheme).WithObjects(nodePools[0], nodePools[1]).Build()
+	r := &NodePoolReconciler{Client: c, nodePoolReader: &clusterNameIndexReader{Reader: c}}
+
+	tests := map[string]struct {
+		ConfigMap *corev1.ConfigMap
+		Expected  []string
+	}{
+		"synced additional trust bundle": {
+			ConfigMap: &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{
+				Namespace:   "clusters-example",
+				Name:        "user-ca-bundle",
+				Annotations: map[string]string{hostedClusterAnnotation: "clusters/example"},
+			}},
+			Expected: []string{"a"},
+		},
+		"other config map": {
+			ConfigMap: &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{
+				Namespace:   "clusters-example",
+				Name:        "other",
+				Annotations: map[string]string{hostedClusterAnnotation: "clusters/example"},
+			}},
+		},
+		"config map not synced by the hostedcluster controller": {
+			ConfigMap: &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Namespace: "clusters-example", Name: "user-ca-bundle"}},
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			requests := r.enqueueNodePoolsForAdditionalTrustBundle(test.ConfigMap)
+			var actual []string
+			for _, request := range requests {
+				actual = append(actual, request.Name)
+			}
+			if len(actual) != len(test.Expected) || (len(actual) > 0 && actual[0] != test.Expected[0]) {
+				t.Errorf("expected requests for %v, got %v", test.Expected, actual)
+			}
+		})
+	}
+}
@@ -28,8 +28,10 @@ import (
 	"github.com/openshift/hypershift/hypershift-operator/controllers/machineconfigserver"
 	"github.com/openshift/hypershift/hypershift-operator/controllers/machineimage/static"
 	"github.com/openshift/hypershift/hypershift-operator/controllers/nodepool"
+	"github.com/openshift/hypershift/hypershift-operator/webhook"
 	"github.com/spf13/cobra"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/client-go/kubernetes"
 	appsv1client "k8s.io/client-go/kubernetes/typed/apps/v1"
 	_ "k8s.io/client-go/plugin/pkg/client/auth/gcp"
@@ -73,6 +75,10 @@ func NewStartCommand() *cobra.Command {
 	var metricsAddr string
 	var enableLeaderElection bool
 	var operatorImage string
+	var enableWebhook bool
+	var webhookCertDir string
+	var imageOverrides map[string]string
+	var allowedImageAnnotations []string
 
 	cmd.Flags().StringVar(&namespace, "namespace", "hypershift", "The namespace this operator lives in")
 	cmd.Flags().StringVar(&deploymentName, "deployment-name", "operator", "The name of the deployment of this operator")
@@ -81,6 +87,10 @@ func NewStartCommand() *cobra.Command {
 		"Enable leader election for controller manager. "+
 			"Enabling this will ensure there is only one active controller manager.")
 	cmd.Flags().StringVar(&operatorImage, "operator-image", "", "A control plane operator image to use (defaults to match this operator if running in a deployment)")
+	cmd.Flags().BoolVar(&enableWebhook, "enable-webhook", false, "Serve the HostedCluster and NodePool admission webhooks")
+	cmd.Flags().StringVar(&webhookCertDir, "webhook-cert-dir", "/var/run/secrets/serving-cert", "The directory the webhook serving certificate is written to")
+	cmd.Flags().StringToStringVar(&imageOverrides, "image-overrides", nil, "Images which replace the default images of the components managed next to each control plane, by component name (e.g. cluster-autoscaler=registry.example.com/autoscaler:v1.21.0)")
+	cmd.Flags().StringSliceVar(&allowedImageAnnotations, "allowed-image-annotations", nil, "Names of the components whose images may be overridden per HostedCluster with an annotation. The components run with credentials of the management cluster, so only allow users who can edit HostedClusters to choose their images if they are trusted with those credentials")
 
 	cmd.Run = func(cmd *cobra.Command, args []string) {
 		ctrl.SetLogger(zap.New(zap.UseDevMode(true)))
@@ -88,7 +98,8 @@ func NewStartCommand() *cobra.Command {
 		mgr, err := ctrl.NewManager(ctrl.GetConfigOrDie(), ctrl.Options{
 			Scheme:             hyperapi.Scheme,
 			MetricsBindAddress: metricsAddr,
-			Port:               9443,
+			Port:               webhook.ServerPort,
+			CertDir:            webhookCertDir,
 			LeaderElection:     enableLeaderElection,
 			LeaderElectionID:   "b2ed43ca.hypershift.openshift.io",
 			// Use a non-caching client everywhere. The default split client does not
@@ -139,34 +150,39 @@ func NewStartCommand() *cobra.Command {
 		}
 		setupLog.Info("using operator image", "operator-image", operatorImage)
 
+		// Release images are looked up by launching pods, so share one cache of
+		// the lookups between the controllers.
+		releaseProvider := &releaseinfo.StaticProviderDecorator{
+			Delegate: &releaseinfo.CachedProvider{
+				Delegate: &releaseinfo.PodProvider{
+					Pods: kubeClient.CoreV1().Pods(namespace),
+				},
+			},
+		}
+
 		if err = (&hostedcluster.HostedClusterReconciler{
-			Client:        mgr.GetClient(),
-			OperatorImage: operatorImage,
+			Client:                  mgr.GetClient(),
+			OperatorImage:           operatorImage,
+			ImageOverrides:          imageOverrides,
+			AllowedImageAnnotations: sets.NewString(allowedImageAnnotations...),
+			ReleaseProvider:         releaseProvider,
 		}).SetupWithManager(mgr); err != nil {
 			setupLog.Error(err, "unable to create controller", "controller", "HostedCluster")
 			os.Exit(1)
 		}
 
 		if err := (&nodepool.NodePoolReconciler{
-			Client:        mgr.GetClient(),
-			ImageProvider: &static.StaticImageProvider{},
-			ReleaseProvider: &releaseinfo.StaticProviderDecorator{
-				Delegate: &releaseinfo.PodProvider{
-					Pods: kubeClient.CoreV1().Pods(namespace),
-				},
-			},
+			Client:          mgr.GetClient(),
+			ImageProvider:   &static.StaticImageProvider{},
+			ReleaseProvider: releaseProvider,
 		}).SetupWithManager(mgr); err != nil {
 			setupLog.Error(err, "unable to create controller", "controller", "nodePool")
 			os.Exit(1)
 		}
 
 		if err := (&machineconfigserver.MachineConfigServerReconciler{
-			Client: mgr.GetClient(),
-			ReleaseProvider: &releaseinfo.StaticProviderDecorator{
-				Delegate: &releaseinfo.PodProvider{
-					Pods: kubeClient.CoreV1().Pods(namespace),
-				},
-			},
+			Client:          mgr.GetClient(),
+			ReleaseProvider: releaseProvider,
 		}).SetupWithManager(mgr); err != nil {
 			setupLog.Error(err, "unable to create controller", "controller", "machineConfigReconciler")
 			os.Exit(1)
@@ -179,6 +195,26 @@ func NewStartCommand() *cobra.Command {
 			os.Exit(1)
 		}
 
+		if enableWebhook {
+			if err := webhook.EnsureServingCertificate(context.TODO(), mgr.GetClient(), namespace, webhookCertDir); err != nil {
+				setupLog.Error(err, "unable to set up webhook serving certificate")
+				os.Exit(1)
+			}
+			if err := mgr.Add(&webhook.ServingCertificateRotator{
+				Client:    mgr.GetClient(),
+				Log:       ctrl.Log.WithName("webhook-cert-rotator"),
+				Namespace: namespace,
+				CertDir:   webhookCertDir,
+			}); err != nil {
+				setupLog.Error(err, "unable to set up webhook serving certificate rotation")
+				os.Exit(1)
+			}
+			if err := webhook.SetupWebhookWithManager(mgr); err != nil {
+				setupLog.Error(err, "unable to create webhook", "webhook", "HostedCluster")
+				os.Exit(1)
+			}
+		}
+
 		// +kubebuilder:scaffold:builder
 
 		setupLog.Info("starting manager")
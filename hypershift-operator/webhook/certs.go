@@ -0,0 +1,249 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/go-logr/logr"
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	corev1 "k8s.io/api/core/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/util/retry"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	hyperv1 "github.com/openshift/hypershift/api/v1alpha1"
+	pkiutil "github.com/openshift/hypershift/control-plane-operator/controllers/hostedcontrolplane/render/pki/util"
+)
+
+const (
+	caCertKey      = "ca.crt"
+	caKeyKey       = "ca.key"
+	servingCertKey = corev1.TLSCertKey
+	servingKeyKey  = corev1.TLSPrivateKeyKey
+
+	// certRenewalWindow is how long before expiry the serving certificate is
+	// regenerated.
+	certRenewalWindow = 30 * 24 * time.Hour
+
+	// caRenewalWindow is how long before expiry the webhook CA is
+	// regenerated. Until the other replicas pick up the new CA, the API server
+	// does not trust their serving certificates, so it is only regenerated
+	// once during its ten years of validity.
+	caRenewalWindow = 365 * 24 * time.Hour
+
+	// certCheckInterval is how often the ServingCertificateRotator checks
+	// whether the serving certificate is due for renewal.
+	certCheckInterval = time.Hour
+)
+
+// EnsureServingCertificate makes sure the webhook CA and serving certificate
+// exist in the operator namespace, writes the serving pair into certDir for the
+// webhook server, and publishes the CA bundle in the webhook configurations
+// and the conversion settings of the HyperShift CRDs.
+// It must be called before the manager starts.
+func EnsureServingCertificate(ctx context.Context, c client.Client, namespace, certDir string) error {
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: namespace,
+			Name:      ServingCertSecretName,
+		},
+	}
+	// Replicas starting at the same time race to provision the secret.
+	err := retry.OnError(retry.DefaultRetry, func(err error) bool {
+		return apierrors.IsConflict(err) || apierrors.IsAlreadyExists(err)
+	}, func() error {
+		_, err := controllerutil.CreateOrUpdate(ctx, c, secret, func() error {
+			return reconcileServingCertSecret(secret, namespace, time.Now())
+		})
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("failed to reconcile webhook serving cert secret: %w", err)
+	}
+
+	if err := os.MkdirAll(certDir, 0755); err != nil {
+		return fmt.Errorf("failed to create webhook cert dir: %w", err)
+	}
+	for _, key := range []string{servingCertKey, servingKeyKey} {
+		path := filepath.Join(certDir, key)
+		// The webhook server reloads the serving pair whenever it is written.
+		if current, err := ioutil.ReadFile(path); err == nil && bytes.Equal(current, secret.Data[key]) {
+			continue
+		}
+		if err := ioutil.WriteFile(path, secret.Data[key], 0600); err != nil {
+			return fmt.Errorf("failed to write webhook %s: %w", key, err)
+		}
+	}
+
+	if err := injectCABundle(ctx, c, secret.Data[caCertKey]); err != nil {
+		return fmt.Errorf("failed to inject webhook ca bundle: %w", err)
+	}
+	return nil
+}
+
+// ServingCertificateRotator renews the webhook serving certificate before it
+// expires. EnsureServingCertificate only provisions it when the operator
+// starts, and the operator may run for longer than the certificate is valid.
+type ServingCertificateRotator struct {
+	Client    client.Client
+	Log       logr.Logger
+	Namespace string
+	CertDir   string
+}
+
+// Start checks the serving certificate every certCheckInterval until the
+// context is done, renewing it when it is about to expire and picking up a
+// certificate renewed by another replica.
+func (r *ServingCertificateRotator) Start(ctx context.Context) error {
+	wait.UntilWithContext(ctx, func(ctx context.Context) {
+		if err := EnsureServingCertificate(ctx, r.Client, r.Namespace, r.CertDir); err != nil {
+			r.Log.Error(err, "failed to renew webhook serving certificate")
+		}
+	}, certCheckInterval)
+	return nil
+}
+
+// NeedLeaderElection returns false since every replica serves the webhook
+// from its own copy of the serving certificate.
+func (r *ServingCertificateRotator) NeedLeaderElection() bool {
+	return false
+}
+
+// reconcileServingCertSecret renews the serving certificate of the secret
+// when it is about to expire. The serving certificate is signed by a
+// long-lived CA kept in the secret, so that the serving certificates of the
+// other replicas stay trusted when one of them renews it.
+func reconcileServingCertSecret(secret *corev1.Secret, namespace string, now time.Time) error {
+	caKey, caCert, err := reconcileCA(secret, now)
+	if err != nil {
+		return err
+	}
+	if servingCertValid(secret, caCert, now) {
+		return nil
+	}
+	serviceHost := fmt.Sprintf("%s.%s.svc", ServiceName, namespace)
+	key, cert, err := pkiutil.GenerateSignedCertificate(caKey, caCert, &pkiutil.CertCfg{
+		Subject:      pkix.Name{CommonName: serviceHost, OrganizationalUnit: []string{"openshift"}},
+		DNSNames:     []string{serviceHost, serviceHost + ".cluster.local"},
+		KeyUsages:    x509.KeyUsageKeyEncipherment | x509.KeyUsageDigitalSignature,
+		ExtKeyUsages: []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+		Validity:     pkiutil.ValidityOneYear,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to generate webhook serving cert: %w", err)
+	}
+	secret.Type = corev1.SecretTypeTLS
+	secret.Data[servingCertKey] = pkiutil.CertToPem(cert)
+	secret.Data[servingKeyKey] = pkiutil.PrivateKeyToPem(key)
+	return nil
+}
+
+// reconcileCA returns the webhook CA of the secret, generating it when the
+// secret has none or it is about to expire.
+func reconcileCA(secret *corev1.Secret, now time.Time) (*rsa.PrivateKey, *x509.Certificate, error) {
+	if secret.Data == nil {
+		secret.Data = map[string][]byte{}
+	}
+	caKey, keyErr := pkiutil.PemToPrivateKey(secret.Data[caKeyKey])
+	caCert, certErr := pkiutil.PemToCertificate(secret.Data[caCertKey])
+	if keyErr == nil && certErr == nil && now.Add(caRenewalWindow).Before(caCert.NotAfter) {
+		return caKey, caCert, nil
+	}
+	caKey, caCert, err := pkiutil.GenerateSelfSignedCertificate(&pkiutil.CertCfg{
+		Subject:   pkix.Name{CommonName: "hypershift-operator-webhook-ca", OrganizationalUnit: []string{"openshift"}},
+		KeyUsages: x509.KeyUsageKeyEncipherment | x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		Validity:  pkiutil.ValidityTenYears,
+		IsCA:      true,
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to generate webhook ca: %w", err)
+	}
+	secret.Data[caCertKey] = pkiutil.CertToPem(caCert)
+	secret.Data[caKeyKey] = pkiutil.PrivateKeyToPem(caKey)
+	// The serving certificate signed by the previous CA is regenerated.
+	delete(secret.Data, servingCertKey)
+	delete(secret.Data, servingKeyKey)
+	return caKey, caCert, nil
+}
+
+// servingCertValid returns whether the serving certificate of the secret is
+// signed by the CA and not about to expire.
+func servingCertValid(secret *corev1.Secret, caCert *x509.Certificate, now time.Time) bool {
+	if len(secret.Data[servingKeyKey]) == 0 {
+		return false
+	}
+	cert, err := pkiutil.PemToCertificate(secret.Data[servingCertKey])
+	if err != nil {
+		return false
+	}
+	if err := cert.CheckSignatureFrom(caCert); err != nil {
+		return false
+	}
+	return now.Add(certRenewalWindow).Before(cert.NotAfter)
+}
+
+func injectCABundle(ctx context.Context, c client.Client, caBundle []byte) error {
+	key := types.NamespacedName{Name: WebhookConfigurationName}
+
+	mutating := &admissionregistrationv1.MutatingWebhookConfiguration{}
+	if err := c.Get(ctx, key, mutating); err != nil {
+		if !apierrors.IsNotFound(err) {
+			return fmt.Errorf("failed to get mutating webhook configuration: %w", err)
+		}
+	} else {
+		original := mutating.DeepCopy()
+		for i := range mutating.Webhooks {
+			mutating.Webhooks[i].ClientConfig.CABundle = caBundle
+		}
+		if err := c.Patch(ctx, mutating, client.MergeFrom(original)); err != nil {
+			return fmt.Errorf("failed to update mutating webhook configuration: %w", err)
+		}
+	}
+
+	validating := &admissionregistrationv1.ValidatingWebhookConfiguration{}
+	if err := c.Get(ctx, key, validating); err != nil {
+		if !apierrors.IsNotFound(err) {
+			return fmt.Errorf("failed to get validating webhook configuration: %w", err)
+		}
+	} else {
+		original := validating.DeepCopy()
+		for i := range validating.Webhooks {
+			validating.Webhooks[i].ClientConfig.CABundle = caBundle
+		}
+		if err := c.Patch(ctx, validating, client.MergeFrom(original)); err != nil {
+			return fmt.Errorf("failed to update validating webhook configuration: %w", err)
+		}
+	}
+
+	crds := &apiextensionsv1.CustomResourceDefinitionList{}
+	if err := c.List(ctx, crds); err != nil {
+		return fmt.Errorf("failed to list custom resource definitions: %w", err)
+	}
+	for i := range crds.Items {
+		crd := &crds.Items[i]
+		if crd.Spec.Group != hyperv1.GroupVersion.Group || crd.Spec.Conversion == nil ||
+			crd.Spec.Conversion.Strategy != apiextensionsv1.WebhookConverter ||
+			crd.Spec.Conversion.Webhook == nil || crd.Spec.Conversion.Webhook.ClientConfig == nil {
+			continue
+		}
+		original := crd.DeepCopy()
+		crd.Spec.Conversion.Webhook.ClientConfig.CABundle = caBundle
+		if err := c.Patch(ctx, crd, client.MergeFrom(original)); err != nil {
+			return fmt.Errorf("failed to update custom resource definition %s: %w", crd.Name, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,113 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/x509/pkix"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	hyperapi "github.com/openshift/hypershift/api"
+	pkiutil "github.com/openshift/hypershift/control-plane-operator/controllers/hostedcontrolplane/render/pki/util"
+)
+
+func TestEnsureServingCertificate(t *testing.T) {
+	valid := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Namespace: "hypershift", Name: ServingCertSecretName}}
+	if err := reconcileServingCertSecret(valid, "hypershift", time.Now()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	caKey, err := pkiutil.PemToPrivateKey(valid.Data[caKeyKey])
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	caCert, err := pkiutil.PemToCertificate(valid.Data[caCertKey])
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	key, cert, err := pkiutil.GenerateSignedCertificate(caKey, caCert, &pkiutil.CertCfg{
+		Subject:  pkix.Name{CommonName: "hypershift"},
+		Validity: 24 * time.Hour,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expiring := valid.DeepCopy()
+	expiring.Data[servingCertKey] = pkiutil.CertToPem(cert)
+	expiring.Data[servingKeyKey] = pkiutil.PrivateKeyToPem(key)
+
+	tests := map[string]struct {
+		Secret            *corev1.Secret
+		ExpectedRenewed   bool
+		ExpectedCARenewed bool
+	}{
+		"no certificate": {
+			ExpectedRenewed:   true,
+			ExpectedCARenewed: true,
+		},
+		"valid certificate": {
+			Secret: valid.DeepCopy(),
+		},
+		"expiring certificate is renewed with the same ca": {
+			Secret:          expiring,
+			ExpectedRenewed: true,
+		},
+		"invalid certificate": {
+			Secret: &corev1.Secret{
+				ObjectMeta: valid.ObjectMeta,
+				Data:       map[string][]byte{caCertKey: []byte("ca"), caKeyKey: []byte("ca key"), servingCertKey: []byte("cert"), servingKeyKey: []byte("key")},
+			},
+			ExpectedRenewed:   true,
+			ExpectedCARenewed: true,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			builder := fake.NewClientBuilder().WithScheme(hyperapi.Scheme)
+			if test.Secret != nil {
+				builder = builder.WithObjects(test.Secret)
+			}
+			c := builder.Build()
+			certDir := t.TempDir()
+			// A stale serving pair left by a previous certificate.
+			if err := ioutil.WriteFile(filepath.Join(certDir, servingCertKey), []byte("stale"), 0600); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if err := EnsureServingCertificate(context.Background(), c, "hypershift", certDir); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			secret := &corev1.Secret{}
+			if err := c.Get(context.Background(), client.ObjectKeyFromObject(valid), secret); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			ca, err := pkiutil.PemToCertificate(secret.Data[caCertKey])
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !servingCertValid(secret, ca, time.Now()) {
+				t.Errorf("expected a valid serving certificate")
+			}
+			renewed := test.Secret == nil || !bytes.Equal(secret.Data[servingCertKey], test.Secret.Data[servingCertKey])
+			if renewed != test.ExpectedRenewed {
+				t.Errorf("expected renewed to be %t, got %t", test.ExpectedRenewed, renewed)
+			}
+			caRenewed := test.Secret == nil || !bytes.Equal(secret.Data[caCertKey], test.Secret.Data[caCertKey])
+			if caRenewed != test.ExpectedCARenewed {
+				t.Errorf("expected ca renewed to be %t, got %t", test.ExpectedCARenewed, caRenewed)
+			}
+			for _, key := range []string{servingCertKey, servingKeyKey} {
+				written, err := ioutil.ReadFile(filepath.Join(certDir, key))
+				if err != nil || !bytes.Equal(written, secret.Data[key]) {
+					t.Errorf("expected %s to be written from the secret: %v", key, err)
+				}
+			}
+		})
+	}
+}
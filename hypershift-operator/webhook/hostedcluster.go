@@ -0,0 +1,621 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	admissionv1 "k8s.io/api/admission/v1"
+	corev1 "k8s.io/api/core/v1"
+	apivalidation "k8s.io/apimachinery/pkg/api/validation"
+	"k8s.io/apimachinery/pkg/util/sets"
+	k8svalidation "k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	hyperv1 "github.com/openshift/hypershift/api/v1alpha1"
+	hyperconfig "github.com/openshift/hypershift/support/config"
+	hyperutil "github.com/openshift/hypershift/support/util"
+)
+
+const (
+	DefaultServiceCIDR = "172.31.0.0/16"
+	DefaultPodCIDR     = "10.132.0.0/14"
+
+	// ovnKubernetesJoinSubnet is the subnet OVNKubernetes uses internally to
+	// connect its node routers, which cluster networks must not overlap.
+	ovnKubernetesJoinSubnet = "100.64.0.0/16"
+)
+
+type hostedClusterDefaulter struct {
+	decoder *admission.Decoder
+}
+
+func (d *hostedClusterDefaulter) Handle(ctx context.Context, req admission.Request) admission.Response {
+	hcluster := &hyperv1.HostedCluster{}
+	if err := d.decoder.Decode(req, hcluster); err != nil {
+		return admission.Errored(http.StatusBadRequest, err)
+	}
+	DefaultHostedCluster(hcluster)
+	marshaled, err := json.Marshal(hcluster)
+	if err != nil {
+		return admission.Errored(http.StatusInternalServerError, err)
+	}
+	return admission.PatchResponseFromRaw(req.Object.Raw, marshaled)
+}
+
+type hostedClusterValidator struct {
+	decoder *admission.Decoder
+}
+
+func (v *hostedClusterValidator) Handle(ctx context.Context, req admission.Request) admission.Response {
+	hcluster := &hyperv1.HostedCluster{}
+	if err := v.decoder.Decode(req, hcluster); err != nil {
+		return admission.Errored(http.StatusBadRequest, err)
+	}
+	// Never block finalizer removal on a cluster that is going away.
+	if !hcluster.DeletionTimestamp.IsZero() {
+		return admission.Allowed("")
+	}
+	var errs field.ErrorList
+	switch req.Operation {
+	case admissionv1.Create:
+		errs = ValidateHostedCluster(hcluster)
+	case admissionv1.Update:
+		old := &hyperv1.HostedCluster{}
+		if err := v.decoder.DecodeRaw(req.OldObject, old); err != nil {
+			return admission.Errored(http.StatusBadRequest, err)
+		}
+		errs = ValidateHostedClusterUpdate(hcluster, old)
+	}
+	if len(errs) > 0 {
+		return admission.Denied(errs.ToAggregate().Error())
+	}
+	return admission.Allowed("")
+}
+
+// DefaultHostedCluster fills in unset optional fields of a HostedCluster.
+func DefaultHostedCluster(hcluster *hyperv1.HostedCluster) {
+	networking := &hcluster.Spec.Networking
+	if len(networking.ServiceCIDR) == 0 {
+		networking.ServiceCIDR = DefaultServiceCIDR
+		if len(networking.ServiceNetwork) > 0 {
+			networking.ServiceCIDR = networking.ServiceNetwork[0]
+		}
+	}
+	if len(networking.PodCIDR) == 0 {
+		networking.PodCIDR = DefaultPodCIDR
+		if len(networking.ClusterNetwork) > 0 {
+			networking.PodCIDR = networking.ClusterNetwork[0].CIDR
+		}
+	}
+	if len(networking.MachineCIDR) == 0 && len(networking.MachineNetwork) > 0 {
+		networking.MachineCIDR = networking.MachineNetwork[0].CIDR
+	}
+	networking.ClusterNetwork = hyperutil.ClusterNetworks(networking.PodCIDR, networking.ClusterNetwork)
+	networking.ServiceNetwork = hyperutil.ServiceNetworks(networking.ServiceCIDR, networking.ServiceNetwork)
+	networking.MachineNetwork = hyperutil.MachineNetworks(networking.MachineCIDR, networking.MachineNetwork)
+	if len(hcluster.Spec.Networking.NetworkType) == 0 {
+		hcluster.Spec.Networking.NetworkType = hyperv1.OpenShiftSDN
+	}
+	if len(hcluster.Spec.Platform.Type) == 0 && hcluster.Spec.Platform.AWS != nil {
+		hcluster.Spec.Platform.Type = hyperv1.AWSPlatform
+	}
+	hcluster.Spec.NodeConnectivity = hyperutil.NodeConnectivity(hcluster.Spec.NodeConnectivity)
+}
+
+// ValidateHostedCluster validates a new HostedCluster.
+func ValidateHostedCluster(hcluster *hyperv1.HostedCluster) field.ErrorList {
+	var errs field.ErrorList
+	specPath := field.NewPath("spec")
+	if len(hcluster.Spec.Release.Image) == 0 {
+		errs = append(errs, field.Required(specPath.Child("release", "image"), "a release image is required"))
+	}
+	if len(hcluster.Spec.InfraID) == 0 {
+		errs = append(errs, field.Required(specPath.Child("infraID"), "an infrastructure id is required"))
+	}
+	if hcluster.Spec.InitialComputeReplicas < 0 {
+		errs = append(errs, field.Invalid(specPath.Child("initialComputeReplicas"), hcluster.Spec.InitialComputeReplicas, "must be greater than or equal to 0"))
+	}
+	if _, _, err := hyperutil.IsReconciliationPaused(time.Now(), hcluster.Spec.PausedUntil); err != nil {
+		errs = append(errs, field.Invalid(specPath.Child("pausedUntil"), *hcluster.Spec.PausedUntil, "must be a boolean or an RFC3339 timestamp"))
+	}
+	errs = append(errs, validateClusterNetworking(&hcluster.Spec.Networking, specPath.Child("networking"))...)
+	errs = append(errs, validatePlatform(&hcluster.Spec.Platform, specPath.Child("platform"))...)
+	if hcluster.Spec.Configuration != nil {
+		_, configErrs := hyperconfig.ParseGlobalConfig(hcluster.Spec.Configuration.Items, specPath.Child("configuration", "items"))
+		errs = append(errs, configErrs...)
+	}
+	if hcluster.Spec.Proxy != nil {
+		errs = append(errs, validateProxy(hcluster.Spec.Proxy, specPath.Child("proxy"))...)
+	}
+	errs = append(errs, validateImageContentSources(hcluster.Spec.ImageContentSources, specPath.Child("imageContentSources"))...)
+	if hcluster.Spec.Autoscaling != nil {
+		errs = append(errs, validateAutoscaling(hcluster.Spec.Autoscaling, specPath.Child("autoscaling"))...)
+	}
+	if hcluster.Spec.OAuth != nil {
+		errs = append(errs, validateOAuth(hcluster.Spec.OAuth, specPath.Child("oauth"))...)
+	}
+	if hcluster.Spec.Sizing != nil {
+		errs = append(errs, validateSizing(hcluster.Spec.Sizing, specPath.Child("sizing"))...)
+	}
+	errs = append(errs, validateServices(hcluster.Spec.Services, specPath.Child("services"))...)
+	errs = append(errs, validateEndpointAccess(hcluster, specPath)...)
+	switch connectivity := hyperutil.NodeConnectivity(hcluster.Spec.NodeConnectivity); connectivity {
+	case hyperv1.OpenVPN, hyperv1.Konnectivity:
+	default:
+		errs = append(errs, field.NotSupported(specPath.Child("nodeConnectivity"), connectivity, []string{string(hyperv1.OpenVPN), string(hyperv1.Konnectivity)}))
+	}
+	return errs
+}
+
+// ValidateHostedClusterUpdate validates an update to an existing HostedCluster,
+// including the immutability of fields which cannot be changed once the
+// cluster infrastructure exists.
+func ValidateHostedClusterUpdate(hcluster, old *hyperv1.HostedCluster) field.ErrorList {
+	errs := ValidateHostedCluster(hcluster)
+	specPath := field.NewPath("spec")
+	errs = append(errs, apivalidation.ValidateImmutableField(hcluster.Spec.InfraID, old.Spec.InfraID, specPath.Child("infraID"))...)
+	networkingPath := specPath.Child("networking")
+	errs = append(errs, apivalidation.ValidateImmutableField(hcluster.Spec.Networking.ServiceCIDR, old.Spec.Networking.ServiceCIDR, networkingPath.Child("serviceCIDR"))...)
+	errs = append(errs, apivalidation.ValidateImmutableField(hcluster.Spec.Networking.PodCIDR, old.Spec.Networking.PodCIDR, networkingPath.Child("podCIDR"))...)
+	errs = append(errs, apivalidation.ValidateImmutableField(hcluster.Spec.Networking.MachineCIDR, old.Spec.Networking.MachineCIDR, networkingPath.Child("machineCIDR"))...)
+	newNetworking, oldNetworking := &hcluster.Spec.Networking, &old.Spec.Networking
+	errs = append(errs, apivalidation.ValidateImmutableField(hyperutil.ClusterNetworks(newNetworking.PodCIDR, newNetworking.ClusterNetwork), hyperutil.ClusterNetworks(oldNetworking.PodCIDR, oldNetworking.ClusterNetwork), networkingPath.Child("clusterNetwork"))...)
+	errs = append(errs, apivalidation.ValidateImmutableField(hyperutil.ServiceNetworks(newNetworking.ServiceCIDR, newNetworking.ServiceNetwork), hyperutil.ServiceNetworks(oldNetworking.ServiceCIDR, oldNetworking.ServiceNetwork), networkingPath.Child("serviceNetwork"))...)
+	errs = append(errs, apivalidation.ValidateImmutableField(hyperutil.MachineNetworks(newNetworking.MachineCIDR, newNetworking.MachineNetwork), hyperutil.MachineNetworks(oldNetworking.MachineCIDR, oldNetworking.MachineNetwork), networkingPath.Child("machineNetwork"))...)
+	errs = append(errs, apivalidation.ValidateImmutableField(networkType(&hcluster.Spec.Networking), networkType(&old.Spec.Networking), networkingPath.Child("networkType"))...)
+	errs = append(errs, apivalidation.ValidateImmutableField(hcluster.Spec.Platform.Type, old.Spec.Platform.Type, specPath.Child("platform", "type"))...)
+	errs = append(errs, apivalidation.ValidateImmutableField(hyperutil.EndpointAccess(hcluster.Spec.Platform), hyperutil.EndpointAccess(old.Spec.Platform), specPath.Child("platform", "aws", "endpointAccess"))...)
+	// The addresses of the published services are in the certificates and
+	// kubeconfigs generated for the cluster. The Konnectivity server may be
+	// published differently until the cluster is migrated to it, and the OVN
+	// southbound database is only published for the OVNKubernetes network
+	// type.
+	for _, service := range publishedServices {
+		if service == hyperv1.KonnectivityServer && hyperutil.NodeConnectivity(old.Spec.NodeConnectivity) != hyperv1.Konnectivity {
+			continue
+		}
+		if service == hyperv1.OVNSbDb && networkType(&old.Spec.Networking) != hyperv1.OVNKubernetes {
+			continue
+		}
+		// The serving certificate may be rotated.
+		strategy, oldStrategy := hyperutil.ServicePublishingStrategy(hcluster.Spec.Services, service), hyperutil.ServicePublishingStrategy(old.Spec.Services, service)
+		strategy.ServingCert, oldStrategy.ServingCert = nil, nil
+		errs = append(errs, apivalidation.ValidateImmutableField(strategy, oldStrategy, specPath.Child("services").Key(string(service)))...)
+	}
+	if old.Spec.OAuth != nil && old.Spec.OAuth.DisableKubeadmin && (hcluster.Spec.OAuth == nil || !hcluster.Spec.OAuth.DisableKubeadmin) {
+		errs = append(errs, field.Forbidden(specPath.Child("oauth", "disableKubeadmin"), "the kubeadmin user cannot be enabled once disabled"))
+	}
+	return errs
+}
+
+func validateOAuth(oauth *hyperv1.OAuthSpec, fldPath *field.Path) field.ErrorList {
+	errs := hyperconfig.ValidateIdentityProviders(oauth.IdentityProviders, fldPath.Child("identityProviders"))
+	if oauth.DisableKubeadmin && len(oauth.IdentityProviders) == 0 {
+		errs = append(errs, field.Invalid(fldPath.Child("disableKubeadmin"), oauth.DisableKubeadmin, "at least one identity provider is required to disable the kubeadmin user"))
+	}
+	return errs
+}
+
+// publishedServices are the control plane services which have a publishing
+// strategy.
+var publishedServices = []hyperv1.ServiceType{hyperv1.APIServer, hyperv1.OAuthServer, hyperv1.VPN, hyperv1.Ignition, hyperv1.KonnectivityServer, hyperv1.OVNSbDb}
+
+func validateServices(services []hyperv1.ServicePublishingStrategyMapping, fldPath *field.Path) field.ErrorList {
+	var errs field.ErrorList
+	supported := sets.NewString()
+	for _, service := range publishedServices {
+		supported.Insert(string(service))
+	}
+	seen := sets.NewString()
+	for i, mapping := range services {
+		path := fldPath.Index(i)
+		switch {
+		case !supported.Has(string(mapping.Service)):
+			errs = append(errs, field.NotSupported(path.Child("service"), mapping.Service, supported.List()))
+		case seen.Has(string(mapping.Service)):
+			errs = append(errs, field.Duplicate(path.Child("service"), mapping.Service))
+		}
+		seen.Insert(string(mapping.Service))
+
+		strategyPath := path.Child("servicePublishingStrategy")
+		switch mapping.Type {
+		case hyperv1.LoadBalancer, hyperv1.Route:
+			if mapping.Type == hyperv1.Route && mapping.Service == hyperv1.VPN {
+				errs = append(errs, field.Invalid(strategyPath.Child("type"), mapping.Type, "the VPN cannot be published through a route"))
+			}
+			if mapping.NodePort != nil {
+				errs = append(errs, field.Forbidden(strategyPath.Child("nodePort"), "only allowed for the NodePort strategy"))
+			}
+		case hyperv1.NodePort:
+			switch {
+			case mapping.NodePort == nil:
+				errs = append(errs, field.Required(strategyPath.Child("nodePort"), "the NodePort strategy requires the address of the nodes"))
+			case len(mapping.NodePort.Address) == 0:
+				errs = append(errs, field.Required(strategyPath.Child("nodePort", "address"), ""))
+			case mapping.NodePort.Port < 0 || mapping.NodePort.Port > 65535:
+				errs = append(errs, field.Invalid(strategyPath.Child("nodePort", "port"), mapping.NodePort.Port, "must be a valid port number"))
+			}
+		default:
+			errs = append(errs, field.NotSupported(strategyPath.Child("type"), mapping.Type, []string{string(hyperv1.LoadBalancer), string(hyperv1.NodePort), string(hyperv1.Route)}))
+		}
+
+		if len(mapping.Hostname) > 0 {
+			hostnamePath := strategyPath.Child("hostname")
+			switch {
+			case mapping.Service != hyperv1.APIServer && mapping.Service != hyperv1.OAuthServer:
+				errs = append(errs, field.Forbidden(hostnamePath, "only the APIServer and OAuthServer services may have a hostname"))
+			case mapping.Type == hyperv1.NodePort:
+				errs = append(errs, field.Forbidden(hostnamePath, "not allowed for the NodePort strategy, whose address is already stable"))
+			}
+			for _, msg := range k8svalidation.IsDNS1123Subdomain(mapping.Hostname) {
+				errs = append(errs, field.Invalid(hostnamePath, mapping.Hostname, msg))
+			}
+		}
+		if mapping.ServingCert != nil {
+			certPath := strategyPath.Child("servingCert")
+			switch {
+			case len(mapping.Hostname) == 0:
+				errs = append(errs, field.Forbidden(certPath, "a serving certificate requires a hostname"))
+			case len(mapping.ServingCert.Name) == 0:
+				errs = append(errs, field.Required(certPath.Child("name"), ""))
+			}
+		}
+	}
+	return errs
+}
+
+// validateEndpointAccess validates the requirements of publishing the Kube API
+// server privately to the VPC of the cluster.
+func validateEndpointAccess(hcluster *hyperv1.HostedCluster, specPath *field.Path) field.ErrorList {
+	access := hyperutil.EndpointAccess(hcluster.Spec.Platform)
+	switch access {
+	case hyperv1.Public:
+		return nil
+	case hyperv1.PublicAndPrivate, hyperv1.Private:
+	default:
+		return field.ErrorList{field.NotSupported(specPath.Child("platform", "aws", "endpointAccess"), access, []string{string(hyperv1.Public), string(hyperv1.PublicAndPrivate), string(hyperv1.Private)})}
+	}
+	var errs field.ErrorList
+	awsPath := specPath.Child("platform", "aws")
+	platform := hcluster.Spec.Platform.AWS
+	if len(platform.ControlPlaneOperatorCreds.Name) == 0 {
+		errs = append(errs, field.Required(awsPath.Child("controlPlaneOperatorCreds", "name"), fmt.Sprintf("required for %s endpoint access", access)))
+	}
+	if len(platform.DNSManagementCreds.Name) == 0 {
+		errs = append(errs, field.Required(awsPath.Child("dnsManagementCreds", "name"), fmt.Sprintf("required for %s endpoint access", access)))
+	}
+	if platform.NodePoolDefaults == nil || platform.NodePoolDefaults.Subnet == nil || platform.NodePoolDefaults.Subnet.ID == nil {
+		errs = append(errs, field.Required(awsPath.Child("nodePoolDefaults", "subnet", "id"), fmt.Sprintf("the subnet of the VPC endpoint is required for %s endpoint access", access)))
+	}
+	if len(hcluster.Spec.DNS.PrivateZoneID) == 0 {
+		errs = append(errs, field.Required(specPath.Child("dns", "privateZoneID"), fmt.Sprintf("required for %s endpoint access", access)))
+	}
+	apiPath := specPath.Child("services").Key(string(hyperv1.APIServer))
+	apiStrategy := hyperutil.ServicePublishingStrategy(hcluster.Spec.Services, hyperv1.APIServer)
+	if apiStrategy.Type != hyperv1.LoadBalancer {
+		errs = append(errs, field.Invalid(apiPath.Child("type"), apiStrategy.Type, fmt.Sprintf("the Kube API server must be published with the LoadBalancer strategy for %s endpoint access", access)))
+	}
+	if access == hyperv1.Private && len(apiStrategy.Hostname) > 0 {
+		errs = append(errs, field.Forbidden(apiPath.Child("hostname"), "the Kube API server has no public address for Private endpoint access"))
+	}
+	if access == hyperv1.Private {
+		errs = append(errs, validatePrivateNodeServices(hcluster, specPath)...)
+	}
+	return errs
+}
+
+// privateNodeServices are the control plane services besides the Kube API
+// server which the nodes of a cluster connect to. The nodes only connect to
+// the OVN southbound database with the OVNKubernetes network type.
+var privateNodeServices = []hyperv1.ServiceType{hyperv1.OAuthServer, hyperv1.Ignition, hyperv1.KonnectivityServer, hyperv1.OVNSbDb}
+
+// validatePrivateNodeServices validates that no service the nodes connect to
+// is published through a public load balancer or route with Private endpoint
+// access. Only the Kube API server is published through AWS PrivateLink, so
+// the nodes must connect to the control plane with Konnectivity rather than
+// the VPN, and reach the other services on the node ports of the management
+// cluster.
+func validatePrivateNodeServices(hcluster *hyperv1.HostedCluster, specPath *field.Path) field.ErrorList {
+	var errs field.ErrorList
+	if connectivity := hyperutil.NodeConnectivity(hcluster.Spec.NodeConnectivity); connectivity != hyperv1.Konnectivity {
+		errs = append(errs, field.Invalid(specPath.Child("nodeConnectivity"), connectivity, "the VPN has no private address, Konnectivity is required for Private endpoint access"))
+	}
+	for _, service := range privateNodeServices {
+		if service == hyperv1.OVNSbDb && networkType(&hcluster.Spec.Networking) != hyperv1.OVNKubernetes {
+			continue
+		}
+		if strategy := hyperutil.ServicePublishingStrategy(hcluster.Spec.Services, service); strategy.Type != hyperv1.NodePort {
+			errs = append(errs, field.Invalid(specPath.Child("services").Key(string(service)).Child("type"), strategy.Type, "the service must be published with the NodePort strategy for Private endpoint access"))
+		}
+	}
+	return errs
+}
+
+// validateProxy validates the proxy URLs, whose schemes are restricted as in
+// the proxy configuration of an OpenShift cluster.
+func validateProxy(proxy *hyperv1.ProxySpec, fldPath *field.Path) field.ErrorList {
+	var errs field.ErrorList
+	for _, p := range []struct {
+		name    string
+		value   string
+		schemes []string
+	}{
+		{name: "httpProxy", value: proxy.HTTPProxy, schemes: []string{"http"}},
+		{name: "httpsProxy", value: proxy.HTTPSProxy, schemes: []string{"http", "https"}},
+	} {
+		if len(p.value) == 0 {
+			continue
+		}
+		u, err := url.Parse(p.value)
+		switch {
+		case err != nil:
+			errs = append(errs, field.Invalid(fldPath.Child(p.name), p.value, err.Error()))
+		case !sets.NewString(p.schemes...).Has(u.Scheme):
+			errs = append(errs, field.Invalid(fldPath.Child(p.name), p.value, fmt.Sprintf("scheme must be one of %s", strings.Join(p.schemes, ", "))))
+		case len(u.Hostname()) == 0:
+			errs = append(errs, field.Invalid(fldPath.Child(p.name), p.value, "a host is required"))
+		}
+	}
+	if len(proxy.NoProxy) > 0 && len(proxy.HTTPProxy) == 0 && len(proxy.HTTPSProxy) == 0 {
+		errs = append(errs, field.Invalid(fldPath.Child("noProxy"), proxy.NoProxy, "requires httpProxy or httpsProxy"))
+	}
+	return errs
+}
+
+// validateImageContentSources validates that the sources and mirrors are
+// repositories, without tag or digest, and that each source is listed once.
+func validateImageContentSources(sources []hyperv1.ImageContentSource, fldPath *field.Path) field.ErrorList {
+	var errs field.ErrorList
+	seen := sets.NewString()
+	for i, source := range sources {
+		sourcePath := fldPath.Index(i).Child("source")
+		if msg := validateRepository(source.Source); len(msg) > 0 {
+			errs = append(errs, field.Invalid(sourcePath, source.Source, msg))
+		} else if seen.Has(source.Source) {
+			errs = append(errs, field.Duplicate(sourcePath, source.Source))
+		}
+		seen.Insert(source.Source)
+		for j, mirror := range source.Mirrors {
+			if msg := validateRepository(mirror); len(msg) > 0 {
+				errs = append(errs, field.Invalid(fldPath.Index(i).Child("mirrors").Index(j), mirror, msg))
+			}
+		}
+	}
+	return errs
+}
+
+// validateRepository returns why a value is not an image repository, or an
+// empty string if it is one.
+func validateRepository(repository string) string {
+	switch {
+	case len(repository) == 0:
+		return "a repository is required"
+	case strings.Contains(repository, "://"):
+		return "must not have a scheme"
+	case strings.ContainsAny(repository, "@ \t"):
+		return "must be a repository without digest"
+	case strings.Contains(repository, "/") && strings.LastIndex(repository, ":") > strings.LastIndex(repository, "/"):
+		// Without a slash, a colon separates the port of a registry.
+		return "must be a repository without tag"
+	}
+	return ""
+}
+
+// validateAutoscaling validates the settings of the cluster autoscaler which
+// the API schema cannot express.
+func validateAutoscaling(autoscaling *hyperv1.ClusterAutoscaling, fldPath *field.Path) field.ErrorList {
+	var errs field.ErrorList
+	if autoscaling.ScaleDownDelayAfterAdd != nil && autoscaling.ScaleDownDelayAfterAdd.Duration < 0 {
+		errs = append(errs, field.Invalid(fldPath.Child("scaleDownDelayAfterAdd"), autoscaling.ScaleDownDelayAfterAdd.Duration.String(), "must not be negative"))
+	}
+	if threshold := autoscaling.ScaleDownUtilizationThreshold; threshold != nil {
+		if value, err := strconv.ParseFloat(*threshold, 64); err != nil || value <= 0 || value > 1 {
+			errs = append(errs, field.Invalid(fldPath.Child("scaleDownUtilizationThreshold"), *threshold, "must be a decimal number greater than 0 and at most 1"))
+		}
+	}
+	return errs
+}
+
+func validateSizing(sizing *hyperv1.ControlPlaneSizing, fldPath *field.Path) field.ErrorList {
+	var errs field.ErrorList
+	components := sets.NewString()
+	for i, override := range sizing.Overrides {
+		namePath := fldPath.Child("overrides").Index(i).Child("name")
+		switch {
+		case !hyperconfig.IsSizingComponent(override.Name):
+			errs = append(errs, field.Invalid(namePath, override.Name, "not a sized control plane component"))
+		case components.Has(override.Name):
+			errs = append(errs, field.Duplicate(namePath, override.Name))
+		}
+		components.Insert(override.Name)
+		resourcesPath := fldPath.Child("overrides").Index(i).Child("resources")
+		errs = append(errs, validateSizingResources(override.Resources.Requests, resourcesPath.Child("requests"))...)
+		errs = append(errs, validateSizingResources(override.Resources.Limits, resourcesPath.Child("limits"))...)
+		// A request of the profile above a limit is lowered to the limit, but
+		// a request of the override must not exceed it.
+		for name, request := range override.Resources.Requests {
+			if limit, ok := override.Resources.Limits[name]; ok && request.Cmp(limit) > 0 {
+				errs = append(errs, field.Invalid(resourcesPath.Child("requests").Key(string(name)), request.String(), "must be less than or equal to the limit "+limit.String()))
+			}
+		}
+	}
+	return errs
+}
+
+func validateSizingResources(resources corev1.ResourceList, fldPath *field.Path) field.ErrorList {
+	var errs field.ErrorList
+	for name := range resources {
+		if !hyperconfig.IsSizingResource(name) {
+			errs = append(errs, field.NotSupported(fldPath.Key(string(name)), name, []string{string(corev1.ResourceCPU), string(corev1.ResourceMemory)}))
+		}
+	}
+	return errs
+}
+
+func validateClusterNetworking(networking *hyperv1.ClusterNetworking, fldPath *field.Path) field.ErrorList {
+	var errs field.ErrorList
+	type namedCIDR struct {
+		path *field.Path
+		net  *net.IPNet
+	}
+	var cidrs []namedCIDR
+	apiServerIP := net.ParseIP(hyperconfig.DefaultAPIServerIPAddress)
+	parseCIDR := func(path *field.Path, value string) *net.IPNet {
+		_, cidr, err := net.ParseCIDR(value)
+		if err != nil {
+			errs = append(errs, field.Invalid(path, value, err.Error()))
+			return nil
+		}
+		if cidr.Contains(apiServerIP) {
+			errs = append(errs, field.Invalid(path, value, "must not contain the internal API server address "+hyperconfig.DefaultAPIServerIPAddress))
+		}
+		cidrs = append(cidrs, namedCIDR{path: path, net: cidr})
+		return cidr
+	}
+	for _, entry := range []struct {
+		name  string
+		value string
+	}{
+		{name: "serviceCIDR", value: networking.ServiceCIDR},
+		{name: "podCIDR", value: networking.PodCIDR},
+		{name: "machineCIDR", value: networking.MachineCIDR},
+	} {
+		path := fldPath.Child(entry.name)
+		if len(entry.value) == 0 {
+			errs = append(errs, field.Required(path, ""))
+			continue
+		}
+		parseCIDR(path, entry.value)
+	}
+
+	// The first entry of each list is the corresponding single network, which
+	// has been parsed above.
+	var clusterFamilies, serviceFamilies []bool
+	for i, entry := range networking.ClusterNetwork {
+		path := fldPath.Child("clusterNetwork").Index(i)
+		if i == 0 {
+			if entry.CIDR != networking.PodCIDR {
+				errs = append(errs, field.Invalid(path.Child("cidr"), entry.CIDR, "must be the podCIDR"))
+			}
+		} else if parseCIDR(path.Child("cidr"), entry.CIDR) == nil {
+			continue
+		}
+		_, cidr, err := net.ParseCIDR(entry.CIDR)
+		if err != nil {
+			continue
+		}
+		prefix, bits := cidr.Mask.Size()
+		if entry.HostPrefix != 0 && (int(entry.HostPrefix) < prefix || int(entry.HostPrefix) > bits) {
+			errs = append(errs, field.Invalid(path.Child("hostPrefix"), entry.HostPrefix, fmt.Sprintf("must be between %d and %d", prefix, bits)))
+		}
+		clusterFamilies = append(clusterFamilies, cidr.IP.To4() == nil)
+	}
+	for i, entry := range networking.ServiceNetwork {
+		path := fldPath.Child("serviceNetwork").Index(i)
+		if i == 0 {
+			if entry != networking.ServiceCIDR {
+				errs = append(errs, field.Invalid(path, entry, "must be the serviceCIDR"))
+			}
+		} else if parseCIDR(path, entry) == nil {
+			continue
+		}
+		if _, cidr, err := net.ParseCIDR(entry); err == nil {
+			serviceFamilies = append(serviceFamilies, cidr.IP.To4() == nil)
+		}
+	}
+	for i, entry := range networking.MachineNetwork {
+		path := fldPath.Child("machineNetwork").Index(i).Child("cidr")
+		if i == 0 {
+			if entry.CIDR != networking.MachineCIDR {
+				errs = append(errs, field.Invalid(path, entry.CIDR, "must be the machineCIDR"))
+			}
+			continue
+		}
+		parseCIDR(path, entry.CIDR)
+	}
+	errs = append(errs, validateIPFamilies(clusterFamilies, fldPath.Child("clusterNetwork"))...)
+	errs = append(errs, validateIPFamilies(serviceFamilies, fldPath.Child("serviceNetwork"))...)
+	// An empty list is the single network.
+	if (len(clusterFamilies) > 1) != (len(serviceFamilies) > 1) {
+		errs = append(errs, field.Invalid(fldPath.Child("serviceNetwork"), networking.ServiceNetwork, "must have the same IP families as the clusterNetwork"))
+	}
+
+	for i := range cidrs {
+		for j := i + 1; j < len(cidrs); j++ {
+			if cidrs[i].net.Contains(cidrs[j].net.IP) || cidrs[j].net.Contains(cidrs[i].net.IP) {
+				errs = append(errs, field.Invalid(cidrs[j].path, cidrs[j].net.String(), "must not overlap with "+cidrs[i].path.String()))
+			}
+		}
+	}
+	switch networkType(networking) {
+	case hyperv1.OpenShiftSDN:
+		for _, cidr := range cidrs {
+			if cidr.net.IP.To4() == nil {
+				errs = append(errs, field.Invalid(cidr.path, cidr.net.String(), "IPv6 networks require the OVNKubernetes network type"))
+			}
+		}
+	case hyperv1.OVNKubernetes:
+		_, joinSubnet, _ := net.ParseCIDR(ovnKubernetesJoinSubnet)
+		for _, cidr := range cidrs {
+			if cidr.net.Contains(joinSubnet.IP) || joinSubnet.Contains(cidr.net.IP) {
+				errs = append(errs, field.Invalid(cidr.path, cidr.net.String(), "must not overlap with the OVNKubernetes join subnet "+ovnKubernetesJoinSubnet))
+			}
+		}
+	default:
+		errs = append(errs, field.NotSupported(fldPath.Child("networkType"), networking.NetworkType, []string{string(hyperv1.OpenShiftSDN), string(hyperv1.OVNKubernetes)}))
+	}
+	return errs
+}
+
+// validateIPFamilies validates that a list of networks, given as whether each
+// is IPv6, has at most one network of each IP family.
+func validateIPFamilies(ipv6 []bool, fldPath *field.Path) field.ErrorList {
+	switch {
+	case len(ipv6) > 2:
+		return field.ErrorList{field.TooMany(fldPath, len(ipv6), 2)}
+	case len(ipv6) == 2 && ipv6[0] == ipv6[1]:
+		return field.ErrorList{field.Invalid(fldPath.Index(1), "", "must be of a different IP family than the first network")}
+	}
+	return nil
+}
+
+// networkType returns the network type of a cluster, which is OpenShiftSDN
+// unless specified.
+func networkType(networking *hyperv1.ClusterNetworking) hyperv1.NetworkType {
+	if len(networking.NetworkType) == 0 {
+		return hyperv1.OpenShiftSDN
+	}
+	return networking.NetworkType
+}
+
+func validatePlatform(platform *hyperv1.PlatformSpec, fldPath *field.Path) field.ErrorList {
+	var errs field.ErrorList
+	switch platform.Type {
+	case hyperv1.AWSPlatform:
+		awsPath := fldPath.Child("aws")
+		if platform.AWS == nil {
+			errs = append(errs, field.Required(awsPath, "required when platform type is AWS"))
+			break
+		}
+		if len(platform.AWS.Region) == 0 {
+			errs = append(errs, field.Required(awsPath.Child("region"), ""))
+		}
+		if len(platform.AWS.KubeCloudControllerCreds.Name) == 0 {
+			errs = append(errs, field.Required(awsPath.Child("kubeCloudControllerCreds", "name"), ""))
+		}
+		if len(platform.AWS.NodePoolManagementCreds.Name) == 0 {
+			errs = append(errs, field.Required(awsPath.Child("nodePoolManagementCreds", "name"), ""))
+		}
+	default:
+		errs = append(errs, field.NotSupported(fldPath.Child("type"), platform.Type, []string{string(hyperv1.AWSPlatform)}))
+	}
+	return errs
+}
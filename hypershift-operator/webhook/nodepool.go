@@ -0,0 +1,133 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	admissionv1 "k8s.io/api/admission/v1"
+	apivalidation "k8s.io/apimachinery/pkg/api/validation"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/utils/pointer"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	hyperv1 "github.com/openshift/hypershift/api/v1alpha1"
+)
+
+type nodePoolDefaulter struct {
+	client  client.Client
+	decoder *admission.Decoder
+}
+
+func (d *nodePoolDefaulter) Handle(ctx context.Context, req admission.Request) admission.Response {
+	nodePool := &hyperv1.NodePool{}
+	if err := d.decoder.Decode(req, nodePool); err != nil {
+		return admission.Errored(http.StatusBadRequest, err)
+	}
+
+	// Platform defaults come from the owning cluster. A missing cluster is not
+	// an error here; validation reports whatever is still unset.
+	var hcluster *hyperv1.HostedCluster
+	if len(nodePool.Spec.ClusterName) > 0 {
+		hcluster = &hyperv1.HostedCluster{}
+		err := d.client.Get(ctx, types.NamespacedName{Namespace: nodePool.Namespace, Name: nodePool.Spec.ClusterName}, hcluster)
+		if err != nil {
+			hcluster = nil
+		}
+	}
+	DefaultNodePool(nodePool, hcluster)
+
+	marshaled, err := json.Marshal(nodePool)
+	if err != nil {
+		return admission.Errored(http.StatusInternalServerError, err)
+	}
+	return admission.PatchResponseFromRaw(req.Object.Raw, marshaled)
+}
+
+type nodePoolValidator struct {
+	decoder *admission.Decoder
+}
+
+func (v *nodePoolValidator) Handle(ctx context.Context, req admission.Request) admission.Response {
+	nodePool := &hyperv1.NodePool{}
+	if err := v.decoder.Decode(req, nodePool); err != nil {
+		return admission.Errored(http.StatusBadRequest, err)
+	}
+	// Never block finalizer removal on a nodepool that is going away.
+	if !nodePool.DeletionTimestamp.IsZero() {
+		return admission.Allowed("")
+	}
+	var errs field.ErrorList
+	switch req.Operation {
+	case admissionv1.Create:
+		errs = ValidateNodePool(nodePool)
+	case admissionv1.Update:
+		old := &hyperv1.NodePool{}
+		if err := v.decoder.DecodeRaw(req.OldObject, old); err != nil {
+			return admission.Errored(http.StatusBadRequest, err)
+		}
+		errs = ValidateNodePoolUpdate(nodePool, old)
+	}
+	if len(errs) > 0 {
+		return admission.Denied(errs.ToAggregate().Error())
+	}
+	return admission.Allowed("")
+}
+
+// DefaultNodePool fills in unset optional fields of a NodePool. If hcluster is
+// non-nil, its AWS nodepool defaults are used for an unset platform.
+func DefaultNodePool(nodePool *hyperv1.NodePool, hcluster *hyperv1.HostedCluster) {
+	if nodePool.Spec.NodeCount == nil && nodePool.Spec.AutoScaling == nil {
+		nodePool.Spec.NodeCount = pointer.Int32Ptr(0)
+	}
+	if nodePool.Spec.Platform.AWS == nil && hcluster != nil &&
+		hcluster.Spec.Platform.AWS != nil && hcluster.Spec.Platform.AWS.NodePoolDefaults != nil {
+		nodePool.Spec.Platform.AWS = hcluster.Spec.Platform.AWS.NodePoolDefaults.DeepCopy()
+	}
+}
+
+// ValidateNodePool validates a new NodePool.
+func ValidateNodePool(nodePool *hyperv1.NodePool) field.ErrorList {
+	var errs field.ErrorList
+	specPath := field.NewPath("spec")
+	if len(nodePool.Spec.ClusterName) == 0 {
+		errs = append(errs, field.Required(specPath.Child("clusterName"), ""))
+	}
+
+	if nodePool.Spec.NodeCount != nil && nodePool.Spec.AutoScaling != nil {
+		errs = append(errs, field.Forbidden(specPath.Child("nodeCount"), "nodeCount and autoScaling are mutually exclusive"))
+	}
+	if nodePool.Spec.NodeCount != nil && *nodePool.Spec.NodeCount < 0 {
+		errs = append(errs, field.Invalid(specPath.Child("nodeCount"), *nodePool.Spec.NodeCount, "must be greater than or equal to 0"))
+	}
+	if autoScaling := nodePool.Spec.AutoScaling; autoScaling != nil {
+		autoScalingPath := specPath.Child("autoScaling")
+		switch {
+		case autoScaling.Min == nil:
+			errs = append(errs, field.Required(autoScalingPath.Child("min"), ""))
+		case autoScaling.Max == nil:
+			errs = append(errs, field.Required(autoScalingPath.Child("max"), ""))
+		case *autoScaling.Max < 1:
+			errs = append(errs, field.Invalid(autoScalingPath.Child("max"), *autoScaling.Max, "must be greater than 0"))
+		case *autoScaling.Min > *autoScaling.Max:
+			errs = append(errs, field.Invalid(autoScalingPath.Child("min"), *autoScaling.Min, "must be less than or equal to max"))
+		}
+	}
+
+	awsPath := specPath.Child("platform", "aws")
+	if nodePool.Spec.Platform.AWS == nil {
+		errs = append(errs, field.Required(awsPath, ""))
+	} else if len(nodePool.Spec.Platform.AWS.InstanceType) == 0 {
+		errs = append(errs, field.Required(awsPath.Child("instanceType"), ""))
+	}
+	return errs
+}
+
+// ValidateNodePoolUpdate validates an update to an existing NodePool.
+func ValidateNodePoolUpdate(nodePool, old *hyperv1.NodePool) field.ErrorList {
+	errs := ValidateNodePool(nodePool)
+	errs = append(errs, apivalidation.ValidateImmutableField(nodePool.Spec.ClusterName, old.Spec.ClusterName, field.NewPath("spec", "clusterName"))...)
+	return errs
+}
@@ -0,0 +1,50 @@
+package webhook
+
+import (
+	"fmt"
+
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/conversion"
+)
+
+const (
+	// WebhookConfigurationName is the name shared by the mutating and
+	// validating webhook configurations registered by the installer.
+	WebhookConfigurationName = "hypershift.openshift.io"
+
+	// ServiceName is the name of the service fronting the operator webhook
+	// server.
+	ServiceName = "operator"
+
+	// ServingCertSecretName is the secret in the operator namespace that holds
+	// the webhook CA and serving certificate.
+	ServingCertSecretName = "operator-webhook-serving-cert"
+
+	// ServerPort is the port the webhook server listens on in the operator pod.
+	ServerPort = 9443
+
+	HostedClusterDefaultingPath = "/mutate-hypershift-openshift-io-v1alpha1-hostedcluster"
+	HostedClusterValidatingPath = "/validate-hypershift-openshift-io-v1alpha1-hostedcluster"
+	NodePoolDefaultingPath      = "/mutate-hypershift-openshift-io-v1alpha1-nodepool"
+	NodePoolValidatingPath      = "/validate-hypershift-openshift-io-v1alpha1-nodepool"
+	ConversionPath              = "/convert"
+)
+
+// SetupWebhookWithManager registers the HostedCluster and NodePool admission
+// handlers and the API version conversion handler with the manager's webhook
+// server.
+func SetupWebhookWithManager(mgr ctrl.Manager) error {
+	decoder, err := admission.NewDecoder(mgr.GetScheme())
+	if err != nil {
+		return fmt.Errorf("failed to create admission decoder: %w", err)
+	}
+	server := mgr.GetWebhookServer()
+	server.Register(HostedClusterDefaultingPath, &webhook.Admission{Handler: &hostedClusterDefaulter{decoder: decoder}})
+	server.Register(HostedClusterValidatingPath, &webhook.Admission{Handler: &hostedClusterValidator{decoder: decoder}})
+	server.Register(NodePoolDefaultingPath, &webhook.Admission{Handler: &nodePoolDefaulter{client: mgr.GetClient(), decoder: decoder}})
+	server.Register(NodePoolValidatingPath, &webhook.Admission{Handler: &nodePoolValidator{decoder: decoder}})
+	server.Register(ConversionPath, &conversion.Webhook{})
+	return nil
+}
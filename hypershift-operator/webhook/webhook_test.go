@@ -0,0 +1,873 @@
+package webhook
+
+import (
+	"testing"
+	"time"
+
+	configv1 "github.com/openshift/api/config/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/pointer"
+
+	hyperv1 "github.com/openshift/hypershift/api/v1alpha1"
+)
+
+func validHostedCluster() *hyperv1.HostedCluster {
+	return &hyperv1.HostedCluster{
+		Spec: hyperv1.HostedClusterSpec{
+			Release: hyperv1.Release{Image: "a"},
+			InfraID: "infra",
+			Networking: hyperv1.ClusterNetworking{
+				ServiceCIDR: DefaultServiceCIDR,
+				PodCIDR:     DefaultPodCIDR,
+				MachineCIDR: "10.0.0.0/16",
+			},
+			Platform: hyperv1.PlatformSpec{
+				Type: hyperv1.AWSPlatform,
+				AWS: &hyperv1.AWSPlatformSpec{
+					Region:                   "us-east-1",
+					KubeCloudControllerCreds: corev1.LocalObjectReference{Name: "kcc"},
+					NodePoolManagementCreds:  corev1.LocalObjectReference{Name: "npm"},
+				},
+			},
+		},
+	}
+}
+
+// setDualStack gives a cluster IPv6 cluster, service and machine networks in
+// addition to its IPv4 networks.
+func setDualStack(hc *hyperv1.HostedCluster) {
+	networking := &hc.Spec.Networking
+	networking.NetworkType = hyperv1.OVNKubernetes
+	networking.ClusterNetwork = []hyperv1.ClusterNetworkEntry{{CIDR: networking.PodCIDR}, {CIDR: "fd01::/48", HostPrefix: 64}}
+	networking.ServiceNetwork = []string{networking.ServiceCIDR, "fd02::/112"}
+	networking.MachineNetwork = []hyperv1.MachineNetworkEntry{{CIDR: networking.MachineCIDR}, {CIDR: "2600:1f18:1:100::/56"}}
+}
+
+// setPrivateEndpointAccess publishes the Kube API server of a cluster
+// privately with the given endpoint access.
+func setPrivateEndpointAccess(hc *hyperv1.HostedCluster, access hyperv1.AWSEndpointAccessType) {
+	hc.Spec.DNS.PrivateZoneID = "private"
+	hc.Spec.Platform.AWS.EndpointAccess = access
+	hc.Spec.Platform.AWS.ControlPlaneOperatorCreds = corev1.LocalObjectReference{Name: "cpo"}
+	hc.Spec.Platform.AWS.DNSManagementCreds = corev1.LocalObjectReference{Name: "dns"}
+	hc.Spec.Platform.AWS.NodePoolDefaults = &hyperv1.AWSNodePoolPlatform{
+		Subnet: &hyperv1.AWSResourceReference{ID: pointer.StringPtr("subnet")},
+	}
+}
+
+// setPrivateNodeServices connects the nodes of a cluster to the control plane
+// with Konnectivity and publishes the services they connect to besides the
+// Kube API server on the node ports of the management cluster.
+func setPrivateNodeServices(hc *hyperv1.HostedCluster) {
+	hc.Spec.NodeConnectivity = hyperv1.Konnectivity
+	hc.Spec.Services = []hyperv1.ServicePublishingStrategyMapping{
+		nodePortService(hyperv1.OAuthServer, 30001),
+		nodePortService(hyperv1.Ignition, 30002),
+		nodePortService(hyperv1.KonnectivityServer, 30003),
+	}
+}
+
+func htpasswdIdentityProvider() configv1.IdentityProvider {
+	return configv1.IdentityProvider{
+		Name: "htpasswd",
+		IdentityProviderConfig: configv1.IdentityProviderConfig{
+			Type:     configv1.IdentityProviderTypeHTPasswd,
+			HTPasswd: &configv1.HTPasswdIdentityProvider{FileData: configv1.SecretNameReference{Name: "htpasswd"}},
+		},
+	}
+}
+
+// nodePortService publishes a service on the node ports of the management
+// cluster.
+func nodePortService(service hyperv1.ServiceType, port int32) hyperv1.ServicePublishingStrategyMapping {
+	return hyperv1.ServicePublishingStrategyMapping{
+		Service: service,
+		ServicePublishingStrategy: hyperv1.ServicePublishingStrategy{
+			Type:     hyperv1.NodePort,
+			NodePort: &hyperv1.NodePortPublishingStrategy{Address: "nodes.example.com", Port: port},
+		},
+	}
+}
+
+// hostnameService publishes a service with a hostname and serving cert.
+func hostnameService(service hyperv1.ServiceType, strategy hyperv1.PublishingStrategyType, hostname string) hyperv1.ServicePublishingStrategyMapping {
+	return hyperv1.ServicePublishingStrategyMapping{
+		Service: service,
+		ServicePublishingStrategy: hyperv1.ServicePublishingStrategy{
+			Type:        strategy,
+			Hostname:    hostname,
+			ServingCert: &corev1.LocalObjectReference{Name: "serving-cert"},
+		},
+	}
+}
+
+func TestValidateHostedCluster(t *testing.T) {
+	tests := map[string]struct {
+		Mutate        func(*hyperv1.HostedCluster)
+		ExpectedValid bool
+	}{
+		"valid cluster": {
+			Mutate:        func(*hyperv1.HostedCluster) {},
+			ExpectedValid: true,
+		},
+		"overlapping service and pod cidrs": {
+			Mutate: func(hc *hyperv1.HostedCluster) {
+				hc.Spec.Networking.PodCIDR = "172.31.128.0/17"
+			},
+			ExpectedValid: false,
+		},
+		"overlapping pod and machine cidrs": {
+			Mutate: func(hc *hyperv1.HostedCluster) {
+				hc.Spec.Networking.MachineCIDR = "10.132.0.0/16"
+			},
+			ExpectedValid: false,
+		},
+		"cidr containing the internal api server address": {
+			Mutate: func(hc *hyperv1.HostedCluster) {
+				hc.Spec.Networking.ServiceCIDR = "172.16.0.0/12"
+			},
+			ExpectedValid: false,
+		},
+		"unparseable cidr": {
+			Mutate: func(hc *hyperv1.HostedCluster) {
+				hc.Spec.Networking.MachineCIDR = "10.0.0.0"
+			},
+			ExpectedValid: false,
+		},
+		"aws platform without aws spec": {
+			Mutate: func(hc *hyperv1.HostedCluster) {
+				hc.Spec.Platform.AWS = nil
+			},
+			ExpectedValid: false,
+		},
+		"invalid pausedUntil": {
+			Mutate: func(hc *hyperv1.HostedCluster) {
+				hc.Spec.PausedUntil = pointer.StringPtr("tomorrow")
+			},
+			ExpectedValid: false,
+		},
+		"missing infra id": {
+			Mutate: func(hc *hyperv1.HostedCluster) {
+				hc.Spec.InfraID = ""
+			},
+			ExpectedValid: false,
+		},
+		"valid configuration": {
+			Mutate: func(hc *hyperv1.HostedCluster) {
+				hc.Spec.Configuration = &hyperv1.ClusterConfiguration{
+					Items: []runtime.RawExtension{{Raw: []byte(`{"apiVersion":"config.openshift.io/v1","kind":"OAuth","metadata":{"name":"cluster"},"spec":{"tokenConfig":{"accessTokenMaxAgeSeconds":3600}}}`)}},
+				}
+			},
+			ExpectedValid: true,
+		},
+		"configuration with unsupported kind": {
+			Mutate: func(hc *hyperv1.HostedCluster) {
+				hc.Spec.Configuration = &hyperv1.ClusterConfiguration{
+					Items: []runtime.RawExtension{{Raw: []byte(`{"apiVersion":"config.openshift.io/v1","kind":"DNS","metadata":{"name":"cluster"}}`)}},
+				}
+			},
+			ExpectedValid: false,
+		},
+		"valid identity provider": {
+			Mutate: func(hc *hyperv1.HostedCluster) {
+				hc.Spec.OAuth = &hyperv1.OAuthSpec{IdentityProviders: []configv1.IdentityProvider{htpasswdIdentityProvider()}, DisableKubeadmin: true}
+			},
+			ExpectedValid: true,
+		},
+		"identity provider without its configuration": {
+			Mutate: func(hc *hyperv1.HostedCluster) {
+				idp := htpasswdIdentityProvider()
+				idp.Type = configv1.IdentityProviderTypeGitHub
+				hc.Spec.OAuth = &hyperv1.OAuthSpec{IdentityProviders: []configv1.IdentityProvider{idp}}
+			},
+			ExpectedValid: false,
+		},
+		"kubeadmin disabled without identity providers": {
+			Mutate: func(hc *hyperv1.HostedCluster) {
+				hc.Spec.OAuth = &hyperv1.OAuthSpec{DisableKubeadmin: true}
+			},
+			ExpectedValid: false,
+		},
+		"sizing with a component override": {
+			Mutate: func(hc *hyperv1.HostedCluster) {
+				hc.Spec.Sizing = &hyperv1.ControlPlaneSizing{
+					Profile: hyperv1.MediumSizingProfile,
+					Overrides: []hyperv1.ControlPlaneComponentResources{
+						{Name: "kube-apiserver", Resources: corev1.ResourceRequirements{Requests: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("8Gi")}}},
+					},
+				}
+			},
+			ExpectedValid: true,
+		},
+		"sizing override of an unknown component": {
+			Mutate: func(hc *hyperv1.HostedCluster) {
+				hc.Spec.Sizing = &hyperv1.ControlPlaneSizing{
+					Overrides: []hyperv1.ControlPlaneComponentResources{{Name: "openvpn-server"}},
+				}
+			},
+			ExpectedValid: false,
+		},
+		"sizing override of an unsupported resource": {
+			Mutate: func(hc *hyperv1.HostedCluster) {
+				hc.Spec.Sizing = &hyperv1.ControlPlaneSizing{
+					Overrides: []hyperv1.ControlPlaneComponentResources{
+						{Name: "etcd", Resources: corev1.ResourceRequirements{Limits: corev1.ResourceList{corev1.ResourceEphemeralStorage: resource.MustParse("10Gi")}}},
+					},
+				}
+			},
+			ExpectedValid: false,
+		},
+		"sizing override limit below the request of the profile": {
+			Mutate: func(hc *hyperv1.HostedCluster) {
+				hc.Spec.Sizing = &hyperv1.ControlPlaneSizing{
+					Profile: hyperv1.MediumSizingProfile,
+					Overrides: []hyperv1.ControlPlaneComponentResources{
+						{Name: "kube-apiserver", Resources: corev1.ResourceRequirements{Limits: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("500m")}}},
+					},
+				}
+			},
+			ExpectedValid: true,
+		},
+		"sizing override request above its limit": {
+			Mutate: func(hc *hyperv1.HostedCluster) {
+				hc.Spec.Sizing = &hyperv1.ControlPlaneSizing{
+					Overrides: []hyperv1.ControlPlaneComponentResources{
+						{Name: "kube-apiserver", Resources: corev1.ResourceRequirements{
+							Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("1")},
+							Limits:   corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("500m")},
+						}},
+					},
+				}
+			},
+			ExpectedValid: false,
+		},
+		"duplicate sizing overrides": {
+			Mutate: func(hc *hyperv1.HostedCluster) {
+				hc.Spec.Sizing = &hyperv1.ControlPlaneSizing{
+					Overrides: []hyperv1.ControlPlaneComponentResources{{Name: "etcd"}, {Name: "etcd"}},
+				}
+			},
+			ExpectedValid: false,
+		},
+		"OVNKubernetes network type": {
+			Mutate: func(hc *hyperv1.HostedCluster) {
+				hc.Spec.Networking.NetworkType = hyperv1.OVNKubernetes
+			},
+			ExpectedValid: true,
+		},
+		"OVNKubernetes network overlapping the join subnet": {
+			Mutate: func(hc *hyperv1.HostedCluster) {
+				hc.Spec.Networking.NetworkType = hyperv1.OVNKubernetes
+				hc.Spec.Networking.PodCIDR = "100.64.0.0/14"
+			},
+			ExpectedValid: false,
+		},
+		"OpenShiftSDN network may use the OVNKubernetes join subnet": {
+			Mutate: func(hc *hyperv1.HostedCluster) {
+				hc.Spec.Networking.NetworkType = hyperv1.OpenShiftSDN
+				hc.Spec.Networking.PodCIDR = "100.64.0.0/14"
+			},
+			ExpectedValid: true,
+		},
+		"unknown network type": {
+			Mutate: func(hc *hyperv1.HostedCluster) {
+				hc.Spec.Networking.NetworkType = "Calico"
+			},
+			ExpectedValid: false,
+		},
+		"dual-stack networks": {
+			Mutate: func(hc *hyperv1.HostedCluster) {
+				setDualStack(hc)
+			},
+			ExpectedValid: true,
+		},
+		"dual-stack networks require OVNKubernetes": {
+			Mutate: func(hc *hyperv1.HostedCluster) {
+				setDualStack(hc)
+				hc.Spec.Networking.NetworkType = hyperv1.OpenShiftSDN
+			},
+			ExpectedValid: false,
+		},
+		"first cluster network is not the pod cidr": {
+			Mutate: func(hc *hyperv1.HostedCluster) {
+				setDualStack(hc)
+				hc.Spec.Networking.ClusterNetwork[0].CIDR = "10.136.0.0/14"
+			},
+			ExpectedValid: false,
+		},
+		"service networks of the same ip family": {
+			Mutate: func(hc *hyperv1.HostedCluster) {
+				hc.Spec.Networking.ServiceNetwork = []string{DefaultServiceCIDR, "172.29.0.0/16"}
+			},
+			ExpectedValid: false,
+		},
+		"dual-stack cluster network with a single-stack service network": {
+			Mutate: func(hc *hyperv1.HostedCluster) {
+				setDualStack(hc)
+				hc.Spec.Networking.ServiceNetwork = nil
+			},
+			ExpectedValid: false,
+		},
+		"host prefix shorter than the cluster network": {
+			Mutate: func(hc *hyperv1.HostedCluster) {
+				hc.Spec.Networking.ClusterNetwork = []hyperv1.ClusterNetworkEntry{{CIDR: DefaultPodCIDR, HostPrefix: 8}}
+			},
+			ExpectedValid: false,
+		},
+		"overlapping ipv6 networks": {
+			Mutate: func(hc *hyperv1.HostedCluster) {
+				setDualStack(hc)
+				hc.Spec.Networking.ServiceNetwork[1] = "fd01::/112"
+			},
+			ExpectedValid: false,
+		},
+		"api server published on node ports": {
+			Mutate: func(hc *hyperv1.HostedCluster) {
+				hc.Spec.Services = []hyperv1.ServicePublishingStrategyMapping{nodePortService(hyperv1.APIServer, 30000)}
+			},
+			ExpectedValid: true,
+		},
+		"node port publishing without an address": {
+			Mutate: func(hc *hyperv1.HostedCluster) {
+				hc.Spec.Services = []hyperv1.ServicePublishingStrategyMapping{{
+					Service:                   hyperv1.APIServer,
+					ServicePublishingStrategy: hyperv1.ServicePublishingStrategy{Type: hyperv1.NodePort},
+				}}
+			},
+			ExpectedValid: false,
+		},
+		"node port out of range": {
+			Mutate: func(hc *hyperv1.HostedCluster) {
+				hc.Spec.Services = []hyperv1.ServicePublishingStrategyMapping{nodePortService(hyperv1.APIServer, 70000)}
+			},
+			ExpectedValid: false,
+		},
+		"vpn published through a route": {
+			Mutate: func(hc *hyperv1.HostedCluster) {
+				hc.Spec.Services = []hyperv1.ServicePublishingStrategyMapping{{
+					Service:                   hyperv1.VPN,
+					ServicePublishingStrategy: hyperv1.ServicePublishingStrategy{Type: hyperv1.Route},
+				}}
+			},
+			ExpectedValid: false,
+		},
+		"node port configuration with the load balancer strategy": {
+			Mutate: func(hc *hyperv1.HostedCluster) {
+				service := nodePortService(hyperv1.OAuthServer, 0)
+				service.Type = hyperv1.LoadBalancer
+				hc.Spec.Services = []hyperv1.ServicePublishingStrategyMapping{service}
+			},
+			ExpectedValid: false,
+		},
+		"duplicate service publishing strategies": {
+			Mutate: func(hc *hyperv1.HostedCluster) {
+				hc.Spec.Services = []hyperv1.ServicePublishingStrategyMapping{
+					nodePortService(hyperv1.Ignition, 0),
+					nodePortService(hyperv1.Ignition, 0),
+				}
+			},
+			ExpectedValid: false,
+		},
+		"api server with a hostname and serving cert": {
+			Mutate: func(hc *hyperv1.HostedCluster) {
+				hc.Spec.Services = []hyperv1.ServicePublishingStrategyMapping{hostnameService(hyperv1.APIServer, hyperv1.LoadBalancer, "api.example.com")}
+			},
+			ExpectedValid: true,
+		},
+		"oauth server route with a hostname": {
+			Mutate: func(hc *hyperv1.HostedCluster) {
+				hc.Spec.Services = []hyperv1.ServicePublishingStrategyMapping{hostnameService(hyperv1.OAuthServer, hyperv1.Route, "oauth.example.com")}
+			},
+			ExpectedValid: true,
+		},
+		"vpn with a hostname": {
+			Mutate: func(hc *hyperv1.HostedCluster) {
+				hc.Spec.Services = []hyperv1.ServicePublishingStrategyMapping{hostnameService(hyperv1.VPN, hyperv1.LoadBalancer, "vpn.example.com")}
+			},
+			ExpectedValid: false,
+		},
+		"hostname with the node port strategy": {
+			Mutate: func(hc *hyperv1.HostedCluster) {
+				service := nodePortService(hyperv1.APIServer, 0)
+				service.Hostname = "api.example.com"
+				hc.Spec.Services = []hyperv1.ServicePublishingStrategyMapping{service}
+			},
+			ExpectedValid: false,
+		},
+		"invalid hostname": {
+			Mutate: func(hc *hyperv1.HostedCluster) {
+				hc.Spec.Services = []hyperv1.ServicePublishingStrategyMapping{hostnameService(hyperv1.APIServer, hyperv1.LoadBalancer, "API_server")}
+			},
+			ExpectedValid: false,
+		},
+		"serving cert without a hostname": {
+			Mutate: func(hc *hyperv1.HostedCluster) {
+				service := hostnameService(hyperv1.APIServer, hyperv1.LoadBalancer, "")
+				hc.Spec.Services = []hyperv1.ServicePublishingStrategyMapping{service}
+			},
+			ExpectedValid: false,
+		},
+		"unknown service": {
+			Mutate: func(hc *hyperv1.HostedCluster) {
+				hc.Spec.Services = []hyperv1.ServicePublishingStrategyMapping{nodePortService("Console", 0)}
+			},
+			ExpectedValid: false,
+		},
+		"public and private endpoint access": {
+			Mutate: func(hc *hyperv1.HostedCluster) {
+				setPrivateEndpointAccess(hc, hyperv1.PublicAndPrivate)
+				hc.Spec.Services = []hyperv1.ServicePublishingStrategyMapping{hostnameService(hyperv1.APIServer, hyperv1.LoadBalancer, "api.example.com")}
+			},
+			ExpectedValid: true,
+		},
+		"private endpoint access": {
+			Mutate: func(hc *hyperv1.HostedCluster) {
+				setPrivateEndpointAccess(hc, hyperv1.Private)
+				setPrivateNodeServices(hc)
+			},
+			ExpectedValid: true,
+		},
+		"private endpoint access with OVNKubernetes": {
+			Mutate: func(hc *hyperv1.HostedCluster) {
+				setPrivateEndpointAccess(hc, hyperv1.Private)
+				setPrivateNodeServices(hc)
+				hc.Spec.Networking.NetworkType = hyperv1.OVNKubernetes
+				hc.Spec.Services = append(hc.Spec.Services, nodePortService(hyperv1.OVNSbDb, 30004))
+			},
+			ExpectedValid: true,
+		},
+		"private endpoint access with a routed ovn sbdb": {
+			Mutate: func(hc *hyperv1.HostedCluster) {
+				setPrivateEndpointAccess(hc, hyperv1.Private)
+				setPrivateNodeServices(hc)
+				hc.Spec.Networking.NetworkType = hyperv1.OVNKubernetes
+			},
+			ExpectedValid: false,
+		},
+		"private endpoint access with the vpn": {
+			Mutate: func(hc *hyperv1.HostedCluster) {
+				setPrivateEndpointAccess(hc, hyperv1.Private)
+				setPrivateNodeServices(hc)
+				hc.Spec.NodeConnectivity = hyperv1.OpenVPN
+			},
+			ExpectedValid: false,
+		},
+		"private endpoint access with a routed ignition server": {
+			Mutate: func(hc *hyperv1.HostedCluster) {
+				setPrivateEndpointAccess(hc, hyperv1.Private)
+				setPrivateNodeServices(hc)
+				hc.Spec.Services[1] = hyperv1.ServicePublishingStrategyMapping{Service: hyperv1.Ignition, ServicePublishingStrategy: hyperv1.ServicePublishingStrategy{Type: hyperv1.Route}}
+			},
+			ExpectedValid: false,
+		},
+		"private endpoint access with the default oauth server strategy": {
+			Mutate: func(hc *hyperv1.HostedCluster) {
+				setPrivateEndpointAccess(hc, hyperv1.Private)
+				setPrivateNodeServices(hc)
+				hc.Spec.Services = hc.Spec.Services[1:]
+			},
+			ExpectedValid: false,
+		},
+		"private endpoint access without control plane operator creds": {
+			Mutate: func(hc *hyperv1.HostedCluster) {
+				setPrivateEndpointAccess(hc, hyperv1.Private)
+				hc.Spec.Platform.AWS.ControlPlaneOperatorCreds.Name = ""
+			},
+			ExpectedValid: false,
+		},
+		"private endpoint access without a private zone": {
+			Mutate: func(hc *hyperv1.HostedCluster) {
+				setPrivateEndpointAccess(hc, hyperv1.Private)
+				hc.Spec.DNS.PrivateZoneID = ""
+			},
+			ExpectedValid: false,
+		},
+		"private endpoint access without a subnet": {
+			Mutate: func(hc *hyperv1.HostedCluster) {
+				setPrivateEndpointAccess(hc, hyperv1.Private)
+				hc.Spec.Platform.AWS.NodePoolDefaults = nil
+			},
+			ExpectedValid: false,
+		},
+		"private endpoint access with a node port api server": {
+			Mutate: func(hc *hyperv1.HostedCluster) {
+				setPrivateEndpointAccess(hc, hyperv1.Private)
+				hc.Spec.Services = []hyperv1.ServicePublishingStrategyMapping{nodePortService(hyperv1.APIServer, 0)}
+			},
+			ExpectedValid: false,
+		},
+		"private endpoint access with an api server hostname": {
+			Mutate: func(hc *hyperv1.HostedCluster) {
+				setPrivateEndpointAccess(hc, hyperv1.Private)
+				hc.Spec.Services = []hyperv1.ServicePublishingStrategyMapping{hostnameService(hyperv1.APIServer, hyperv1.LoadBalancer, "api.example.com")}
+			},
+			ExpectedValid: false,
+		},
+		"unknown endpoint access": {
+			Mutate: func(hc *hyperv1.HostedCluster) {
+				setPrivateEndpointAccess(hc, "Internal")
+			},
+			ExpectedValid: false,
+		},
+		"konnectivity node connectivity": {
+			Mutate: func(hc *hyperv1.HostedCluster) {
+				hc.Spec.NodeConnectivity = hyperv1.Konnectivity
+			},
+			ExpectedValid: true,
+		},
+		"konnectivity server published on a node port": {
+			Mutate: func(hc *hyperv1.HostedCluster) {
+				hc.Spec.NodeConnectivity = hyperv1.Konnectivity
+				hc.Spec.Services = []hyperv1.ServicePublishingStrategyMapping{nodePortService(hyperv1.KonnectivityServer, 30091)}
+			},
+			ExpectedValid: true,
+		},
+		"unknown node connectivity": {
+			Mutate: func(hc *hyperv1.HostedCluster) {
+				hc.Spec.NodeConnectivity = "SSH"
+			},
+			ExpectedValid: false,
+		},
+		"proxy": {
+			Mutate: func(hc *hyperv1.HostedCluster) {
+				hc.Spec.Proxy = &hyperv1.ProxySpec{
+					HTTPProxy:  "http://proxy.example.com:3128",
+					HTTPSProxy: "https://proxy.example.com:3129",
+					NoProxy:    ".example.com,10.1.0.0/16",
+				}
+			},
+			ExpectedValid: true,
+		},
+		"https proxy with an http scheme": {
+			Mutate: func(hc *hyperv1.HostedCluster) {
+				hc.Spec.Proxy = &hyperv1.ProxySpec{HTTPSProxy: "http://proxy.example.com:3128"}
+			},
+			ExpectedValid: true,
+		},
+		"http proxy with an https scheme": {
+			Mutate: func(hc *hyperv1.HostedCluster) {
+				hc.Spec.Proxy = &hyperv1.ProxySpec{HTTPProxy: "https://proxy.example.com:3128"}
+			},
+			ExpectedValid: false,
+		},
+		"proxy without a host": {
+			Mutate: func(hc *hyperv1.HostedCluster) {
+				hc.Spec.Proxy = &hyperv1.ProxySpec{HTTPProxy: "proxy.example.com:3128"}
+			},
+			ExpectedValid: false,
+		},
+		"no proxy without a proxy": {
+			Mutate: func(hc *hyperv1.HostedCluster) {
+				hc.Spec.Proxy = &hyperv1.ProxySpec{NoProxy: ".example.com"}
+			},
+			ExpectedValid: false,
+		},
+		"image content sources": {
+			Mutate: func(hc *hyperv1.HostedCluster) {
+				hc.Spec.ImageContentSources = []hyperv1.ImageContentSource{
+					{Source: "quay.io/openshift-release-dev/ocp-release", Mirrors: []string{"mirror.example.com:5000/ocp/release"}},
+					{Source: "registry.example.com:5000", Mirrors: []string{"mirror.example.com:5000/registry"}},
+				}
+			},
+			ExpectedValid: true,
+		},
+		"image content source with a tag": {
+			Mutate: func(hc *hyperv1.HostedCluster) {
+				hc.Spec.ImageContentSources = []hyperv1.ImageContentSource{
+					{Source: "quay.io/openshift-release-dev/ocp-release:4.8.0", Mirrors: []string{"mirror.example.com/ocp/release"}},
+				}
+			},
+			ExpectedValid: false,
+		},
+		"image content source mirror with a digest": {
+			Mutate: func(hc *hyperv1.HostedCluster) {
+				hc.Spec.ImageContentSources = []hyperv1.ImageContentSource{
+					{Source: "quay.io/openshift-release-dev/ocp-release", Mirrors: []string{"mirror.example.com/ocp/release@sha256:0123"}},
+				}
+			},
+			ExpectedValid: false,
+		},
+		"autoscaling": {
+			Mutate: func(hc *hyperv1.HostedCluster) {
+				hc.Spec.Autoscaling = &hyperv1.ClusterAutoscaling{
+					ScaleDownDelayAfterAdd:        &metav1.Duration{Duration: 5 * time.Minute},
+					ScaleDownUtilizationThreshold: pointer.StringPtr("0.4"),
+					Expander:                      hyperv1.LeastWasteExpander,
+				}
+			},
+			ExpectedValid: true,
+		},
+		"autoscaling with a negative scale down delay": {
+			Mutate: func(hc *hyperv1.HostedCluster) {
+				hc.Spec.Autoscaling = &hyperv1.ClusterAutoscaling{ScaleDownDelayAfterAdd: &metav1.Duration{Duration: -time.Minute}}
+			},
+			ExpectedValid: false,
+		},
+		"autoscaling with an out of range utilization threshold": {
+			Mutate: func(hc *hyperv1.HostedCluster) {
+				hc.Spec.Autoscaling = &hyperv1.ClusterAutoscaling{ScaleDownUtilizationThreshold: pointer.StringPtr("1.5")}
+			},
+			ExpectedValid: false,
+		},
+		"autoscaling with a malformed utilization threshold": {
+			Mutate: func(hc *hyperv1.HostedCluster) {
+				hc.Spec.Autoscaling = &hyperv1.ClusterAutoscaling{ScaleDownUtilizationThreshold: pointer.StringPtr("half")}
+			},
+			ExpectedValid: false,
+		},
+		"duplicate image content sources": {
+			Mutate: func(hc *hyperv1.HostedCluster) {
+				hc.Spec.ImageContentSources = []hyperv1.ImageContentSource{
+					{Source: "quay.io/openshift-release-dev/ocp-release", Mirrors: []string{"mirror.example.com/ocp/release"}},
+					{Source: "quay.io/openshift-release-dev/ocp-release", Mirrors: []string{"backup.example.com/ocp/release"}},
+				}
+			},
+			ExpectedValid: false,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			hcluster := validHostedCluster()
+			test.Mutate(hcluster)
+			errs := ValidateHostedCluster(hcluster)
+			if actualValid := len(errs) == 0; actualValid != test.ExpectedValid {
+				t.Errorf("expected valid=%t, got errors: %v", test.ExpectedValid, errs)
+			}
+		})
+	}
+}
+
+func TestValidateHostedClusterUpdate(t *testing.T) {
+	tests := map[string]struct {
+		MutateOld     func(*hyperv1.HostedCluster)
+		Mutate        func(*hyperv1.HostedCluster)
+		ExpectedValid bool
+	}{
+		"release image change is allowed": {
+			Mutate: func(hc *hyperv1.HostedCluster) {
+				hc.Spec.Release.Image = "b"
+			},
+			ExpectedValid: true,
+		},
+		"infra id is immutable": {
+			Mutate: func(hc *hyperv1.HostedCluster) {
+				hc.Spec.InfraID = "other"
+			},
+			ExpectedValid: false,
+		},
+		"service cidr is immutable": {
+			Mutate: func(hc *hyperv1.HostedCluster) {
+				hc.Spec.Networking.ServiceCIDR = "172.30.0.0/16"
+			},
+			ExpectedValid: false,
+		},
+		"machine cidr is immutable": {
+			Mutate: func(hc *hyperv1.HostedCluster) {
+				hc.Spec.Networking.MachineCIDR = "10.1.0.0/16"
+			},
+			ExpectedValid: false,
+		},
+		"network type is immutable": {
+			Mutate: func(hc *hyperv1.HostedCluster) {
+				hc.Spec.Networking.NetworkType = hyperv1.OVNKubernetes
+			},
+			ExpectedValid: false,
+		},
+		"defaulting the network type is allowed": {
+			MutateOld: func(hc *hyperv1.HostedCluster) {
+				hc.Spec.Networking.NetworkType = ""
+			},
+			Mutate: func(hc *hyperv1.HostedCluster) {
+				hc.Spec.Networking.NetworkType = hyperv1.OpenShiftSDN
+			},
+			ExpectedValid: true,
+		},
+		"adding an ipv6 network is not allowed": {
+			Mutate: func(hc *hyperv1.HostedCluster) {
+				setDualStack(hc)
+			},
+			ExpectedValid: false,
+		},
+		"listing the single networks is allowed": {
+			Mutate: func(hc *hyperv1.HostedCluster) {
+				hc.Spec.Networking.ClusterNetwork = []hyperv1.ClusterNetworkEntry{{CIDR: DefaultPodCIDR}}
+				hc.Spec.Networking.ServiceNetwork = []string{DefaultServiceCIDR}
+			},
+			ExpectedValid: true,
+		},
+		"kubeadmin can be disabled": {
+			Mutate: func(hc *hyperv1.HostedCluster) {
+				hc.Spec.OAuth = &hyperv1.OAuthSpec{IdentityProviders: []configv1.IdentityProvider{htpasswdIdentityProvider()}, DisableKubeadmin: true}
+			},
+			ExpectedValid: true,
+		},
+		"kubeadmin cannot be enabled once disabled": {
+			MutateOld: func(hc *hyperv1.HostedCluster) {
+				hc.Spec.OAuth = &hyperv1.OAuthSpec{IdentityProviders: []configv1.IdentityProvider{htpasswdIdentityProvider()}, DisableKubeadmin: true}
+			},
+			Mutate: func(hc *hyperv1.HostedCluster) {
+				hc.Spec.OAuth.DisableKubeadmin = false
+			},
+			ExpectedValid: false,
+		},
+		"publishing strategies are immutable": {
+			Mutate: func(hc *hyperv1.HostedCluster) {
+				hc.Spec.Services = []hyperv1.ServicePublishingStrategyMapping{nodePortService(hyperv1.APIServer, 0)}
+			},
+			ExpectedValid: false,
+		},
+		"hostnames are immutable": {
+			Mutate: func(hc *hyperv1.HostedCluster) {
+				hc.Spec.Services = []hyperv1.ServicePublishingStrategyMapping{hostnameService(hyperv1.APIServer, hyperv1.LoadBalancer, "api.example.com")}
+			},
+			ExpectedValid: false,
+		},
+		"serving certs can be rotated": {
+			MutateOld: func(hc *hyperv1.HostedCluster) {
+				hc.Spec.Services = []hyperv1.ServicePublishingStrategyMapping{hostnameService(hyperv1.APIServer, hyperv1.LoadBalancer, "api.example.com")}
+			},
+			Mutate: func(hc *hyperv1.HostedCluster) {
+				hc.Spec.Services[0].ServingCert.Name = "rotated"
+			},
+			ExpectedValid: true,
+		},
+		"endpoint access is immutable": {
+			Mutate: func(hc *hyperv1.HostedCluster) {
+				setPrivateEndpointAccess(hc, hyperv1.PublicAndPrivate)
+			},
+			ExpectedValid: false,
+		},
+		"listing the default publishing strategy is allowed": {
+			Mutate: func(hc *hyperv1.HostedCluster) {
+				hc.Spec.Services = []hyperv1.ServicePublishingStrategyMapping{{
+					Service:                   hyperv1.APIServer,
+					ServicePublishingStrategy: hyperv1.ServicePublishingStrategy{Type: hyperv1.LoadBalancer},
+				}}
+			},
+			ExpectedValid: true,
+		},
+		"node connectivity can be migrated to konnectivity": {
+			Mutate: func(hc *hyperv1.HostedCluster) {
+				hc.Spec.NodeConnectivity = hyperv1.Konnectivity
+			},
+			ExpectedValid: true,
+		},
+		"node connectivity can be migrated back to openvpn": {
+			MutateOld: func(hc *hyperv1.HostedCluster) {
+				hc.Spec.NodeConnectivity = hyperv1.Konnectivity
+			},
+			Mutate: func(hc *hyperv1.HostedCluster) {
+				hc.Spec.NodeConnectivity = hyperv1.OpenVPN
+			},
+			ExpectedValid: true,
+		},
+		"konnectivity server can be published while migrating to konnectivity": {
+			Mutate: func(hc *hyperv1.HostedCluster) {
+				hc.Spec.NodeConnectivity = hyperv1.Konnectivity
+				hc.Spec.Services = []hyperv1.ServicePublishingStrategyMapping{nodePortService(hyperv1.KonnectivityServer, 30091)}
+			},
+			ExpectedValid: true,
+		},
+		"konnectivity server publishing strategy is immutable once in use": {
+			MutateOld: func(hc *hyperv1.HostedCluster) {
+				hc.Spec.NodeConnectivity = hyperv1.Konnectivity
+			},
+			Mutate: func(hc *hyperv1.HostedCluster) {
+				hc.Spec.Services = []hyperv1.ServicePublishingStrategyMapping{nodePortService(hyperv1.KonnectivityServer, 30091)}
+			},
+			ExpectedValid: false,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			old := validHostedCluster()
+			if test.MutateOld != nil {
+				test.MutateOld(old)
+			}
+			hcluster := old.DeepCopy()
+			test.Mutate(hcluster)
+			errs := ValidateHostedClusterUpdate(hcluster, old)
+			if actualValid := len(errs) == 0; actualValid != test.ExpectedValid {
+				t.Errorf("expected valid=%t, got errors: %v", test.ExpectedValid, errs)
+			}
+		})
+	}
+}
+
+func TestValidateNodePool(t *testing.T) {
+	intPtr := func(i int) *int { return &i }
+	tests := map[string]struct {
+		Spec          hyperv1.NodePoolSpec
+		Old           *hyperv1.NodePoolSpec
+		ExpectedValid bool
+	}{
+		"valid fixed size nodepool": {
+			Spec: hyperv1.NodePoolSpec{
+				ClusterName: "a",
+				NodeCount:   pointer.Int32Ptr(2),
+				Platform:    hyperv1.NodePoolPlatform{AWS: &hyperv1.AWSNodePoolPlatform{InstanceType: "m5.large"}},
+			},
+			ExpectedValid: true,
+		},
+		"valid autoscaling nodepool": {
+			Spec: hyperv1.NodePoolSpec{
+				ClusterName: "a",
+				AutoScaling: &hyperv1.NodePoolAutoScaling{Min: intPtr(1), Max: intPtr(3)},
+				Platform:    hyperv1.NodePoolPlatform{AWS: &hyperv1.AWSNodePoolPlatform{InstanceType: "m5.large"}},
+			},
+			ExpectedValid: true,
+		},
+		"autoscaling min greater than max": {
+			Spec: hyperv1.NodePoolSpec{
+				ClusterName: "a",
+				AutoScaling: &hyperv1.NodePoolAutoScaling{Min: intPtr(3), Max: intPtr(1)},
+				Platform:    hyperv1.NodePoolPlatform{AWS: &hyperv1.AWSNodePoolPlatform{InstanceType: "m5.large"}},
+			},
+			ExpectedValid: false,
+		},
+		"nodecount and autoscaling are exclusive": {
+			Spec: hyperv1.NodePoolSpec{
+				ClusterName: "a",
+				NodeCount:   pointer.Int32Ptr(2),
+				AutoScaling: &hyperv1.NodePoolAutoScaling{Min: intPtr(1), Max: intPtr(3)},
+				Platform:    hyperv1.NodePoolPlatform{AWS: &hyperv1.AWSNodePoolPlatform{InstanceType: "m5.large"}},
+			},
+			ExpectedValid: false,
+		},
+		"missing aws platform": {
+			Spec: hyperv1.NodePoolSpec{
+				ClusterName: "a",
+				NodeCount:   pointer.Int32Ptr(2),
+			},
+			ExpectedValid: false,
+		},
+		"cluster name is immutable": {
+			Spec: hyperv1.NodePoolSpec{
+				ClusterName: "b",
+				NodeCount:   pointer.Int32Ptr(2),
+				Platform:    hyperv1.NodePoolPlatform{AWS: &hyperv1.AWSNodePoolPlatform{InstanceType: "m5.large"}},
+			},
+			Old: &hyperv1.NodePoolSpec{
+				ClusterName: "a",
+				NodeCount:   pointer.Int32Ptr(2),
+				Platform:    hyperv1.NodePoolPlatform{AWS: &hyperv1.AWSNodePoolPlatform{InstanceType: "m5.large"}},
+			},
+			ExpectedValid: false,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			nodePool := &hyperv1.NodePool{Spec: test.Spec}
+			errs := ValidateNodePool(nodePool)
+			if test.Old != nil {
+				errs = ValidateNodePoolUpdate(nodePool, &hyperv1.NodePool{Spec: *test.Old})
+			}
+			if actualValid := len(errs) == 0; actualValid != test.ExpectedValid {
+				t.Errorf("expected valid=%t, got errors: %v", test.ExpectedValid, errs)
+			}
+		})
+	}
+}
@@ -0,0 +1,9 @@
+// Package config holds the configuration of hosted clusters shared by the
+// HyperShift operator and the control plane operator: the global
+// configuration resources, identity providers and sizing of the control plane
+// components.
+package config
+
+// DefaultAPIServerIPAddress is the fixed address at which pods on the nodes
+// of a guest cluster reach its API server.
+const DefaultAPIServerIPAddress = "172.20.0.1"
@@ -0,0 +1,372 @@
+package config
+
+import (
+	"crypto/md5"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+
+	buildv1 "github.com/openshift/api/build/v1"
+	configv1 "github.com/openshift/api/config/v1"
+	openshiftcontrolplanev1 "github.com/openshift/api/openshiftcontrolplane/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/serializer"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+const (
+	// GlobalConfigName is the name every global configuration resource must
+	// have, matching the singleton resources of an OpenShift cluster.
+	GlobalConfigName = "cluster"
+
+	defaultAccessTokenMaxAgeSeconds = 86400
+)
+
+var (
+	globalConfigScheme = runtime.NewScheme()
+
+	supportedGlobalConfigKinds = []string{"APIServer", "OAuth", "Proxy", "Image", "Network", "Scheduler", "Ingress", "FeatureGate", "Build"}
+)
+
+func init() {
+	utilruntime.Must(configv1.Install(globalConfigScheme))
+}
+
+// GlobalConfig holds the config.openshift.io resources specified for a guest
+// cluster. Resources which were not specified are nil.
+type GlobalConfig struct {
+	APIServer   *configv1.APIServer
+	OAuth       *configv1.OAuth
+	Proxy       *configv1.Proxy
+	Image       *configv1.Image
+	Network     *configv1.Network
+	Scheduler   *configv1.Scheduler
+	Ingress     *configv1.Ingress
+	FeatureGate *configv1.FeatureGate
+	Build       *configv1.Build
+}
+
+// ParseGlobalConfig decodes the config.openshift.io resources embedded in a
+// cluster configuration. Errors are returned for resources which cannot be
+// decoded, are of an unsupported kind, are not named "cluster" or are
+// specified more than once, and for identity providers, which are configured
+// separately.
+func ParseGlobalConfig(items []runtime.RawExtension, fldPath *field.Path) (GlobalConfig, field.ErrorList) {
+	config := GlobalConfig{}
+	var errs field.ErrorList
+	decoder := serializer.NewCodecFactory(globalConfigScheme).UniversalDeserializer()
+	for i, item := range items {
+		itemPath := fldPath.Index(i)
+		raw := item.Raw
+		if raw == nil && item.Object != nil {
+			var err error
+			if raw, err = json.Marshal(item.Object); err != nil {
+				errs = append(errs, field.Invalid(itemPath, "", err.Error()))
+				continue
+			}
+		}
+		obj, gvk, err := decoder.Decode(raw, nil, nil)
+		if err != nil {
+			if runtime.IsNotRegisteredError(err) && gvk != nil {
+				errs = append(errs, field.NotSupported(itemPath.Child("kind"), gvk.Kind, supportedGlobalConfigKinds))
+			} else {
+				errs = append(errs, field.Invalid(itemPath, string(raw), err.Error()))
+			}
+			continue
+		}
+		if name := obj.(metav1.Object).GetName(); name != GlobalConfigName {
+			errs = append(errs, field.Invalid(itemPath.Child("metadata", "name"), name, fmt.Sprintf("must be %q", GlobalConfigName)))
+			continue
+		}
+		var duplicate bool
+		switch o := obj.(type) {
+		case *configv1.APIServer:
+			duplicate, config.APIServer = config.APIServer != nil, o
+		case *configv1.OAuth:
+			duplicate, config.OAuth = config.OAuth != nil, o
+			if len(o.Spec.IdentityProviders) > 0 {
+				errs = append(errs, field.Forbidden(itemPath.Child("spec", "identityProviders"), "identity providers are configured in spec.oauth.identityProviders"))
+			}
+		case *configv1.Proxy:
+			duplicate, config.Proxy = config.Proxy != nil, o
+		case *configv1.Image:
+			duplicate, config.Image = config.Image != nil, o
+		case *configv1.Network:
+			duplicate, config.Network = config.Network != nil, o
+		case *configv1.Scheduler:
+			duplicate, config.Scheduler = config.Scheduler != nil, o
+		case *configv1.Ingress:
+			duplicate, config.Ingress = config.Ingress != nil, o
+		case *configv1.FeatureGate:
+			duplicate, config.FeatureGate = config.FeatureGate != nil, o
+		case *configv1.Build:
+			duplicate, config.Build = config.Build != nil, o
+		default:
+			errs = append(errs, field.NotSupported(itemPath.Child("kind"), gvk.Kind, supportedGlobalConfigKinds))
+			continue
+		}
+		if duplicate {
+			errs = append(errs, field.Duplicate(itemPath.Child("kind"), gvk.Kind))
+		}
+	}
+	return config, errs
+}
+
+// FeatureGates returns the kube feature gate flags for the selected feature
+// set, in the form Name=true or Name=false. Nothing is returned for the
+// default feature set.
+func (c GlobalConfig) FeatureGates() []string {
+	if c.FeatureGate == nil {
+		return nil
+	}
+	var enabled, disabled []string
+	switch c.FeatureGate.Spec.FeatureSet {
+	case configv1.Default:
+		return nil
+	case configv1.CustomNoUpgrade:
+		if custom := c.FeatureGate.Spec.CustomNoUpgrade; custom != nil {
+			enabled, disabled = custom.Enabled, custom.Disabled
+		}
+	default:
+		if featureSet, ok := configv1.FeatureSets[c.FeatureGate.Spec.FeatureSet]; ok {
+			enabled, disabled = featureSet.Enabled, featureSet.Disabled
+		}
+	}
+	var gates []string
+	for _, name := range enabled {
+		gates = append(gates, name+"=true")
+	}
+	for _, name := range disabled {
+		gates = append(gates, name+"=false")
+	}
+	return gates
+}
+
+// AdditionalCORSAllowedOrigins returns the CORS origins allowed in addition
+// to the defaults of the API servers.
+func (c GlobalConfig) AdditionalCORSAllowedOrigins() []string {
+	if c.APIServer == nil {
+		return nil
+	}
+	return c.APIServer.Spec.AdditionalCORSAllowedOrigins
+}
+
+// TLSProfile returns the TLS settings of the selected security profile, with
+// the ciphers translated to the IANA names understood by Go servers, or nil
+// if no profile was selected.
+func (c GlobalConfig) TLSProfile() *configv1.TLSProfileSpec {
+	if c.APIServer == nil || c.APIServer.Spec.TLSSecurityProfile == nil {
+		return nil
+	}
+	profile := c.APIServer.Spec.TLSSecurityProfile
+	var spec *configv1.TLSProfileSpec
+	if profile.Type == configv1.TLSProfileCustomType {
+		if profile.Custom == nil {
+			return nil
+		}
+		spec = &profile.Custom.TLSProfileSpec
+	} else if spec = configv1.TLSProfiles[profile.Type]; spec == nil {
+		return nil
+	}
+	result := &configv1.TLSProfileSpec{MinTLSVersion: spec.MinTLSVersion}
+	for _, cipher := range spec.Ciphers {
+		if ianaCipher, ok := openSSLToIANACiphers[cipher]; ok {
+			result.Ciphers = append(result.Ciphers, ianaCipher)
+		}
+	}
+	return result
+}
+
+// AllowedRegistriesForImport returns the registries users may import images
+// from.
+func (c GlobalConfig) AllowedRegistriesForImport() []configv1.RegistryLocation {
+	if c.Image == nil {
+		return nil
+	}
+	return c.Image.Spec.AllowedRegistriesForImport
+}
+
+// ExternalRegistryHostnames returns the external hostnames of the image
+// registry.
+func (c GlobalConfig) ExternalRegistryHostnames() []string {
+	if c.Image == nil {
+		return nil
+	}
+	return c.Image.Spec.ExternalRegistryHostnames
+}
+
+// BuildDefaults returns the defaults the build controller applies to builds,
+// translated from the build configuration, as inline JSON. The default proxy
+// is set as environment variables of builds, and as their git proxy unless a
+// git proxy is specified.
+func (c GlobalConfig) BuildDefaults() string {
+	defaults := openshiftcontrolplanev1.BuildDefaultsConfig{}
+	if c.Build != nil {
+		spec := c.Build.Spec.BuildDefaults
+		gitProxy := spec.GitProxy
+		if proxy := spec.DefaultProxy; proxy != nil {
+			if gitProxy == nil {
+				gitProxy = proxy
+			}
+			for _, env := range []corev1.EnvVar{
+				{Name: "HTTP_PROXY", Value: proxy.HTTPProxy},
+				{Name: "HTTPS_PROXY", Value: proxy.HTTPSProxy},
+				{Name: "NO_PROXY", Value: proxy.NoProxy},
+			} {
+				if len(env.Value) > 0 {
+					defaults.Env = append(defaults.Env, env, corev1.EnvVar{Name: strings.ToLower(env.Name), Value: env.Value})
+				}
+			}
+		}
+		if gitProxy != nil {
+			defaults.GitHTTPProxy = gitProxy.HTTPProxy
+			defaults.GitHTTPSProxy = gitProxy.HTTPSProxy
+			defaults.GitNoProxy = gitProxy.NoProxy
+		}
+		defaults.Env = append(defaults.Env, spec.Env...)
+		defaults.ImageLabels = buildImageLabels(spec.ImageLabels)
+		defaults.Resources = spec.Resources
+	}
+	return inlineJSON(defaults)
+}
+
+// BuildOverrides returns the overrides the build controller applies to
+// builds, translated from the build configuration, as inline JSON.
+func (c GlobalConfig) BuildOverrides() string {
+	overrides := openshiftcontrolplanev1.BuildOverridesConfig{}
+	if c.Build != nil {
+		spec := c.Build.Spec.BuildOverrides
+		overrides.ForcePull = spec.ForcePull
+		overrides.ImageLabels = buildImageLabels(spec.ImageLabels)
+		overrides.NodeSelector = spec.NodeSelector
+		overrides.Tolerations = spec.Tolerations
+	}
+	return inlineJSON(overrides)
+}
+
+func buildImageLabels(labels []configv1.ImageLabel) []buildv1.ImageLabel {
+	var result []buildv1.ImageLabel
+	for _, label := range labels {
+		result = append(result, buildv1.ImageLabel{Name: label.Name, Value: label.Value})
+	}
+	return result
+}
+
+// inlineJSON encodes a value as JSON, which is valid inline YAML.
+func inlineJSON(v interface{}) string {
+	b, err := json.Marshal(v)
+	if err != nil {
+		panic(err.Error())
+	}
+	return string(b)
+}
+
+// DefaultNodeSelector returns the cluster wide default project node selector.
+func (c GlobalConfig) DefaultNodeSelector() string {
+	if c.Scheduler == nil {
+		return ""
+	}
+	return c.Scheduler.Spec.DefaultNodeSelector
+}
+
+// ExternalIPNetworkCIDRs returns the CIDRs services may use as external IPs,
+// with rejected CIDRs prefixed by "!", as expected by the ExternalIPRanger
+// admission plugin.
+func (c GlobalConfig) ExternalIPNetworkCIDRs() []string {
+	if c.Network == nil || c.Network.Spec.ExternalIP == nil || c.Network.Spec.ExternalIP.Policy == nil {
+		return nil
+	}
+	policy := c.Network.Spec.ExternalIP.Policy
+	var cidrs []string
+	for _, cidr := range policy.RejectedCIDRs {
+		cidrs = append(cidrs, "!"+cidr)
+	}
+	return append(cidrs, policy.AllowedCIDRs...)
+}
+
+// AllowIngressIP returns whether external IPs are automatically assigned to
+// LoadBalancer services.
+func (c GlobalConfig) AllowIngressIP() bool {
+	return c.Network != nil && c.Network.Spec.ExternalIP != nil && len(c.Network.Spec.ExternalIP.AutoAssignCIDRs) > 0
+}
+
+// AccessTokenMaxAgeSeconds returns the maximum age of OAuth access tokens.
+func (c GlobalConfig) AccessTokenMaxAgeSeconds() int32 {
+	if c.OAuth == nil || c.OAuth.Spec.TokenConfig.AccessTokenMaxAgeSeconds == 0 {
+		return defaultAccessTokenMaxAgeSeconds
+	}
+	return c.OAuth.Spec.TokenConfig.AccessTokenMaxAgeSeconds
+}
+
+// The config hashes below cover the resources each control plane component
+// consumes, and are set as pod annotations so that a configuration change
+// rolls out the affected components. They are empty when none of the
+// resources were specified.
+
+func (c GlobalConfig) KubeAPIServerConfigHash() string {
+	return configHash(c.APIServer, c.Image, c.Network, c.Scheduler, c.FeatureGate)
+}
+
+func (c GlobalConfig) OAuthServerConfigHash() string {
+	return configHash(c.APIServer, c.OAuth)
+}
+
+func (c GlobalConfig) KubeControllerManagerConfigHash() string {
+	return configHash(c.FeatureGate)
+}
+
+func (c GlobalConfig) KubeSchedulerConfigHash() string {
+	return configHash(c.FeatureGate)
+}
+
+func (c GlobalConfig) OpenShiftAPIServerConfigHash() string {
+	return configHash(c.Image)
+}
+
+func (c GlobalConfig) OpenShiftControllerManagerConfigHash() string {
+	return configHash(c.Build)
+}
+
+func configHash(objs ...runtime.Object) string {
+	var specified []runtime.Object
+	for _, obj := range objs {
+		if !reflect.ValueOf(obj).IsNil() {
+			specified = append(specified, obj)
+		}
+	}
+	if len(specified) == 0 {
+		return ""
+	}
+	b, err := json.Marshal(specified)
+	if err != nil {
+		panic(err.Error())
+	}
+	return fmt.Sprintf("%x", md5.Sum(b))
+}
+
+// openSSLToIANACiphers maps the OpenSSL cipher names used by TLS security
+// profiles to the IANA names of the ciphers supported by Go servers.
+var openSSLToIANACiphers = map[string]string{
+	"ECDHE-ECDSA-AES128-GCM-SHA256": "TLS_ECDHE_ECDSA_WITH_AES_128_GCM_SHA256",
+	"ECDHE-RSA-AES128-GCM-SHA256":   "TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256",
+	"ECDHE-ECDSA-AES256-GCM-SHA384": "TLS_ECDHE_ECDSA_WITH_AES_256_GCM_SHA384",
+	"ECDHE-RSA-AES256-GCM-SHA384":   "TLS_ECDHE_RSA_WITH_AES_256_GCM_SHA384",
+	"ECDHE-ECDSA-CHACHA20-POLY1305": "TLS_ECDHE_ECDSA_WITH_CHACHA20_POLY1305_SHA256",
+	"ECDHE-RSA-CHACHA20-POLY1305":   "TLS_ECDHE_RSA_WITH_CHACHA20_POLY1305_SHA256",
+	"ECDHE-ECDSA-AES128-SHA256":     "TLS_ECDHE_ECDSA_WITH_AES_128_CBC_SHA256",
+	"ECDHE-RSA-AES128-SHA256":       "TLS_ECDHE_RSA_WITH_AES_128_CBC_SHA256",
+	"ECDHE-ECDSA-AES128-SHA":        "TLS_ECDHE_ECDSA_WITH_AES_128_CBC_SHA",
+	"ECDHE-RSA-AES128-SHA":          "TLS_ECDHE_RSA_WITH_AES_128_CBC_SHA",
+	"ECDHE-ECDSA-AES256-SHA":        "TLS_ECDHE_ECDSA_WITH_AES_256_CBC_SHA",
+	"ECDHE-RSA-AES256-SHA":          "TLS_ECDHE_RSA_WITH_AES_256_CBC_SHA",
+	"AES128-GCM-SHA256":             "TLS_RSA_WITH_AES_128_GCM_SHA256",
+	"AES256-GCM-SHA384":             "TLS_RSA_WITH_AES_256_GCM_SHA384",
+	"AES128-SHA256":                 "TLS_RSA_WITH_AES_128_CBC_SHA256",
+	"AES128-SHA":                    "TLS_RSA_WITH_AES_128_CBC_SHA",
+	"AES256-SHA":                    "TLS_RSA_WITH_AES_256_CBC_SHA",
+	"DES-CBC3-SHA":                  "TLS_RSA_WITH_3DES_EDE_CBC_SHA",
+}
@@ -0,0 +1,153 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	configv1 "github.com/openshift/api/config/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/yaml"
+)
+
+func rawItems(items ...string) []runtime.RawExtension {
+	var result []runtime.RawExtension
+	for _, item := range items {
+		raw, err := yaml.YAMLToJSON([]byte(item))
+		if err != nil {
+			panic(err)
+		}
+		result = append(result, runtime.RawExtension{Raw: raw})
+	}
+	return result
+}
+
+const (
+	apiServerItem = `
+apiVersion: config.openshift.io/v1
+kind: APIServer
+metadata:
+  name: cluster
+spec:
+  additionalCORSAllowedOrigins:
+  - "//example\\.com(:|$)"
+  tlsSecurityProfile:
+    type: Old
+`
+	featureGateItem = `
+apiVersion: config.openshift.io/v1
+kind: FeatureGate
+metadata:
+  name: cluster
+spec:
+  featureSet: CustomNoUpgrade
+  customNoUpgrade:
+    enabled:
+    - Foo
+    disabled:
+    - Bar
+`
+	networkItem = `
+apiVersion: config.openshift.io/v1
+kind: Network
+metadata:
+  name: cluster
+spec:
+  serviceNetwork:
+  - 10.0.0.0/8
+  externalIP:
+    autoAssignCIDRs:
+    - 192.168.0.0/24
+    policy:
+      allowedCIDRs:
+      - 192.168.0.0/16
+      rejectedCIDRs:
+      - 192.168.1.0/24
+`
+)
+
+func TestParseGlobalConfig(t *testing.T) {
+	tests := map[string]struct {
+		Items          []runtime.RawExtension
+		ExpectedErrors []field.ErrorType
+	}{
+		"valid configuration": {
+			Items: rawItems(apiServerItem, featureGateItem, networkItem),
+		},
+		"resource not named cluster": {
+			Items: rawItems(`
+apiVersion: config.openshift.io/v1
+kind: Proxy
+metadata:
+  name: proxy
+`),
+			ExpectedErrors: []field.ErrorType{field.ErrorTypeInvalid},
+		},
+		"unsupported kind": {
+			Items: rawItems(`
+apiVersion: config.openshift.io/v1
+kind: Infrastructure
+metadata:
+  name: cluster
+`),
+			ExpectedErrors: []field.ErrorType{field.ErrorTypeNotSupported},
+		},
+		"duplicate kind": {
+			Items:          rawItems(apiServerItem, apiServerItem),
+			ExpectedErrors: []field.ErrorType{field.ErrorTypeDuplicate},
+		},
+		"missing kind": {
+			Items:          rawItems(`metadata: {name: cluster}`),
+			ExpectedErrors: []field.ErrorType{field.ErrorTypeInvalid},
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, errs := ParseGlobalConfig(test.Items, field.NewPath("items"))
+			var actual []field.ErrorType
+			for _, err := range errs {
+				actual = append(actual, err.Type)
+			}
+			if diff := cmp.Diff(test.ExpectedErrors, actual); diff != "" {
+				t.Errorf("unexpected errors (-want +got): %s\n%v", diff, errs)
+			}
+		})
+	}
+}
+
+func TestGlobalConfigValues(t *testing.T) {
+	config, errs := ParseGlobalConfig(rawItems(apiServerItem, featureGateItem, networkItem), field.NewPath("items"))
+	if len(errs) > 0 {
+		t.Fatalf("unexpected errors: %v", errs)
+	}
+	if diff := cmp.Diff([]string{"Foo=true", "Bar=false"}, config.FeatureGates()); diff != "" {
+		t.Errorf("unexpected feature gates (-want +got): %s", diff)
+	}
+	if diff := cmp.Diff([]string{"!192.168.1.0/24", "192.168.0.0/16"}, config.ExternalIPNetworkCIDRs()); diff != "" {
+		t.Errorf("unexpected external ip cidrs (-want +got): %s", diff)
+	}
+	if !config.AllowIngressIP() {
+		t.Errorf("expected ingress ips to be allowed")
+	}
+	profile := config.TLSProfile()
+	if profile == nil || profile.MinTLSVersion != configv1.VersionTLS10 {
+		t.Fatalf("expected the old tls profile, got %v", profile)
+	}
+	for _, cipher := range profile.Ciphers {
+		if cipher == "DHE-RSA-AES128-GCM-SHA256" || cipher == "TLS_AES_128_GCM_SHA256" {
+			t.Errorf("unexpected cipher %s not supported by go servers", cipher)
+		}
+	}
+	if config.AccessTokenMaxAgeSeconds() != defaultAccessTokenMaxAgeSeconds {
+		t.Errorf("expected default access token max age, got %d", config.AccessTokenMaxAgeSeconds())
+	}
+	if len(config.KubeAPIServerConfigHash()) == 0 || len(config.KubeControllerManagerConfigHash()) == 0 {
+		t.Errorf("expected config hashes for the configured components")
+	}
+	if len(config.OAuthServerConfigHash()) == 0 {
+		t.Errorf("expected an oauth server config hash from the apiserver configuration")
+	}
+	if hash := (GlobalConfig{}).KubeAPIServerConfigHash(); len(hash) != 0 {
+		t.Errorf("expected no config hash without configuration, got %s", hash)
+	}
+}
@@ -0,0 +1,138 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+
+	configv1 "github.com/openshift/api/config/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+var supportedIdentityProviderTypes = []string{
+	string(configv1.IdentityProviderTypeBasicAuth),
+	string(configv1.IdentityProviderTypeGitHub),
+	string(configv1.IdentityProviderTypeGitLab),
+	string(configv1.IdentityProviderTypeGoogle),
+	string(configv1.IdentityProviderTypeHTPasswd),
+	string(configv1.IdentityProviderTypeKeystone),
+	string(configv1.IdentityProviderTypeLDAP),
+	string(configv1.IdentityProviderTypeOpenID),
+	string(configv1.IdentityProviderTypeRequestHeader),
+}
+
+// ValidateIdentityProviders validates the identity providers of a cluster.
+func ValidateIdentityProviders(idps []configv1.IdentityProvider, fldPath *field.Path) field.ErrorList {
+	var errs field.ErrorList
+	names := sets.NewString()
+	for i := range idps {
+		idp := &idps[i]
+		idpPath := fldPath.Index(i)
+		if len(idp.Name) == 0 {
+			errs = append(errs, field.Required(idpPath.Child("name"), ""))
+		} else if names.Has(idp.Name) {
+			errs = append(errs, field.Duplicate(idpPath.Child("name"), idp.Name))
+		}
+		names.Insert(idp.Name)
+
+		configs := identityProviderConfigs(idp)
+		configPath, supported := identityProviderConfigPaths[idp.Type]
+		switch {
+		case !supported:
+			errs = append(errs, field.NotSupported(idpPath.Child("type"), idp.Type, supportedIdentityProviderTypes))
+			continue
+		case !configs.Has(string(idp.Type)):
+			errs = append(errs, field.Required(idpPath.Child(configPath), fmt.Sprintf("required for identity providers of type %s", idp.Type)))
+			continue
+		case configs.Len() > 1:
+			errs = append(errs, field.Forbidden(idpPath, fmt.Sprintf("only the %s configuration may be set for identity providers of type %s", configPath, idp.Type)))
+			continue
+		}
+		errs = append(errs, validateIdentityProviderConfig(idp, idpPath.Child(configPath))...)
+	}
+	return errs
+}
+
+// identityProviderConfigPaths maps identity provider types to the field
+// holding their configuration.
+var identityProviderConfigPaths = map[configv1.IdentityProviderType]string{
+	configv1.IdentityProviderTypeBasicAuth:     "basicAuth",
+	configv1.IdentityProviderTypeGitHub:        "github",
+	configv1.IdentityProviderTypeGitLab:        "gitlab",
+	configv1.IdentityProviderTypeGoogle:        "google",
+	configv1.IdentityProviderTypeHTPasswd:      "htpasswd",
+	configv1.IdentityProviderTypeKeystone:      "keystone",
+	configv1.IdentityProviderTypeLDAP:          "ldap",
+	configv1.IdentityProviderTypeOpenID:        "openID",
+	configv1.IdentityProviderTypeRequestHeader: "requestHeader",
+}
+
+// validateIdentityProviderConfig validates the fields required by the
+// configuration of an identity provider.
+func validateIdentityProviderConfig(idp *configv1.IdentityProvider, fldPath *field.Path) field.ErrorList {
+	var errs field.ErrorList
+	required := func(value string, path ...string) {
+		if len(value) == 0 {
+			errs = append(errs, field.Required(fldPath.Child(path[0], path[1:]...), ""))
+		}
+	}
+	switch idp.Type {
+	case configv1.IdentityProviderTypeBasicAuth:
+		required(idp.BasicAuth.URL, "url")
+	case configv1.IdentityProviderTypeGitHub:
+		required(idp.GitHub.ClientID, "clientID")
+		required(idp.GitHub.ClientSecret.Name, "clientSecret", "name")
+	case configv1.IdentityProviderTypeGitLab:
+		required(idp.GitLab.URL, "url")
+		required(idp.GitLab.ClientID, "clientID")
+		required(idp.GitLab.ClientSecret.Name, "clientSecret", "name")
+	case configv1.IdentityProviderTypeGoogle:
+		required(idp.Google.ClientID, "clientID")
+		required(idp.Google.ClientSecret.Name, "clientSecret", "name")
+	case configv1.IdentityProviderTypeHTPasswd:
+		required(idp.HTPasswd.FileData.Name, "fileData", "name")
+	case configv1.IdentityProviderTypeKeystone:
+		required(idp.Keystone.URL, "url")
+	case configv1.IdentityProviderTypeLDAP:
+		required(idp.LDAP.URL, "url")
+	case configv1.IdentityProviderTypeOpenID:
+		required(idp.OpenID.ClientID, "clientID")
+		required(idp.OpenID.ClientSecret.Name, "clientSecret", "name")
+		if !strings.HasPrefix(idp.OpenID.Issuer, "https://") {
+			errs = append(errs, field.Invalid(fldPath.Child("issuer"), idp.OpenID.Issuer, "must be an https URL"))
+		}
+	case configv1.IdentityProviderTypeRequestHeader:
+		if len(idp.RequestHeader.LoginURL) == 0 && len(idp.RequestHeader.ChallengeURL) == 0 {
+			errs = append(errs, field.Required(fldPath, "at least one of loginURL and challengeURL is required"))
+		}
+	}
+	return errs
+}
+
+// identityProviderConfigs returns the types of the provider configurations
+// set on an identity provider.
+func identityProviderConfigs(idp *configv1.IdentityProvider) sets.String {
+	types := sets.NewString()
+	for t, set := range map[configv1.IdentityProviderType]bool{
+		configv1.IdentityProviderTypeBasicAuth:     idp.BasicAuth != nil,
+		configv1.IdentityProviderTypeGitHub:        idp.GitHub != nil,
+		configv1.IdentityProviderTypeGitLab:        idp.GitLab != nil,
+		configv1.IdentityProviderTypeGoogle:        idp.Google != nil,
+		configv1.IdentityProviderTypeHTPasswd:      idp.HTPasswd != nil,
+		configv1.IdentityProviderTypeKeystone:      idp.Keystone != nil,
+		configv1.IdentityProviderTypeLDAP:          idp.LDAP != nil,
+		configv1.IdentityProviderTypeOpenID:        idp.OpenID != nil,
+		configv1.IdentityProviderTypeRequestHeader: idp.RequestHeader != nil,
+	} {
+		if set {
+			types.Insert(string(t))
+		}
+	}
+	return types
+}
+
+// IdentityProviderConfigPath returns the field of an identity provider which
+// holds the configuration of its type.
+func IdentityProviderConfigPath(idpType configv1.IdentityProviderType) string {
+	return identityProviderConfigPaths[idpType]
+}
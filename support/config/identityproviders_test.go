@@ -0,0 +1,105 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	configv1 "github.com/openshift/api/config/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+func htpasswdIdentityProvider(name, secretName string) configv1.IdentityProvider {
+	return configv1.IdentityProvider{
+		Name: name,
+		IdentityProviderConfig: configv1.IdentityProviderConfig{
+			Type:     configv1.IdentityProviderTypeHTPasswd,
+			HTPasswd: &configv1.HTPasswdIdentityProvider{FileData: configv1.SecretNameReference{Name: secretName}},
+		},
+	}
+}
+
+func ldapIdentityProvider(name string) configv1.IdentityProvider {
+	return configv1.IdentityProvider{
+		Name: name,
+		IdentityProviderConfig: configv1.IdentityProviderConfig{
+			Type: configv1.IdentityProviderTypeLDAP,
+			LDAP: &configv1.LDAPIdentityProvider{
+				URL:          "ldaps://ldap.example.com/ou=users,dc=example,dc=com?uid",
+				BindDN:       "cn=admin,dc=example,dc=com",
+				BindPassword: configv1.SecretNameReference{Name: "ldap-bind"},
+				CA:           configv1.ConfigMapNameReference{Name: "ldap-ca"},
+				Attributes:   configv1.LDAPAttributeMapping{ID: []string{"dn"}, PreferredUsername: []string{"uid"}},
+			},
+		},
+	}
+}
+
+func TestValidateIdentityProviders(t *testing.T) {
+	tests := map[string]struct {
+		idps     []configv1.IdentityProvider
+		expected []string
+	}{
+		"valid identity providers": {
+			idps: []configv1.IdentityProvider{htpasswdIdentityProvider("htpasswd", "users"), ldapIdentityProvider("ldap")},
+		},
+		"missing name": {
+			idps:     []configv1.IdentityProvider{htpasswdIdentityProvider("", "users")},
+			expected: []string{"idps[0].name"},
+		},
+		"duplicate name": {
+			idps:     []configv1.IdentityProvider{htpasswdIdentityProvider("users", "a"), htpasswdIdentityProvider("users", "b")},
+			expected: []string{"idps[1].name"},
+		},
+		"unsupported type": {
+			idps: []configv1.IdentityProvider{{
+				Name:                   "unknown",
+				IdentityProviderConfig: configv1.IdentityProviderConfig{Type: "Unknown"},
+			}},
+			expected: []string{"idps[0].type"},
+		},
+		"missing configuration": {
+			idps: []configv1.IdentityProvider{{
+				Name:                   "htpasswd",
+				IdentityProviderConfig: configv1.IdentityProviderConfig{Type: configv1.IdentityProviderTypeHTPasswd},
+			}},
+			expected: []string{"idps[0].htpasswd"},
+		},
+		"configuration of another type": {
+			idps: []configv1.IdentityProvider{func() configv1.IdentityProvider {
+				idp := htpasswdIdentityProvider("htpasswd", "users")
+				idp.GitHub = &configv1.GitHubIdentityProvider{ClientID: "id", ClientSecret: configv1.SecretNameReference{Name: "secret"}}
+				return idp
+			}()},
+			expected: []string{"idps[0]"},
+		},
+		"missing file data": {
+			idps:     []configv1.IdentityProvider{htpasswdIdentityProvider("htpasswd", "")},
+			expected: []string{"idps[0].htpasswd.fileData.name"},
+		},
+		"insecure openid issuer": {
+			idps: []configv1.IdentityProvider{{
+				Name: "openid",
+				IdentityProviderConfig: configv1.IdentityProviderConfig{
+					Type: configv1.IdentityProviderTypeOpenID,
+					OpenID: &configv1.OpenIDIdentityProvider{
+						ClientID:     "id",
+						ClientSecret: configv1.SecretNameReference{Name: "secret"},
+						Issuer:       "http://issuer.example.com",
+					},
+				},
+			}},
+			expected: []string{"idps[0].openID.issuer"},
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			var actual []string
+			for _, err := range ValidateIdentityProviders(test.idps, field.NewPath("idps")) {
+				actual = append(actual, err.Field)
+			}
+			if diff := cmp.Diff(test.expected, actual); diff != "" {
+				t.Errorf("unexpected errors (-want +got): %s", diff)
+			}
+		})
+	}
+}
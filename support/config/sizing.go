@@ -0,0 +1,51 @@
+package config
+
+import (
+	corev1 "k8s.io/api/core/v1"
+)
+
+// Names of the control plane components whose resources are sized.
+const (
+	EtcdComponent                       = "etcd"
+	KubeAPIServerComponent              = "kube-apiserver"
+	KubeControllerManagerComponent      = "kube-controller-manager"
+	KubeSchedulerComponent              = "kube-scheduler"
+	OpenshiftAPIServerComponent         = "openshift-apiserver"
+	OAuthAPIServerComponent             = "openshift-oauth-apiserver"
+	OpenshiftControllerManagerComponent = "openshift-controller-manager"
+	ClusterPolicyControllerComponent    = "cluster-policy-controller"
+	OAuthServerComponent                = "oauth-openshift"
+	ClusterVersionOperatorComponent     = "cluster-version-operator"
+)
+
+// SizingComponents are the control plane components whose resources are
+// sized.
+var SizingComponents = []string{
+	EtcdComponent,
+	KubeAPIServerComponent,
+	KubeControllerManagerComponent,
+	KubeSchedulerComponent,
+	OpenshiftAPIServerComponent,
+	OAuthAPIServerComponent,
+	OpenshiftControllerManagerComponent,
+	ClusterPolicyControllerComponent,
+	OAuthServerComponent,
+	ClusterVersionOperatorComponent,
+}
+
+// IsSizingComponent returns whether the resources of the named component are
+// sized.
+func IsSizingComponent(name string) bool {
+	for _, component := range SizingComponents {
+		if component == name {
+			return true
+		}
+	}
+	return false
+}
+
+// IsSizingResource returns whether a resource of the sized components can be
+// set. The manifest templates only render CPU and memory.
+func IsSizingResource(name corev1.ResourceName) bool {
+	return name == corev1.ResourceCPU || name == corev1.ResourceMemory
+}
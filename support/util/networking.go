@@ -0,0 +1,66 @@
+package util
+
+import (
+	"net"
+
+	hyperv1 "github.com/openshift/hypershift/api/v1alpha1"
+)
+
+// Default prefix lengths of the subnets allocated to each node from a cluster
+// network.
+const (
+	DefaultIPv4HostPrefix = 23
+	DefaultIPv6HostPrefix = 64
+)
+
+// ClusterNetworks returns the cluster networks of a cluster, which default to
+// the pod CIDR. Host prefixes which are not set are defaulted.
+func ClusterNetworks(podCIDR string, entries []hyperv1.ClusterNetworkEntry) []hyperv1.ClusterNetworkEntry {
+	if len(entries) == 0 {
+		if len(podCIDR) == 0 {
+			return nil
+		}
+		entries = []hyperv1.ClusterNetworkEntry{{CIDR: podCIDR}}
+	}
+	result := make([]hyperv1.ClusterNetworkEntry, 0, len(entries))
+	for _, entry := range entries {
+		if entry.HostPrefix == 0 {
+			entry.HostPrefix = DefaultIPv4HostPrefix
+			if IsIPv6CIDR(entry.CIDR) {
+				entry.HostPrefix = DefaultIPv6HostPrefix
+			}
+		}
+		result = append(result, entry)
+	}
+	return result
+}
+
+// ServiceNetworks returns the service networks of a cluster, which default to
+// the service CIDR.
+func ServiceNetworks(serviceCIDR string, entries []string) []string {
+	if len(entries) == 0 {
+		if len(serviceCIDR) == 0 {
+			return nil
+		}
+		return []string{serviceCIDR}
+	}
+	return append([]string(nil), entries...)
+}
+
+// MachineNetworks returns the machine networks of a cluster, which default to
+// the machine CIDR.
+func MachineNetworks(machineCIDR string, entries []hyperv1.MachineNetworkEntry) []hyperv1.MachineNetworkEntry {
+	if len(entries) == 0 {
+		if len(machineCIDR) == 0 {
+			return nil
+		}
+		return []hyperv1.MachineNetworkEntry{{CIDR: machineCIDR}}
+	}
+	return append([]hyperv1.MachineNetworkEntry(nil), entries...)
+}
+
+// IsIPv6CIDR returns whether a CIDR is an IPv6 network.
+func IsIPv6CIDR(cidr string) bool {
+	ip, _, err := net.ParseCIDR(cidr)
+	return err == nil && ip.To4() == nil
+}
@@ -0,0 +1,29 @@
+package util
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// IsReconciliationPaused interprets a pausedUntil value, which is either a
+// boolean or an RFC3339 timestamp. It reports whether reconciliation is
+// currently paused and, for a timestamp in the future, how long remains until
+// reconciliation resumes. A zero duration for a paused resource means it is
+// paused until the value is changed.
+func IsReconciliationPaused(now time.Time, pausedUntil *string) (bool, time.Duration, error) {
+	if pausedUntil == nil {
+		return false, 0, nil
+	}
+	if isPaused, err := strconv.ParseBool(*pausedUntil); err == nil {
+		return isPaused, 0, nil
+	}
+	until, err := time.Parse(time.RFC3339, *pausedUntil)
+	if err != nil {
+		return false, 0, fmt.Errorf("invalid pausedUntil value %q: must be a boolean or an RFC3339 timestamp", *pausedUntil)
+	}
+	if now.Before(until) {
+		return true, until.Sub(now), nil
+	}
+	return false, 0, nil
+}
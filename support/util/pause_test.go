@@ -0,0 +1,55 @@
+package util
+
+import (
+	"testing"
+	"time"
+
+	"k8s.io/utils/pointer"
+)
+
+func TestIsReconciliationPaused(t *testing.T) {
+	now := time.Date(2021, 4, 1, 12, 0, 0, 0, time.UTC)
+	tests := map[string]struct {
+		PausedUntil      *string
+		ExpectedPaused   bool
+		ExpectedDuration time.Duration
+		ExpectError      bool
+	}{
+		"unset is not paused": {
+			PausedUntil: nil,
+		},
+		"true pauses indefinitely": {
+			PausedUntil:    pointer.StringPtr("true"),
+			ExpectedPaused: true,
+		},
+		"false is not paused": {
+			PausedUntil: pointer.StringPtr("false"),
+		},
+		"future timestamp pauses until it passes": {
+			PausedUntil:      pointer.StringPtr("2021-04-01T13:00:00Z"),
+			ExpectedPaused:   true,
+			ExpectedDuration: time.Hour,
+		},
+		"past timestamp is not paused": {
+			PausedUntil: pointer.StringPtr("2021-04-01T11:00:00Z"),
+		},
+		"invalid value is an error": {
+			PausedUntil: pointer.StringPtr("tomorrow"),
+			ExpectError: true,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			paused, duration, err := IsReconciliationPaused(now, test.PausedUntil)
+			if (err != nil) != test.ExpectError {
+				t.Fatalf("expected error: %t, got: %v", test.ExpectError, err)
+			}
+			if paused != test.ExpectedPaused {
+				t.Errorf("expected paused %t, got %t", test.ExpectedPaused, paused)
+			}
+			if duration != test.ExpectedDuration {
+				t.Errorf("expected duration %s, got %s", test.ExpectedDuration, duration)
+			}
+		})
+	}
+}
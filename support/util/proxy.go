@@ -0,0 +1,58 @@
+package util
+
+import (
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+
+	hyperv1 "github.com/openshift/hypershift/api/v1alpha1"
+)
+
+// NoProxy returns the hosts, domains and CIDRs which a cluster reaches without
+// its proxy: those listed in its proxy spec, followed by the given networks of
+// the cluster. Duplicate and empty entries are dropped.
+func NoProxy(proxy *hyperv1.ProxySpec, networks ...string) string {
+	var entries []string
+	if proxy != nil {
+		entries = strings.Split(proxy.NoProxy, ",")
+	}
+	return JoinNoProxy(append(entries, networks...)...)
+}
+
+// ProxyEnvVars returns the environment variables which make a control plane
+// component use the proxy of a cluster, reaching the hosts in noProxy
+// directly. It returns nil when the cluster has no proxy.
+func ProxyEnvVars(proxy *hyperv1.ProxySpec, noProxy ...string) []corev1.EnvVar {
+	if proxy == nil || (len(proxy.HTTPProxy) == 0 && len(proxy.HTTPSProxy) == 0) {
+		return nil
+	}
+	var env []corev1.EnvVar
+	if len(proxy.HTTPProxy) > 0 {
+		env = append(env, corev1.EnvVar{Name: "HTTP_PROXY", Value: proxy.HTTPProxy})
+	}
+	if len(proxy.HTTPSProxy) > 0 {
+		env = append(env, corev1.EnvVar{Name: "HTTPS_PROXY", Value: proxy.HTTPSProxy})
+	}
+	if value := JoinNoProxy(noProxy...); len(value) > 0 {
+		env = append(env, corev1.EnvVar{Name: "NO_PROXY", Value: value})
+	}
+	return env
+}
+
+// JoinNoProxy joins comma-separated lists of hosts, domains and CIDRs
+// reached without a proxy into one, dropping duplicate and empty entries.
+func JoinNoProxy(entries ...string) string {
+	seen := map[string]bool{}
+	var result []string
+	for _, entry := range entries {
+		for _, e := range strings.Split(entry, ",") {
+			e = strings.TrimSpace(e)
+			if len(e) == 0 || seen[e] {
+				continue
+			}
+			seen[e] = true
+			result = append(result, e)
+		}
+	}
+	return strings.Join(result, ",")
+}
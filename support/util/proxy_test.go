@@ -0,0 +1,63 @@
+package util
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+
+	hyperv1 "github.com/openshift/hypershift/api/v1alpha1"
+)
+
+func TestNoProxy(t *testing.T) {
+	tests := map[string]struct {
+		Proxy    *hyperv1.ProxySpec
+		Expected string
+	}{
+		"no proxy": {
+			Expected: "10.132.0.0/14,172.31.0.0/16",
+		},
+		"listed hosts precede the networks": {
+			Proxy:    &hyperv1.ProxySpec{HTTPProxy: "http://proxy:3128", NoProxy: ".example.com, 10.1.0.0/16"},
+			Expected: ".example.com,10.1.0.0/16,10.132.0.0/14,172.31.0.0/16",
+		},
+		"duplicates are dropped": {
+			Proxy:    &hyperv1.ProxySpec{HTTPProxy: "http://proxy:3128", NoProxy: "172.31.0.0/16,,.example.com"},
+			Expected: "172.31.0.0/16,.example.com,10.132.0.0/14",
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			if actual := NoProxy(test.Proxy, "10.132.0.0/14", "172.31.0.0/16"); actual != test.Expected {
+				t.Errorf("expected %q, got %q", test.Expected, actual)
+			}
+		})
+	}
+}
+
+func TestProxyEnvVars(t *testing.T) {
+	tests := map[string]struct {
+		Proxy    *hyperv1.ProxySpec
+		Expected []corev1.EnvVar
+	}{
+		"no proxy": {},
+		"only no proxy": {
+			Proxy: &hyperv1.ProxySpec{NoProxy: ".example.com"},
+		},
+		"http and https proxies": {
+			Proxy: &hyperv1.ProxySpec{HTTPProxy: "http://proxy:3128", HTTPSProxy: "https://proxy:3129"},
+			Expected: []corev1.EnvVar{
+				{Name: "HTTP_PROXY", Value: "http://proxy:3128"},
+				{Name: "HTTPS_PROXY", Value: "https://proxy:3129"},
+				{Name: "NO_PROXY", Value: ".example.com,localhost"},
+			},
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			if diff := cmp.Diff(test.Expected, ProxyEnvVars(test.Proxy, ".example.com", "localhost")); diff != "" {
+				t.Errorf("unexpected env vars (-want +got): %s", diff)
+			}
+		})
+	}
+}
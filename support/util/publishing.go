@@ -0,0 +1,45 @@
+package util
+
+import (
+	hyperv1 "github.com/openshift/hypershift/api/v1alpha1"
+)
+
+// defaultPublishingStrategies are the publishing strategies of the services
+// which a cluster does not list.
+var defaultPublishingStrategies = map[hyperv1.ServiceType]hyperv1.PublishingStrategyType{
+	hyperv1.APIServer:          hyperv1.LoadBalancer,
+	hyperv1.OAuthServer:        hyperv1.Route,
+	hyperv1.VPN:                hyperv1.LoadBalancer,
+	hyperv1.Ignition:           hyperv1.Route,
+	hyperv1.KonnectivityServer: hyperv1.Route,
+	hyperv1.OVNSbDb:            hyperv1.Route,
+}
+
+// ServicePublishingStrategy returns the publishing strategy of a control plane
+// service, which is its default strategy unless the service is listed.
+func ServicePublishingStrategy(services []hyperv1.ServicePublishingStrategyMapping, service hyperv1.ServiceType) hyperv1.ServicePublishingStrategy {
+	for _, mapping := range services {
+		if mapping.Service == service {
+			return mapping.ServicePublishingStrategy
+		}
+	}
+	return hyperv1.ServicePublishingStrategy{Type: defaultPublishingStrategies[service]}
+}
+
+// EndpointAccess returns the endpoint access of the Kube API server of a
+// cluster on the platform, which is Public unless it is set.
+func EndpointAccess(platform hyperv1.PlatformSpec) hyperv1.AWSEndpointAccessType {
+	if platform.AWS == nil || len(platform.AWS.EndpointAccess) == 0 {
+		return hyperv1.Public
+	}
+	return platform.AWS.EndpointAccess
+}
+
+// NodeConnectivity returns the data path through which the control plane of a
+// cluster reaches the guest cluster, which is OpenVPN unless it is set.
+func NodeConnectivity(connectivity hyperv1.NodeConnectivityType) hyperv1.NodeConnectivityType {
+	if len(connectivity) == 0 {
+		return hyperv1.OpenVPN
+	}
+	return connectivity
+}
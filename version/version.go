@@ -9,6 +9,8 @@ import (
 var (
 	// TODO: This goes away when control-plane-operator becomes another component
 	// in the OCP payload.
+	// It is the default of the --hypershift-image install flag and is pinned
+	// at build time by the HYPERSHIFT_IMAGE make variable.
 	HyperShiftImage = "registry.ci.openshift.org/hypershift/hypershift:latest"
 )
 